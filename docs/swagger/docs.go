@@ -24,6 +24,46 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "RFC 7517，返回当前用于验证访问令牌的公钥（含轮换重叠期内的旧密钥和即将启用的新密钥）；\n使用 HS256 签名时返回空集合",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OAuth2"
+                ],
+                "summary": "JWKS 公钥集合",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_infrastructure_jwt.JSONWebKeySet"
+                        }
+                    }
+                }
+            }
+        },
+        "/.well-known/oauth-authorization-server": {
+            "get": {
+                "description": "RFC 8414，供客户端自动发现各端点地址",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OAuth2"
+                ],
+                "summary": "OAuth2 授权服务元数据",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/attachment/business": {
             "get": {
                 "description": "根据业务类型和业务ID查询附件列表",
@@ -428,14 +468,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/config": {
-            "put": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "更新配置数据",
+        "/api/v1/auth/api-keys": {
+            "get": {
+                "description": "列出当前用户创建的 API Key（不含明文）",
                 "consumes": [
                     "application/json"
                 ],
@@ -443,48 +478,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "配置管理"
-                ],
-                "summary": "更新配置",
-                "parameters": [
-                    {
-                        "description": "更新配置请求",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.UpdateConfigRequest"
-                        }
-                    }
+                    "API Key"
                 ],
+                "summary": "查询我的 API Key",
                 "responses": {
                     "200": {
-                        "description": "更新成功",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.ApiKeyResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "创建新的配置数据，支持存储JSON格式的配置信息",
+                ]
+            },
+            "post": {
+                "description": "为当前用户创建 API Key，用于脚本和 CI 调用接口；通过 X-API-Key 请求头或 Authorization: Bearer ntz_... 传入。\n实际权限为授权范围与本人权限的交集；明文仅在创建时返回一次",
                 "consumes": [
                     "application/json"
                 ],
@@ -492,50 +519,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "配置管理"
+                    "API Key"
                 ],
-                "summary": "创建配置",
+                "summary": "创建我的 API Key",
                 "parameters": [
                     {
-                        "description": "创建配置请求",
-                        "name": "request",
+                        "description": "API Key 信息",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CreateConfigRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.ApiKeyCreateRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.ApiKeyCreateResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/config/batch": {
-            "delete": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "批量删除配置数据",
+                ]
+            }
+        },
+        "/api/v1/auth/api-keys/{id}": {
+            "delete": {
+                "description": "删除当前用户指定的 API Key，删除后立即失效",
                 "consumes": [
                     "application/json"
                 ],
@@ -543,45 +570,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "配置管理"
+                    "API Key"
                 ],
-                "summary": "批量删除配置",
+                "summary": "删除我的 API Key",
                 "parameters": [
                     {
-                        "description": "批量删除请求",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteConfigRequest"
-                        }
+                        "type": "integer",
+                        "description": "API Key 记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除成功",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/api/v1/config/code": {
+        "/api/v1/auth/impersonations": {
             "get": {
-                "description": "根据配置编码获取配置列表",
+                "description": "列出管理员以当前用户身份登录的历史记录（含管理员、原因和时间）",
                 "consumes": [
                     "application/json"
                 ],
@@ -589,22 +607,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "配置管理"
+                    "代登录"
                 ],
-                "summary": "根据编码获取配置列表",
+                "summary": "查询我被代登录的记录",
                 "parameters": [
                     {
-                        "type": "string",
-                        "example": "\"system_settings\"",
-                        "description": "配置编码",
-                        "name": "code",
-                        "in": "query",
-                        "required": true
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "pageNum",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "查询成功",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -614,34 +636,24 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.ConfigResponse"
-                                            }
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_utils_pagination.Page-github_com_force-c_nai-tizi_internal_domain_response_ImpersonationLogResponse"
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/api/v1/config/data": {
+        "/api/v1/auth/mfa": {
             "get": {
-                "description": "根据配置编码获取配置的data字段（JSON格式），返回第一个匹配的配置",
+                "description": "查询当前用户是否已启用两步验证、所属角色是否强制启用以及剩余恢复码数量",
                 "consumes": [
                     "application/json"
                 ],
@@ -649,22 +661,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "配置管理"
-                ],
-                "summary": "根据编码获取配置数据",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "example": "\"system_settings\"",
-                        "description": "配置编码",
-                        "name": "code",
-                        "in": "query",
-                        "required": true
-                    }
+                    "两步验证"
                 ],
+                "summary": "查询两步验证状态",
                 "responses": {
                     "200": {
-                        "description": "查询成功",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -674,36 +676,24 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.ConfigDataResponse"
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.MfaStatusResponse"
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
                     }
-                }
-            }
-        },
-        "/api/v1/config/page": {
-            "post": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "使用 Paginator 分页查询配置列表，支持按类型、名称筛选",
+                ]
+            }
+        },
+        "/api/v1/auth/mfa/recovery-codes": {
+            "post": {
+                "description": "校验动态码或恢复码后生成新的一组恢复码，旧恢复码全部作废",
                 "consumes": [
                     "application/json"
                 ],
@@ -711,30 +701,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "配置管理"
+                    "两步验证"
                 ],
-                "summary": "分页查询配置列表",
+                "summary": "重新生成恢复码",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "查询参数",
+                        "description": "动态验证码或恢复码",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.PageConfigRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.MfaCodeRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "查询成功",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -744,30 +727,24 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.MfaRecoveryCodesResponse"
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
                     }
-                }
-            }
-        },
-        "/api/v1/config/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "根据配置ID查询配置详情",
+                ]
+            }
+        },
+        "/api/v1/auth/mfa/totp/confirm": {
+            "post": {
+                "description": "校验认证器 App 生成的动态码，成功后启用两步验证并返回一次性恢复码",
                 "consumes": [
                     "application/json"
                 ],
@@ -775,21 +752,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "配置管理"
+                    "两步验证"
                 ],
-                "summary": "根据ID查询配置",
+                "summary": "确认绑定两步验证",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "配置ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "动态验证码",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.MfaCodeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "查询成功",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -799,34 +778,24 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.ConfigResponse"
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.MfaRecoveryCodesResponse"
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "删除单个配置数据",
+                ]
+            }
+        },
+        "/api/v1/auth/mfa/totp/disable": {
+            "post": {
+                "description": "校验动态码或恢复码后关闭两步验证；所属角色强制启用时不可关闭",
                 "consumes": [
                     "application/json"
                 ],
@@ -834,48 +803,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "配置管理"
+                    "两步验证"
                 ],
-                "summary": "删除配置",
+                "summary": "关闭两步验证",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "配置ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "动态验证码或恢复码",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.MfaCodeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除成功",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/dict": {
-            "put": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "更新字典数据",
+                ]
+            }
+        },
+        "/api/v1/auth/mfa/totp/enroll": {
+            "post": {
+                "description": "生成 TOTP 密钥和 otpauth 绑定地址，使用认证器 App 扫码后调用确认接口完成绑定",
                 "consumes": [
                     "application/json"
                 ],
@@ -883,48 +842,113 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "字典管理"
+                    "两步验证"
                 ],
-                "summary": "更新字典",
-                "parameters": [
-                    {
-                        "description": "更新字典请求",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                "summary": "开始绑定两步验证",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.UpdateDictRequest"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.MfaEnrollResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/auth/oidc/identities": {
+            "get": {
+                "description": "列出当前用户已绑定的 OIDC 身份",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "第三方登录"
                 ],
+                "summary": "查询我绑定的第三方账号",
                 "responses": {
                     "200": {
-                        "description": "更新成功",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.UserIdentityResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/auth/oidc/identities/{id}": {
+            "delete": {
+                "description": "解除后无法再通过该第三方账号登录；未设置密码的账号不能解除唯一的绑定",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "第三方登录"
+                ],
+                "summary": "解除绑定第三方账号",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "绑定记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "创建新的字典数据，支持树形结构（通过parentId）",
+                ]
+            }
+        },
+        "/api/v1/auth/oidc/link": {
+            "post": {
+                "description": "校验提供方返回的 ID Token 后将第三方身份绑定到当前用户",
                 "consumes": [
                     "application/json"
                 ],
@@ -932,50 +956,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "字典管理"
+                    "第三方登录"
                 ],
-                "summary": "创建字典",
+                "summary": "完成绑定第三方账号",
                 "parameters": [
                     {
-                        "description": "创建字典请求",
-                        "name": "request",
+                        "description": "授权码及 state",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CreateDictRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.OidcLinkRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.UserIdentityResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/dict/batch": {
-            "delete": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "批量删除字典数据（如果有子字典则无法删除）",
+                ]
+            }
+        },
+        "/api/v1/auth/oidc/link/authorize": {
+            "post": {
+                "description": "前端跳转到 authorizeUrl，提供方回调后提交 code 和 state 到 /api/v1/auth/oidc/link 完成绑定",
                 "consumes": [
                     "application/json"
                 ],
@@ -983,45 +1007,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "字典管理"
+                    "第三方登录"
                 ],
-                "summary": "批量删除字典",
+                "summary": "获取绑定授权地址",
                 "parameters": [
                     {
-                        "description": "批量删除请求",
-                        "name": "request",
+                        "description": "提供方",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteDictRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.OidcLinkAuthorizeRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除成功",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.OidcAuthorizeResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/api/v1/dict/label": {
+        "/api/v1/auth/sessions": {
             "get": {
-                "description": "根据字典类型和键值获取对应的标签（用于数据展示）",
+                "description": "列出当前用户所有活跃的登录会话，current=true 表示当前请求所在会话",
                 "consumes": [
                     "application/json"
                 ],
@@ -1029,30 +1058,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "字典管理"
-                ],
-                "summary": "根据类型和键值获取标签",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "example": "\"sys_user_sex\"",
-                        "description": "字典类型",
-                        "name": "dictType",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"0\"",
-                        "description": "字典键值",
-                        "name": "dictValue",
-                        "in": "query",
-                        "required": true
-                    }
+                    "在线会话"
                 ],
+                "summary": "查询我的在线会话",
                 "responses": {
                     "200": {
-                        "description": "查询成功",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -1062,31 +1073,27 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "string"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.SessionResponse"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/api/v1/dict/type": {
-            "get": {
-                "description": "根据字典类型获取字典列表，用于前端下拉框等场景。支持获取子字典列表（通过parentId参数）",
+        "/api/v1/auth/sessions/others": {
+            "delete": {
+                "description": "保留当前会话，注销当前用户的其余所有登录会话",
                 "consumes": [
                     "application/json"
                 ],
@@ -1094,29 +1101,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "字典管理"
-                ],
-                "summary": "根据类型获取字典列表",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "example": "\"sys_user_sex\"",
-                        "description": "字典类型",
-                        "name": "dictType",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "example": 0,
-                        "description": "父字典ID（可选，用于获取子字典）",
-                        "name": "parentId",
-                        "in": "query"
-                    }
+                    "在线会话"
                 ],
+                "summary": "注销我的其他会话",
                 "responses": {
                     "200": {
-                        "description": "查询成功",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -1126,39 +1116,29 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.DictDataResponse"
+                                            "type": "object",
+                                            "properties": {
+                                                "count": {
+                                                    "type": "integer"
+                                                }
                                             }
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
                     }
-                }
-            }
-        },
-        "/api/v1/dict/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "根据字典ID查询字典详情",
+                ]
+            }
+        },
+        "/api/v1/auth/sessions/{sessionId}": {
+            "delete": {
+                "description": "远程注销当前用户的某个登录会话，该会话的 AccessToken 和 RefreshToken 立即失效",
                 "consumes": [
                     "application/json"
                 ],
@@ -1166,21 +1146,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "字典管理"
+                    "在线会话"
                 ],
-                "summary": "根据ID查询字典",
+                "summary": "注销我的指定会话",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "字典ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "sessionId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "查询成功",
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/auth/webauthn/credentials": {
+            "get": {
+                "description": "列出当前用户已注册的通行密钥",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "通行密钥"
+                ],
+                "summary": "查询我的通行密钥",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -1190,34 +1198,27 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.DictDataResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.WebAuthnCredentialResponse"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "删除单个字典数据（如果有子字典则无法删除）",
+                ]
+            }
+        },
+        "/api/v1/auth/webauthn/credentials/{id}": {
+            "put": {
+                "description": "修改当前用户指定通行密钥的名称",
                 "consumes": [
                     "application/json"
                 ],
@@ -1225,48 +1226,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "字典管理"
+                    "通行密钥"
                 ],
-                "summary": "删除字典",
+                "summary": "重命名通行密钥",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "字典ID",
+                        "description": "凭证记录ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "新名称",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.WebAuthnRenameRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除成功",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/loginLog": {
-            "put": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "更新登录日志记录",
+                ]
+            },
+            "delete": {
+                "description": "删除当前用户指定的通行密钥，删除后该凭证无法再用于登录",
                 "consumes": [
                     "application/json"
                 ],
@@ -1274,48 +1270,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "登录日志"
+                    "通行密钥"
                 ],
-                "summary": "更新登录日志",
+                "summary": "删除通行密钥",
                 "parameters": [
                     {
-                        "description": "更新登录日志请求",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.UpdateLoginLogRequest"
-                        }
+                        "type": "integer",
+                        "description": "凭证记录ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新成功",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "创建新的登录日志记录",
+                ]
+            }
+        },
+        "/api/v1/auth/webauthn/register": {
+            "post": {
+                "description": "提交 navigator.credentials.create() 返回的凭证，校验通过后保存",
                 "consumes": [
                     "application/json"
                 ],
@@ -1323,50 +1307,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "登录日志"
+                    "通行密钥"
                 ],
-                "summary": "创建登录日志",
+                "summary": "完成通行密钥注册",
                 "parameters": [
                     {
-                        "description": "创建登录日志请求",
-                        "name": "request",
+                        "description": "注册凭证",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CreateLoginLogRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.WebAuthnRegisterRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.WebAuthnCredentialResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/loginLog/batch": {
-            "delete": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "批量删除登录日志记录",
+                ]
+            }
+        },
+        "/api/v1/auth/webauthn/register/options": {
+            "post": {
+                "description": "生成注册挑战，前端将返回值传给 navigator.credentials.create()",
                 "consumes": [
                     "application/json"
                 ],
@@ -1374,50 +1358,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "登录日志"
-                ],
-                "summary": "批量删除登录日志",
-                "parameters": [
-                    {
-                        "description": "批量删除请求",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteLoginLogRequest"
-                        }
-                    }
+                    "通行密钥"
                 ],
+                "summary": "获取通行密钥注册参数",
                 "responses": {
                     "200": {
-                        "description": "删除成功",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/loginLog/clean": {
-            "post": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "清理指定天数之前的登录日志",
+                ]
+            }
+        },
+        "/api/v1/client": {
+            "post": {
+                "description": "创建客户端应用，密钥由系统生成并只保存哈希，明文仅在创建时返回一次；需要 client.create 权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -1425,50 +1398,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "登录日志"
+                    "客户端管理"
                 ],
-                "summary": "清理登录日志",
+                "summary": "创建客户端",
                 "parameters": [
                     {
-                        "description": "清理日志请求",
-                        "name": "request",
+                        "description": "客户端信息",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CleanLoginLogRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CreateClientRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "清理成功",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.ClientSecretResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/loginLog/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "根据日志ID查询登录日志详情",
+                ]
+            }
+        },
+        "/api/v1/client/grant-types": {
+            "get": {
+                "description": "返回客户端可开通的授权类型，用于管理页面选项",
                 "consumes": [
                     "application/json"
                 ],
@@ -1476,21 +1449,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "登录日志"
-                ],
-                "summary": "根据ID查询登录日志",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "日志ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "客户端管理"
                 ],
+                "summary": "可开通的授权类型",
                 "responses": {
                     "200": {
-                        "description": "查询成功",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -1500,34 +1464,27 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.LoginLogResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "删除单个登录日志记录",
+                ]
+            }
+        },
+        "/api/v1/client/page": {
+            "post": {
+                "description": "分页查询客户端应用列表（不含密钥），需要 client.read 权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -1535,48 +1492,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "登录日志"
+                    "客户端管理"
                 ],
-                "summary": "删除登录日志",
+                "summary": "分页查询客户端",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "日志ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "查询参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.PageClientRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除成功",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_utils_pagination.Page-github_com_force-c_nai-tizi_internal_domain_response_ClientResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/menu": {
-            "get": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "获取所有菜单的列表形式",
+                ]
+            }
+        },
+        "/api/v1/client/{clientId}": {
+            "get": {
+                "description": "根据客户端ID查询客户端配置（不含密钥），需要 client.read 权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -1584,12 +1543,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "菜单管理"
+                    "客户端管理"
+                ],
+                "summary": "查询客户端详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "客户端ID",
+                        "name": "clientId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "获取菜单列表",
                 "responses": {
                     "200": {
-                        "description": "成功",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -1599,31 +1567,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_model.Menu"
-                                            }
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.ClientResponse"
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "500": {
-                        "description": "服务器错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "创建新的菜单项",
+                ]
+            },
+            "put": {
+                "description": "更新客户端的授权类型、Token 有效期、状态和 OAuth2 配置，clientKey 与密钥不可在此修改；需要 client.update 权限。\n修改立即生效，已签发的 Token 在刷新时按新配置计算有效期",
                 "consumes": [
                     "application/json"
                 ],
@@ -1631,50 +1590,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "菜单管理"
+                    "客户端管理"
                 ],
-                "summary": "创建菜单",
+                "summary": "更新客户端",
                 "parameters": [
                     {
-                        "description": "菜单信息",
-                        "name": "menu",
+                        "type": "string",
+                        "description": "客户端ID",
+                        "name": "clientId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "客户端配置",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_model.Menu"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.UpdateClientRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器错误",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/menu/tree": {
-            "get": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "获取所有菜单的树形结构，用于菜单管理页面",
+                ]
+            },
+            "delete": {
+                "description": "删除客户端应用，删除后使用该客户端的登录和刷新请求立即失败；需要 client.delete 权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -1682,103 +1634,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "菜单管理"
+                    "客户端管理"
                 ],
-                "summary": "获取菜单树",
-                "responses": {
-                    "200": {
-                        "description": "成功",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_service.MenuTree"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "500": {
-                        "description": "服务器错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/menu/user/tree": {
-            "get": {
-                "security": [
+                "summary": "删除客户端",
+                "parameters": [
                     {
-                        "Bearer": []
+                        "type": "string",
+                        "description": "客户端ID",
+                        "name": "clientId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
-                "description": "获取当前登录用户的菜单树，用于前端生成动态路由",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "菜单管理"
-                ],
-                "summary": "获取用户菜单树",
                 "responses": {
                     "200": {
-                        "description": "成功",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_service.MenuTree"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "401": {
-                        "description": "未授权",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器错误",
+                        "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/menu/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "根据菜单ID获取菜单详细信息",
+                ]
+            }
+        },
+        "/api/v1/client/{clientId}/secret": {
+            "post": {
+                "description": "生成新的客户端密钥，明文仅返回一次；旧密钥在 gracePeriod 秒内仍然有效，便于调用方切换，0 表示立即失效。\n需要 client.secret 权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -1786,21 +1671,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "菜单管理"
+                    "客户端管理"
                 ],
-                "summary": "获取菜单详情",
+                "summary": "轮换客户端密钥",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "菜单ID",
-                        "name": "id",
+                        "type": "string",
+                        "description": "客户端ID",
+                        "name": "clientId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "旧密钥保留时间",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.RotateClientSecretRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -1810,40 +1704,24 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_model.Menu"
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.ClientSecretResponse"
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "400": {
-                        "description": "参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "菜单不存在",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "更新菜单信息",
+                ]
+            }
+        },
+        "/api/v1/config": {
+            "put": {
+                "description": "更新配置数据",
                 "consumes": [
                     "application/json"
                 ],
@@ -1851,55 +1729,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "菜单管理"
+                    "配置管理"
                 ],
-                "summary": "更新菜单",
+                "summary": "更新配置",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "菜单ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "菜单信息",
-                        "name": "menu",
+                        "description": "更新配置请求",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_model.Menu"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.UpdateConfigRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功",
+                        "description": "更新成功",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "请求参数错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "500": {
-                        "description": "服务器错误",
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "删除指定的菜单",
+                ]
+            },
+            "post": {
+                "description": "创建新的配置数据，支持存储JSON格式的配置信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -1907,48 +1778,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "菜单管理"
+                    "配置管理"
                 ],
-                "summary": "删除菜单",
+                "summary": "创建配置",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "菜单ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "创建配置请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CreateConfigRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功",
+                        "description": "创建成功",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "请求参数错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "500": {
-                        "description": "服务器错误",
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/operLog": {
-            "put": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "更新操作日志记录",
+                ]
+            }
+        },
+        "/api/v1/config/batch": {
+            "delete": {
+                "description": "批量删除配置数据",
                 "consumes": [
                     "application/json"
                 ],
@@ -1956,23 +1829,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "操作日志"
+                    "配置管理"
                 ],
-                "summary": "更新操作日志",
+                "summary": "批量删除配置",
                 "parameters": [
                     {
-                        "description": "更新操作日志请求",
+                        "description": "批量删除请求",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.UpdateOperLogRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteConfigRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新成功",
+                        "description": "删除成功",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
@@ -1989,15 +1862,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "创建新的操作日志记录",
+                ]
+            }
+        },
+        "/api/v1/config/code": {
+            "get": {
+                "description": "根据配置编码获取配置列表",
                 "consumes": [
                     "application/json"
                 ],
@@ -2005,25 +1880,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "操作日志"
+                    "配置管理"
                 ],
-                "summary": "创建操作日志",
+                "summary": "根据编码获取配置列表",
                 "parameters": [
                     {
-                        "description": "创建操作日志请求",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CreateOperLogRequest"
-                        }
+                        "type": "string",
+                        "example": "\"system_settings\"",
+                        "description": "配置编码",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功",
+                        "description": "查询成功",
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.ConfigResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -2041,14 +1930,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/operLog/batch": {
-            "delete": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "批量删除操作日志记录",
+        "/api/v1/config/data": {
+            "get": {
+                "description": "根据配置编码获取配置的data字段（JSON格式），返回第一个匹配的配置",
                 "consumes": [
                     "application/json"
                 ],
@@ -2056,25 +1940,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "操作日志"
+                    "配置管理"
                 ],
-                "summary": "批量删除操作日志",
+                "summary": "根据编码获取配置数据",
                 "parameters": [
                     {
-                        "description": "批量删除请求",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteOperLogRequest"
-                        }
+                        "type": "string",
+                        "example": "\"system_settings\"",
+                        "description": "配置编码",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除成功",
+                        "description": "查询成功",
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.ConfigDataResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -2092,14 +1987,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/operLog/clean": {
+        "/api/v1/config/page": {
             "post": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "清理指定天数之前的操作日志",
+                "description": "使用 Paginator 分页查询配置列表，支持按类型、名称筛选",
                 "consumes": [
                     "application/json"
                 ],
@@ -2107,68 +1997,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "操作日志"
+                    "配置管理"
                 ],
-                "summary": "清理操作日志",
+                "summary": "分页查询配置列表",
                 "parameters": [
                     {
-                        "description": "清理日志请求",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CleanOperLogRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "清理成功",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/operLog/page": {
-            "post": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "分页查询操作日志列表，支持按标题、操作者、业务类型、状态、时间范围筛选",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "操作日志"
-                ],
-                "summary": "分页查询操作日志列表",
-                "parameters": [
                     {
                         "description": "查询参数",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.PageOperLogRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.PageConfigRequest"
                         }
                     }
                 ],
@@ -2176,7 +2022,19 @@ const docTemplate = `{
                     "200": {
                         "description": "查询成功",
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -2184,24 +2042,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
                     }
-                }
-            }
-        },
-        "/api/v1/operLog/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "根据日志ID查询操作日志详情",
+                ]
+            }
+        },
+        "/api/v1/config/{id}": {
+            "get": {
+                "description": "根据配置ID查询配置详情",
                 "consumes": [
                     "application/json"
                 ],
@@ -2209,13 +2061,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "操作日志"
+                    "配置管理"
                 ],
-                "summary": "根据ID查询操作日志",
+                "summary": "根据ID查询配置",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "日志ID",
+                        "description": "配置ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2233,7 +2085,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.OperLogResponse"
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.ConfigResponse"
                                         }
                                     }
                                 }
@@ -2252,15 +2104,15 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "删除单个操作日志记录",
+                ]
+            },
+            "delete": {
+                "description": "删除单个配置数据",
                 "consumes": [
                     "application/json"
                 ],
@@ -2268,13 +2120,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "操作日志"
+                    "配置管理"
                 ],
-                "summary": "删除操作日志",
+                "summary": "删除配置",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "日志ID",
+                        "description": "配置ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2299,17 +2151,17 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/org": {
-            "post": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "创建新组织，需要管理员权限",
+                ]
+            }
+        },
+        "/api/v1/dict": {
+            "put": {
+                "description": "更新字典数据",
                 "consumes": [
                     "application/json"
                 ],
@@ -2317,76 +2169,99 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "组织管理"
+                    "字典管理"
                 ],
-                "summary": "创建组织",
+                "summary": "更新字典",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "创建组织请求",
+                        "description": "更新字典请求",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CreateOrgRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.UpdateDictRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功，返回组织ID",
+                        "description": "更新成功",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "integer",
-                                            "format": "int64"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "请求参数错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
-                    "401": {
-                        "description": "未授权",
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "创建新的字典数据，支持树形结构（通过parentId）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "字典管理"
+                ],
+                "summary": "创建字典",
+                "parameters": [
+                    {
+                        "description": "创建字典请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CreateDictRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "500": {
-                        "description": "服务器错误",
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/org/batch": {
-            "delete": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "批量删除多个组织（软删除），需要管理员权限",
+                ]
+            }
+        },
+        "/api/v1/dict/batch": {
+            "delete": {
+                "description": "批量删除字典数据（如果有子字典则无法删除）",
                 "consumes": [
                     "application/json"
                 ],
@@ -2394,75 +2269,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "组织管理"
+                    "字典管理"
                 ],
-                "summary": "批量删除组织",
+                "summary": "批量删除字典",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
                     {
                         "description": "批量删除请求",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteOrgsRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteDictRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "删除成功",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "400": {
-                        "description": "参数错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
-                    "401": {
-                        "description": "未授权",
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "500": {
-                        "description": "服务器错误",
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/org/page": {
-            "post": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "使用 Paginator 分页查询组织列表",
+                ]
+            }
+        },
+        "/api/v1/dict/label": {
+            "get": {
+                "description": "根据字典类型和键值获取对应的标签（用于数据展示）",
                 "consumes": [
                     "application/json"
                 ],
@@ -2470,30 +2320,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "组织管理"
+                    "字典管理"
                 ],
-                "summary": "分页查询组织列表",
+                "summary": "根据类型和键值获取标签",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
+                        "example": "\"sys_user_sex\"",
+                        "description": "字典类型",
+                        "name": "dictType",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "查询参数",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.PageOrgsRequest"
-                        }
+                        "type": "string",
+                        "example": "\"0\"",
+                        "description": "字典键值",
+                        "name": "dictValue",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "查询成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -2503,7 +2353,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "type": "string"
                                         }
                                     }
                                 }
@@ -2511,7 +2361,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
@@ -2519,14 +2375,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/org/tree": {
+        "/api/v1/dict/type": {
             "get": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "获取完整的组织树结构",
+                "description": "根据字典类型获取字典列表，用于前端下拉框等场景。支持获取子字典列表（通过parentId参数）",
                 "consumes": [
                     "application/json"
                 ],
@@ -2534,16 +2385,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "组织管理"
+                    "字典管理"
                 ],
-                "summary": "获取组织树",
+                "summary": "根据类型获取字典列表",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
+                        "example": "\"sys_user_sex\"",
+                        "description": "字典类型",
+                        "name": "dictType",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 0,
+                        "description": "父字典ID（可选，用于获取子字典）",
+                        "name": "parentId",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2560,7 +2419,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "type": "object"
+                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.DictDataResponse"
                                             }
                                         }
                                     }
@@ -2568,14 +2427,14 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "401": {
-                        "description": "未授权",
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "500": {
-                        "description": "服务器错误",
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
@@ -2583,14 +2442,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/org/{id}": {
+        "/api/v1/dict/{id}": {
             "get": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "description": "根据组织ID查询组织详细信息",
+                "description": "根据字典ID查询字典详情",
                 "consumes": [
                     "application/json"
                 ],
@@ -2598,20 +2452,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "组织管理"
+                    "字典管理"
                 ],
-                "summary": "查询组织详情",
+                "summary": "根据ID查询字典",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
                     {
                         "type": "integer",
-                        "description": "组织ID",
+                        "description": "字典ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -2629,7 +2476,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.DictDataResponse"
                                         }
                                     }
                                 }
@@ -2637,38 +2484,26 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "401": {
-                        "description": "未授权",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "组织不存在",
+                        "description": "请求参数错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "500": {
-                        "description": "服务器错误",
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "更新指定组织的信息，需要管理员权限",
+                ]
+            },
+            "delete": {
+                "description": "删除单个字典数据（如果有子字典则无法删除）",
                 "consumes": [
                     "application/json"
                 ],
@@ -2676,86 +2511,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "组织管理"
+                    "字典管理"
                 ],
-                "summary": "更新组织信息",
+                "summary": "删除字典",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
                     {
                         "type": "integer",
-                        "description": "组织ID",
+                        "description": "字典ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "更新组织请求",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.UpdateOrgRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "更新成功",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "string"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "400": {
-                        "description": "参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "401": {
-                        "description": "未授权",
+                        "description": "删除成功",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
-                    "404": {
-                        "description": "组织不存在",
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "500": {
-                        "description": "服务器错误",
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "删除指定组织（软删除），需要管理员权限",
+                ]
+            }
+        },
+        "/api/v1/invite-code": {
+            "post": {
+                "description": "批量生成绑定组织和默认角色的注册邀请码，一次最多 100 个；需要 invite_code.create 权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -2763,28 +2560,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "组织管理"
+                    "邀请码管理"
                 ],
-                "summary": "删除组织",
+                "summary": "生成邀请码",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "组织ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "邀请码设置",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CreateInviteCodeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除成功",
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -2794,88 +2586,27 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "string"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.InviteCodeResponse"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "400": {
-                        "description": "参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "401": {
-                        "description": "未授权",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "组织不存在",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
                     }
-                }
-            }
-        },
-        "/api/v1/role": {
-            "put": {
-                "description": "更新角色信息",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "角色管理"
-                ],
-                "summary": "更新角色",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
+                },
+                "security": [
                     {
-                        "description": "角色信息",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.UpdateRoleRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
+                        "Bearer": []
                     }
-                }
-            },
+                ]
+            }
+        },
+        "/api/v1/invite-code/page": {
             "post": {
-                "description": "创建新角色",
+                "description": "分页查询当前租户的注册邀请码，需要 invite_code.read 权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -2883,24 +2614,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "邀请码管理"
                 ],
-                "summary": "创建角色",
+                "summary": "分页查询邀请码",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "角色信息",
+                        "description": "查询参数",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CreateRoleRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.PageInviteCodeRequest"
                         }
                     }
                 ],
@@ -2916,25 +2640,24 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.RoleResponse"
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_utils_pagination.Page-github_com_force-c_nai-tizi_internal_domain_response_InviteCodeResponse"
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "400": {
-                        "description": "参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/api/v1/role/assign": {
-            "post": {
-                "description": "为用户分配角色",
+        "/api/v1/invite-code/{id}": {
+            "put": {
+                "description": "更新邀请码的默认角色、使用次数、有效期、状态和备注，绑定的组织不可修改；需要 invite_code.update 权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -2942,24 +2665,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "邀请码管理"
                 ],
-                "summary": "为用户分配角色",
+                "summary": "更新邀请码",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
+                        "type": "integer",
+                        "description": "邀请码ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "分配信息",
+                        "description": "邀请码设置",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.AssignRoleToUserRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.UpdateInviteCodeRequest"
                         }
                     }
                 ],
@@ -2969,19 +2692,16 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
-                    },
-                    "400": {
-                        "description": "参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
                     }
-                }
-            }
-        },
-        "/api/v1/role/page": {
-            "post": {
-                "description": "使用 Paginator 分页查询角色列表",
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "删除注册邀请码，已通过该邀请码注册的用户不受影响；需要 invite_code.delete 权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -2989,52 +2709,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "邀请码管理"
                 ],
-                "summary": "分页查询角色列表",
+                "summary": "删除邀请码",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
+                        "type": "integer",
+                        "description": "邀请码ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "查询参数",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.PageRoleRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/api/v1/role/permission": {
-            "post": {
-                "description": "为角色添加权限（支持通配符）",
+        "/api/v1/loginLog": {
+            "put": {
+                "description": "更新登录日志记录",
                 "consumes": [
                     "application/json"
                 ],
@@ -3042,44 +2746,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "登录日志"
                 ],
-                "summary": "为角色添加权限",
+                "summary": "更新登录日志",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "权限信息",
-                        "name": "body",
+                        "description": "更新登录日志请求",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.AddRolePermissionRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.UpdateLoginLogRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "更新成功",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             },
-            "delete": {
-                "description": "删除角色的指定权限",
+            "post": {
+                "description": "创建新的登录日志记录",
                 "consumes": [
                     "application/json"
                 ],
@@ -3087,58 +2795,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "登录日志"
                 ],
-                "summary": "删除角色权限",
+                "summary": "创建登录日志",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "角色标识",
-                        "name": "roleKey",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "资源路径",
-                        "name": "resource",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "操作类型",
-                        "name": "action",
-                        "in": "query",
-                        "required": true
+                        "description": "创建登录日志请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CreateLoginLogRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "创建成功",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/api/v1/role/permissions": {
-            "get": {
-                "description": "获取角色的所有权限",
+        "/api/v1/loginLog/batch": {
+            "delete": {
+                "description": "批量删除登录日志记录",
                 "consumes": [
                     "application/json"
                 ],
@@ -3146,59 +2846,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "登录日志"
                 ],
-                "summary": "获取角色的所有权限",
+                "summary": "批量删除登录日志",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "角色标识",
-                        "name": "roleKey",
-                        "in": "query",
-                        "required": true
+                        "description": "批量删除请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteLoginLogRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "删除成功",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.RolePermissionResponse"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/api/v1/role/remove": {
-            "delete": {
-                "description": "移除指定用户的角色",
+        "/api/v1/loginLog/clean": {
+            "post": {
+                "description": "清理指定天数之前的登录日志",
                 "consumes": [
                     "application/json"
                 ],
@@ -3206,51 +2897,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "登录日志"
                 ],
-                "summary": "移除用户的角色",
+                "summary": "清理登录日志",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "用户ID",
-                        "name": "userId",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "角色ID",
-                        "name": "roleId",
-                        "in": "query",
-                        "required": true
+                        "description": "清理日志请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CleanLoginLogRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "清理成功",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/api/v1/role/user": {
+        "/api/v1/loginLog/locks": {
             "get": {
-                "description": "获取用户的所有角色",
+                "description": "查询当前租户因登录失败次数过多而被锁定的账号、IP + 账号和 IP，按解锁时间倒序",
                 "consumes": [
                     "application/json"
                 ],
@@ -3258,28 +2948,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
-                ],
-                "summary": "获取用户的所有角色",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "用户ID",
-                        "name": "userId",
-                        "in": "query",
-                        "required": true
-                    }
+                    "登录日志"
                 ],
+                "summary": "查询登录锁定",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "查询成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -3291,7 +2965,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.RoleResponse"
+                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.LoginLockResponse"
                                             }
                                         }
                                     }
@@ -3299,18 +2973,23 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "参数错误",
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/api/v1/role/{roleId}": {
-            "get": {
-                "description": "根据角色ID获取角色详情",
+        "/api/v1/loginLog/locks/unlock": {
+            "post": {
+                "description": "解除当前租户指定账号或 IP 的登录锁定，同时清除失败计数和锁定级别",
                 "consumes": [
                     "application/json"
                 ],
@@ -3318,54 +2997,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "登录日志"
                 ],
-                "summary": "获取角色详情",
+                "summary": "解除登录锁定",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "角色ID",
-                        "name": "roleId",
-                        "in": "path",
-                        "required": true
+                        "description": "解锁请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.UnlockLoginRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "解锁成功",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.RoleResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "请求参数错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            },
-            "delete": {
-                "description": "删除角色",
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/loginLog/{id}": {
+            "get": {
+                "description": "根据日志ID查询登录日志详情",
                 "consumes": [
                     "application/json"
                 ],
@@ -3373,44 +3042,58 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "角色管理"
+                    "登录日志"
                 ],
-                "summary": "删除角色",
+                "summary": "根据ID查询登录日志",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
                     {
                         "type": "integer",
-                        "description": "角色ID",
-                        "name": "roleId",
+                        "description": "日志ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "查询成功",
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.LoginLogResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/storage-env": {
-            "put": {
-                "description": "更新存储环境配置",
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "删除单个登录日志记录",
                 "consumes": [
                     "application/json"
                 ],
@@ -3418,91 +3101,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "存储环境管理"
+                    "登录日志"
                 ],
-                "summary": "更新存储环境",
+                "summary": "删除登录日志",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
+                        "type": "integer",
+                        "description": "日志ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "存储环境信息",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.UpdateStorageEnvRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "删除成功",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
-                    }
-                }
-            },
-            "post": {
-                "description": "创建新的存储环境配置",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "存储环境管理"
-                ],
-                "summary": "创建存储环境",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
                     },
-                    {
-                        "description": "存储环境信息",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CreateStorageEnvRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.StorageEnvResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/api/v1/storage-env/default": {
+        "/api/v1/menu": {
             "get": {
-                "description": "获取当前默认的存储环境",
+                "description": "获取所有菜单的列表形式",
                 "consumes": [
                     "application/json"
                 ],
@@ -3510,21 +3150,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "存储环境管理"
-                ],
-                "summary": "获取默认存储环境",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    }
+                    "菜单管理"
                 ],
+                "summary": "获取菜单列表",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -3534,17 +3165,31 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.StorageEnvResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_model.Menu"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "500": {
+                        "description": "服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             },
             "post": {
-                "description": "设置指定环境为默认存储环境",
+                "description": "创建新的菜单项",
                 "consumes": [
                     "application/json"
                 ],
@@ -3552,40 +3197,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "存储环境管理"
+                    "菜单管理"
                 ],
-                "summary": "设置默认存储环境",
+                "summary": "创建菜单",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "环境ID",
-                        "name": "body",
+                        "description": "菜单信息",
+                        "name": "menu",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.SetDefaultStorageEnvRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_model.Menu"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "成功",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/api/v1/storage-env/page": {
-            "post": {
-                "description": "使用 Paginator 分页查询存储环境列表",
+        "/api/v1/menu/tree": {
+            "get": {
+                "description": "获取所有菜单的树形结构，用于菜单管理页面",
                 "consumes": [
                     "application/json"
                 ],
@@ -3593,30 +3248,61 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "存储环境管理"
+                    "菜单管理"
                 ],
-                "summary": "分页查询存储环境列表",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "查询参数",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
+                "summary": "获取菜单树",
+                "responses": {
+                    "200": {
+                        "description": "成功",
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.PageStorageEnvsRequest"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_service.MenuTree"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/menu/user/tree": {
+            "get": {
+                "description": "获取当前登录用户的菜单树，用于前端生成动态路由",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "菜单管理"
                 ],
+                "summary": "获取用户菜单树",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -3626,19 +3312,39 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_service.MenuTree"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/api/v1/storage-env/{id}": {
+        "/api/v1/menu/{id}": {
             "get": {
-                "description": "根据环境ID获取存储环境详情",
+                "description": "根据菜单ID获取菜单详细信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -3646,20 +3352,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "存储环境管理"
+                    "菜单管理"
                 ],
-                "summary": "获取存储环境详情",
+                "summary": "获取菜单详情",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
                     {
                         "type": "integer",
-                        "description": "环境ID",
+                        "description": "菜单ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -3667,7 +3366,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -3677,17 +3376,40 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.StorageEnvResponse"
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_model.Menu"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "菜单不存在",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             },
-            "delete": {
-                "description": "删除存储环境（不能删除默认环境）",
+            "put": {
+                "description": "更新菜单信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -3695,38 +3417,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "存储环境管理"
+                    "菜单管理"
                 ],
-                "summary": "删除存储环境",
+                "summary": "更新菜单",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
                     {
                         "type": "integer",
-                        "description": "环境ID",
+                        "description": "菜单ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "菜单信息",
+                        "name": "menu",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_model.Menu"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "成功",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/storage-env/{id}/test": {
-            "post": {
-                "description": "测试指定存储环境的连接是否正常",
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "删除指定的菜单",
                 "consumes": [
                     "application/json"
                 ],
@@ -3734,51 +3473,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "存储环境管理"
+                    "菜单管理"
                 ],
-                "summary": "测试存储环境连接",
+                "summary": "删除菜单",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
                     {
                         "type": "integer",
-                        "description": "环境ID",
+                        "description": "菜单ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "测试参数",
-                        "name": "body",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.TestStorageEnvConnectionRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "成功",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/system/dict/page": {
-            "get": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "分页查询字典列表，支持按类型、标签、状态筛选",
+                ]
+            }
+        },
+        "/api/v1/oauth/authorize": {
+            "get": {
+                "description": "第三方应用将用户重定向到前端授权页，前端携带原始查询参数调用本接口校验请求并展示申请的授权范围；\napproved=true 表示用户此前已同意（或客户端免确认），前端可直接提交确认",
                 "consumes": [
                     "application/json"
                 ],
@@ -3786,98 +3522,92 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "字典管理"
+                    "OAuth2"
                 ],
-                "summary": "分页查询字典列表",
+                "summary": "获取授权确认页信息",
                 "parameters": [
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "pageNum",
+                        "type": "string",
+                        "example": "internal-app",
+                        "description": "客户端Key",
+                        "name": "client_id",
                         "in": "query",
                         "required": true
                     },
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "pageSize",
+                        "type": "string",
+                        "example": "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+                        "description": "PKCE 挑战值",
+                        "name": "code_challenge",
                         "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "example": "\"sort\"",
-                        "description": "排序列",
-                        "name": "orderByColumn",
-                        "in": "query"
+                        "example": "S256",
+                        "description": "PKCE 挑战方法，仅支持 S256",
+                        "name": "code_challenge_method",
+                        "in": "query",
+                        "required": true
                     },
                     {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
                         "type": "string",
-                        "example": "\"asc\"",
-                        "description": "排序方向",
-                        "name": "isAsc",
+                        "example": "https://app.example.com/callback",
+                        "description": "回调地址（客户端仅登记一个时可省略）",
+                        "name": "redirect_uri",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "字典类型",
-                        "name": "dictType",
-                        "in": "query"
+                        "example": "code",
+                        "description": "响应类型，固定为 code",
+                        "name": "response_type",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "字典标签（模糊查询）",
-                        "name": "dictLabel",
+                        "example": "profile email",
+                        "description": "授权范围（空格分隔，为空时申请客户端允许的全部范围）",
+                        "name": "scope",
                         "in": "query"
                     },
                     {
-                        "enum": [
-                            0,
-                            1,
-                            -1
-                        ],
-                        "type": "integer",
-                        "description": "状态：0正常 1停用 -1全部",
-                        "name": "status",
+                        "type": "string",
+                        "example": "af0ifjsldkj",
+                        "description": "客户端状态值，原样回传",
+                        "name": "state",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "查询成功",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.OAuthConsentResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/system/loginLog/page": {
-            "post": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "分页查询登录日志列表，支持按用户名、IP、状态、时间范围筛选",
+                ]
+            },
+            "post": {
+                "description": "用户同意后生成一次性授权码，返回携带 code 和 state 的回调地址；拒绝时回调地址携带 error=access_denied。前端收到后直接跳转",
                 "consumes": [
                     "application/json"
                 ],
@@ -3885,106 +3615,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "登录日志"
+                    "OAuth2"
                 ],
-                "summary": "分页查询登录日志列表",
+                "summary": "确认授权",
                 "parameters": [
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "pageNum",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "description": "每页数量",
-                        "name": "pageSize",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"loginTime\"",
-                        "description": "排序列",
-                        "name": "orderByColumn",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
-                        "type": "string",
-                        "example": "\"desc\"",
-                        "description": "排序方向",
-                        "name": "isAsc",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "用户名（模糊查询）",
-                        "name": "userName",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "登录IP（模糊查询）",
-                        "name": "ipaddr",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            0,
-                            1
-                        ],
-                        "type": "integer",
-                        "description": "登录状态：0成功 1失败，不传或null表示全部",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"2024-01-01 00:00:00\"",
-                        "description": "开始时间",
-                        "name": "startTime",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "example": "\"2024-12-31 23:59:59\"",
-                        "description": "结束时间",
-                        "name": "endTime",
-                        "in": "query"
+                        "description": "授权请求参数及确认结果",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.OAuthConsentRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "查询成功",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.OAuthAuthorizeResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/api/v1/user": {
-            "post": {
-                "description": "创建新用户",
+        "/api/v1/oauth/consents": {
+            "get": {
+                "description": "列出当前用户已授权的第三方应用及授予的范围",
                 "consumes": [
                     "application/json"
                 ],
@@ -3992,27 +3666,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
-                ],
-                "summary": "创建用户",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "用户信息",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CreateUserRequest"
-                        }
-                    }
+                    "OAuth2"
                 ],
+                "summary": "查询我授权的应用",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -4025,25 +3681,27 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.OAuthConsentGrantResponse"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
-                    },
-                    "400": {
-                        "description": "参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/api/v1/user/batch": {
+        "/api/v1/oauth/consents/{clientId}": {
             "delete": {
-                "description": "批量删除多个用户（软删除）",
+                "description": "撤销当前用户对指定应用的授权，该应用持有的刷新令牌立即失效，下次登录需重新确认授权",
                 "consumes": [
                     "application/json"
                 ],
@@ -4051,25 +3709,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "OAuth2"
                 ],
-                "summary": "批量删除用户",
+                "summary": "撤销应用授权",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
+                        "description": "客户端Key",
+                        "name": "clientId",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "用户ID列表",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteUsersRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -4078,19 +3727,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
-                    },
-                    "400": {
-                        "description": "参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/api/v1/user/import": {
-            "post": {
-                "description": "批量导入多个用户，返回成功和失败的统计信息",
+        "/api/v1/operLog": {
+            "put": {
+                "description": "更新操作日志记录",
                 "consumes": [
                     "application/json"
                 ],
@@ -4098,58 +3746,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "操作日志"
                 ],
-                "summary": "批量导入用户",
+                "summary": "更新操作日志",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "用户列表",
-                        "name": "body",
+                        "description": "更新操作日志请求",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.BatchImportUsersRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.UpdateOperLogRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "更新成功",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "请求参数错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/user/page": {
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            },
             "post": {
-                "description": "使用 Paginator 分页查询用户列表",
+                "description": "创建新的操作日志记录",
                 "consumes": [
                     "application/json"
                 ],
@@ -4157,58 +3795,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "操作日志"
                 ],
-                "summary": "分页查询用户列表",
+                "summary": "创建操作日志",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "查询参数",
-                        "name": "body",
+                        "description": "创建操作日志请求",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.PageUsersRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CreateOperLogRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "创建成功",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "object"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/api/v1/user/password/change": {
-            "post": {
-                "description": "用户修改自己的密码（需要验证旧密码）",
+        "/api/v1/operLog/batch": {
+            "delete": {
+                "description": "批量删除操作日志记录",
                 "consumes": [
                     "application/json"
                 ],
@@ -4216,46 +3846,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "操作日志"
                 ],
-                "summary": "用户修改密码",
+                "summary": "批量删除操作日志",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "密码信息",
-                        "name": "body",
+                        "description": "批量删除请求",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.ChangePasswordRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteOperLogRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "删除成功",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/api/v1/user/{id}": {
-            "get": {
-                "description": "根据用户ID获取用户详细信息",
+        "/api/v1/operLog/clean": {
+            "post": {
+                "description": "清理指定天数之前的操作日志",
                 "consumes": [
                     "application/json"
                 ],
@@ -4263,54 +3897,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "操作日志"
                 ],
-                "summary": "获取用户详情",
+                "summary": "清理操作日志",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "用户ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "清理日志请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CleanOperLogRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "清理成功",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.UserResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            },
-            "put": {
-                "description": "更新用户信息",
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/operLog/page": {
+            "post": {
+                "description": "分页查询操作日志列表，支持按标题、操作者、业务类型、状态、时间范围筛选",
                 "consumes": [
                     "application/json"
                 ],
@@ -4318,51 +3948,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "操作日志"
                 ],
-                "summary": "更新用户",
+                "summary": "分页查询操作日志列表",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "用户ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "用户信息",
+                        "description": "查询参数",
                         "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.UpdateUserRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.PageOperLogRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "查询成功",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            },
-            "delete": {
-                "description": "删除指定用户（软删除）",
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/operLog/{id}": {
+            "get": {
+                "description": "根据日志ID查询操作日志详情",
                 "consumes": [
                     "application/json"
                 ],
@@ -4370,20 +3999,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "操作日志"
                 ],
-                "summary": "删除用户",
+                "summary": "根据ID查询操作日志",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
                     {
                         "type": "integer",
-                        "description": "用户ID",
+                        "description": "日志ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -4391,23 +4013,44 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "查询成功",
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.OperLogResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            }
-        },
-        "/api/v1/user/{id}/password": {
-            "put": {
-                "description": "管理员重置指定用户的密码",
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "删除单个操作日志记录",
                 "consumes": [
                     "application/json"
                 ],
@@ -4415,53 +4058,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户管理"
+                    "操作日志"
                 ],
-                "summary": "重置用户密码",
+                "summary": "删除操作日志",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bearer {token}",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
                     {
                         "type": "integer",
-                        "description": "用户ID",
+                        "description": "日志ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "新密码",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.ResetPasswordRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "删除成功",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/auth/refresh": {
+        "/api/v1/org": {
             "post": {
-                "description": "使用 RefreshToken 获取新的 AccessToken 和 RefreshToken（轮换机制）",
+                "description": "创建新组织，需要管理员权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -4469,23 +4107,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "认证"
+                    "组织管理"
                 ],
-                "summary": "刷新访问令牌",
+                "summary": "创建组织",
                 "parameters": [
                     {
-                        "description": "刷新令牌请求参数",
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "创建组织请求",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.RefreshTokenRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CreateOrgRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "创建成功，返回组织ID",
                         "schema": {
                             "allOf": [
                                 {
@@ -4495,7 +4140,8 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.RefreshTokenResponse"
+                                            "type": "integer",
+                                            "format": "int64"
                                         }
                                     }
                                 }
@@ -4509,16 +4155,28 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "RefreshToken 无效或已过期",
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/captcha/email": {
-            "post": {
+        "/api/v1/org/batch": {
+            "delete": {
+                "description": "批量删除多个组织（软删除），需要管理员权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -4526,23 +4184,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "验证码"
+                    "组织管理"
                 ],
-                "summary": "发送邮箱验证码",
+                "summary": "批量删除组织",
                 "parameters": [
                     {
-                        "description": "邮箱",
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "批量删除请求",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/internal_controller.SendEmailCaptchaRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteOrgsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "删除成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -4552,18 +4217,42 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_infrastructure_captcha.CaptchaData"
+                                            "type": "string"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/captcha/image": {
-            "get": {
+        "/api/v1/org/page": {
+            "post": {
+                "description": "使用 Paginator 分页查询组织列表",
                 "consumes": [
                     "application/json"
                 ],
@@ -4571,9 +4260,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "验证码"
+                    "组织管理"
+                ],
+                "summary": "分页查询组织列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "查询参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.PageOrgsRequest"
+                        }
+                    }
                 ],
-                "summary": "生成图形验证码",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -4586,18 +4293,30 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_infrastructure_captcha.CaptchaData"
+                                            "type": "object"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/captcha/sms": {
-            "post": {
+        "/api/v1/org/tree": {
+            "get": {
+                "description": "获取完整的组织树结构",
                 "consumes": [
                     "application/json"
                 ],
@@ -4605,23 +4324,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "验证码"
+                    "组织管理"
                 ],
-                "summary": "发送短信验证码",
+                "summary": "获取组织树",
                 "parameters": [
                     {
-                        "description": "手机号",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/internal_controller.SendSMSCaptchaRequest"
-                        }
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "查询成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -4631,28 +4348,68 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_infrastructure_captcha.CaptchaData"
+                                            "type": "array",
+                                            "items": {
+                                                "type": "object"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
             }
         },
-        "/captcha/types": {
+        "/api/v1/org/{id}": {
             "get": {
+                "description": "根据组织ID查询组织详细信息",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "验证码"
+                    "组织管理"
+                ],
+                "summary": "查询组织详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "组织ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "获取已启用的验证码类型",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "查询成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -4662,135 +4419,46 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "type": "string"
-                                            }
+                                            "type": "object"
                                         }
                                     }
                                 }
                             ]
                         }
-                    }
-                }
-            }
-        },
-        "/health": {
-            "get": {
-                "description": "检查服务是否正常运行",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "系统监控"
-                ],
-                "summary": "健康检查",
-                "responses": {
-                    "200": {
-                        "description": "服务正常",
-                        "schema": {
-                            "$ref": "#/definitions/internal_controller.HealthResponse"
-                        }
                     },
-                    "503": {
-                        "description": "服务异常",
-                        "schema": {
-                            "$ref": "#/definitions/internal_controller.HealthResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/health/live": {
-            "get": {
-                "description": "检查服务是否存活（Kubernetes Liveness Probe）",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "系统监控"
-                ],
-                "summary": "存活检查",
-                "responses": {
-                    "200": {
-                        "description": "服务存活",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/health/ready": {
-            "get": {
-                "description": "检查服务是否准备好接收流量（Kubernetes Readiness Probe）",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "系统监控"
-                ],
-                "summary": "就绪检查",
-                "responses": {
-                    "200": {
-                        "description": "服务就绪",
+                    "400": {
+                        "description": "参数错误",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
-                    "503": {
-                        "description": "服务未就绪",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/health/startup": {
-            "get": {
-                "description": "检查服务是否已完成启动（Kubernetes Startup Probe）",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "系统监控"
-                ],
-                "summary": "启动检查",
-                "responses": {
-                    "200": {
-                        "description": "服务已启动",
+                    },
+                    "404": {
+                        "description": "组织不存在",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     },
-                    "503": {
-                        "description": "服务启动中",
+                    "500": {
+                        "description": "服务器错误",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            }
-        },
-        "/login": {
-            "post": {
-                "description": "支持多种登录方式：密码登录(password)、邮箱验证码(email)、微信小程序(xcx)",
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "更新指定组织的信息，需要管理员权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -4798,23 +4466,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "认证"
+                    "组织管理"
                 ],
-                "summary": "用户登录",
+                "summary": "更新组织信息",
                 "parameters": [
                     {
-                        "description": "登录请求参数",
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "组织ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新组织请求",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.LoginRequest"
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.UpdateOrgRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "更新成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -4824,7 +4506,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.LoginResponse"
+                                            "type": "string"
                                         }
                                     }
                                 }
@@ -4838,22 +4520,32 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "认证失败",
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "组织不存在",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器错误",
                         "schema": {
                             "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
                         }
                     }
-                }
-            }
-        },
-        "/logout": {
-            "post": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "使当前用户的 RefreshToken 失效，AccessToken 会在过期后自动失效",
+                ]
+            },
+            "delete": {
+                "description": "删除指定组织（软删除），需要管理员权限",
                 "consumes": [
                     "application/json"
                 ],
@@ -4861,12 +4553,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "认证"
+                    "组织管理"
+                ],
+                "summary": "删除组织",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "组织ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "用户登出",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "删除成功",
                         "schema": {
                             "allOf": [
                                 {
@@ -4882,18 +4590,42 @@ const docTemplate = `{
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "组织不存在",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
                     }
-                }
-            }
-        },
-        "/me": {
-            "get": {
+                },
                 "security": [
                     {
                         "Bearer": []
                     }
-                ],
-                "description": "获取当前登录用户的基本信息",
+                ]
+            }
+        },
+        "/api/v1/role": {
+            "put": {
+                "description": "更新角色信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -4901,9 +4633,72 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "认证"
+                    "角色管理"
+                ],
+                "summary": "更新角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "角色信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.UpdateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "创建新角色",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "创建角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "角色信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CreateRoleRequest"
+                        }
+                    }
                 ],
-                "summary": "获取当前用户信息",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -4916,351 +4711,5609 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "properties": {
-                                                "userId": {
-                                                    "type": "integer",
-                                                    "format": "int64"
-                                                }
-                                            }
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.RoleResponse"
                                         }
                                     }
                                 }
                             ]
                         }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_force-c_nai-tizi_internal_domain_model.Menu": {
+        },
+        "/api/v1/role/assign": {
+            "post": {
+                "description": "为用户分配角色",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "为用户分配角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "分配信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.AssignRoleToUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/role/page": {
+            "post": {
+                "description": "使用 Paginator 分页查询角色列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "分页查询角色列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "查询参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.PageRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/role/permission": {
+            "post": {
+                "description": "为角色添加权限（支持通配符）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "为角色添加权限",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "权限信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.AddRolePermissionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除角色的指定权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "删除角色权限",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "角色标识",
+                        "name": "roleKey",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "资源路径",
+                        "name": "resource",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "操作类型",
+                        "name": "action",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/role/permissions": {
+            "get": {
+                "description": "获取角色的所有权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "获取角色的所有权限",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "角色标识",
+                        "name": "roleKey",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.RolePermissionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/role/remove": {
+            "delete": {
+                "description": "移除指定用户的角色",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "移除用户的角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "角色ID",
+                        "name": "roleId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/role/user": {
+            "get": {
+                "description": "获取用户的所有角色",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "获取用户的所有角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "userId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.RoleResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/role/{roleId}": {
+            "get": {
+                "description": "根据角色ID获取角色详情",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "获取角色详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "角色ID",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.RoleResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除角色",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "角色管理"
+                ],
+                "summary": "删除角色",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "角色ID",
+                        "name": "roleId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/session/user/{userId}": {
+            "get": {
+                "description": "管理员查询指定用户所有活跃的登录会话，目标用户须在当前租户和数据权限范围内",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "在线会话"
+                ],
+                "summary": "查询用户在线会话",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.SessionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "管理员注销指定用户的全部登录会话，该用户此前签发的所有 Token 立即失效；目标用户须在当前租户和数据权限范围内",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "在线会话"
+                ],
+                "summary": "强制下线用户",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/session/user/{userId}/{sessionId}": {
+            "delete": {
+                "description": "管理员注销指定用户的某个登录会话，目标用户须在当前租户和数据权限范围内",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "在线会话"
+                ],
+                "summary": "强制下线用户会话",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "会话ID",
+                        "name": "sessionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/storage-env": {
+            "put": {
+                "description": "更新存储环境配置",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "存储环境管理"
+                ],
+                "summary": "更新存储环境",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "存储环境信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.UpdateStorageEnvRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "创建新的存储环境配置",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "存储环境管理"
+                ],
+                "summary": "创建存储环境",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "存储环境信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CreateStorageEnvRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.StorageEnvResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/storage-env/default": {
+            "get": {
+                "description": "获取当前默认的存储环境",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "存储环境管理"
+                ],
+                "summary": "获取默认存储环境",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.StorageEnvResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "设置指定环境为默认存储环境",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "存储环境管理"
+                ],
+                "summary": "设置默认存储环境",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "环境ID",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.SetDefaultStorageEnvRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/storage-env/page": {
+            "post": {
+                "description": "使用 Paginator 分页查询存储环境列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "存储环境管理"
+                ],
+                "summary": "分页查询存储环境列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "查询参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.PageStorageEnvsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/storage-env/{id}": {
+            "get": {
+                "description": "根据环境ID获取存储环境详情",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "存储环境管理"
+                ],
+                "summary": "获取存储环境详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "环境ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.StorageEnvResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除存储环境（不能删除默认环境）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "存储环境管理"
+                ],
+                "summary": "删除存储环境",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "环境ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/storage-env/{id}/test": {
+            "post": {
+                "description": "测试指定存储环境的连接是否正常",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "存储环境管理"
+                ],
+                "summary": "测试存储环境连接",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "环境ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "测试参数",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.TestStorageEnvConnectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/system/dict/page": {
+            "get": {
+                "description": "分页查询字典列表，支持按类型、标签、状态筛选",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "字典管理"
+                ],
+                "summary": "分页查询字典列表",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "pageNum",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"sort\"",
+                        "description": "排序列",
+                        "name": "orderByColumn",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "example": "\"asc\"",
+                        "description": "排序方向",
+                        "name": "isAsc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "字典类型",
+                        "name": "dictType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "字典标签（模糊查询）",
+                        "name": "dictLabel",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            0,
+                            1,
+                            -1
+                        ],
+                        "type": "integer",
+                        "description": "状态：0正常 1停用 -1全部",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/system/loginLog/page": {
+            "post": {
+                "description": "分页查询登录日志列表，支持按用户名、IP、状态、时间范围筛选",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "登录日志"
+                ],
+                "summary": "分页查询登录日志列表",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "pageNum",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"loginTime\"",
+                        "description": "排序列",
+                        "name": "orderByColumn",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "example": "\"desc\"",
+                        "description": "排序方向",
+                        "name": "isAsc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户名（模糊查询）",
+                        "name": "userName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "登录IP（模糊查询）",
+                        "name": "ipaddr",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            0,
+                            1
+                        ],
+                        "type": "integer",
+                        "description": "登录状态：0成功 1失败，不传或null表示全部",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2024-01-01 00:00:00\"",
+                        "description": "开始时间",
+                        "name": "startTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"2024-12-31 23:59:59\"",
+                        "description": "结束时间",
+                        "name": "endTime",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "查询成功",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/tenant": {
+            "post": {
+                "description": "开通租户：创建根组织和租户管理员，从模板租户复制角色、角色菜单、Casbin 权限、字典和参数配置；仅多租户模式可用，需要 tenant.create 权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "开通租户",
+                "parameters": [
+                    {
+                        "description": "租户信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CreateTenantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.TenantOnboardResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/tenant/page": {
+            "post": {
+                "description": "分页查询租户列表，仅平台租户可访问，需要 tenant.read 权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "分页查询租户",
+                "parameters": [
+                    {
+                        "description": "查询参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.PageTenantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_utils_pagination.Page-github_com_force-c_nai-tizi_internal_domain_response_TenantResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/tenant/{id}": {
+            "get": {
+                "description": "查询租户详情，仅平台租户可访问，需要 tenant.read 权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "查询租户详情",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "租户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.TenantResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "更新租户名称、套餐、到期时间和联系人，租户编码不可修改；需要 tenant.update 权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "更新租户",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "租户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "租户信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.UpdateTenantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "删除租户并清除其用户、组织、角色、字典、参数、日志、附件文件等全部数据和权限策略，不可恢复；默认租户和模板租户不能删除；需要 tenant.delete 权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "删除租户",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "租户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/tenant/{id}/resume": {
+            "post": {
+                "description": "恢复已停用的租户；需要 tenant.update 权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "恢复租户",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "租户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/tenant/{id}/suspend": {
+            "post": {
+                "description": "停用租户，租户用户无法登录，已签发的 Token 和 API Key 立即失效；默认租户不能停用；需要 tenant.update 权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "租户管理"
+                ],
+                "summary": "停用租户",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "租户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/user": {
+            "post": {
+                "description": "创建新用户",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "创建用户",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "用户信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/batch": {
+            "delete": {
+                "description": "批量删除多个用户（软删除）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "批量删除用户",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "用户ID列表",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteUsersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/import": {
+            "post": {
+                "description": "批量导入多个用户，返回成功和失败的统计信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "批量导入用户",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "用户列表",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.BatchImportUsersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/page": {
+            "post": {
+                "description": "使用 Paginator 分页查询用户列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "分页查询用户列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "查询参数",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.PageUsersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/password/change": {
+            "post": {
+                "description": "用户修改自己的密码（需要验证旧密码）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "用户修改密码",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "密码信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/{id}": {
+            "get": {
+                "description": "根据用户ID获取用户详细信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "获取用户详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "更新用户信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "更新用户",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "用户信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除指定用户（软删除）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "删除用户",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/user/{id}/api-keys": {
+            "get": {
+                "description": "管理员查询指定用户（含服务账号）的 API Key，需要 api_key.read 权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Key"
+                ],
+                "summary": "查询用户的 API Key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.ApiKeyResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "管理员为服务账号（userType=3）创建 API Key，需要 api_key.create 权限；实际权限为授权范围与服务账号角色权限的交集",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Key"
+                ],
+                "summary": "为服务账号创建 API Key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "服务账号用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "API Key 信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.ApiKeyCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.ApiKeyCreateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/user/{id}/api-keys/{keyId}": {
+            "delete": {
+                "description": "管理员删除指定用户的 API Key，删除后立即失效，需要 api_key.delete 权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Key"
+                ],
+                "summary": "删除用户的 API Key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "API Key 记录ID",
+                        "name": "keyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/user/{id}/approve": {
+            "post": {
+                "description": "审批待审批状态的自助注册用户：通过后可以登录；拒绝将删除该注册，用户名和邮箱可重新注册。\n用户填写了邮箱时会邮件通知审批结果；需要 user.approve 权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "审批自助注册用户",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "审批结果",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.ApproveUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/user/{id}/impersonate": {
+            "post": {
+                "description": "管理员以目标用户身份签发短期 Token，用于排查用户问题，需要 user.impersonate 权限。\nToken 携带 act 声明标识管理员，不签发 RefreshToken；代登录期间的操作日志同时记录管理员，且不能修改密码、两步验证、管理凭证或再次代登录。\n不能代登录自己、服务账号、停用用户以及同样拥有代登录权限的用户，目标用户须在当前租户和数据权限范围内",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "代登录"
+                ],
+                "summary": "以用户身份登录（代登录）",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "目标用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "代登录原因",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.ImpersonateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.ImpersonateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/user/{id}/impersonations": {
+            "get": {
+                "description": "管理员查询指定用户被代登录的历史记录，需要 user.read 权限，目标用户须在当前租户和数据权限范围内",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "代登录"
+                ],
+                "summary": "查询用户被代登录的记录",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "pageNum",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页数量",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_utils_pagination.Page-github_com_force-c_nai-tizi_internal_domain_response_ImpersonationLogResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/user/{id}/mfa": {
+            "delete": {
+                "description": "管理员清除指定用户的 TOTP 绑定和恢复码（用户丢失认证器时使用）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "两步验证"
+                ],
+                "summary": "重置用户两步验证",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/api/v1/user/{id}/password": {
+            "put": {
+                "description": "管理员重置指定用户的密码",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户管理"
+                ],
+                "summary": "重置用户密码",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer {token}",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "用户ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "新密码",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login-risk/revoke": {
+            "post": {
+                "description": "用户收到异常登录提醒后，通过邮件或站内消息中的\"不是我本人\"链接注销对应会话，并将该设备移出已知设备。\n链接中的 token 只能使用一次，有效期由 auth.loginRisk.revokeTokenExpire 配置；注销后建议用户立即修改密码",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "认证"
+                ],
+                "summary": "注销异常登录的会话",
+                "parameters": [
+                    {
+                        "description": "提醒链接中的 token",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.LoginRiskRevokeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/mfa/enroll": {
+            "post": {
+                "description": "所属角色强制启用两步验证但尚未绑定时，登录返回 mfa_setup_required=true 和票据；\n使用票据获取绑定信息，扫码后以 grantType=mfa 提交票据和动态码完成绑定并登录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "两步验证"
+                ],
+                "summary": "登录时绑定两步验证",
+                "parameters": [
+                    {
+                        "description": "两步验证票据",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.MfaTicketEnrollRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.MfaEnrollResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/authorize": {
+            "post": {
+                "description": "前端跳转到 authorizeUrl，提供方回调后以 grantType=oidc 提交 code 和 state 完成登录；客户端需开通 oidc 授权类型",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "第三方登录"
+                ],
+                "summary": "获取第三方登录授权地址",
+                "parameters": [
+                    {
+                        "description": "客户端信息及提供方",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.OidcAuthorizeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.OidcAuthorizeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/providers": {
+            "get": {
+                "description": "返回已配置的 OIDC 提供方，用于登录页展示第三方登录按钮",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "第三方登录"
+                ],
+                "summary": "查询第三方登录提供方",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.OidcProviderResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password/forgot": {
+            "post": {
+                "description": "向账号绑定的邮箱发送重置链接，或向手机号发送验证码；账号是否存在均返回成功。\n同一账号 60 秒内只能申请一次，同一 IP 每小时最多 10 次",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "找回密码"
+                ],
+                "summary": "申请找回密码",
+                "parameters": [
+                    {
+                        "description": "找回方式和账号",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.PasswordForgotRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password/reset": {
+            "post": {
+                "description": "使用重置令牌设置新密码，新密码需符合密码策略；成功后令牌失效，该用户所有已登录会话被吊销",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "找回密码"
+                ],
+                "summary": "设置新密码",
+                "parameters": [
+                    {
+                        "description": "重置令牌和新密码",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.PasswordResetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password/verify": {
+            "post": {
+                "description": "校验邮件链接中的 token 或短信验证码，返回设置新密码使用的重置令牌；短信验证码最多尝试 5 次",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "找回密码"
+                ],
+                "summary": "校验找回密码凭证",
+                "parameters": [
+                    {
+                        "description": "凭证",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.PasswordResetVerifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.PasswordResetVerifyResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "使用 RefreshToken 获取新的 AccessToken 和 RefreshToken（轮换机制），已轮换的旧 RefreshToken 再次使用将吊销整个登录会话",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "认证"
+                ],
+                "summary": "刷新访问令牌",
+                "parameters": [
+                    {
+                        "description": "刷新令牌请求参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.RefreshTokenResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "RefreshToken 无效或已过期",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "填写邀请码时加入邀请码绑定的组织并获得其默认角色；否则为开放注册，必须填写并验证邮箱，且邮箱域名须在允许列表中。\n填写邮箱时必须提供邮箱验证码。注册到开启审批的组织时账号处于待审批状态，审批通过前不能登录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "自助注册"
+                ],
+                "summary": "自助注册",
+                "parameters": [
+                    {
+                        "description": "注册信息",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.RegisterResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register/email-code": {
+            "post": {
+                "description": "向注册邮箱发送 6 位验证码，10 分钟内有效；同一邮箱 60 秒内只能发送一次，同一 IP 每小时最多 20 次（与注册共用）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "自助注册"
+                ],
+                "summary": "发送注册邮箱验证码",
+                "parameters": [
+                    {
+                        "description": "邮箱和图形验证码",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.RegisterEmailCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register/options": {
+            "get": {
+                "description": "返回当前租户是否开放注册、支持的注册方式、允许的邮箱域名以及是否需要图形验证码",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "自助注册"
+                ],
+                "summary": "注册页面选项",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.RegistrationOptionsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sms/code": {
+            "post": {
+                "description": "向手机号发送登录验证码（grantType=sms/xcx 使用）；同一手机号 60 秒内只能发送一次，同一 IP 每小时最多发送 10 次",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "认证"
+                ],
+                "summary": "发送短信登录验证码",
+                "parameters": [
+                    {
+                        "description": "手机号",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.SendSmsCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/webauthn/login/options": {
+            "post": {
+                "description": "生成登录挑战，前端将 options 传给 navigator.credentials.get()，\n再以 grantType=webauthn 提交 challengeId 和返回的凭证完成登录；客户端需开通 webauthn 授权类型",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "通行密钥"
+                ],
+                "summary": "获取通行密钥登录参数",
+                "parameters": [
+                    {
+                        "description": "客户端信息及用户名（可选）",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.WebAuthnLoginOptionsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.WebAuthnLoginOptionsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/captcha/email": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "验证码"
+                ],
+                "summary": "发送邮箱验证码",
+                "parameters": [
+                    {
+                        "description": "邮箱",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.SendEmailCaptchaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_infrastructure_captcha.CaptchaData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/captcha/image": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "验证码"
+                ],
+                "summary": "生成图形验证码",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_infrastructure_captcha.CaptchaData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/captcha/sms": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "验证码"
+                ],
+                "summary": "发送短信验证码",
+                "parameters": [
+                    {
+                        "description": "手机号",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.SendSMSCaptchaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_infrastructure_captcha.CaptchaData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/captcha/types": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "验证码"
+                ],
+                "summary": "获取已启用的验证码类型",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "检查服务是否正常运行",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统监控"
+                ],
+                "summary": "健康检查",
+                "responses": {
+                    "200": {
+                        "description": "服务正常",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "服务异常",
+                        "schema": {
+                            "$ref": "#/definitions/internal_controller.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/live": {
+            "get": {
+                "description": "检查服务是否存活（Kubernetes Liveness Probe）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统监控"
+                ],
+                "summary": "存活检查",
+                "responses": {
+                    "200": {
+                        "description": "服务存活",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "检查服务是否准备好接收流量（Kubernetes Readiness Probe）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统监控"
+                ],
+                "summary": "就绪检查",
+                "responses": {
+                    "200": {
+                        "description": "服务就绪",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "服务未就绪",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/health/startup": {
+            "get": {
+                "description": "检查服务是否已完成启动（Kubernetes Startup Probe）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统监控"
+                ],
+                "summary": "启动检查",
+                "responses": {
+                    "200": {
+                        "description": "服务已启动",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "服务启动中",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "支持多种登录方式：密码登录(password)、邮箱验证码(email)、微信小程序(xcx)、通行密钥(webauthn)、第三方登录(oidc)、域账号(ldap)、短信验证码(sms)\n已启用两步验证的用户返回 mfa_required=true 和 mfa_ticket，需以 grantType=mfa 提交票据和动态码完成登录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "认证"
+                ],
+                "summary": "用户登录",
+                "parameters": [
+                    {
+                        "description": "登录请求参数",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "认证失败",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/logout": {
+            "post": {
+                "description": "使当前用户的 RefreshToken 失效，并立即吊销当前 AccessToken",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "认证"
+                ],
+                "summary": "用户登出",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/me": {
+            "get": {
+                "description": "获取当前登录用户的基本信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "认证"
+                ],
+                "summary": "获取当前用户信息",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "properties": {
+                                                "userId": {
+                                                    "type": "integer",
+                                                    "format": "int64"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        },
+        "/oauth/authorize": {
+            "get": {
+                "description": "第三方应用将用户重定向到此地址，服务端携带原始查询参数跳转到前端授权确认页（配置 oauth.consentPage）；\n用户未登录时由前端先完成登录",
+                "tags": [
+                    "OAuth2"
+                ],
+                "summary": "OAuth2 授权端点",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "internal-app",
+                        "description": "客户端Key",
+                        "name": "client_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+                        "description": "PKCE 挑战值",
+                        "name": "code_challenge",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "S256",
+                        "description": "PKCE 挑战方法，仅支持 S256",
+                        "name": "code_challenge_method",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "https://app.example.com/callback",
+                        "description": "回调地址（客户端仅登记一个时可省略）",
+                        "name": "redirect_uri",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "code",
+                        "description": "响应类型，固定为 code",
+                        "name": "response_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "profile email",
+                        "description": "授权范围（空格分隔，为空时申请客户端允许的全部范围）",
+                        "name": "scope",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "af0ifjsldkj",
+                        "description": "客户端状态值，原样回传",
+                        "name": "state",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    }
+                }
+            }
+        },
+        "/oauth/introspect": {
+            "post": {
+                "description": "RFC 7662，资源服务使用自身客户端凭证查询令牌是否有效；刷新令牌仅对其所属客户端可见",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OAuth2"
+                ],
+                "summary": "OAuth2 令牌内省",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "客户端Key（未使用 HTTP Basic 认证时必填）",
+                        "name": "client_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "客户端密钥（未使用 HTTP Basic 认证时必填）",
+                        "name": "client_secret",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "待内省或撤销的令牌",
+                        "name": "token",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "令牌类型提示：access_token / refresh_token",
+                        "name": "token_type_hint",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.OAuthIntrospectionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.OAuthErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth/revoke": {
+            "post": {
+                "description": "RFC 7009，客户端撤销自己持有的访问令牌或刷新令牌；令牌无效或不属于该客户端时同样返回 200",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OAuth2"
+                ],
+                "summary": "OAuth2 令牌撤销",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "客户端Key（未使用 HTTP Basic 认证时必填）",
+                        "name": "client_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "客户端密钥（未使用 HTTP Basic 认证时必填）",
+                        "name": "client_secret",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "待内省或撤销的令牌",
+                        "name": "token",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "令牌类型提示：access_token / refresh_token",
+                        "name": "token_type_hint",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.OAuthErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth/token": {
+            "post": {
+                "description": "支持 authorization_code（必须携带 PKCE code_verifier）、client_credentials、refresh_token；\n客户端认证使用 HTTP Basic（client_secret_basic）或表单参数（client_secret_post），client_id 即 clientKey",
+                "consumes": [
+                    "application/x-www-form-urlencoded"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OAuth2"
+                ],
+                "summary": "OAuth2 令牌端点",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "客户端Key（未使用 HTTP Basic 认证时必填）",
+                        "name": "client_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "客户端密钥（未使用 HTTP Basic 认证时必填）",
+                        "name": "client_secret",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "授权码（authorization_code 必填）",
+                        "name": "code",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "PKCE 校验值（authorization_code 必填）",
+                        "name": "code_verifier",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "example": "authorization_code",
+                        "description": "授权类型：authorization_code / client_credentials / refresh_token",
+                        "name": "grant_type",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "回调地址（须与授权请求一致）",
+                        "name": "redirect_uri",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "刷新令牌（refresh_token 必填）",
+                        "name": "refresh_token",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "授权范围（client_credentials / refresh_token 可选）",
+                        "name": "scope",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.OAuthTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.OAuthErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.OAuthErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/oauth/userinfo": {
+            "get": {
+                "description": "使用 OAuth2 访问令牌查询授权用户信息，返回字段随授予的范围（profile/email/phone）变化",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "OAuth2"
+                ],
+                "summary": "OAuth2 用户信息",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "email": {
+                                    "type": "string"
+                                },
+                                "name": {
+                                    "type": "string"
+                                },
+                                "phone_number": {
+                                    "type": "string"
+                                },
+                                "picture": {
+                                    "type": "string"
+                                },
+                                "preferred_username": {
+                                    "type": "string"
+                                },
+                                "sub": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.OAuthErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ]
+            }
+        }
+    },
+    "definitions": {
+        "github_com_force-c_nai-tizi_internal_domain_model.Menu": {
+            "type": "object",
+            "properties": {
+                "component": {
+                    "description": "组件路径",
+                    "type": "string"
+                },
+                "createBy": {
+                    "description": "创建人",
+                    "type": "integer"
+                },
+                "createdTime": {
+                    "type": "string"
+                },
+                "icon": {
+                    "description": "菜单图标",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "菜单ID（使用分布式ID）",
+                    "type": "integer"
+                },
+                "isCache": {
+                    "description": "是否缓存：0否 1是",
+                    "type": "integer"
+                },
+                "isFrame": {
+                    "description": "是否外链：0否 1是",
+                    "type": "integer"
+                },
+                "menuName": {
+                    "description": "菜单名称",
+                    "type": "string"
+                },
+                "menuType": {
+                    "description": "菜单类型：0目录 1菜单 2按钮",
+                    "type": "integer"
+                },
+                "parentId": {
+                    "description": "父菜单ID（0表示根菜单）",
+                    "type": "integer"
+                },
+                "path": {
+                    "description": "路由地址",
+                    "type": "string"
+                },
+                "perms": {
+                    "description": "权限标识（例如: user.create, user.*, *）",
+                    "type": "string"
+                },
+                "query": {
+                    "description": "路由参数",
+                    "type": "string"
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "sort": {
+                    "description": "显示顺序",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态：0正常 1停用",
+                    "type": "integer"
+                },
+                "updateBy": {
+                    "description": "更新人",
+                    "type": "integer"
+                },
+                "updatedTime": {
+                    "type": "string"
+                },
+                "visible": {
+                    "description": "显示状态：0显示 1隐藏",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.AddRolePermissionRequest": {
+            "type": "object",
+            "required": [
+                "action",
+                "orgId",
+                "resource",
+                "roleKey"
+            ],
+            "properties": {
+                "action": {
+                    "description": "操作类型（支持通配符）",
+                    "type": "string",
+                    "example": "write"
+                },
+                "orgId": {
+                    "description": "组织ID",
+                    "type": "integer",
+                    "example": 1
+                },
+                "resource": {
+                    "description": "资源路径（支持通配符）",
+                    "type": "string",
+                    "example": "user.*"
+                },
+                "roleKey": {
+                    "description": "角色标识",
+                    "type": "string",
+                    "example": "user_manager"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.ApiKeyCreateRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expireAt": {
+                    "description": "过期时间（时间戳），0 表示永不过期",
+                    "type": "integer",
+                    "example": 1767225600
+                },
+                "name": {
+                    "description": "名称",
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "CI 部署"
+                },
+                "scopes": {
+                    "description": "授权范围（权限资源，支持 module.*、*.read 通配）",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "user.read",
+                        "attachment.*"
+                    ]
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.ApproveUserRequest": {
+            "type": "object",
+            "properties": {
+                "approved": {
+                    "description": "true 通过 / false 拒绝（拒绝将删除该注册）",
+                    "type": "boolean"
+                },
+                "reason": {
+                    "description": "拒绝原因（会通过邮件告知用户）",
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": ""
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.AssignRoleToUserRequest": {
+            "type": "object",
+            "required": [
+                "orgId",
+                "roleId",
+                "userId"
+            ],
+            "properties": {
+                "orgId": {
+                    "description": "组织ID",
+                    "type": "integer",
+                    "example": 1
+                },
+                "roleId": {
+                    "description": "角色ID",
+                    "type": "integer",
+                    "example": 1
+                },
+                "userId": {
+                    "description": "用户ID",
+                    "type": "integer",
+                    "example": 1001
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteConfigRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "description": "配置ID列表",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteDictRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "description": "字典ID列表",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteLoginLogRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "description": "日志ID列表",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteOperLogRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "description": "日志ID列表",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteOrgsRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "description": "组织ID列表",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteUsersRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.BatchImportUsersRequest": {
+            "type": "object",
+            "required": [
+                "users"
+            ],
+            "properties": {
+                "users": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.ImportUserRequest"
+                    }
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.BindAttachmentToBusinessRequest": {
+            "type": "object",
+            "required": [
+                "businessId",
+                "businessType"
+            ],
+            "properties": {
+                "businessField": {
+                    "description": "业务字段",
+                    "type": "string"
+                },
+                "businessId": {
+                    "description": "业务ID",
+                    "type": "string"
+                },
+                "businessType": {
+                    "description": "业务类型",
+                    "type": "string"
+                },
+                "expireTime": {
+                    "description": "过期时间",
+                    "type": "string"
+                },
+                "isPublic": {
+                    "description": "是否公开",
+                    "type": "boolean"
+                },
+                "metadata": {
+                    "description": "元数据（JSON对象）",
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "newPassword",
+                "oldPassword"
+            ],
+            "properties": {
+                "newPassword": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "oldPassword": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.CleanLoginLogRequest": {
+            "type": "object",
+            "required": [
+                "days"
+            ],
+            "properties": {
+                "days": {
+                    "description": "清理多少天之前的日志",
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.CleanOperLogRequest": {
+            "type": "object",
+            "required": [
+                "days"
+            ],
+            "properties": {
+                "days": {
+                    "description": "清理多少天之前的日志",
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.CreateClientRequest": {
+            "type": "object",
+            "required": [
+                "activeTimeout",
+                "clientKey",
+                "grantTypes",
+                "timeout"
+            ],
+            "properties": {
+                "activeTimeout": {
+                    "description": "AccessToken 有效期及空闲超时时间（秒）",
+                    "type": "integer",
+                    "maximum": 31536000,
+                    "minimum": 60,
+                    "example": 1800
+                },
+                "autoApprove": {
+                    "description": "OAuth2 是否跳过用户授权确认",
+                    "type": "boolean",
+                    "example": false
+                },
+                "clientKey": {
+                    "description": "客户端Key（唯一，字母、数字、下划线或中划线，创建后不可修改）",
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "pc"
+                },
+                "clientName": {
+                    "description": "客户端名称（授权页展示）",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "管理后台"
+                },
+                "deviceType": {
+                    "description": "设备类型",
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "pc"
+                },
+                "grantTypes": {
+                    "description": "允许的授权类型",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "password",
+                        "refresh"
+                    ]
+                },
+                "idleCheck": {
+                    "description": "空闲超时检测：0启用 1关闭",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ],
+                    "example": 0
+                },
+                "redirectUris": {
+                    "description": "OAuth2 回调地址",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "https://app.example.com/callback"
+                    ]
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "PC 管理后台"
+                },
+                "scopes": {
+                    "description": "OAuth2 允许的授权范围",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "profile",
+                        "email"
+                    ]
+                },
+                "status": {
+                    "description": "状态：0正常 1停用",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ],
+                    "example": 0
+                },
+                "timeout": {
+                    "description": "RefreshToken 有效期（秒）",
+                    "type": "integer",
+                    "maximum": 31536000,
+                    "minimum": 60,
+                    "example": 604800
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.CreateConfigRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "createBy",
+                "name",
+                "updateBy"
+            ],
+            "properties": {
+                "code": {
+                    "description": "配置编码",
+                    "type": "string"
+                },
+                "createBy": {
+                    "description": "创建者",
+                    "type": "integer"
+                },
+                "data": {
+                    "description": "配置数据（JSON格式）",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "description": "配置名称",
+                    "type": "string"
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "updateBy": {
+                    "description": "更新者",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.CreateDictRequest": {
+            "type": "object",
+            "required": [
+                "dictLabel",
+                "dictType",
+                "dictValue"
+            ],
+            "properties": {
+                "createBy": {
+                    "description": "创建者",
+                    "type": "integer"
+                },
+                "dictLabel": {
+                    "description": "字典标签",
+                    "type": "string"
+                },
+                "dictType": {
+                    "description": "字典类型",
+                    "type": "string"
+                },
+                "dictValue": {
+                    "description": "字典键值",
+                    "type": "string"
+                },
+                "isDefault": {
+                    "description": "是否默认",
+                    "type": "boolean"
+                },
+                "parentId": {
+                    "description": "父字典ID（0表示根节点）",
+                    "type": "integer"
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "sort": {
+                    "description": "排序",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态：0正常 1停用",
+                    "type": "integer"
+                },
+                "updateBy": {
+                    "description": "更新者",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.CreateInviteCodeRequest": {
+            "type": "object",
+            "required": [
+                "orgId"
+            ],
+            "properties": {
+                "count": {
+                    "description": "批量生成数量，默认 1",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 1
+                },
+                "expireAt": {
+                    "description": "过期时间（时间戳），0 表示永不过期",
+                    "type": "integer",
+                    "example": 1767196800
+                },
+                "maxUses": {
+                    "description": "每个邀请码最多可使用次数，0 表示不限",
+                    "type": "integer",
+                    "maximum": 100000,
+                    "minimum": 0,
+                    "example": 1
+                },
+                "orgId": {
+                    "description": "注册用户所属组织ID",
+                    "type": "integer",
+                    "example": 1
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "2026 届校招"
+                },
+                "roleIds": {
+                    "description": "注册用户默认角色ID",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.CreateLoginLogRequest": {
+            "type": "object",
+            "required": [
+                "userName"
+            ],
+            "properties": {
+                "browser": {
+                    "description": "浏览器类型",
+                    "type": "string"
+                },
+                "clientId": {
+                    "description": "客户端ID",
+                    "type": "string"
+                },
+                "ipaddr": {
+                    "description": "登录IP",
+                    "type": "string"
+                },
+                "loginLocation": {
+                    "description": "登录地点",
+                    "type": "string"
+                },
+                "msg": {
+                    "description": "提示消息",
+                    "type": "string"
+                },
+                "os": {
+                    "description": "操作系统",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "登录状态：0成功 1失败",
+                    "type": "integer"
+                },
+                "userName": {
+                    "description": "用户名",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.CreateOperLogRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "businessType": {
+                    "description": "业务类型",
+                    "type": "string"
+                },
+                "costTime": {
+                    "description": "耗时（毫秒）",
+                    "type": "integer"
+                },
+                "deviceType": {
+                    "description": "终端类型：web/ios/android/wechat",
+                    "type": "string"
+                },
+                "errorMsg": {
+                    "description": "错误信息",
+                    "type": "string"
+                },
+                "jsonResult": {
+                    "description": "返回结果",
+                    "type": "string"
+                },
+                "method": {
+                    "description": "调用方法",
+                    "type": "string"
+                },
+                "operIp": {
+                    "description": "操作IP",
+                    "type": "string"
+                },
+                "operLocation": {
+                    "description": "操作地点",
+                    "type": "string"
+                },
+                "operName": {
+                    "description": "操作者",
+                    "type": "string"
+                },
+                "operParam": {
+                    "description": "请求参数",
+                    "type": "string"
+                },
+                "operUrl": {
+                    "description": "请求URL",
+                    "type": "string"
+                },
+                "requestMethod": {
+                    "description": "请求方式：GET/POST",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "操作状态：0成功 1失败",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "模块标题",
+                    "type": "string"
+                },
+                "userAgent": {
+                    "description": "UA",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.CreateOrgRequest": {
+            "type": "object",
+            "required": [
+                "orgCode",
+                "orgName"
+            ],
+            "properties": {
+                "email": {
+                    "description": "邮箱",
+                    "type": "string"
+                },
+                "leader": {
+                    "description": "负责人",
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "orgCode": {
+                    "description": "组织编码",
+                    "type": "string",
+                    "maxLength": 30,
+                    "minLength": 2
+                },
+                "orgName": {
+                    "description": "组织名称",
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2
+                },
+                "orgType": {
+                    "description": "组织类型",
+                    "type": "string",
+                    "enum": [
+                        "company",
+                        "department",
+                        "group"
+                    ]
+                },
+                "parentId": {
+                    "description": "父组织ID，0表示根组织",
+                    "type": "integer"
+                },
+                "phone": {
+                    "description": "联系电话",
+                    "type": "string",
+                    "maxLength": 11,
+                    "minLength": 11
+                },
+                "regApproval": {
+                    "description": "自助注册到该组织的用户是否需要审批",
+                    "type": "boolean"
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "sort": {
+                    "description": "显示顺序",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态：0正常 1停用",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.CreateRoleRequest": {
+            "type": "object",
+            "required": [
+                "roleKey",
+                "roleName"
+            ],
+            "properties": {
+                "dataScope": {
+                    "description": "数据范围：1全部 2自定义 3本组织 4本组织及以下 5仅本人，默认全部",
+                    "type": "integer",
+                    "enum": [
+                        1,
+                        2,
+                        3,
+                        4,
+                        5
+                    ],
+                    "example": 2
+                },
+                "orgIds": {
+                    "description": "自定义数据权限的组织ID（数据范围为自定义时必填）",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string",
+                    "example": "负责用户管理"
+                },
+                "requireMfa": {
+                    "description": "是否强制启用两步验证",
+                    "type": "boolean",
+                    "example": false
+                },
+                "roleKey": {
+                    "description": "角色标识（唯一）",
+                    "type": "string",
+                    "example": "user_manager"
+                },
+                "roleName": {
+                    "description": "角色名称",
+                    "type": "string",
+                    "example": "用户管理员"
+                },
+                "sort": {
+                    "description": "显示顺序",
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "description": "状态：0正常 1停用",
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.CreateStorageEnvRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "name",
+                "storageType"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "config": {
+                    "description": "JSON 对象",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "isDefault": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "remark": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态：0正常 1停用",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                },
+                "storageType": {
+                    "description": "存储类型：local/minio/s3/oss",
+                    "type": "string",
+                    "enum": [
+                        "local",
+                        "minio",
+                        "s3",
+                        "oss"
+                    ]
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.CreateTenantRequest": {
+            "type": "object",
+            "required": [
+                "adminPassword",
+                "adminUserName",
+                "tenantCode",
+                "tenantName"
+            ],
+            "properties": {
+                "adminNickName": {
+                    "description": "租户管理员昵称",
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "管理员"
+                },
+                "adminPassword": {
+                    "description": "租户管理员初始密码（按模板租户的密码策略校验）",
+                    "type": "string",
+                    "example": "Admin@123"
+                },
+                "adminUserName": {
+                    "description": "租户管理员用户名",
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "admin"
+                },
+                "contactName": {
+                    "description": "联系人",
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "张三"
+                },
+                "contactPhone": {
+                    "description": "联系电话",
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "13800000000"
+                },
+                "expireAt": {
+                    "description": "到期时间（时间戳），0 表示永不过期",
+                    "type": "integer",
+                    "example": 1798732800
+                },
+                "plan": {
+                    "description": "套餐",
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "standard"
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "tenantCode": {
+                    "description": "租户编码（小写字母、数字和中划线，用于子域名）",
+                    "type": "string",
+                    "maxLength": 31,
+                    "minLength": 2,
+                    "example": "acme"
+                },
+                "tenantName": {
+                    "description": "租户名称",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Acme 公司"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.CreateUserRequest": {
+            "type": "object",
+            "required": [
+                "nickName",
+                "orgId",
+                "password",
+                "userName"
+            ],
+            "properties": {
+                "avatar": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "nickName": {
+                    "type": "string"
+                },
+                "orgId": {
+                    "type": "integer"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "phonenumber": {
+                    "type": "string"
+                },
+                "remark": {
+                    "type": "string"
+                },
+                "sex": {
+                    "description": "性别：0男 1女 2未知",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1,
+                        2
+                    ]
+                },
+                "status": {
+                    "description": "状态：0正常 1停用",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                },
+                "userName": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 3
+                },
+                "userType": {
+                    "description": "用户类型：0系统用户 1微信用户 2APP用户 3服务账号",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.ImpersonateRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "description": "代登录原因（记录在审计日志中，目标用户可见）",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "工单 #1024：用户反馈订单页面显示异常"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.ImportUserRequest": {
+            "type": "object",
+            "required": [
+                "nickName",
+                "orgId",
+                "userName"
+            ],
+            "properties": {
+                "avatar": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "nickName": {
+                    "type": "string"
+                },
+                "orgId": {
+                    "type": "integer"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "passwordHash": {
+                    "description": "其他系统的密码哈希（bcrypt / argon2id / scrypt / $legacy$ 加盐摘要），提供时不校验密码策略，首次登录后自动升级为当前算法",
+                    "type": "string"
+                },
+                "phonenumber": {
+                    "type": "string"
+                },
+                "remark": {
+                    "type": "string"
+                },
+                "sex": {
+                    "description": "性别：0男 1女 2未知",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1,
+                        2
+                    ]
+                },
+                "status": {
+                    "description": "状态：0正常 1停用",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                },
+                "userName": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 3
+                },
+                "userType": {
+                    "description": "用户类型：0系统用户 1微信用户 2APP用户 3服务账号",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.LoginRequest": {
+            "description": "统一登录请求参数，根据 grantType 使用不同的字段组合",
+            "type": "object",
+            "required": [
+                "clientKey",
+                "clientSecret",
+                "grantType"
+            ],
+            "properties": {
+                "challengeId": {
+                    "description": "通行密钥（webauthn 必填）",
+                    "type": "string",
+                    "example": "0b6f3c9e-6a4f-4d8e-9a51-1c2f3e4d5a6b"
+                },
+                "clientKey": {
+                    "description": "客户端认证（必填）",
+                    "type": "string",
+                    "example": "web-admin"
+                },
+                "clientSecret": {
+                    "description": "客户端密钥",
+                    "type": "string",
+                    "example": "web-secret-2024"
+                },
+                "code": {
+                    "description": "验证码（email/xcx/sms 必填；mfa 时为动态验证码或恢复码；oidc 时为提供方回调返回的授权码）",
+                    "type": "string",
+                    "example": "123456"
+                },
+                "credential": {
+                    "description": "navigator.credentials.get() 返回的凭证（JSON）",
+                    "type": "object"
+                },
+                "deviceId": {
+                    "description": "设备标识（可选，客户端本地持久化的随机ID，用于识别新设备；为空时按 User-Agent 识别）",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "邮箱（email 必填）",
+                    "type": "string",
+                    "example": "admin@example.com"
+                },
+                "grantType": {
+                    "description": "授权类型：password-密码登录, email-邮箱验证码, xcx-微信小程序, mfa-两步验证, webauthn-通行密钥, oidc-第三方登录, ldap-域账号, sms-短信验证码",
+                    "type": "string",
+                    "enum": [
+                        "password",
+                        "email",
+                        "xcx",
+                        "mfa",
+                        "webauthn",
+                        "oidc",
+                        "ldap",
+                        "sms"
+                    ],
+                    "example": "password"
+                },
+                "mfaTicket": {
+                    "description": "两步验证票据（mfa 必填）",
+                    "type": "string",
+                    "example": "bWZhLXRpY2tldA=="
+                },
+                "password": {
+                    "description": "密码（password/ldap 必填）",
+                    "type": "string",
+                    "example": "admin123"
+                },
+                "phonenumber": {
+                    "description": "手机号（xcx/sms 必填）",
+                    "type": "string",
+                    "example": "13800138000"
+                },
+                "state": {
+                    "description": "提供方回调返回的 state（oidc 必填）",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "用户凭证（根据 grantType 选填）",
+                    "type": "string",
+                    "example": "admin"
+                },
+                "uuid": {
+                    "description": "图形验证码UUID（password 可选）",
+                    "type": "string",
+                    "example": "captcha-uuid-12345"
+                },
+                "wxCode": {
+                    "description": "微信code（xcx 必填）",
+                    "type": "string",
+                    "example": "wx-code-from-wechat"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.LoginRiskRevokeRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "description": "异常登录提醒链接中的 token",
+                    "type": "string",
+                    "maxLength": 128
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.MfaCodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "description": "动态验证码（6位）或恢复码",
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.MfaTicketEnrollRequest": {
+            "type": "object",
+            "required": [
+                "mfaTicket"
+            ],
+            "properties": {
+                "mfaTicket": {
+                    "description": "登录返回的两步验证票据",
+                    "type": "string",
+                    "example": "bWZhLXRpY2tldA=="
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.OAuthConsentRequest": {
+            "type": "object",
+            "required": [
+                "client_id",
+                "code_challenge",
+                "code_challenge_method",
+                "response_type"
+            ],
+            "properties": {
+                "approve": {
+                    "description": "是否同意授权",
+                    "type": "boolean",
+                    "example": true
+                },
+                "client_id": {
+                    "description": "客户端Key",
+                    "type": "string",
+                    "example": "internal-app"
+                },
+                "code_challenge": {
+                    "description": "PKCE 挑战值",
+                    "type": "string",
+                    "example": "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+                },
+                "code_challenge_method": {
+                    "description": "PKCE 挑战方法，仅支持 S256",
+                    "type": "string",
+                    "example": "S256"
+                },
+                "redirect_uri": {
+                    "description": "回调地址（客户端仅登记一个时可省略）",
+                    "type": "string",
+                    "example": "https://app.example.com/callback"
+                },
+                "response_type": {
+                    "description": "响应类型，固定为 code",
+                    "type": "string",
+                    "example": "code"
+                },
+                "scope": {
+                    "description": "授权范围（空格分隔，为空时申请客户端允许的全部范围）",
+                    "type": "string",
+                    "example": "profile email"
+                },
+                "state": {
+                    "description": "客户端状态值，原样回传",
+                    "type": "string",
+                    "example": "af0ifjsldkj"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.OidcAuthorizeRequest": {
+            "type": "object",
+            "required": [
+                "clientKey",
+                "clientSecret",
+                "provider"
+            ],
+            "properties": {
+                "clientKey": {
+                    "description": "客户端Key",
+                    "type": "string",
+                    "example": "web-admin"
+                },
+                "clientSecret": {
+                    "description": "客户端密钥",
+                    "type": "string",
+                    "example": "web-secret-2024"
+                },
+                "provider": {
+                    "description": "提供方标识",
+                    "type": "string",
+                    "example": "keycloak"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.OidcLinkAuthorizeRequest": {
+            "type": "object",
+            "required": [
+                "provider"
+            ],
+            "properties": {
+                "provider": {
+                    "description": "提供方标识",
+                    "type": "string",
+                    "example": "keycloak"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.OidcLinkRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "state"
+            ],
+            "properties": {
+                "code": {
+                    "description": "提供方回调返回的授权码",
+                    "type": "string"
+                },
+                "state": {
+                    "description": "提供方回调返回的 state",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.PageAttachmentsRequest": {
+            "type": "object",
+            "required": [
+                "pageNum",
+                "pageSize"
+            ],
+            "properties": {
+                "businessType": {
+                    "type": "string"
+                },
+                "fileName": {
+                    "type": "string"
+                },
+                "fileType": {
+                    "type": "string"
+                },
+                "pageNum": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "pageSize": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.PageClientRequest": {
+            "type": "object",
+            "properties": {
+                "clientKey": {
+                    "description": "客户端Key（模糊查询）",
+                    "type": "string",
+                    "example": "pc"
+                },
+                "clientName": {
+                    "description": "客户端名称（模糊查询）",
+                    "type": "string",
+                    "example": "管理后台"
+                },
+                "isAsc": {
+                    "description": "IsAsc 排序方向，asc或desc，支持多个，逗号分隔，如: \"asc,desc\"",
+                    "type": "string"
+                },
+                "orderByColumn": {
+                    "description": "OrderByColumn 排序列，支持多列排序，逗号分隔，如: \"id,createTime\"",
+                    "type": "string"
+                },
+                "pageNum": {
+                    "description": "PageNum 当前页码，从1开始",
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "description": "PageSize 每页数量",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态：0正常 1停用，不传表示不过滤",
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.PageConfigRequest": {
             "type": "object",
             "properties": {
-                "component": {
-                    "description": "组件路径",
+                "code": {
+                    "description": "配置编码(可选)",
                     "type": "string"
                 },
-                "createBy": {
-                    "description": "创建人",
+                "isAsc": {
+                    "description": "IsAsc 排序方向，asc或desc，支持多个，逗号分隔，如: \"asc,desc\"",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "配置名称(可选,模糊查询)",
+                    "type": "string"
+                },
+                "orderByColumn": {
+                    "description": "OrderByColumn 排序列，支持多列排序，逗号分隔，如: \"id,createTime\"",
+                    "type": "string"
+                },
+                "pageNum": {
+                    "description": "PageNum 当前页码，从1开始",
                     "type": "integer"
                 },
-                "createdTime": {
+                "pageSize": {
+                    "description": "PageSize 每页数量",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.PageInviteCodeRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "邀请码（可选，模糊查询）",
                     "type": "string"
                 },
-                "icon": {
-                    "description": "菜单图标",
+                "isAsc": {
+                    "description": "IsAsc 排序方向，asc或desc，支持多个，逗号分隔，如: \"asc,desc\"",
                     "type": "string"
                 },
-                "id": {
-                    "description": "菜单ID（使用分布式ID）",
+                "orderByColumn": {
+                    "description": "OrderByColumn 排序列，支持多列排序，逗号分隔，如: \"id,createTime\"",
+                    "type": "string"
+                },
+                "orgId": {
+                    "description": "组织ID（可选）",
                     "type": "integer"
                 },
-                "isCache": {
-                    "description": "是否缓存：0否 1是",
+                "pageNum": {
+                    "description": "PageNum 当前页码，从1开始",
                     "type": "integer"
                 },
-                "isFrame": {
-                    "description": "是否外链：0否 1是",
+                "pageSize": {
+                    "description": "PageSize 每页数量",
                     "type": "integer"
                 },
-                "menuName": {
-                    "description": "菜单名称",
+                "status": {
+                    "description": "状态（可选，nil 表示全部）",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.PageOperLogRequest": {
+            "type": "object",
+            "properties": {
+                "actorName": {
+                    "description": "代登录的管理员（可选,模糊查询）",
                     "type": "string"
                 },
-                "menuType": {
-                    "description": "菜单类型：0目录 1菜单 2按钮",
+                "businessType": {
+                    "description": "业务类型（可选）",
+                    "type": "string"
+                },
+                "endTime": {
+                    "description": "结束时间（可选）",
+                    "type": "string"
+                },
+                "isAsc": {
+                    "description": "IsAsc 排序方向，asc或desc，支持多个，逗号分隔，如: \"asc,desc\"",
+                    "type": "string"
+                },
+                "operName": {
+                    "description": "操作者（可选,模糊查询）",
+                    "type": "string"
+                },
+                "orderByColumn": {
+                    "description": "OrderByColumn 排序列，支持多列排序，逗号分隔，如: \"id,createTime\"",
+                    "type": "string"
+                },
+                "pageNum": {
+                    "description": "PageNum 当前页码，从1开始",
                     "type": "integer"
                 },
-                "parentId": {
-                    "description": "父菜单ID（0表示根菜单）",
+                "pageSize": {
+                    "description": "PageSize 每页数量",
                     "type": "integer"
                 },
-                "path": {
-                    "description": "路由地址",
+                "startTime": {
+                    "description": "开始时间（可选）",
                     "type": "string"
                 },
-                "perms": {
-                    "description": "权限标识（例如: user.create, user.*, *）",
+                "status": {
+                    "description": "操作状态（可选,nil表示全部,\"0\"成功 \"1\"失败）",
                     "type": "string"
                 },
-                "query": {
-                    "description": "路由参数",
+                "title": {
+                    "description": "模块标题（可选,模糊查询）",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.PageOrgsRequest": {
+            "type": "object",
+            "properties": {
+                "isAsc": {
+                    "description": "IsAsc 排序方向，asc或desc，支持多个，逗号分隔，如: \"asc,desc\"",
                     "type": "string"
                 },
-                "remark": {
-                    "description": "备注",
+                "orderByColumn": {
+                    "description": "OrderByColumn 排序列，支持多列排序，逗号分隔，如: \"id,createTime\"",
                     "type": "string"
                 },
-                "sort": {
-                    "description": "显示顺序",
+                "orgCode": {
+                    "description": "组织编码",
+                    "type": "string"
+                },
+                "orgName": {
+                    "description": "组织名称（模糊查询）",
+                    "type": "string"
+                },
+                "pageNum": {
+                    "description": "PageNum 当前页码，从1开始",
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "description": "PageSize 每页数量",
+                    "type": "integer"
+                },
+                "parentId": {
+                    "description": "父组织ID（可选）",
                     "type": "integer"
                 },
                 "status": {
                     "description": "状态：0正常 1停用",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.PageRoleRequest": {
+            "type": "object",
+            "properties": {
+                "isAsc": {
+                    "description": "IsAsc 排序方向，asc或desc，支持多个，逗号分隔，如: \"asc,desc\"",
+                    "type": "string"
+                },
+                "orderByColumn": {
+                    "description": "OrderByColumn 排序列，支持多列排序，逗号分隔，如: \"id,createTime\"",
+                    "type": "string"
+                },
+                "pageNum": {
+                    "description": "PageNum 当前页码，从1开始",
                     "type": "integer"
                 },
-                "updateBy": {
-                    "description": "更新人",
+                "pageSize": {
+                    "description": "PageSize 每页数量",
                     "type": "integer"
                 },
-                "updatedTime": {
+                "roleName": {
+                    "description": "角色名称（模糊查询）",
+                    "type": "string",
+                    "example": "管理员"
+                },
+                "status": {
+                    "description": "状态：0正常 1停用，-1表示不过滤",
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.PageStorageEnvsRequest": {
+            "type": "object",
+            "properties": {
+                "isAsc": {
+                    "description": "IsAsc 排序方向，asc或desc，支持多个，逗号分隔，如: \"asc,desc\"",
                     "type": "string"
                 },
-                "visible": {
-                    "description": "显示状态：0显示 1隐藏",
+                "name": {
+                    "type": "string"
+                },
+                "orderByColumn": {
+                    "description": "OrderByColumn 排序列，支持多列排序，逗号分隔，如: \"id,createTime\"",
+                    "type": "string"
+                },
+                "pageNum": {
+                    "description": "PageNum 当前页码，从1开始",
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "description": "PageSize 每页数量",
+                    "type": "integer"
+                },
+                "storageType": {
+                    "description": "存储类型：local/minio/s3/oss",
+                    "type": "string",
+                    "enum": [
+                        "local",
+                        "minio",
+                        "s3",
+                        "oss"
+                    ]
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.PageTenantRequest": {
+            "type": "object",
+            "properties": {
+                "isAsc": {
+                    "description": "IsAsc 排序方向，asc或desc，支持多个，逗号分隔，如: \"asc,desc\"",
+                    "type": "string"
+                },
+                "orderByColumn": {
+                    "description": "OrderByColumn 排序列，支持多列排序，逗号分隔，如: \"id,createTime\"",
+                    "type": "string"
+                },
+                "pageNum": {
+                    "description": "PageNum 当前页码，从1开始",
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "description": "PageSize 每页数量",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "状态（可选，nil 表示全部）",
                     "type": "integer"
+                },
+                "tenantCode": {
+                    "description": "租户编码（可选，模糊查询）",
+                    "type": "string"
+                },
+                "tenantName": {
+                    "description": "租户名称（可选，模糊查询）",
+                    "type": "string"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.AddRolePermissionRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.PageUsersRequest": {
             "type": "object",
-            "required": [
-                "action",
-                "orgId",
-                "resource",
-                "roleKey"
-            ],
             "properties": {
-                "action": {
-                    "description": "操作类型（支持通配符）",
-                    "type": "string",
-                    "example": "write"
+                "isAsc": {
+                    "description": "IsAsc 排序方向，asc或desc，支持多个，逗号分隔，如: \"asc,desc\"",
+                    "type": "string"
+                },
+                "orderByColumn": {
+                    "description": "OrderByColumn 排序列，支持多列排序，逗号分隔，如: \"id,createTime\"",
+                    "type": "string"
+                },
+                "pageNum": {
+                    "description": "PageNum 当前页码，从1开始",
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "description": "PageSize 每页数量",
+                    "type": "integer"
+                },
+                "phonenumber": {
+                    "type": "string"
                 },
-                "orgId": {
-                    "description": "组织ID",
+                "status": {
+                    "description": "状态：0正常 1停用 2待审批",
                     "type": "integer",
-                    "example": 1
-                },
-                "resource": {
-                    "description": "资源路径（支持通配符）",
-                    "type": "string",
-                    "example": "user.*"
+                    "enum": [
+                        0,
+                        1,
+                        2
+                    ]
                 },
-                "roleKey": {
-                    "description": "角色标识",
-                    "type": "string",
-                    "example": "user_manager"
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.AssignRoleToUserRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.PasswordForgotRequest": {
             "type": "object",
             "required": [
-                "orgId",
-                "roleId",
-                "userId"
+                "account",
+                "channel"
             ],
             "properties": {
-                "orgId": {
-                    "description": "组织ID",
-                    "type": "integer",
-                    "example": 1
-                },
-                "roleId": {
-                    "description": "角色ID",
-                    "type": "integer",
-                    "example": 1
+                "account": {
+                    "description": "邮箱或手机号",
+                    "type": "string",
+                    "maxLength": 128,
+                    "example": "user@example.com"
                 },
-                "userId": {
-                    "description": "用户ID",
-                    "type": "integer",
-                    "example": 1001
+                "channel": {
+                    "description": "找回方式：email 邮箱链接 / sms 短信验证码",
+                    "type": "string",
+                    "enum": [
+                        "email",
+                        "sms"
+                    ],
+                    "example": "email"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteConfigRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.PasswordResetRequest": {
             "type": "object",
             "required": [
-                "ids"
+                "newPassword",
+                "resetToken"
             ],
             "properties": {
-                "ids": {
-                    "description": "配置ID列表",
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "integer"
-                    }
+                "newPassword": {
+                    "description": "新密码",
+                    "type": "string",
+                    "example": "Abc@123456"
+                },
+                "resetToken": {
+                    "description": "重置令牌（verify 接口返回）",
+                    "type": "string"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteDictRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.PasswordResetVerifyRequest": {
             "type": "object",
             "required": [
-                "ids"
+                "channel",
+                "code"
             ],
             "properties": {
-                "ids": {
-                    "description": "字典ID列表",
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "integer"
-                    }
+                "account": {
+                    "description": "手机号（sms 必填）",
+                    "type": "string",
+                    "example": "13800138000"
+                },
+                "channel": {
+                    "description": "找回方式",
+                    "type": "string",
+                    "enum": [
+                        "email",
+                        "sms"
+                    ],
+                    "example": "sms"
+                },
+                "code": {
+                    "description": "短信验证码或邮件链接中的 token",
+                    "type": "string",
+                    "example": "123456"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteLoginLogRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.RegisterEmailCodeRequest": {
             "type": "object",
             "required": [
-                "ids"
+                "email"
             ],
             "properties": {
-                "ids": {
-                    "description": "日志ID列表",
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "integer"
-                    }
+                "captchaCode": {
+                    "description": "图形验证码",
+                    "type": "string",
+                    "example": "a8k2"
+                },
+                "captchaId": {
+                    "description": "图形验证码ID（注册设置开启验证码时必填）",
+                    "type": "string",
+                    "example": "captcha-uuid-12345"
+                },
+                "email": {
+                    "description": "邮箱",
+                    "type": "string",
+                    "maxLength": 128,
+                    "example": "user@example.com"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteOperLogRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.RegisterRequest": {
             "type": "object",
             "required": [
-                "ids"
+                "password",
+                "userName"
             ],
             "properties": {
-                "ids": {
-                    "description": "日志ID列表",
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "integer"
-                    }
+                "captchaCode": {
+                    "description": "图形验证码",
+                    "type": "string",
+                    "example": "a8k2"
+                },
+                "captchaId": {
+                    "description": "图形验证码ID（注册设置开启验证码时必填）",
+                    "type": "string",
+                    "example": "captcha-uuid-12345"
+                },
+                "email": {
+                    "description": "邮箱（开放注册必填）",
+                    "type": "string",
+                    "maxLength": 128,
+                    "example": "user@example.com"
+                },
+                "emailCode": {
+                    "description": "邮箱验证码（填写邮箱时必填）",
+                    "type": "string",
+                    "example": "123456"
+                },
+                "inviteCode": {
+                    "description": "邀请码（邀请码注册时必填）",
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "K7Q2M9XD"
+                },
+                "nickName": {
+                    "description": "昵称，为空时使用用户名",
+                    "type": "string",
+                    "maxLength": 30,
+                    "example": "张三"
+                },
+                "password": {
+                    "description": "密码（按组织密码策略校验）",
+                    "type": "string",
+                    "example": "Abc@123456"
+                },
+                "userName": {
+                    "description": "用户名（字母和数字）",
+                    "type": "string",
+                    "maxLength": 20,
+                    "minLength": 3,
+                    "example": "zhangsan"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteOrgsRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.ResetPasswordRequest": {
             "type": "object",
             "required": [
-                "ids"
+                "newPassword"
             ],
             "properties": {
-                "ids": {
-                    "description": "组织ID列表",
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "integer"
-                    }
+                "newPassword": {
+                    "type": "string",
+                    "minLength": 6
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.BatchDeleteUsersRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.RotateClientSecretRequest": {
             "type": "object",
-            "required": [
-                "ids"
-            ],
             "properties": {
-                "ids": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "integer"
-                    }
+                "gracePeriod": {
+                    "description": "旧密钥保留时间（秒），0 表示立即失效，最长 30 天",
+                    "type": "integer",
+                    "maximum": 2592000,
+                    "minimum": 0,
+                    "example": 86400
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.BatchImportUsersRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.SendSmsCodeRequest": {
             "type": "object",
             "required": [
-                "users"
+                "phonenumber"
             ],
             "properties": {
-                "users": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_request.CreateUserRequest"
-                    }
+                "phonenumber": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.BindAttachmentToBusinessRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.SetDefaultStorageEnvRequest": {
             "type": "object",
             "required": [
-                "businessId",
-                "businessType"
+                "id"
             ],
             "properties": {
-                "businessField": {
-                    "description": "业务字段",
-                    "type": "string"
-                },
-                "businessId": {
-                    "description": "业务ID",
-                    "type": "string"
-                },
-                "businessType": {
-                    "description": "业务类型",
-                    "type": "string"
-                },
-                "expireTime": {
-                    "description": "过期时间",
-                    "type": "string"
-                },
-                "isPublic": {
-                    "description": "是否公开",
-                    "type": "boolean"
-                },
-                "metadata": {
-                    "description": "元数据（JSON对象）",
-                    "type": "object",
-                    "additionalProperties": true
+                "id": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.ChangePasswordRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.TestStorageEnvConnectionRequest": {
+            "type": "object"
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.UnlockLoginRequest": {
             "type": "object",
             "required": [
-                "newPassword",
-                "oldPassword"
+                "scope",
+                "subject"
             ],
             "properties": {
-                "newPassword": {
+                "scope": {
+                    "description": "锁定维度：account 账号 / ip_account IP+账号 / ip IP",
                     "type": "string",
-                    "minLength": 6
+                    "enum": [
+                        "account",
+                        "ip_account",
+                        "ip"
+                    ],
+                    "example": "account"
                 },
-                "oldPassword": {
+                "subject": {
+                    "description": "锁定对象（与列表返回的 subject 一致）",
                     "type": "string",
-                    "minLength": 6
+                    "example": "admin"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.CleanLoginLogRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.UpdateClientRequest": {
             "type": "object",
             "required": [
-                "days"
+                "activeTimeout",
+                "grantTypes",
+                "timeout"
             ],
             "properties": {
-                "days": {
-                    "description": "清理多少天之前的日志",
+                "activeTimeout": {
+                    "description": "AccessToken 有效期及空闲超时时间（秒）",
                     "type": "integer",
-                    "minimum": 1
-                }
-            }
-        },
-        "github_com_force-c_nai-tizi_internal_domain_request.CleanOperLogRequest": {
-            "type": "object",
-            "required": [
-                "days"
-            ],
-            "properties": {
-                "days": {
-                    "description": "清理多少天之前的日志",
+                    "maximum": 31536000,
+                    "minimum": 60,
+                    "example": 1800
+                },
+                "autoApprove": {
+                    "description": "OAuth2 是否跳过用户授权确认",
+                    "type": "boolean",
+                    "example": false
+                },
+                "clientName": {
+                    "description": "客户端名称（授权页展示）",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "管理后台"
+                },
+                "deviceType": {
+                    "description": "设备类型",
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "pc"
+                },
+                "grantTypes": {
+                    "description": "允许的授权类型",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "password",
+                        "refresh"
+                    ]
+                },
+                "idleCheck": {
+                    "description": "空闲超时检测：0启用 1关闭",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ],
+                    "example": 0
+                },
+                "redirectUris": {
+                    "description": "OAuth2 回调地址",
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "https://app.example.com/callback"
+                    ]
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "PC 管理后台"
+                },
+                "scopes": {
+                    "description": "OAuth2 允许的授权范围",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "profile",
+                        "email"
+                    ]
+                },
+                "status": {
+                    "description": "状态：0正常 1停用",
                     "type": "integer",
-                    "minimum": 1
+                    "enum": [
+                        0,
+                        1
+                    ],
+                    "example": 0
+                },
+                "timeout": {
+                    "description": "RefreshToken 有效期（秒）",
+                    "type": "integer",
+                    "maximum": 31536000,
+                    "minimum": 60,
+                    "example": 604800
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.CreateConfigRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.UpdateConfigRequest": {
             "type": "object",
             "required": [
                 "code",
-                "createBy",
+                "id",
                 "name",
                 "updateBy"
             ],
@@ -5269,10 +10322,6 @@ const docTemplate = `{
                     "description": "配置编码",
                     "type": "string"
                 },
-                "createBy": {
-                    "description": "创建者",
-                    "type": "integer"
-                },
                 "data": {
                     "description": "配置数据（JSON格式）",
                     "type": "array",
@@ -5280,6 +10329,10 @@ const docTemplate = `{
                         "type": "integer"
                     }
                 },
+                "id": {
+                    "description": "配置ID",
+                    "type": "integer"
+                },
                 "name": {
                     "description": "配置名称",
                     "type": "string"
@@ -5294,18 +10347,15 @@ const docTemplate = `{
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.CreateDictRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.UpdateDictRequest": {
             "type": "object",
             "required": [
                 "dictLabel",
                 "dictType",
-                "dictValue"
+                "dictValue",
+                "id"
             ],
             "properties": {
-                "createBy": {
-                    "description": "创建者",
-                    "type": "integer"
-                },
                 "dictLabel": {
                     "description": "字典标签",
                     "type": "string"
@@ -5318,12 +10368,16 @@ const docTemplate = `{
                     "description": "字典键值",
                     "type": "string"
                 },
+                "id": {
+                    "description": "字典ID",
+                    "type": "integer"
+                },
                 "isDefault": {
                     "description": "是否默认",
                     "type": "boolean"
                 },
                 "parentId": {
-                    "description": "父字典ID（0表示根节点）",
+                    "description": "父字典ID",
                     "type": "integer"
                 },
                 "remark": {
@@ -5335,7 +10389,7 @@ const docTemplate = `{
                     "type": "integer"
                 },
                 "status": {
-                    "description": "状态：0正常 1停用",
+                    "description": "状态",
                     "type": "integer"
                 },
                 "updateBy": {
@@ -5344,10 +10398,45 @@ const docTemplate = `{
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.CreateLoginLogRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.UpdateInviteCodeRequest": {
+            "type": "object",
+            "properties": {
+                "expireAt": {
+                    "description": "过期时间（时间戳），0 表示永不过期",
+                    "type": "integer"
+                },
+                "maxUses": {
+                    "description": "最多可使用次数，0 表示不限",
+                    "type": "integer",
+                    "maximum": 100000,
+                    "minimum": 0
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "roleIds": {
+                    "description": "注册用户默认角色ID",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "status": {
+                    "description": "状态：0正常 1停用",
+                    "type": "integer",
+                    "enum": [
+                        0,
+                        1
+                    ]
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.UpdateLoginLogRequest": {
             "type": "object",
             "required": [
-                "userName"
+                "id"
             ],
             "properties": {
                 "browser": {
@@ -5358,6 +10447,10 @@ const docTemplate = `{
                     "description": "客户端ID",
                     "type": "string"
                 },
+                "id": {
+                    "description": "日志ID",
+                    "type": "integer"
+                },
                 "ipaddr": {
                     "description": "登录IP",
                     "type": "string"
@@ -5378,20 +10471,16 @@ const docTemplate = `{
                     "description": "登录状态：0成功 1失败",
                     "type": "integer"
                 },
-                "tenantId": {
-                    "description": "租户ID",
-                    "type": "string"
-                },
                 "userName": {
                     "description": "用户名",
                     "type": "string"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.CreateOperLogRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.UpdateOperLogRequest": {
             "type": "object",
             "required": [
-                "title"
+                "id"
             ],
             "properties": {
                 "businessType": {
@@ -5410,6 +10499,10 @@ const docTemplate = `{
                     "description": "错误信息",
                     "type": "string"
                 },
+                "id": {
+                    "description": "日志ID",
+                    "type": "integer"
+                },
                 "jsonResult": {
                     "description": "返回结果",
                     "type": "string"
@@ -5456,12 +10549,8 @@ const docTemplate = `{
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.CreateOrgRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.UpdateOrgRequest": {
             "type": "object",
-            "required": [
-                "orgCode",
-                "orgName"
-            ],
             "properties": {
                 "email": {
                     "description": "邮箱",
@@ -5494,7 +10583,7 @@ const docTemplate = `{
                     ]
                 },
                 "parentId": {
-                    "description": "父组织ID，0表示根组织",
+                    "description": "父组织ID",
                     "type": "integer"
                 },
                 "phone": {
@@ -5503,6 +10592,10 @@ const docTemplate = `{
                     "maxLength": 11,
                     "minLength": 11
                 },
+                "regApproval": {
+                    "description": "自助注册到该组织的用户是否需要审批",
+                    "type": "boolean"
+                },
                 "remark": {
                     "description": "备注",
                     "type": "string",
@@ -5522,27 +10615,50 @@ const docTemplate = `{
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.CreateRoleRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.UpdateRoleRequest": {
             "type": "object",
             "required": [
-                "roleKey",
+                "roleId",
                 "roleName"
             ],
             "properties": {
                 "dataScope": {
-                    "description": "数据范围：1全部 2自定义 3本组织 4本组织及以下 5仅本人",
+                    "description": "数据范围：1全部 2自定义 3本组织 4本组织及以下 5仅本人，默认全部",
                     "type": "integer",
+                    "enum": [
+                        1,
+                        2,
+                        3,
+                        4,
+                        5
+                    ],
                     "example": 2
                 },
+                "orgIds": {
+                    "description": "自定义数据权限的组织ID（数据范围为自定义时必填）",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
                 "remark": {
                     "description": "备注",
                     "type": "string",
                     "example": "负责用户管理"
                 },
-                "roleKey": {
-                    "description": "角色标识（唯一）",
-                    "type": "string",
-                    "example": "user_manager"
+                "requireMfa": {
+                    "description": "是否强制启用两步验证",
+                    "type": "boolean",
+                    "example": false
+                },
+                "roleId": {
+                    "description": "角色ID",
+                    "type": "integer",
+                    "example": 1
                 },
                 "roleName": {
                     "description": "角色名称",
@@ -5561,10 +10677,12 @@ const docTemplate = `{
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.CreateStorageEnvRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.UpdateStorageEnvRequest": {
             "type": "object",
             "required": [
                 "code",
+                "config",
+                "id",
                 "name",
                 "storageType"
             ],
@@ -5573,12 +10691,14 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "config": {
-                    "description": "JSON 对象",
                     "type": "array",
                     "items": {
                         "type": "integer"
                     }
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "isDefault": {
                     "type": "boolean"
                 },
@@ -5608,14 +10728,45 @@ const docTemplate = `{
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.CreateUserRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.UpdateTenantRequest": {
             "type": "object",
             "required": [
-                "nickName",
-                "orgId",
-                "password",
-                "userName"
+                "tenantName"
             ],
+            "properties": {
+                "contactName": {
+                    "description": "联系人",
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "contactPhone": {
+                    "description": "联系电话",
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "expireAt": {
+                    "description": "到期时间（时间戳），0 表示永不过期",
+                    "type": "integer"
+                },
+                "plan": {
+                    "description": "套餐",
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string",
+                    "maxLength": 200
+                },
+                "tenantName": {
+                    "description": "租户名称",
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.UpdateUserRequest": {
+            "type": "object",
             "properties": {
                 "avatar": {
                     "type": "string"
@@ -5629,10 +10780,6 @@ const docTemplate = `{
                 "orgId": {
                     "type": "integer"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
-                },
                 "phonenumber": {
                     "type": "string"
                 },
@@ -5662,22 +10809,20 @@ const docTemplate = `{
                     "minLength": 3
                 },
                 "userType": {
-                    "description": "用户类型：0系统用户 1微信用户 2APP用户",
+                    "description": "用户类型：0系统用户 1微信用户 2APP用户 3服务账号",
                     "type": "integer"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.LoginRequest": {
-            "description": "统一登录请求参数，根据 grantType 使用不同的字段组合",
+        "github_com_force-c_nai-tizi_internal_domain_request.WebAuthnLoginOptionsRequest": {
             "type": "object",
             "required": [
                 "clientKey",
-                "clientSecret",
-                "grantType"
+                "clientSecret"
             ],
             "properties": {
                 "clientKey": {
-                    "description": "客户端认证（必填）",
+                    "description": "客户端Key",
                     "type": "string",
                     "example": "web-admin"
                 },
@@ -5686,334 +10831,380 @@ const docTemplate = `{
                     "type": "string",
                     "example": "web-secret-2024"
                 },
-                "code": {
-                    "description": "验证码（email/xcx 必填）",
-                    "type": "string",
-                    "example": "123456"
-                },
-                "email": {
-                    "description": "邮箱（email 必填）",
-                    "type": "string",
-                    "example": "admin@example.com"
-                },
-                "grantType": {
-                    "description": "授权类型：password-密码登录, email-邮箱验证码, xcx-微信小程序",
-                    "type": "string",
-                    "enum": [
-                        "password",
-                        "email",
-                        "xcx"
-                    ],
-                    "example": "password"
-                },
-                "password": {
-                    "description": "密码（password 必填）",
-                    "type": "string",
-                    "example": "admin123"
-                },
-                "phonenumber": {
-                    "description": "手机号（xcx 必填）",
-                    "type": "string",
-                    "example": "13800138000"
-                },
                 "username": {
-                    "description": "用户凭证（根据 grantType 选填）",
+                    "description": "用户名（可选，为空时使用可发现凭证登录）",
                     "type": "string",
                     "example": "admin"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_request.WebAuthnRegisterRequest": {
+            "type": "object",
+            "required": [
+                "credential"
+            ],
+            "properties": {
+                "credential": {
+                    "description": "navigator.credentials.create() 返回的凭证（JSON）",
+                    "type": "object"
                 },
-                "uuid": {
-                    "description": "图形验证码UUID（password 可选）",
-                    "type": "string",
-                    "example": "captcha-uuid-12345"
-                },
-                "wxCode": {
-                    "description": "微信code（xcx 必填）",
+                "name": {
+                    "description": "凭证名称（可选）",
                     "type": "string",
-                    "example": "wx-code-from-wechat"
+                    "example": "我的 MacBook"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.PageAttachmentsRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_request.WebAuthnRenameRequest": {
             "type": "object",
             "required": [
-                "pageNum",
-                "pageSize"
+                "name"
             ],
             "properties": {
-                "businessType": {
-                    "type": "string"
-                },
-                "fileName": {
+                "name": {
+                    "description": "凭证名称",
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "办公室 YubiKey"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_response.ApiKeyCreateResponse": {
+            "type": "object",
+            "properties": {
+                "createdTime": {
+                    "description": "创建时间",
                     "type": "string"
                 },
-                "fileType": {
-                    "type": "string"
+                "expireAt": {
+                    "description": "过期时间（时间戳），0 表示永不过期",
+                    "type": "integer",
+                    "example": 1767225600
                 },
-                "pageNum": {
+                "id": {
+                    "description": "记录ID",
                     "type": "integer",
-                    "minimum": 1
+                    "example": 1
                 },
-                "pageSize": {
+                "key": {
+                    "description": "API Key 明文，仅在创建时返回一次，请妥善保存",
+                    "type": "string",
+                    "example": "ntz_Ab3dE9xQ..."
+                },
+                "keyPrefix": {
+                    "description": "明文前缀（用于识别）",
+                    "type": "string",
+                    "example": "ntz_Ab3dE9xQ"
+                },
+                "lastUsedAt": {
+                    "description": "最后使用时间（时间戳）",
                     "type": "integer",
-                    "maximum": 100,
-                    "minimum": 1
+                    "example": 1700000000
+                },
+                "lastUsedIp": {
+                    "description": "最后使用IP",
+                    "type": "string",
+                    "example": "10.0.0.8"
+                },
+                "name": {
+                    "description": "名称",
+                    "type": "string",
+                    "example": "CI 部署"
+                },
+                "scopes": {
+                    "description": "授权范围",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "user.read",
+                        "attachment.*"
+                    ]
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.PageConfigRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_response.ApiKeyResponse": {
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "配置编码(可选)",
+                "createdTime": {
+                    "description": "创建时间",
                     "type": "string"
                 },
-                "isAsc": {
-                    "description": "IsAsc 排序方向，asc或desc，支持多个，逗号分隔，如: \"asc,desc\"",
-                    "type": "string"
+                "expireAt": {
+                    "description": "过期时间（时间戳），0 表示永不过期",
+                    "type": "integer",
+                    "example": 1767225600
                 },
-                "name": {
-                    "description": "配置名称(可选,模糊查询)",
-                    "type": "string"
+                "id": {
+                    "description": "记录ID",
+                    "type": "integer",
+                    "example": 1
                 },
-                "orderByColumn": {
-                    "description": "OrderByColumn 排序列，支持多列排序，逗号分隔，如: \"id,createTime\"",
-                    "type": "string"
+                "keyPrefix": {
+                    "description": "明文前缀（用于识别）",
+                    "type": "string",
+                    "example": "ntz_Ab3dE9xQ"
                 },
-                "pageNum": {
-                    "description": "PageNum 当前页码，从1开始",
-                    "type": "integer"
+                "lastUsedAt": {
+                    "description": "最后使用时间（时间戳）",
+                    "type": "integer",
+                    "example": 1700000000
                 },
-                "pageSize": {
-                    "description": "PageSize 每页数量",
-                    "type": "integer"
+                "lastUsedIp": {
+                    "description": "最后使用IP",
+                    "type": "string",
+                    "example": "10.0.0.8"
+                },
+                "name": {
+                    "description": "名称",
+                    "type": "string",
+                    "example": "CI 部署"
+                },
+                "scopes": {
+                    "description": "授权范围",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "user.read",
+                        "attachment.*"
+                    ]
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.PageOperLogRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_response.AttachmentResponse": {
             "type": "object",
             "properties": {
-                "businessType": {
-                    "description": "业务类型（可选）",
+                "accessUrl": {
                     "type": "string"
                 },
-                "endTime": {
-                    "description": "结束时间（可选）",
-                    "type": "string"
+                "attachmentId": {
+                    "type": "integer"
                 },
-                "isAsc": {
-                    "description": "IsAsc 排序方向，asc或desc，支持多个，逗号分隔，如: \"asc,desc\"",
+                "businessField": {
                     "type": "string"
                 },
-                "operName": {
-                    "description": "操作者（可选,模糊查询）",
+                "businessId": {
                     "type": "string"
                 },
-                "orderByColumn": {
-                    "description": "OrderByColumn 排序列，支持多列排序，逗号分隔，如: \"id,createTime\"",
+                "businessType": {
                     "type": "string"
                 },
-                "pageNum": {
-                    "description": "PageNum 当前页码，从1开始",
+                "createBy": {
                     "type": "integer"
                 },
-                "pageSize": {
-                    "description": "PageSize 每页数量",
+                "createTime": {
+                    "type": "string"
+                },
+                "envId": {
                     "type": "integer"
                 },
-                "startTime": {
-                    "description": "开始时间（可选）",
+                "expireTime": {
                     "type": "string"
                 },
-                "status": {
-                    "description": "操作状态（可选,nil表示全部,\"0\"成功 \"1\"失败）",
+                "fileExt": {
                     "type": "string"
                 },
-                "title": {
-                    "description": "模块标题（可选,模糊查询）",
-                    "type": "string"
-                }
-            }
-        },
-        "github_com_force-c_nai-tizi_internal_domain_request.PageOrgsRequest": {
-            "type": "object",
-            "properties": {
-                "isAsc": {
-                    "description": "IsAsc 排序方向，asc或desc，支持多个，逗号分隔，如: \"asc,desc\"",
+                "fileKey": {
                     "type": "string"
                 },
-                "orderByColumn": {
-                    "description": "OrderByColumn 排序列，支持多列排序，逗号分隔，如: \"id,createTime\"",
+                "fileName": {
                     "type": "string"
                 },
-                "orgCode": {
-                    "description": "组织编码",
-                    "type": "string"
+                "fileSize": {
+                    "type": "integer"
                 },
-                "orgName": {
-                    "description": "组织名称（模糊查询）",
+                "fileType": {
                     "type": "string"
                 },
-                "pageNum": {
-                    "description": "PageNum 当前页码，从1开始",
-                    "type": "integer"
+                "isPublic": {
+                    "type": "boolean"
                 },
-                "pageSize": {
-                    "description": "PageSize 每页数量",
-                    "type": "integer"
+                "metadata": {
+                    "type": "string"
                 },
-                "parentId": {
-                    "description": "父组织ID（可选）",
+                "status": {
+                    "description": "状态：0正常 1已删除",
                     "type": "integer"
                 },
-                "status": {
-                    "description": "状态：0正常 1停用",
-                    "type": "integer",
-                    "enum": [
-                        0,
-                        1
-                    ]
+                "updateTime": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.PageRoleRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_response.AttachmentURLResponse": {
             "type": "object",
             "properties": {
-                "isAsc": {
-                    "description": "IsAsc 排序方向，asc或desc，支持多个，逗号分隔，如: \"asc,desc\"",
-                    "type": "string"
-                },
-                "orderByColumn": {
-                    "description": "OrderByColumn 排序列，支持多列排序，逗号分隔，如: \"id,createTime\"",
-                    "type": "string"
-                },
-                "pageNum": {
-                    "description": "PageNum 当前页码，从1开始",
+                "attachmentId": {
                     "type": "integer"
                 },
-                "pageSize": {
-                    "description": "PageSize 每页数量",
+                "expires": {
+                    "description": "过期时间（秒）",
                     "type": "integer"
                 },
-                "roleName": {
-                    "description": "角色名称（模糊查询）",
-                    "type": "string",
-                    "example": "管理员"
-                },
-                "status": {
-                    "description": "状态：0正常 1停用，-1表示不过滤",
-                    "type": "integer",
-                    "example": 0
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.PageStorageEnvsRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_response.ClientResponse": {
             "type": "object",
             "properties": {
-                "isAsc": {
-                    "description": "IsAsc 排序方向，asc或desc，支持多个，逗号分隔，如: \"asc,desc\"",
-                    "type": "string"
+                "activeTimeout": {
+                    "description": "AccessToken 有效期及空闲超时时间（秒）",
+                    "type": "integer",
+                    "example": 1800
                 },
-                "name": {
-                    "type": "string"
+                "autoApprove": {
+                    "description": "OAuth2 是否跳过用户授权确认",
+                    "type": "boolean",
+                    "example": false
                 },
-                "orderByColumn": {
-                    "description": "OrderByColumn 排序列，支持多列排序，逗号分隔，如: \"id,createTime\"",
-                    "type": "string"
+                "clientId": {
+                    "description": "客户端ID",
+                    "type": "string",
+                    "example": "e5cd7e4891bf95d1d19206ce24a7b32e"
                 },
-                "pageNum": {
-                    "description": "PageNum 当前页码，从1开始",
-                    "type": "integer"
+                "clientKey": {
+                    "description": "客户端Key",
+                    "type": "string",
+                    "example": "pc"
                 },
-                "pageSize": {
-                    "description": "PageSize 每页数量",
-                    "type": "integer"
+                "clientName": {
+                    "description": "客户端名称",
+                    "type": "string",
+                    "example": "管理后台"
                 },
-                "storageType": {
-                    "description": "存储类型：local/minio/s3/oss",
+                "createdTime": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "deviceType": {
+                    "description": "设备类型",
                     "type": "string",
-                    "enum": [
-                        "local",
-                        "minio",
-                        "s3",
-                        "oss"
+                    "example": "pc"
+                },
+                "grantTypes": {
+                    "description": "允许的授权类型",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "password",
+                        "refresh"
                     ]
-                }
-            }
-        },
-        "github_com_force-c_nai-tizi_internal_domain_request.PageUsersRequest": {
-            "type": "object",
-            "properties": {
-                "isAsc": {
-                    "description": "IsAsc 排序方向，asc或desc，支持多个，逗号分隔，如: \"asc,desc\"",
-                    "type": "string"
                 },
-                "orderByColumn": {
-                    "description": "OrderByColumn 排序列，支持多列排序，逗号分隔，如: \"id,createTime\"",
-                    "type": "string"
+                "idleCheck": {
+                    "description": "空闲超时检测：0启用 1关闭",
+                    "type": "integer",
+                    "example": 0
                 },
-                "pageNum": {
-                    "description": "PageNum 当前页码，从1开始",
-                    "type": "integer"
+                "prevExpireAt": {
+                    "description": "旧密钥失效时间（时间戳），0 表示没有仍有效的旧密钥",
+                    "type": "integer",
+                    "example": 1700086400
                 },
-                "pageSize": {
-                    "description": "PageSize 每页数量",
-                    "type": "integer"
+                "redirectUris": {
+                    "description": "OAuth2 回调地址",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "https://app.example.com/cb"
+                    ]
                 },
-                "phonenumber": {
-                    "type": "string"
+                "remark": {
+                    "description": "备注",
+                    "type": "string",
+                    "example": "PC 管理后台"
+                },
+                "scopes": {
+                    "description": "OAuth2 允许的授权范围",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "profile",
+                        "email"
+                    ]
                 },
                 "status": {
                     "description": "状态：0正常 1停用",
                     "type": "integer",
-                    "enum": [
-                        0,
-                        1
-                    ]
+                    "example": 0
                 },
-                "username": {
+                "timeout": {
+                    "description": "RefreshToken 有效期（秒）",
+                    "type": "integer",
+                    "example": 604800
+                },
+                "updatedTime": {
+                    "description": "更新时间",
                     "type": "string"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.ResetPasswordRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_response.ClientSecretResponse": {
             "type": "object",
-            "required": [
-                "newPassword"
-            ],
             "properties": {
-                "newPassword": {
+                "clientId": {
+                    "description": "客户端ID",
                     "type": "string",
-                    "minLength": 6
+                    "example": "e5cd7e4891bf95d1d19206ce24a7b32e"
+                },
+                "clientKey": {
+                    "description": "客户端Key",
+                    "type": "string",
+                    "example": "pc"
+                },
+                "clientSecret": {
+                    "description": "客户端密钥明文，仅返回一次，请妥善保存",
+                    "type": "string",
+                    "example": "Xq3...="
+                },
+                "prevExpireAt": {
+                    "description": "旧密钥失效时间（时间戳），0 表示旧密钥已失效",
+                    "type": "integer",
+                    "example": 1700086400
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.SetDefaultStorageEnvRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_response.ConfigDataResponse": {
             "type": "object",
-            "required": [
-                "id"
-            ],
             "properties": {
-                "id": {
-                    "type": "integer"
+                "code": {
+                    "description": "配置编码",
+                    "type": "string"
+                },
+                "data": {
+                    "description": "配置数据",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.TestStorageEnvConnectionRequest": {
-            "type": "object"
-        },
-        "github_com_force-c_nai-tizi_internal_domain_request.UpdateConfigRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_response.ConfigResponse": {
             "type": "object",
-            "required": [
-                "code",
-                "id",
-                "name",
-                "updateBy"
-            ],
             "properties": {
                 "code": {
                     "description": "配置编码",
                     "type": "string"
                 },
+                "createBy": {
+                    "description": "创建者",
+                    "type": "integer"
+                },
+                "createdTime": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
                 "data": {
                     "description": "配置数据（JSON格式）",
                     "type": "array",
@@ -6036,18 +11227,24 @@ const docTemplate = `{
                 "updateBy": {
                     "description": "更新者",
                     "type": "integer"
+                },
+                "updatedTime": {
+                    "description": "更新时间",
+                    "type": "string"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.UpdateDictRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_response.DictDataResponse": {
             "type": "object",
-            "required": [
-                "dictLabel",
-                "dictType",
-                "dictValue",
-                "id"
-            ],
             "properties": {
+                "createBy": {
+                    "description": "创建者",
+                    "type": "integer"
+                },
+                "createdTime": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
                 "dictLabel": {
                     "description": "字典标签",
                     "type": "string"
@@ -6087,608 +11284,543 @@ const docTemplate = `{
                 "updateBy": {
                     "description": "更新者",
                     "type": "integer"
-                }
-            }
-        },
-        "github_com_force-c_nai-tizi_internal_domain_request.UpdateLoginLogRequest": {
-            "type": "object",
-            "required": [
-                "id"
-            ],
-            "properties": {
-                "browser": {
-                    "description": "浏览器类型",
-                    "type": "string"
-                },
-                "clientId": {
-                    "description": "客户端ID",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "日志ID",
-                    "type": "integer"
-                },
-                "ipaddr": {
-                    "description": "登录IP",
-                    "type": "string"
-                },
-                "loginLocation": {
-                    "description": "登录地点",
-                    "type": "string"
-                },
-                "msg": {
-                    "description": "提示消息",
-                    "type": "string"
-                },
-                "os": {
-                    "description": "操作系统",
-                    "type": "string"
-                },
-                "status": {
-                    "description": "登录状态：0成功 1失败",
-                    "type": "integer"
-                },
-                "tenantId": {
-                    "description": "租户ID",
-                    "type": "string"
                 },
-                "userName": {
-                    "description": "用户名",
+                "updatedTime": {
+                    "description": "更新时间",
                     "type": "string"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.UpdateOperLogRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_response.ImpersonateResponse": {
+            "description": "以用户身份签发的短期 Token，不签发 RefreshToken，过期后需重新代登录",
             "type": "object",
-            "required": [
-                "id"
-            ],
             "properties": {
-                "businessType": {
-                    "description": "业务类型",
-                    "type": "string"
-                },
-                "costTime": {
-                    "description": "耗时（毫秒）",
-                    "type": "integer"
-                },
-                "deviceType": {
-                    "description": "终端类型：web/ios/android/wechat",
-                    "type": "string"
-                },
-                "errorMsg": {
-                    "description": "错误信息",
-                    "type": "string"
-                },
-                "id": {
-                    "description": "日志ID",
-                    "type": "integer"
-                },
-                "jsonResult": {
-                    "description": "返回结果",
-                    "type": "string"
-                },
-                "method": {
-                    "description": "调用方法",
-                    "type": "string"
-                },
-                "operIp": {
-                    "description": "操作IP",
-                    "type": "string"
-                },
-                "operLocation": {
-                    "description": "操作地点",
-                    "type": "string"
-                },
-                "operName": {
-                    "description": "操作者",
-                    "type": "string"
-                },
-                "operParam": {
-                    "description": "请求参数",
-                    "type": "string"
-                },
-                "operUrl": {
-                    "description": "请求URL",
-                    "type": "string"
-                },
-                "requestMethod": {
-                    "description": "请求方式：GET/POST",
-                    "type": "string"
+                "access_token": {
+                    "description": "访问令牌（携带 act 声明）",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
                 },
-                "status": {
-                    "description": "操作状态：0成功 1失败",
-                    "type": "string"
+                "expires_in": {
+                    "description": "AccessToken 过期时间（秒）",
+                    "type": "integer",
+                    "example": 900
                 },
-                "title": {
-                    "description": "模块标题",
+                "session_id": {
+                    "description": "代登录会话ID",
                     "type": "string"
                 },
-                "userAgent": {
-                    "description": "UA",
-                    "type": "string"
+                "user_info": {
+                    "description": "被代登录的用户信息",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.UserInfo"
+                        }
+                    ]
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.UpdateOrgRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_response.ImpersonationLogResponse": {
             "type": "object",
             "properties": {
-                "email": {
-                    "description": "邮箱",
-                    "type": "string"
-                },
-                "leader": {
-                    "description": "负责人",
-                    "type": "string",
-                    "maxLength": 50
-                },
-                "orgCode": {
-                    "description": "组织编码",
+                "actorName": {
+                    "description": "代登录的管理员",
                     "type": "string",
-                    "maxLength": 30,
-                    "minLength": 2
-                },
-                "orgName": {
-                    "description": "组织名称",
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 2
-                },
-                "orgType": {
-                    "description": "组织类型",
-                    "type": "string",
-                    "enum": [
-                        "company",
-                        "department",
-                        "group"
-                    ]
-                },
-                "parentId": {
-                    "description": "父组织ID",
-                    "type": "integer"
-                },
-                "phone": {
-                    "description": "联系电话",
-                    "type": "string",
-                    "maxLength": 11,
-                    "minLength": 11
-                },
-                "remark": {
-                    "description": "备注",
-                    "type": "string",
-                    "maxLength": 500
+                    "example": "support01"
                 },
-                "sort": {
-                    "description": "显示顺序",
-                    "type": "integer"
-                },
-                "status": {
-                    "description": "状态：0正常 1停用",
-                    "type": "integer",
-                    "enum": [
-                        0,
-                        1
-                    ]
-                }
-            }
-        },
-        "github_com_force-c_nai-tizi_internal_domain_request.UpdateRoleRequest": {
-            "type": "object",
-            "required": [
-                "roleId",
-                "roleName"
-            ],
-            "properties": {
-                "dataScope": {
-                    "description": "数据范围",
-                    "type": "integer",
-                    "example": 2
+                "createdTime": {
+                    "description": "代登录时间",
+                    "type": "string"
                 },
-                "remark": {
-                    "description": "备注",
-                    "type": "string",
-                    "example": "负责用户管理"
+                "expireAt": {
+                    "description": "Token 过期时间（时间戳）",
+                    "type": "integer",
+                    "example": 1700000900
                 },
-                "roleId": {
-                    "description": "角色ID",
+                "id": {
+                    "description": "记录ID",
                     "type": "integer",
                     "example": 1
                 },
-                "roleName": {
-                    "description": "角色名称",
+                "ipaddr": {
+                    "description": "管理员IP",
                     "type": "string",
-                    "example": "用户管理员"
+                    "example": "10.0.0.8"
                 },
-                "sort": {
-                    "description": "显示顺序",
-                    "type": "integer",
-                    "example": 1
-                },
-                "status": {
-                    "description": "状态：0正常 1停用",
-                    "type": "integer",
-                    "example": 0
+                "reason": {
+                    "description": "代登录原因",
+                    "type": "string",
+                    "example": "工单 #1024：用户反馈订单页面显示异常"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.UpdateStorageEnvRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_response.InviteCodeResponse": {
             "type": "object",
-            "required": [
-                "code",
-                "config",
-                "id",
-                "name",
-                "storageType"
-            ],
             "properties": {
                 "code": {
+                    "description": "邀请码",
                     "type": "string"
                 },
-                "config": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "createdTime": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "expireAt": {
+                    "description": "过期时间（时间戳），0 表示永不过期",
+                    "type": "integer"
                 },
                 "id": {
+                    "description": "邀请码ID",
                     "type": "integer"
                 },
-                "isDefault": {
-                    "type": "boolean"
+                "maxUses": {
+                    "description": "最多可使用次数，0 表示不限",
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "orgId": {
+                    "description": "注册用户所属组织ID",
+                    "type": "integer"
                 },
                 "remark": {
+                    "description": "备注",
                     "type": "string"
                 },
+                "roleIds": {
+                    "description": "注册用户默认角色ID",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
                 "status": {
                     "description": "状态：0正常 1停用",
-                    "type": "integer",
-                    "enum": [
-                        0,
-                        1
-                    ]
+                    "type": "integer"
                 },
-                "storageType": {
-                    "description": "存储类型：local/minio/s3/oss",
-                    "type": "string",
-                    "enum": [
-                        "local",
-                        "minio",
-                        "s3",
-                        "oss"
-                    ]
+                "usable": {
+                    "description": "当前是否可用",
+                    "type": "boolean"
+                },
+                "usedCount": {
+                    "description": "已使用次数",
+                    "type": "integer"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_request.UpdateUserRequest": {
+        "github_com_force-c_nai-tizi_internal_domain_response.LoginLockResponse": {
             "type": "object",
             "properties": {
-                "avatar": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "nickName": {
-                    "type": "string"
-                },
-                "orgId": {
-                    "type": "integer"
-                },
-                "phonenumber": {
-                    "type": "string"
+                "account": {
+                    "description": "触发锁定的账号",
+                    "type": "string",
+                    "example": "admin"
                 },
-                "remark": {
-                    "type": "string"
+                "ip": {
+                    "description": "触发锁定的 IP",
+                    "type": "string",
+                    "example": "10.0.0.8"
                 },
-                "sex": {
-                    "description": "性别：0男 1女 2未知",
+                "level": {
+                    "description": "锁定级别（第几次锁定，级别越高锁定越久）",
                     "type": "integer",
-                    "enum": [
-                        0,
-                        1,
-                        2
-                    ]
+                    "example": 1
                 },
-                "status": {
-                    "description": "状态：0正常 1停用",
+                "lockedAt": {
+                    "description": "锁定时间（时间戳）",
                     "type": "integer",
-                    "enum": [
-                        0,
-                        1
-                    ]
+                    "example": 1700000000
                 },
-                "userName": {
+                "scope": {
+                    "description": "锁定维度：account 账号 / ip_account IP+账号 / ip IP",
                     "type": "string",
-                    "maxLength": 20,
-                    "minLength": 3
+                    "example": "ip_account"
                 },
-                "userType": {
-                    "description": "用户类型：0系统用户 1微信用户 2APP用户",
-                    "type": "integer"
+                "subject": {
+                    "description": "锁定对象",
+                    "type": "string",
+                    "example": "10.0.0.8|admin"
+                },
+                "until": {
+                    "description": "解锁时间（时间戳）",
+                    "type": "integer",
+                    "example": 1700000300
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_response.AttachmentResponse": {
+        "github_com_force-c_nai-tizi_internal_domain_response.LoginLogResponse": {
             "type": "object",
             "properties": {
-                "accessUrl": {
+                "browser": {
+                    "description": "浏览器类型",
                     "type": "string"
                 },
-                "attachmentId": {
+                "clientId": {
+                    "description": "客户端ID",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "日志ID",
                     "type": "integer"
                 },
-                "businessField": {
+                "ipaddr": {
+                    "description": "登录IP",
                     "type": "string"
                 },
-                "businessId": {
+                "loginLocation": {
+                    "description": "登录地点",
                     "type": "string"
                 },
-                "businessType": {
+                "loginTime": {
+                    "description": "登录时间",
                     "type": "string"
                 },
-                "createBy": {
-                    "type": "integer"
+                "msg": {
+                    "description": "提示消息",
+                    "type": "string"
                 },
-                "createTime": {
+                "os": {
+                    "description": "操作系统",
                     "type": "string"
                 },
-                "envId": {
+                "riskReasons": {
+                    "description": "命中的风险因素：new_device 新设备 / new_ip_range 新 IP 段 / new_country 新国家 / impossible_travel 不可能的移动速度 / blocked_ip 黑名单 IP",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "riskScore": {
+                    "description": "登录风险分（0-100）",
                     "type": "integer"
                 },
-                "expireTime": {
-                    "type": "string"
+                "status": {
+                    "description": "登录状态：0成功 1失败",
+                    "type": "integer"
                 },
-                "fileExt": {
-                    "type": "string"
+                "tenantId": {
+                    "description": "租户ID",
+                    "type": "integer"
                 },
-                "fileKey": {
+                "userName": {
+                    "description": "用户名",
                     "type": "string"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_response.LoginResponse": {
+            "description": "登录成功后返回的 Token 信息和用户信息",
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "description": "访问令牌（短期有效）",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
                 },
-                "fileName": {
-                    "type": "string"
+                "expires_in": {
+                    "description": "AccessToken 过期时间（秒）",
+                    "type": "integer",
+                    "example": 1800
                 },
-                "fileSize": {
-                    "type": "integer"
+                "mfa_required": {
+                    "description": "两步验证（用户已启用或所属角色强制启用时返回，此时不签发 Token）",
+                    "type": "boolean",
+                    "example": false
                 },
-                "fileType": {
+                "mfa_setup_required": {
+                    "description": "是否需要先绑定两步验证",
+                    "type": "boolean",
+                    "example": false
+                },
+                "mfa_ticket": {
+                    "description": "两步验证票据（grantType=mfa 时提交）",
                     "type": "string"
                 },
-                "isPublic": {
-                    "type": "boolean"
+                "password_change_reason": {
+                    "description": "原因：first_login 首次登录 / reset 管理员重置 / expired 密码过期",
+                    "type": "string",
+                    "example": "first_login"
                 },
-                "metadata": {
-                    "type": "string"
+                "password_change_required": {
+                    "description": "密码策略（需要修改密码时返回，前端应跳转修改密码页面）",
+                    "type": "boolean",
+                    "example": false
                 },
-                "status": {
-                    "description": "状态：0正常 1已删除",
-                    "type": "integer"
+                "recovery_codes": {
+                    "description": "登录时完成绑定返回的恢复码",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "updateTime": {
-                    "type": "string"
+                "refresh_expires_in": {
+                    "description": "RefreshToken 过期时间（秒）",
+                    "type": "integer",
+                    "example": 604800
+                },
+                "refresh_token": {
+                    "description": "刷新令牌（长期有效）",
+                    "type": "string",
+                    "example": "dGhpcyBpcyBhIHJlZnJlc2ggdG9rZW4="
+                },
+                "user_info": {
+                    "description": "用户信息",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.UserInfo"
+                        }
+                    ]
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_response.AttachmentURLResponse": {
+        "github_com_force-c_nai-tizi_internal_domain_response.MfaEnrollResponse": {
             "type": "object",
             "properties": {
-                "attachmentId": {
-                    "type": "integer"
-                },
-                "expires": {
-                    "description": "过期时间（秒）",
-                    "type": "integer"
+                "otpauthUri": {
+                    "description": "绑定地址（前端生成二维码）",
+                    "type": "string",
+                    "example": "otpauth://totp/NTZ:admin?secret=JBSWY3DPEHPK3PXP"
                 },
-                "url": {
-                    "type": "string"
+                "secret": {
+                    "description": "TOTP 密钥（可手动输入认证器）",
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXP"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_response.ConfigDataResponse": {
+        "github_com_force-c_nai-tizi_internal_domain_response.MfaRecoveryCodesResponse": {
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "配置编码",
-                    "type": "string"
-                },
-                "data": {
-                    "description": "配置数据",
+                "recoveryCodes": {
+                    "description": "一次性恢复码（仅展示这一次，请妥善保存）",
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "type": "string"
                     }
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_response.ConfigResponse": {
+        "github_com_force-c_nai-tizi_internal_domain_response.MfaStatusResponse": {
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "配置编码",
-                    "type": "string"
+                "enabled": {
+                    "description": "是否已启用",
+                    "type": "boolean",
+                    "example": true
+                },
+                "recoveryCodesRemaining": {
+                    "description": "剩余可用恢复码数量",
+                    "type": "integer",
+                    "example": 10
+                },
+                "required": {
+                    "description": "所属角色是否强制启用",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_response.OAuthAuthorizeResponse": {
+            "type": "object",
+            "properties": {
+                "redirectUri": {
+                    "description": "前端跳转地址（携带授权码或错误信息）",
+                    "type": "string",
+                    "example": "https://app.example.com/callback?code=xxx\u0026state=af0ifjsldkj"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_response.OAuthConsentGrantResponse": {
+            "type": "object",
+            "properties": {
+                "clientId": {
+                    "description": "客户端Key",
+                    "type": "string",
+                    "example": "internal-app"
                 },
-                "createBy": {
-                    "description": "创建者",
-                    "type": "integer"
+                "clientName": {
+                    "description": "客户端名称",
+                    "type": "string",
+                    "example": "内部应用"
                 },
                 "createdTime": {
-                    "description": "创建时间",
+                    "description": "首次授权时间",
                     "type": "string"
                 },
-                "data": {
-                    "description": "配置数据（JSON格式）",
+                "scopes": {
+                    "description": "已授予的范围",
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "type": "string"
                     }
                 },
-                "id": {
-                    "description": "配置ID",
-                    "type": "integer"
-                },
-                "name": {
-                    "description": "配置名称",
-                    "type": "string"
-                },
-                "remark": {
-                    "description": "备注",
-                    "type": "string"
-                },
-                "updateBy": {
-                    "description": "更新者",
-                    "type": "integer"
-                },
                 "updatedTime": {
-                    "description": "更新时间",
+                    "description": "最近授权时间",
                     "type": "string"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_response.DictDataResponse": {
+        "github_com_force-c_nai-tizi_internal_domain_response.OAuthConsentResponse": {
             "type": "object",
             "properties": {
-                "createBy": {
-                    "description": "创建者",
-                    "type": "integer"
+                "approved": {
+                    "description": "是否已授权过（为 true 时前端可直接提交确认）",
+                    "type": "boolean",
+                    "example": false
                 },
-                "createdTime": {
-                    "description": "创建时间",
+                "clientId": {
+                    "description": "客户端Key",
+                    "type": "string",
+                    "example": "internal-app"
+                },
+                "clientName": {
+                    "description": "客户端名称",
+                    "type": "string",
+                    "example": "内部应用"
+                },
+                "redirectUri": {
+                    "description": "授权完成后的回调地址",
                     "type": "string"
                 },
-                "dictLabel": {
-                    "description": "字典标签",
+                "scopes": {
+                    "description": "申请的授权范围",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.OAuthScopeResponse"
+                    }
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_response.OAuthErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "错误码",
                     "type": "string"
                 },
-                "dictType": {
-                    "description": "字典类型",
+                "error_description": {
+                    "description": "错误描述",
                     "type": "string"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_response.OAuthIntrospectionResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "令牌是否有效",
+                    "type": "boolean"
                 },
-                "dictValue": {
-                    "description": "字典键值",
+                "client_id": {
+                    "description": "令牌所属客户端Key",
                     "type": "string"
                 },
-                "id": {
-                    "description": "字典ID",
+                "exp": {
+                    "description": "过期时间",
                     "type": "integer"
                 },
-                "isDefault": {
-                    "description": "是否默认",
-                    "type": "boolean"
-                },
-                "parentId": {
-                    "description": "父字典ID",
+                "iat": {
+                    "description": "签发时间",
                     "type": "integer"
                 },
-                "remark": {
-                    "description": "备注",
+                "iss": {
+                    "description": "签发方",
                     "type": "string"
                 },
-                "sort": {
-                    "description": "排序",
-                    "type": "integer"
+                "jti": {
+                    "description": "令牌ID",
+                    "type": "string"
                 },
-                "status": {
-                    "description": "状态",
-                    "type": "integer"
+                "scope": {
+                    "description": "授权范围",
+                    "type": "string"
                 },
-                "updateBy": {
-                    "description": "更新者",
-                    "type": "integer"
+                "sub": {
+                    "description": "主体（用户ID；客户端模式为客户端Key）",
+                    "type": "string"
                 },
-                "updatedTime": {
-                    "description": "更新时间",
+                "token_type": {
+                    "description": "令牌类型",
+                    "type": "string"
+                },
+                "username": {
+                    "description": "用户名",
                     "type": "string"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_response.LoginLogResponse": {
+        "github_com_force-c_nai-tizi_internal_domain_response.OAuthScopeResponse": {
             "type": "object",
             "properties": {
-                "browser": {
-                    "description": "浏览器类型",
-                    "type": "string"
+                "description": {
+                    "description": "范围说明",
+                    "type": "string",
+                    "example": "读取你的基本资料"
                 },
-                "clientId": {
-                    "description": "客户端ID",
+                "scope": {
+                    "description": "范围标识",
+                    "type": "string",
+                    "example": "profile"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_response.OAuthTokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "description": "访问令牌",
                     "type": "string"
                 },
-                "id": {
-                    "description": "日志ID",
+                "expires_in": {
+                    "description": "访问令牌有效期（秒）",
                     "type": "integer"
                 },
-                "ipaddr": {
-                    "description": "登录IP",
-                    "type": "string"
-                },
-                "loginLocation": {
-                    "description": "登录地点",
-                    "type": "string"
-                },
-                "loginTime": {
-                    "description": "登录时间",
+                "refresh_token": {
+                    "description": "刷新令牌（客户端开通 refresh_token 时返回）",
                     "type": "string"
                 },
-                "msg": {
-                    "description": "提示消息",
+                "scope": {
+                    "description": "实际授予的范围",
                     "type": "string"
                 },
-                "os": {
-                    "description": "操作系统",
+                "token_type": {
+                    "description": "令牌类型，固定为 Bearer",
                     "type": "string"
-                },
-                "status": {
-                    "description": "登录状态：0成功 1失败",
-                    "type": "integer"
-                },
-                "tenantId": {
-                    "description": "租户ID",
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_response.OidcAuthorizeResponse": {
+            "type": "object",
+            "properties": {
+                "authorizeUrl": {
+                    "description": "跳转到提供方的授权地址",
                     "type": "string"
                 },
-                "userName": {
-                    "description": "用户名",
+                "state": {
+                    "description": "授权请求标识（回调时原样返回）",
                     "type": "string"
                 }
             }
         },
-        "github_com_force-c_nai-tizi_internal_domain_response.LoginResponse": {
-            "description": "登录成功后返回的 Token 信息和用户信息",
+        "github_com_force-c_nai-tizi_internal_domain_response.OidcProviderResponse": {
             "type": "object",
             "properties": {
-                "access_token": {
-                    "description": "访问令牌（短期有效）",
+                "displayName": {
+                    "description": "显示名称",
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                },
-                "expires_in": {
-                    "description": "AccessToken 过期时间（秒）",
-                    "type": "integer",
-                    "example": 1800
-                },
-                "refresh_expires_in": {
-                    "description": "RefreshToken 过期时间（秒）",
-                    "type": "integer",
-                    "example": 604800
+                    "example": "Keycloak"
                 },
-                "refresh_token": {
-                    "description": "刷新令牌（长期有效）",
+                "name": {
+                    "description": "提供方标识",
                     "type": "string",
-                    "example": "dGhpcyBpcyBhIHJlZnJlc2ggdG9rZW4="
-                },
-                "user_info": {
-                    "description": "用户信息",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.UserInfo"
-                        }
-                    ]
+                    "example": "keycloak"
                 }
             }
         },
         "github_com_force-c_nai-tizi_internal_domain_response.OperLogResponse": {
             "type": "object",
             "properties": {
+                "actorName": {
+                    "description": "代登录的管理员（为空表示本人操作）",
+                    "type": "string"
+                },
                 "businessType": {
                     "description": "业务类型",
                     "type": "string"
@@ -6783,6 +11915,15 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_force-c_nai-tizi_internal_domain_response.PasswordResetVerifyResponse": {
+            "type": "object",
+            "properties": {
+                "resetToken": {
+                    "description": "重置令牌，提交新密码时使用",
+                    "type": "string"
+                }
+            }
+        },
         "github_com_force-c_nai-tizi_internal_domain_response.RefreshTokenRequest": {
             "description": "使用 RefreshToken 刷新 AccessToken 的请求参数",
             "type": "object",
@@ -6835,6 +11976,51 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_force-c_nai-tizi_internal_domain_response.RegisterResponse": {
+            "type": "object",
+            "properties": {
+                "pending": {
+                    "description": "是否等待管理员审批，审批通过前不能登录",
+                    "type": "boolean"
+                },
+                "userId": {
+                    "description": "用户ID",
+                    "type": "integer"
+                },
+                "userName": {
+                    "description": "用户名",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_response.RegistrationOptionsResponse": {
+            "type": "object",
+            "properties": {
+                "allowedDomains": {
+                    "description": "开放注册允许的邮箱域名，空表示不限制",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "captcha": {
+                    "description": "是否需要图形验证码",
+                    "type": "boolean"
+                },
+                "enabled": {
+                    "description": "是否开放注册",
+                    "type": "boolean"
+                },
+                "inviteSignup": {
+                    "description": "是否允许邀请码注册",
+                    "type": "boolean"
+                },
+                "openSignup": {
+                    "description": "是否允许无邀请码注册（须验证邮箱）",
+                    "type": "boolean"
+                }
+            }
+        },
         "github_com_force-c_nai-tizi_internal_domain_response.Response": {
             "description": "API 统一响应格式",
             "type": "object",
@@ -6898,40 +12084,96 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 1
                 },
-                "isSystem": {
-                    "description": "是否系统内置",
-                    "type": "boolean",
-                    "example": true
+                "isSystem": {
+                    "description": "是否系统内置",
+                    "type": "boolean",
+                    "example": true
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string",
+                    "example": "超级管理员，拥有所有权限"
+                },
+                "roleId": {
+                    "description": "角色ID",
+                    "type": "integer",
+                    "example": 1
+                },
+                "roleKey": {
+                    "description": "角色标识",
+                    "type": "string",
+                    "example": "admin"
+                },
+                "roleName": {
+                    "description": "角色名称",
+                    "type": "string",
+                    "example": "超级管理员"
+                },
+                "sort": {
+                    "description": "显示顺序",
+                    "type": "integer",
+                    "example": 1
+                },
+                "status": {
+                    "description": "状态：0正常 1停用",
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_response.SessionResponse": {
+            "description": "用户当前的活跃登录会话",
+            "type": "object",
+            "properties": {
+                "actorName": {
+                    "description": "代登录的管理员（非代登录会话为空）",
+                    "type": "string",
+                    "example": "admin"
+                },
+                "browser": {
+                    "description": "浏览器",
+                    "type": "string",
+                    "example": "Chrome"
+                },
+                "clientId": {
+                    "description": "客户端ID",
+                    "type": "string"
+                },
+                "createdTime": {
+                    "description": "登录时间",
+                    "type": "string"
+                },
+                "current": {
+                    "description": "是否为当前请求所在会话",
+                    "type": "boolean"
+                },
+                "deviceType": {
+                    "description": "设备类型",
+                    "type": "string",
+                    "example": "pc"
                 },
-                "remark": {
-                    "description": "备注",
+                "ipaddr": {
+                    "description": "登录IP",
                     "type": "string",
-                    "example": "超级管理员，拥有所有权限"
+                    "example": "127.0.0.1"
                 },
-                "roleId": {
-                    "description": "角色ID",
-                    "type": "integer",
-                    "example": 1
+                "lastRefreshTime": {
+                    "description": "最近刷新时间",
+                    "type": "string"
                 },
-                "roleKey": {
-                    "description": "角色标识",
+                "os": {
+                    "description": "操作系统",
                     "type": "string",
-                    "example": "admin"
+                    "example": "Windows"
                 },
-                "roleName": {
-                    "description": "角色名称",
+                "sessionId": {
+                    "description": "会话ID",
                     "type": "string",
-                    "example": "超级管理员"
-                },
-                "sort": {
-                    "description": "显示顺序",
-                    "type": "integer",
-                    "example": 1
+                    "example": "0b7f3c1e-5a2d-4f8e-9c6b-1d2e3f4a5b6c"
                 },
-                "status": {
-                    "description": "状态：0正常 1停用",
-                    "type": "integer",
-                    "example": 0
+                "userAgent": {
+                    "description": "User-Agent",
+                    "type": "string"
                 }
             }
         },
@@ -6981,6 +12223,131 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_force-c_nai-tizi_internal_domain_response.TenantOnboardResponse": {
+            "type": "object",
+            "properties": {
+                "adminUserId": {
+                    "description": "租户管理员ID",
+                    "type": "integer"
+                },
+                "adminUserName": {
+                    "description": "租户管理员用户名",
+                    "type": "string"
+                },
+                "policyCount": {
+                    "description": "复制的权限策略数量",
+                    "type": "integer"
+                },
+                "roleCount": {
+                    "description": "复制的角色数量",
+                    "type": "integer"
+                },
+                "rootOrgId": {
+                    "description": "根组织ID",
+                    "type": "integer"
+                },
+                "tenant": {
+                    "description": "租户",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.TenantResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_response.TenantResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "当前是否可用（未停用且未到期）",
+                    "type": "boolean"
+                },
+                "adminUserId": {
+                    "description": "租户管理员ID",
+                    "type": "integer"
+                },
+                "contactName": {
+                    "description": "联系人",
+                    "type": "string"
+                },
+                "contactPhone": {
+                    "description": "联系电话",
+                    "type": "string"
+                },
+                "createdTime": {
+                    "description": "创建时间",
+                    "type": "string"
+                },
+                "expireAt": {
+                    "description": "到期时间（时间戳），0 表示永不过期",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "租户ID",
+                    "type": "integer"
+                },
+                "plan": {
+                    "description": "套餐",
+                    "type": "string"
+                },
+                "remark": {
+                    "description": "备注",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态：0正常 1停用",
+                    "type": "integer"
+                },
+                "tenantCode": {
+                    "description": "租户编码",
+                    "type": "string"
+                },
+                "tenantName": {
+                    "description": "租户名称",
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_response.UserIdentityResponse": {
+            "type": "object",
+            "properties": {
+                "createdTime": {
+                    "description": "绑定时间",
+                    "type": "string"
+                },
+                "displayName": {
+                    "description": "提供方显示名称",
+                    "type": "string",
+                    "example": "Keycloak"
+                },
+                "email": {
+                    "description": "提供方返回的邮箱",
+                    "type": "string",
+                    "example": "alice@example.com"
+                },
+                "id": {
+                    "description": "绑定记录ID",
+                    "type": "integer",
+                    "example": 1
+                },
+                "lastLoginAt": {
+                    "description": "最后登录时间（时间戳）",
+                    "type": "integer",
+                    "example": 1700000000
+                },
+                "nickname": {
+                    "description": "提供方返回的昵称",
+                    "type": "string",
+                    "example": "Alice"
+                },
+                "provider": {
+                    "description": "提供方标识",
+                    "type": "string",
+                    "example": "keycloak"
+                }
+            }
+        },
         "github_com_force-c_nai-tizi_internal_domain_response.UserInfo": {
             "description": "用户基本信息",
             "type": "object",
@@ -7005,6 +12372,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "13800138000"
                 },
+                "tenantId": {
+                    "description": "租户ID",
+                    "type": "integer",
+                    "example": 1
+                },
                 "userId": {
                     "description": "用户ID",
                     "type": "integer",
@@ -7090,6 +12462,62 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_force-c_nai-tizi_internal_domain_response.WebAuthnCredentialResponse": {
+            "type": "object",
+            "properties": {
+                "aaguid": {
+                    "description": "认证器型号标识",
+                    "type": "string"
+                },
+                "backupEligible": {
+                    "description": "是否支持同步备份",
+                    "type": "boolean",
+                    "example": true
+                },
+                "backupState": {
+                    "description": "是否已同步备份",
+                    "type": "boolean",
+                    "example": true
+                },
+                "createdTime": {
+                    "description": "注册时间",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "凭证记录ID",
+                    "type": "integer",
+                    "example": 1
+                },
+                "lastUsedAt": {
+                    "description": "最后使用时间（时间戳）",
+                    "type": "integer",
+                    "example": 1700000000
+                },
+                "name": {
+                    "description": "凭证名称",
+                    "type": "string",
+                    "example": "我的 MacBook"
+                },
+                "transports": {
+                    "description": "支持的传输方式",
+                    "type": "string",
+                    "example": "internal,hybrid"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_domain_response.WebAuthnLoginOptionsResponse": {
+            "type": "object",
+            "properties": {
+                "challengeId": {
+                    "description": "登录挑战ID（登录时原样提交）",
+                    "type": "string",
+                    "example": "0b6f3c9e-6a4f-4d8e-9a51-1c2f3e4d5a6b"
+                },
+                "options": {
+                    "description": "navigator.credentials.get() 参数"
+                }
+            }
+        },
         "github_com_force-c_nai-tizi_internal_infrastructure_captcha.CaptchaData": {
             "type": "object",
             "properties": {
@@ -7137,6 +12565,49 @@ const docTemplate = `{
                 "CaptchaTypeEmail"
             ]
         },
+        "github_com_force-c_nai-tizi_internal_infrastructure_jwt.JSONWebKey": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                },
+                "y": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_infrastructure_jwt.JSONWebKeySet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_infrastructure_jwt.JSONWebKey"
+                    }
+                }
+            }
+        },
         "github_com_force-c_nai-tizi_internal_service.MenuTree": {
             "type": "object",
             "properties": {
@@ -7222,6 +12693,118 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_force-c_nai-tizi_internal_utils_pagination.Page-github_com_force-c_nai-tizi_internal_domain_response_ClientResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页",
+                    "type": "integer"
+                },
+                "pages": {
+                    "description": "总页数",
+                    "type": "integer"
+                },
+                "records": {
+                    "description": "查询数据列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.ClientResponse"
+                    }
+                },
+                "size": {
+                    "description": "每页显示条数",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "总记录数",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_utils_pagination.Page-github_com_force-c_nai-tizi_internal_domain_response_ImpersonationLogResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页",
+                    "type": "integer"
+                },
+                "pages": {
+                    "description": "总页数",
+                    "type": "integer"
+                },
+                "records": {
+                    "description": "查询数据列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.ImpersonationLogResponse"
+                    }
+                },
+                "size": {
+                    "description": "每页显示条数",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "总记录数",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_utils_pagination.Page-github_com_force-c_nai-tizi_internal_domain_response_InviteCodeResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页",
+                    "type": "integer"
+                },
+                "pages": {
+                    "description": "总页数",
+                    "type": "integer"
+                },
+                "records": {
+                    "description": "查询数据列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.InviteCodeResponse"
+                    }
+                },
+                "size": {
+                    "description": "每页显示条数",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "总记录数",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_force-c_nai-tizi_internal_utils_pagination.Page-github_com_force-c_nai-tizi_internal_domain_response_TenantResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "当前页",
+                    "type": "integer"
+                },
+                "pages": {
+                    "description": "总页数",
+                    "type": "integer"
+                },
+                "records": {
+                    "description": "查询数据列表",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_force-c_nai-tizi_internal_domain_response.TenantResponse"
+                    }
+                },
+                "size": {
+                    "description": "每页显示条数",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "总记录数",
+                    "type": "integer"
+                }
+            }
+        },
         "internal_controller.HealthResponse": {
             "type": "object",
             "properties": {
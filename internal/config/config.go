@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/viper"
 )
@@ -24,8 +25,21 @@ type Redis struct {
 	DB             int
 }
 type JWT struct {
-	Secret string
-	Expire int64
+	Secret    string
+	Expire    int64
+	Algorithm string       `mapstructure:"algorithm"` // 签名算法：HS256（默认，使用 secret）/ RS256 / ES256 / EdDSA
+	KeySource string       `mapstructure:"keySource"` // 非对称密钥来源：file（默认）/ database
+	Keys      []JWTKeyFile `mapstructure:"keys"`      // keySource=file 时的私钥文件，第一个用于签名，其余仅用于验证
+	Rotation  JWTRotation  `mapstructure:"rotation"`  // keySource=database 时的自动轮换
+}
+type JWTKeyFile struct {
+	Kid            string `mapstructure:"kid"`            // 密钥ID，写入 Token 头部的 kid
+	PrivateKeyFile string `mapstructure:"privateKeyFile"` // PEM 私钥文件路径，相对路径基于应用目录
+}
+type JWTRotation struct {
+	Enabled       bool `mapstructure:"enabled"`       // 是否自动轮换（需启用调度器）
+	IntervalHours int  `mapstructure:"intervalHours"` // 轮换周期（小时），默认 720
+	OverlapHours  int  `mapstructure:"overlapHours"`  // 旧密钥在轮换后继续用于验证的时长（小时），默认 48，不应小于 Token 有效期
 }
 type WeChat struct {
 	Enabled    bool   `mapstructure:"enabled"`
@@ -65,6 +79,19 @@ type S3 struct {
 	ForcePathStyle  bool   `mapstructure:"forcePathStyle"`  // 强制路径样式（MinIO需要）
 }
 
+// Casbin 权限配置
+type Casbin struct {
+	Watcher CasbinWatcher `mapstructure:"watcher"` // 多副本策略同步
+}
+
+// CasbinWatcher 多副本间的 Casbin 策略同步（Redis 发布订阅）
+// 每个副本的 Enforcer 在内存中缓存策略，策略变更须广播给其他副本
+type CasbinWatcher struct {
+	Enabled       bool   `mapstructure:"enabled"`       // 是否启用，默认 true（多副本部署必须启用）
+	Channel       string `mapstructure:"channel"`       // 发布订阅频道，默认 casbin:policy
+	CheckInterval int    `mapstructure:"checkInterval"` // 策略版本校验间隔（秒），版本落后的副本全量重新加载，默认 30
+}
+
 type Scheduler struct {
 	Enabled bool `mapstructure:"enabled"`
 }
@@ -75,9 +102,151 @@ type WebSocket struct {
 
 // Auth 认证配置
 type Auth struct {
-	TokenHeader     string `mapstructure:"tokenHeader"`     // Token 请求头名称，默认 "Authorization"
-	AllowConcurrent bool   `mapstructure:"allowConcurrent"` // 是否允许并发登录，默认 false
-	ShareToken      bool   `mapstructure:"shareToken"`      // 并发登录时是否共享 Token，默认 false
+	TokenHeader          string        `mapstructure:"tokenHeader"`          // Token 请求头名称，默认 "Authorization"
+	ApiKeyHeader         string        `mapstructure:"apiKeyHeader"`         // API Key 请求头名称，默认 "X-API-Key"（也可在 Token 请求头中传入 ntz_ 开头的 Key）
+	AllowConcurrent      bool          `mapstructure:"allowConcurrent"`      // 是否允许并发登录，默认 false
+	ShareToken           bool          `mapstructure:"shareToken"`           // 并发登录时是否共享 Token，默认 false
+	MfaIssuer            string        `mapstructure:"mfaIssuer"`            // 两步验证（TOTP）在认证器 App 中显示的发行方名称，默认 "NTZ"
+	ImpersonationTimeout int           `mapstructure:"impersonationTimeout"` // 管理员以用户身份登录签发的 Token 有效期（秒），默认 900，最长 3600
+	SmsLogin             SmsLogin      `mapstructure:"smsLogin"`             // 短信验证码登录配置
+	PasswordReset        PasswordReset `mapstructure:"passwordReset"`        // 找回密码配置
+	Lockout              LoginLockout  `mapstructure:"lockout"`              // 登录失败锁定配置
+	PasswordHash         PasswordHash  `mapstructure:"passwordHash"`         // 密码哈希配置
+	LoginRisk            LoginRisk     `mapstructure:"loginRisk"`            // 登录风险评估与异常登录提醒
+}
+
+// SmsLogin 短信验证码登录配置（grantType=sms）
+type SmsLogin struct {
+	AutoRegister   bool   `mapstructure:"autoRegister"`   // 未注册的手机号是否自动创建用户，默认 false
+	DefaultOrgId   int64  `mapstructure:"defaultOrgId"`   // 自动创建用户的所属组织
+	DefaultRoleKey string `mapstructure:"defaultRoleKey"` // 自动创建用户的默认角色标识，为空时不分配角色
+}
+
+// PasswordReset 找回密码配置（通过邮箱链接或短信验证码自助重置密码）
+type PasswordReset struct {
+	ResetUrl        string `mapstructure:"resetUrl"`        // 前端重置密码页地址，邮件中的链接为 {resetUrl}?token=xxx
+	TokenExpire     int    `mapstructure:"tokenExpire"`     // 重置令牌有效期（秒），默认 1800
+	SmsTemplateCode string `mapstructure:"smsTemplateCode"` // 短信验证码模板编码，为空时使用 sms.templateCode
+}
+
+// LoginLockout 登录失败锁定配置（密码、LDAP、短信、邮箱、小程序登录共用）
+// 分别按账号、IP + 账号、IP 统计失败次数，任一达到阈值即锁定，重复锁定时按 Durations 逐级延长
+type LoginLockout struct {
+	Window             int   `mapstructure:"window"`             // 失败次数统计窗口（秒），默认 600
+	AccountThreshold   int   `mapstructure:"accountThreshold"`   // 同一账号（不区分来源）失败次数阈值，默认 10
+	IpAccountThreshold int   `mapstructure:"ipAccountThreshold"` // 同一 IP 对同一账号失败次数阈值，默认 5
+	IpThreshold        int   `mapstructure:"ipThreshold"`        // 同一 IP 失败次数阈值（防撞库），默认 30
+	Durations          []int `mapstructure:"durations"`          // 逐级递增的锁定时长（秒），默认 [300, 900, 3600, 86400]
+	LevelReset         int   `mapstructure:"levelReset"`         // 锁定级别保留时间（秒），期间无新锁定则回到第一级，默认 86400
+}
+
+// PasswordHash 密码哈希配置
+// 新密码按 algorithm 生成；已有哈希按前缀自动识别算法验证（bcrypt、argon2id、scrypt、旧系统加盐摘要），
+// 密码登录成功时若哈希算法或参数弱于当前配置，自动按当前配置重新哈希并保存
+type PasswordHash struct {
+	Algorithm         string `mapstructure:"algorithm"`         // 当前算法：bcrypt / argon2id / scrypt，默认 bcrypt
+	BcryptCost        int    `mapstructure:"bcryptCost"`        // bcrypt 成本因子，默认 10
+	Argon2Memory      int    `mapstructure:"argon2Memory"`      // argon2id 内存（KiB），默认 65536
+	Argon2Iterations  int    `mapstructure:"argon2Iterations"`  // argon2id 迭代次数，默认 3
+	Argon2Parallelism int    `mapstructure:"argon2Parallelism"` // argon2id 并行度，默认 2
+	ScryptLogN        int    `mapstructure:"scryptLogN"`        // scrypt N 的对数（N=2^logN），默认 15
+	ScryptR           int    `mapstructure:"scryptR"`           // scrypt 块大小，默认 8
+	ScryptP           int    `mapstructure:"scryptP"`           // scrypt 并行度，默认 1
+}
+
+// LoginRisk 登录风险评估配置
+// 登录成功后按新设备、新 IP 段、新国家、不可能的移动速度、黑名单 IP 计算风险分，
+// 分数写入登录日志，达到 NotifyScore 时通过邮件和 WebSocket 提醒用户，提醒中附带"不是我本人"链接用于注销该会话
+type LoginRisk struct {
+	Enabled           bool   `mapstructure:"enabled"`           // 是否启用，默认 false
+	GeoIPFile         string `mapstructure:"geoipFile"`         // 离线 IP 归属地库（DB-IP country/city lite CSV），为空时不判断新国家和不可能的移动速度
+	BlocklistFile     string `mapstructure:"blocklistFile"`     // IP 黑名单文件（每行一个 IP 或 CIDR，可直接使用 TOR 出口节点列表），为空时不检查
+	NotifyScore       int    `mapstructure:"notifyScore"`       // 达到该风险分时提醒用户，默认 30
+	MaxSpeed          int    `mapstructure:"maxSpeed"`          // 两次登录间的最大合理移动速度（km/h），超过即判定为不可能的移动，默认 900
+	DeviceRetention   int    `mapstructure:"deviceRetention"`   // 已知设备保留天数，超过未登录的设备视为新设备，默认 180
+	RevokeUrl         string `mapstructure:"revokeUrl"`         // 前端"不是我本人"页面地址，邮件链接为 {revokeUrl}?token=xxx
+	RevokeTokenExpire int    `mapstructure:"revokeTokenExpire"` // "不是我本人"链接有效期（秒），默认 604800
+}
+
+// WebAuthn 通行密钥（Passkey）配置
+type WebAuthn struct {
+	Enabled       bool     `mapstructure:"enabled"`       // 是否启用
+	RPID          string   `mapstructure:"rpId"`          // 依赖方ID（站点域名，如 example.com）
+	RPDisplayName string   `mapstructure:"rpDisplayName"` // 依赖方显示名称
+	RPOrigins     []string `mapstructure:"rpOrigins"`     // 允许的来源（如 https://admin.example.com）
+	Timeout       int      `mapstructure:"timeout"`       // 注册/登录仪式超时时间（秒），默认 300
+}
+
+// OAuth OAuth2 授权服务配置
+type OAuth struct {
+	Issuer      string `mapstructure:"issuer"`      // 授权服务标识（对外访问地址，如 https://auth.example.com），为空时按请求地址推断
+	ConsentPage string `mapstructure:"consentPage"` // 前端授权确认页地址，授权端点 /oauth/authorize 携带原始参数跳转至此
+	CodeTimeout int    `mapstructure:"codeTimeout"` // 授权码有效期（秒），默认 300，最长 600
+}
+
+// OIDC 第三方 OpenID Connect 登录配置
+type OIDC struct {
+	Providers []OIDCProvider `mapstructure:"providers"` // 上游提供方列表
+}
+
+// OIDCProvider 上游 OIDC 提供方配置
+type OIDCProvider struct {
+	Name           string           `mapstructure:"name"`           // 提供方标识（登录时传入 provider）
+	DisplayName    string           `mapstructure:"displayName"`    // 显示名称
+	Issuer         string           `mapstructure:"issuer"`         // 发行方地址（用于 /.well-known/openid-configuration 自动发现）
+	ClientID       string           `mapstructure:"clientId"`       // 客户端ID
+	ClientSecret   string           `mapstructure:"clientSecret"`   // 客户端密钥
+	RedirectURL    string           `mapstructure:"redirectUrl"`    // 回调地址（前端页面，回调后携带 code 和 state 调用登录接口）
+	Scopes         []string         `mapstructure:"scopes"`         // 申请的范围，默认 openid profile email
+	ClaimMapping   OIDCClaimMapping `mapstructure:"claimMapping"`   // 声明映射
+	AutoProvision  bool             `mapstructure:"autoProvision"`  // 未绑定的身份是否自动创建用户
+	DefaultOrgId   int64            `mapstructure:"defaultOrgId"`   // 自动创建用户的所属组织
+	DefaultRoleKey string           `mapstructure:"defaultRoleKey"` // 自动创建用户的默认角色标识，为空时不分配角色
+}
+
+// OIDCClaimMapping ID Token 声明映射，为空时使用标准声明名
+type OIDCClaimMapping struct {
+	Username string `mapstructure:"username"` // 默认 preferred_username
+	Nickname string `mapstructure:"nickname"` // 默认 name
+	Email    string `mapstructure:"email"`    // 默认 email
+	Phone    string `mapstructure:"phone"`    // 默认 phone_number
+	Avatar   string `mapstructure:"avatar"`   // 默认 picture
+}
+
+// LDAP LDAP / Active Directory 登录配置
+type LDAP struct {
+	Enabled            bool               `mapstructure:"enabled"`            // 是否启用（grantType=ldap）
+	URL                string             `mapstructure:"url"`                // 服务地址，如 ldap://dc.example.com:389 或 ldaps://dc.example.com:636
+	StartTLS           bool               `mapstructure:"startTLS"`           // 是否在 ldap:// 连接上执行 StartTLS
+	InsecureSkipVerify bool               `mapstructure:"insecureSkipVerify"` // 是否跳过证书校验（仅限测试环境）
+	BindDN             string             `mapstructure:"bindDN"`             // 查询用户使用的服务账号 DN，为空时匿名查询
+	BindPassword       string             `mapstructure:"bindPassword"`       // 服务账号密码
+	BaseDN             string             `mapstructure:"baseDN"`             // 用户查询起点
+	UserFilter         string             `mapstructure:"userFilter"`         // 用户过滤器，%s 替换为用户名，默认 (&(objectClass=person)(uid=%s))
+	Attributes         LDAPAttributes     `mapstructure:"attributes"`         // 属性映射
+	GroupAttribute     string             `mapstructure:"groupAttribute"`     // 用户所属组属性，默认 memberOf
+	GroupMappings      []LDAPGroupMapping `mapstructure:"groupMappings"`      // 目录组到角色标识的映射
+	DefaultOrgId       int64              `mapstructure:"defaultOrgId"`       // 默认组织（组织属性为空或未匹配到组织编码时使用）
+	BindExisting       bool               `mapstructure:"bindExisting"`       // 是否将同名的本地账号关联为目录账号，默认 false（拒绝登录）
+	PoolSize           int                `mapstructure:"poolSize"`           // 连接池大小，默认 5
+	Timeout            int                `mapstructure:"timeout"`            // 连接及查询超时时间（秒），默认 10
+	SyncEnabled        bool               `mapstructure:"syncEnabled"`        // 是否启用定时同步（停用目录中已删除的用户）
+	SyncCron           string             `mapstructure:"syncCron"`           // 同步任务 cron 表达式（含秒），默认每天 03:00
+}
+
+// LDAPAttributes 目录属性到用户字段的映射
+type LDAPAttributes struct {
+	Username string `mapstructure:"username"` // 用户名属性，默认 uid（AD 通常为 sAMAccountName）
+	Nickname string `mapstructure:"nickname"` // 昵称属性，默认 cn
+	Email    string `mapstructure:"email"`    // 邮箱属性，默认 mail
+	Phone    string `mapstructure:"phone"`    // 手机号属性，默认 mobile
+	Org      string `mapstructure:"org"`      // 组织属性，其值与组织编码匹配，为空时使用默认组织
+}
+
+// LDAPGroupMapping 目录组到角色的映射
+type LDAPGroupMapping struct {
+	Group   string `mapstructure:"group"`   // 组 DN 或 CN（不区分大小写）
+	RoleKey string `mapstructure:"roleKey"` // 角色标识
 }
 
 // Captcha 验证码配置
@@ -113,9 +282,14 @@ type EmailCaptcha struct {
 	Template string `mapstructure:"template"` // 邮件模板
 }
 
-// MultiTenant 多租户配置（预留扩展）
+// MultiTenant 多租户配置
 type MultiTenant struct {
-	Enabled bool `mapstructure:"enabled"` // 是否启用多租户模式，默认 false（单一企业模式）
+	Enabled    bool   `mapstructure:"enabled"`    // 是否启用多租户模式，默认 false（单一企业模式）
+	Header     string `mapstructure:"header"`     // 租户ID请求头，默认 Tenant-Id
+	BaseDomain string `mapstructure:"baseDomain"` // 租户子域名的主域名（如 example.com，则 acme.example.com 对应租户编码 acme），为空时不按子域名识别
+
+	TemplateTenantId int64  `mapstructure:"templateTenantId"` // 开通租户时复制角色、权限、字典、参数的模板租户，默认 1
+	AdminRoleKey     string `mapstructure:"adminRoleKey"`     // 租户管理员角色标识（需存在于模板租户），默认 admin
 }
 
 type Config struct {
@@ -125,8 +299,13 @@ type Config struct {
 	Redis       Redis
 	JWT         JWT
 	Auth        Auth
+	WebAuthn    WebAuthn    // 通行密钥配置
+	OAuth       OAuth       // OAuth2 授权服务配置
+	OIDC        OIDC        // 第三方 OIDC 登录配置
+	LDAP        LDAP        // LDAP / Active Directory 登录配置
 	Captcha     Captcha     // 验证码配置
 	MultiTenant MultiTenant // 多租户配置
+	Casbin      Casbin      // Casbin 权限配置
 	WeChat      WeChat
 	MQTT        MQTT
 	RabbitMQ    RabbitMQ
@@ -197,6 +376,40 @@ func Load(appDir string) (*Config, *viper.Viper, error) {
 	if cfg.JWT.Secret == "" {
 		return nil, nil, fmt.Errorf("jwt secret is required")
 	}
+	// JWT 默认值设置
+	if cfg.JWT.Algorithm == "" {
+		cfg.JWT.Algorithm = "HS256"
+	}
+	if cfg.JWT.KeySource == "" {
+		cfg.JWT.KeySource = "file"
+	}
+	if cfg.JWT.Rotation.IntervalHours <= 0 {
+		cfg.JWT.Rotation.IntervalHours = 720
+	}
+	if cfg.JWT.Rotation.OverlapHours <= 0 {
+		cfg.JWT.Rotation.OverlapHours = 48
+	}
+	switch cfg.JWT.Algorithm {
+	case "HS256":
+	case "RS256", "ES256", "EdDSA":
+		switch cfg.JWT.KeySource {
+		case "file":
+			if len(cfg.JWT.Keys) == 0 {
+				return nil, nil, fmt.Errorf("jwt keys are required when keySource is file")
+			}
+			if cfg.JWT.Rotation.Enabled {
+				return nil, nil, fmt.Errorf("jwt rotation requires keySource database")
+			}
+		case "database":
+			if cfg.JWT.Rotation.Enabled && !cfg.Scheduler.Enabled {
+				return nil, nil, fmt.Errorf("jwt rotation requires scheduler to be enabled")
+			}
+		default:
+			return nil, nil, fmt.Errorf("unsupported jwt keySource: %s", cfg.JWT.KeySource)
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported jwt algorithm: %s", cfg.JWT.Algorithm)
+	}
 	// MQTT validation
 	if cfg.MQTT.Enabled {
 		if cfg.MQTT.Broker == "" || cfg.MQTT.ClientID == "" {
@@ -209,10 +422,143 @@ func Load(appDir string) (*Config, *viper.Viper, error) {
 			return nil, nil, fmt.Errorf("rabbitmq url and exchange are required when enabled")
 		}
 	}
+	// 多租户默认值设置
+	if cfg.MultiTenant.Header == "" {
+		cfg.MultiTenant.Header = "Tenant-Id"
+	}
+	if cfg.MultiTenant.TemplateTenantId <= 0 {
+		cfg.MultiTenant.TemplateTenantId = 1
+	}
+	if cfg.MultiTenant.AdminRoleKey == "" {
+		cfg.MultiTenant.AdminRoleKey = "admin"
+	}
 	// Auth 默认值设置
 	if cfg.Auth.TokenHeader == "" {
 		cfg.Auth.TokenHeader = "Authorization"
 	}
+	if cfg.Auth.ApiKeyHeader == "" {
+		cfg.Auth.ApiKeyHeader = "X-API-Key"
+	}
+	if cfg.Auth.MfaIssuer == "" {
+		cfg.Auth.MfaIssuer = "NTZ"
+	}
+	if cfg.Auth.ImpersonationTimeout <= 0 {
+		cfg.Auth.ImpersonationTimeout = 900
+	}
+	if cfg.Auth.ImpersonationTimeout > 3600 {
+		return nil, nil, fmt.Errorf("auth.impersonationTimeout must not exceed 3600 seconds")
+	}
+	if cfg.Auth.PasswordReset.TokenExpire <= 0 {
+		cfg.Auth.PasswordReset.TokenExpire = 1800
+	}
+	if cfg.Auth.Lockout.Window <= 0 {
+		cfg.Auth.Lockout.Window = 600
+	}
+	if cfg.Auth.Lockout.AccountThreshold <= 0 {
+		cfg.Auth.Lockout.AccountThreshold = 10
+	}
+	if cfg.Auth.Lockout.IpAccountThreshold <= 0 {
+		cfg.Auth.Lockout.IpAccountThreshold = 5
+	}
+	if cfg.Auth.Lockout.IpThreshold <= 0 {
+		cfg.Auth.Lockout.IpThreshold = 30
+	}
+	if len(cfg.Auth.Lockout.Durations) == 0 {
+		cfg.Auth.Lockout.Durations = []int{300, 900, 3600, 86400}
+	}
+	for _, d := range cfg.Auth.Lockout.Durations {
+		if d <= 0 {
+			return nil, nil, fmt.Errorf("auth lockout durations must be positive")
+		}
+	}
+	if cfg.Auth.Lockout.LevelReset <= 0 {
+		cfg.Auth.Lockout.LevelReset = 86400
+	}
+	switch cfg.Auth.PasswordHash.Algorithm {
+	case "":
+		cfg.Auth.PasswordHash.Algorithm = "bcrypt"
+	case "bcrypt", "argon2id", "scrypt":
+	default:
+		return nil, nil, fmt.Errorf("unsupported auth passwordHash algorithm: %s", cfg.Auth.PasswordHash.Algorithm)
+	}
+	if cfg.Auth.LoginRisk.NotifyScore <= 0 {
+		cfg.Auth.LoginRisk.NotifyScore = 30
+	}
+	if cfg.Auth.LoginRisk.MaxSpeed <= 0 {
+		cfg.Auth.LoginRisk.MaxSpeed = 900
+	}
+	if cfg.Auth.LoginRisk.DeviceRetention <= 0 {
+		cfg.Auth.LoginRisk.DeviceRetention = 180
+	}
+	if cfg.Auth.LoginRisk.RevokeTokenExpire <= 0 {
+		cfg.Auth.LoginRisk.RevokeTokenExpire = 604800
+	}
+	if cfg.Auth.SmsLogin.AutoRegister && cfg.Auth.SmsLogin.DefaultOrgId == 0 {
+		return nil, nil, fmt.Errorf("auth smsLogin defaultOrgId is required when autoRegister is enabled")
+	}
+
+	// WebAuthn 默认值设置
+	if cfg.WebAuthn.Timeout <= 0 {
+		cfg.WebAuthn.Timeout = 300
+	}
+	if cfg.WebAuthn.RPDisplayName == "" {
+		cfg.WebAuthn.RPDisplayName = cfg.Auth.MfaIssuer
+	}
+	if cfg.WebAuthn.Enabled && (cfg.WebAuthn.RPID == "" || len(cfg.WebAuthn.RPOrigins) == 0) {
+		return nil, nil, fmt.Errorf("webauthn rpId and rpOrigins are required when enabled")
+	}
+
+	// OAuth 默认值设置
+	if cfg.OAuth.CodeTimeout <= 0 || cfg.OAuth.CodeTimeout > 600 {
+		cfg.OAuth.CodeTimeout = 300
+	}
+
+	// OIDC 提供方校验
+	for _, p := range cfg.OIDC.Providers {
+		if p.Name == "" || p.Issuer == "" || p.ClientID == "" || p.RedirectURL == "" {
+			return nil, nil, fmt.Errorf("oidc provider name, issuer, clientId and redirectUrl are required")
+		}
+		if p.AutoProvision && p.DefaultOrgId == 0 {
+			return nil, nil, fmt.Errorf("oidc provider %s requires defaultOrgId when autoProvision is enabled", p.Name)
+		}
+	}
+
+	// LDAP 默认值设置
+	if cfg.LDAP.UserFilter == "" {
+		cfg.LDAP.UserFilter = "(&(objectClass=person)(uid=%s))"
+	}
+	if cfg.LDAP.Attributes.Username == "" {
+		cfg.LDAP.Attributes.Username = "uid"
+	}
+	if cfg.LDAP.Attributes.Nickname == "" {
+		cfg.LDAP.Attributes.Nickname = "cn"
+	}
+	if cfg.LDAP.Attributes.Email == "" {
+		cfg.LDAP.Attributes.Email = "mail"
+	}
+	if cfg.LDAP.Attributes.Phone == "" {
+		cfg.LDAP.Attributes.Phone = "mobile"
+	}
+	if cfg.LDAP.GroupAttribute == "" {
+		cfg.LDAP.GroupAttribute = "memberOf"
+	}
+	if cfg.LDAP.PoolSize <= 0 {
+		cfg.LDAP.PoolSize = 5
+	}
+	if cfg.LDAP.Timeout <= 0 {
+		cfg.LDAP.Timeout = 10
+	}
+	if cfg.LDAP.SyncCron == "" {
+		cfg.LDAP.SyncCron = "0 0 3 * * *"
+	}
+	if cfg.LDAP.Enabled {
+		if cfg.LDAP.URL == "" || cfg.LDAP.BaseDN == "" || cfg.LDAP.DefaultOrgId == 0 {
+			return nil, nil, fmt.Errorf("ldap url, baseDN and defaultOrgId are required when enabled")
+		}
+		if strings.Count(cfg.LDAP.UserFilter, "%s") != 1 {
+			return nil, nil, fmt.Errorf("ldap userFilter must contain exactly one %%s placeholder")
+		}
+	}
 
 	// Captcha 默认值设置
 	if !v.IsSet("captcha.image.length") {
@@ -240,5 +586,16 @@ func Load(appDir string) (*Config, *viper.Viper, error) {
 		cfg.Captcha.Email.Expire = 300
 	}
 
+	// Casbin 策略同步默认值设置
+	if !v.IsSet("casbin.watcher.enabled") {
+		cfg.Casbin.Watcher.Enabled = true
+	}
+	if cfg.Casbin.Watcher.Channel == "" {
+		cfg.Casbin.Watcher.Channel = "casbin:policy"
+	}
+	if cfg.Casbin.Watcher.CheckInterval <= 0 {
+		cfg.Casbin.Watcher.CheckInterval = 30
+	}
+
 	return &cfg, v, nil
 }
@@ -8,13 +8,15 @@ const (
 	SexUnknown int32 = 2 // 未知
 
 	// 用户类型
-	UserTypeSystem int32 = 0 // 系统用户
-	UserTypeWechat int32 = 1 // 微信用户
-	UserTypeApp    int32 = 2 // APP用户
+	UserTypeSystem  int32 = 0 // 系统用户
+	UserTypeWechat  int32 = 1 // 微信用户
+	UserTypeApp     int32 = 2 // APP用户
+	UserTypeService int32 = 3 // 服务账号（仅能通过 API Key 访问，不能登录）
 
 	// 状态（通用）
 	StatusNormal   int32 = 0 // 正常
 	StatusDisabled int32 = 1 // 停用
+	StatusPending  int32 = 2 // 待审批（自助注册到需要审批的组织，仅用于用户）
 )
 
 // 角色相关枚举
@@ -12,11 +12,14 @@ const (
 	ResourceRolePermission = "role.permission"
 
 	// 用户管理
-	ResourceUser       = "user"
-	ResourceUserRead   = "user.read"
-	ResourceUserCreate = "user.create"
-	ResourceUserUpdate = "user.update"
-	ResourceUserDelete = "user.delete"
+	ResourceUser            = "user"
+	ResourceUserRead        = "user.read"
+	ResourceUserCreate      = "user.create"
+	ResourceUserUpdate      = "user.update"
+	ResourceUserDelete      = "user.delete"
+	ResourceUserMfa         = "user.mfa"         // 重置用户两步验证
+	ResourceUserImpersonate = "user.impersonate" // 以用户身份登录（代登录）
+	ResourceUserApprove     = "user.approve"     // 审批自助注册的用户
 
 	// 组织管理
 	ResourceOrg       = "org"
@@ -52,6 +55,7 @@ const (
 	ResourceLoginLogCreate = "login_log.create"
 	ResourceLoginLogUpdate = "login_log.update"
 	ResourceLoginLogDelete = "login_log.delete"
+	ResourceLoginLogUnlock = "login_log.unlock" // 查看登录锁定并解锁账号/IP
 
 	// 操作日志管理
 	ResourceOperLog       = "oper_log"
@@ -77,4 +81,57 @@ const (
 	ResourceAttachmentUpload   = "attachment.upload"
 	ResourceAttachmentDownload = "attachment.download"
 	ResourceAttachmentBind     = "attachment.bind"
+
+	// 在线会话管理
+	ResourceSession       = "session"
+	ResourceSessionRead   = "session.read"
+	ResourceSessionDelete = "session.delete"
+
+	// API Key 管理（管理员为其他用户/服务账号管理）
+	ResourceApiKey       = "api_key"
+	ResourceApiKeyRead   = "api_key.read"
+	ResourceApiKeyCreate = "api_key.create"
+	ResourceApiKeyDelete = "api_key.delete"
+
+	// 客户端应用管理
+	ResourceClient             = "client"
+	ResourceClientRead         = "client.read"
+	ResourceClientCreate       = "client.create"
+	ResourceClientUpdate       = "client.update"
+	ResourceClientDelete       = "client.delete"
+	ResourceClientRotateSecret = "client.secret" // 轮换客户端密钥
+
+	// 注册邀请码管理
+	ResourceInviteCode       = "invite_code"
+	ResourceInviteCodeRead   = "invite_code.read"
+	ResourceInviteCodeCreate = "invite_code.create"
+	ResourceInviteCodeUpdate = "invite_code.update"
+	ResourceInviteCodeDelete = "invite_code.delete"
+
+	// 租户管理（仅平台租户可用）
+	ResourceTenant       = "tenant"
+	ResourceTenantRead   = "tenant.read"
+	ResourceTenantCreate = "tenant.create"
+	ResourceTenantUpdate = "tenant.update"
+	ResourceTenantDelete = "tenant.delete"
 )
+
+// Resources 所有可授予的权限资源（API Key 的 scopes 必须取自此列表或匹配其中的通配符）
+var Resources = []string{
+	ResourceRoleRead, ResourceRoleCreate, ResourceRoleUpdate, ResourceRoleDelete, ResourceRoleAssign, ResourceRolePermission,
+	ResourceUserRead, ResourceUserCreate, ResourceUserUpdate, ResourceUserDelete, ResourceUserMfa, ResourceUserImpersonate, ResourceUserApprove,
+	ResourceOrgRead, ResourceOrgCreate, ResourceOrgUpdate, ResourceOrgDelete,
+	ResourceMenuRead, ResourceMenuCreate, ResourceMenuUpdate, ResourceMenuDelete,
+	ResourceDictRead, ResourceDictCreate, ResourceDictUpdate, ResourceDictDelete,
+	ResourceConfigRead, ResourceConfigCreate, ResourceConfigUpdate, ResourceConfigDelete,
+	ResourceLoginLogRead, ResourceLoginLogCreate, ResourceLoginLogUpdate, ResourceLoginLogDelete, ResourceLoginLogUnlock,
+	ResourceOperLogRead, ResourceOperLogCreate, ResourceOperLogUpdate, ResourceOperLogDelete,
+	ResourceStorageEnvRead, ResourceStorageEnvCreate, ResourceStorageEnvUpdate, ResourceStorageEnvDelete, ResourceStorageEnvManage,
+	ResourceAttachmentRead, ResourceAttachmentCreate, ResourceAttachmentUpdate, ResourceAttachmentDelete,
+	ResourceAttachmentUpload, ResourceAttachmentDownload, ResourceAttachmentBind,
+	ResourceSessionRead, ResourceSessionDelete,
+	ResourceApiKeyRead, ResourceApiKeyCreate, ResourceApiKeyDelete,
+	ResourceClientRead, ResourceClientCreate, ResourceClientUpdate, ResourceClientDelete, ResourceClientRotateSecret,
+	ResourceInviteCodeRead, ResourceInviteCodeCreate, ResourceInviteCodeUpdate, ResourceInviteCodeDelete,
+	ResourceTenantRead, ResourceTenantCreate, ResourceTenantUpdate, ResourceTenantDelete,
+}
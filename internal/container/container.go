@@ -12,7 +12,9 @@ import (
 	"github.com/force-c/nai-tizi/internal/config"
 	"github.com/force-c/nai-tizi/internal/domain/model"
 	"github.com/force-c/nai-tizi/internal/infrastructure/captcha"
+	"github.com/force-c/nai-tizi/internal/infrastructure/casbinsync"
 	"github.com/force-c/nai-tizi/internal/infrastructure/database"
+	"github.com/force-c/nai-tizi/internal/infrastructure/geoip"
 	"github.com/force-c/nai-tizi/internal/infrastructure/idempotent"
 	"github.com/force-c/nai-tizi/internal/infrastructure/jwt"
 	"github.com/force-c/nai-tizi/internal/infrastructure/mqtt"
@@ -24,11 +26,16 @@ import (
 	"github.com/force-c/nai-tizi/internal/infrastructure/scheduler"
 	"github.com/force-c/nai-tizi/internal/infrastructure/scheduler/jobs"
 	"github.com/force-c/nai-tizi/internal/infrastructure/storage"
+	"github.com/force-c/nai-tizi/internal/infrastructure/tenant"
 	"github.com/force-c/nai-tizi/internal/infrastructure/thirdparty/email"
+	"github.com/force-c/nai-tizi/internal/infrastructure/thirdparty/ldap"
+	"github.com/force-c/nai-tizi/internal/infrastructure/thirdparty/oidc"
 	"github.com/force-c/nai-tizi/internal/infrastructure/thirdparty/sms"
 	"github.com/force-c/nai-tizi/internal/infrastructure/thirdparty/wechat"
 	"github.com/force-c/nai-tizi/internal/infrastructure/websocket"
 	"github.com/force-c/nai-tizi/internal/logger"
+	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/force-c/nai-tizi/internal/utils"
 	goredis "github.com/redis/go-redis/v9"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
@@ -58,12 +65,15 @@ type Container interface {
 	GetWeChat() *wechat.Manager
 	GetSMS() *sms.Manager
 	GetEmail() *email.Manager
+	GetOIDC() *oidc.Manager
+	GetLDAP() *ldap.Manager
 	GetS3() *s3.Manager
 	GetStorageManager() storage.StorageManager
 	GetWebSocketHub() *websocket.Hub
 	GetScheduler() *scheduler.Scheduler
 	GetIdempotent() *idempotent.Idempotent
 	GetCaptchaManager() *captcha.CaptchaManager
+	GetGeoIP() *geoip.Manager
 	Start() error
 	Stop()
 }
@@ -82,12 +92,15 @@ type container struct {
 	wechatManager  *wechat.Manager
 	smsManager     *sms.Manager
 	emailManager   *email.Manager
+	oidcManager    *oidc.Manager
+	ldapManager    *ldap.Manager
 	s3Manager      *s3.Manager
 	storageManager storage.StorageManager
 	wsHub          *websocket.Hub
 	sched          *scheduler.Scheduler
 	idempotent     *idempotent.Idempotent
 	captchaManager *captcha.CaptchaManager
+	geoIP          *geoip.Manager
 
 	components []Component
 }
@@ -104,13 +117,16 @@ func New(cfg *config.Config, v *viper.Viper) (Container, error) {
 	if err := c.initLogger(); err != nil {
 		return nil, err
 	}
+	c.initPasswordHasher()
 	if err := c.initDB(); err != nil {
 		return nil, err
 	}
 	if err := c.initRedis(); err != nil {
 		return nil, err
 	}
-	c.initJWT()
+	if err := c.initJWT(); err != nil {
+		return nil, err
+	}
 	c.initIdempotent()
 	if err := c.initCasbin(); err != nil {
 		return nil, err
@@ -128,6 +144,9 @@ func New(cfg *config.Config, v *viper.Viper) (Container, error) {
 	c.initStorageManager()
 	c.initWebSocket()
 	c.initCaptchaManager()
+	if err := c.initGeoIP(); err != nil {
+		return nil, err
+	}
 
 	// 3. 初始化调度器（依赖其他组件）
 	c.initScheduler()
@@ -142,6 +161,22 @@ func (c *container) RegisterComponent(comp Component) {
 	c.components = append(c.components, comp)
 }
 
+// initPasswordHasher 按配置设置生成新密码使用的哈希算法
+func (c *container) initPasswordHasher() {
+	cfg := c.config.Auth.PasswordHash
+	var current utils.PasswordAlgorithm
+	switch cfg.Algorithm {
+	case utils.HashAlgArgon2id:
+		current = utils.NewArgon2idHasher(uint32(cfg.Argon2Memory), uint32(cfg.Argon2Iterations), uint8(cfg.Argon2Parallelism))
+	case utils.HashAlgScrypt:
+		current = utils.NewScryptHasher(uint8(cfg.ScryptLogN), cfg.ScryptR, cfg.ScryptP)
+	default:
+		current = utils.NewBcryptHasher(cfg.BcryptCost)
+	}
+	utils.SetDefaultHasherRegistry(utils.NewHasherRegistry(current))
+	c.logger.Info("password hasher initialized", zap.String("algorithm", current.Name()))
+}
+
 // initLogger 初始化日志
 func (c *container) initLogger() error {
 	log, err := logger.NewLogger(c.config.Env)
@@ -183,6 +218,13 @@ func (c *container) initDB() error {
 		c.logger.Warn("failed to register slow query plugin", zap.Error(err))
 	}
 
+	// 注册多租户插件（仅多租户模式，自动追加租户过滤条件）
+	if c.config.MultiTenant.Enabled {
+		if err := db.Use(tenant.NewPlugin()); err != nil {
+			return fmt.Errorf("failed to register tenant plugin: %w", err)
+		}
+	}
+
 	// GORM AutoMigrate 配置
 	if c.config.Database.AutoMigrate {
 		c.logger.Info("starting database auto migration...")
@@ -203,15 +245,53 @@ func (c *container) initDB() error {
 			&model.CasbinRule{},
 			&model.MUserRole{},
 			&model.MRoleMenu{},
+			&model.MRoleOrg{},
+			&model.WebAuthnCredential{},
+			&model.OAuthConsent{},
+			&model.UserIdentity{},
+			&model.JwtKey{},
+			&model.ApiKey{},
+			&model.PasswordHistory{},
+			&model.ImpersonationLog{},
+			&model.LoginDevice{},
+			&model.InviteCode{},
+			&model.Tenant{},
 		); err != nil {
 			return fmt.Errorf("failed to auto migrate: %w", err)
 		}
+		if err := dropLegacyIndexes(db); err != nil {
+			return fmt.Errorf("failed to auto migrate: %w", err)
+		}
 		c.logger.Info("database auto migration completed")
 	}
 	c.db = db
 	return nil
 }
 
+// dropLegacyIndexes 删除多租户改造前的单列唯一索引
+// 用户名、角色标识、组织编码已改为租户内唯一，AutoMigrate 只新增联合唯一索引，不会删除旧索引，
+// 旧索引仍在时不同租户无法使用相同的用户名
+func dropLegacyIndexes(db *gorm.DB) error {
+	legacy := []struct {
+		model any
+		name  string
+	}{
+		{&model.User{}, "idx_s_user_user_name"},
+		{&model.Role{}, "idx_s_role_role_key"},
+		{&model.Org{}, "idx_s_org_org_code"},
+	}
+	migrator := db.Migrator()
+	for _, idx := range legacy {
+		if !migrator.HasIndex(idx.model, idx.name) {
+			continue
+		}
+		if err := migrator.DropIndex(idx.model, idx.name); err != nil {
+			return fmt.Errorf("drop index %s: %w", idx.name, err)
+		}
+	}
+	return nil
+}
+
 // initRedis 初始化Redis
 func (c *container) initRedis() error {
 	redisClient := redis.NewRedis(c.config.Redis.Addr, c.config.Redis.Password, c.config.Redis.DB)
@@ -222,9 +302,40 @@ func (c *container) initRedis() error {
 	return nil
 }
 
-// initJWT 初始化JWT
-func (c *container) initJWT() {
-	c.jwt = jwt.New(c.config.JWT.Secret, int64(c.config.JWT.Expire))
+// initJWT 初始化JWT，非对称算法时从文件或数据库加载密钥环
+func (c *container) initJWT() error {
+	cfg := c.config.JWT
+	c.jwt = jwt.New(cfg.Secret, cfg.Expire)
+	if !jwt.IsAsymmetric(cfg.Algorithm) {
+		return nil
+	}
+
+	if cfg.KeySource == "database" {
+		jwtKeyService := service.NewJwtKeyService(c.db, c.redis, c.jwt, c.config, c.logger)
+		if err := jwtKeyService.Load(context.Background()); err != nil {
+			return fmt.Errorf("failed to load jwt keys: %w", err)
+		}
+	} else {
+		keys := make([]*jwt.Key, 0, len(cfg.Keys))
+		for _, k := range cfg.Keys {
+			path := k.PrivateKeyFile
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(c.config.AppDir, path)
+			}
+			key, err := jwt.LoadPrivateKeyFile(k.Kid, cfg.Algorithm, path)
+			if err != nil {
+				return fmt.Errorf("failed to load jwt key: %w", err)
+			}
+			keys = append(keys, key)
+		}
+		if err := c.jwt.Keyring().Replace(keys, cfg.Keys[0].Kid); err != nil {
+			return fmt.Errorf("failed to load jwt keys: %w", err)
+		}
+	}
+	c.logger.Info("jwt keyring loaded",
+		zap.String("algorithm", cfg.Algorithm),
+		zap.String("activeKid", c.jwt.Keyring().ActiveKid()))
+	return nil
 }
 
 // initIdempotent 初始化幂等处理器
@@ -305,11 +416,27 @@ func (c *container) initCasbin() error {
 		enforcer.EnableLog(true)
 	}
 
+	// 多副本策略同步：先读取全局策略版本作为基线，再加载策略
+	var watcher *casbinsync.Watcher
+	if c.config.Casbin.Watcher.Enabled {
+		watcher, err = casbinsync.NewWatcher(c.redis, enforcer, c.config.Casbin.Watcher, c.logger)
+		if err != nil {
+			return err
+		}
+	}
+
 	// 加载策略
 	if err := enforcer.LoadPolicy(); err != nil {
 		return fmt.Errorf("failed to load casbin policy: %w", err)
 	}
 
+	if watcher != nil {
+		if err := enforcer.SetWatcher(watcher); err != nil {
+			return fmt.Errorf("failed to set casbin watcher: %w", err)
+		}
+		c.RegisterComponent(watcher)
+	}
+
 	c.casbin = enforcer
 	c.logger.Info("casbin enforcer initialized successfully")
 	return nil
@@ -417,6 +544,61 @@ func (c *container) initThirdParty() {
 		}
 	}
 
+	if len(c.config.OIDC.Providers) > 0 {
+		providers := make([]oidc.ProviderConfig, 0, len(c.config.OIDC.Providers))
+		for _, p := range c.config.OIDC.Providers {
+			providers = append(providers, oidc.ProviderConfig{
+				Name:         p.Name,
+				DisplayName:  p.DisplayName,
+				Issuer:       p.Issuer,
+				ClientID:     p.ClientID,
+				ClientSecret: p.ClientSecret,
+				RedirectURL:  p.RedirectURL,
+				Scopes:       p.Scopes,
+				ClaimMapping: oidc.ClaimMapping{
+					Username: p.ClaimMapping.Username,
+					Nickname: p.ClaimMapping.Nickname,
+					Email:    p.ClaimMapping.Email,
+					Phone:    p.ClaimMapping.Phone,
+					Avatar:   p.ClaimMapping.Avatar,
+				},
+			})
+		}
+		oidcManager, err := oidc.NewManager(providers, c.logger)
+		if err != nil {
+			c.logger.Warn("failed to create OIDC manager", zap.Error(err))
+		} else {
+			c.oidcManager = oidcManager
+		}
+	}
+
+	if c.config.LDAP.Enabled {
+		ldapManager, err := ldap.NewManager(ldap.Config{
+			URL:                c.config.LDAP.URL,
+			StartTLS:           c.config.LDAP.StartTLS,
+			InsecureSkipVerify: c.config.LDAP.InsecureSkipVerify,
+			BindDN:             c.config.LDAP.BindDN,
+			BindPassword:       c.config.LDAP.BindPassword,
+			BaseDN:             c.config.LDAP.BaseDN,
+			UserFilter:         c.config.LDAP.UserFilter,
+			Attributes: ldap.Attributes{
+				Username: c.config.LDAP.Attributes.Username,
+				Nickname: c.config.LDAP.Attributes.Nickname,
+				Email:    c.config.LDAP.Attributes.Email,
+				Phone:    c.config.LDAP.Attributes.Phone,
+				Org:      c.config.LDAP.Attributes.Org,
+			},
+			GroupAttribute: c.config.LDAP.GroupAttribute,
+			PoolSize:       c.config.LDAP.PoolSize,
+			Timeout:        time.Duration(c.config.LDAP.Timeout) * time.Second,
+		}, c.logger)
+		if err != nil {
+			c.logger.Warn("failed to create LDAP manager", zap.Error(err))
+		} else {
+			c.ldapManager = ldapManager
+		}
+	}
+
 	if c.config.S3.Enabled {
 		s3Manager, err := s3.NewManager(&s3.Config{
 			Enabled:         c.config.S3.Enabled,
@@ -504,6 +686,22 @@ func (c *container) initCaptchaManager() {
 	c.logger.Info("captcha manager initialized successfully")
 }
 
+// initGeoIP 初始化登录风险评估使用的 IP 归属地库和黑名单
+func (c *container) initGeoIP() error {
+	if !c.config.Auth.LoginRisk.Enabled {
+		return nil
+	}
+	manager, err := geoip.NewManager(geoip.Config{
+		DatabaseFile:  c.config.Auth.LoginRisk.GeoIPFile,
+		BlocklistFile: c.config.Auth.LoginRisk.BlocklistFile,
+	}, c.logger)
+	if err != nil {
+		return err
+	}
+	c.geoIP = manager
+	return nil
+}
+
 // initWebSocket 初始化WebSocket
 func (c *container) initWebSocket() {
 	if !c.config.WebSocket.Enabled {
@@ -575,6 +773,14 @@ func (c *container) GetEmail() *email.Manager {
 	return c.emailManager
 }
 
+func (c *container) GetOIDC() *oidc.Manager {
+	return c.oidcManager
+}
+
+func (c *container) GetLDAP() *ldap.Manager {
+	return c.ldapManager
+}
+
 func (c *container) GetS3() *s3.Manager {
 	return c.s3Manager
 }
@@ -599,6 +805,10 @@ func (c *container) GetCaptchaManager() *captcha.CaptchaManager {
 	return c.captchaManager
 }
 
+func (c *container) GetGeoIP() *geoip.Manager {
+	return c.geoIP
+}
+
 func (c *container) Start() error {
 	for _, comp := range c.components {
 		c.logger.Info("starting component", zap.String("name", comp.Name()))
@@ -620,6 +830,9 @@ func (c *container) Stop() {
 			c.logger.Error("failed to stop component", zap.String("name", comp.Name()), zap.Error(err))
 		}
 	}
+	if c.ldapManager != nil {
+		c.ldapManager.Close()
+	}
 	c.logger.Info("all components stopped")
 }
 
@@ -628,5 +841,18 @@ func (c *container) registerJobs() error {
 		return nil
 	}
 
-	return jobs.RegisterJobs(c.sched, c.db, c.redis, c.retryManager, c.logger)
+	var ldapSync *jobs.LdapSyncJob
+	if c.ldapManager != nil && c.config.LDAP.SyncEnabled {
+		casbinService := service.NewCasbinServiceV2(c.casbin, c.db, c.logger, c.config)
+		tokenManager := service.NewTokenManager(c.jwt, c.redis, c.logger)
+		ldapService := service.NewLdapService(c.db, c.ldapManager, casbinService, tokenManager, c.config, c.logger)
+		ldapSync = jobs.NewLdapSyncJob(ldapService, c.config.LDAP.SyncCron, c.logger)
+	}
+
+	var jwtKey *jobs.JwtKeyJob
+	if jwt.IsAsymmetric(c.config.JWT.Algorithm) && c.config.JWT.KeySource == "database" {
+		jwtKey = jobs.NewJwtKeyJob(service.NewJwtKeyService(c.db, c.redis, c.jwt, c.config, c.logger), c.logger)
+	}
+
+	return jobs.RegisterJobs(c.sched, c.db, c.redis, c.retryManager, ldapSync, jwtKey, c.logger)
 }
@@ -0,0 +1,236 @@
+package controller
+
+import (
+	"strings"
+
+	"github.com/force-c/nai-tizi/internal/constants"
+	"github.com/force-c/nai-tizi/internal/container"
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/domain/request"
+	"github.com/force-c/nai-tizi/internal/domain/response"
+	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/force-c/nai-tizi/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// ApiKeyController API Key（个人访问令牌）控制器接口
+type ApiKeyController interface {
+	ListMyKeys(c *gin.Context)    // 查询我的 API Key
+	CreateMyKey(c *gin.Context)   // 创建我的 API Key
+	DeleteMyKey(c *gin.Context)   // 删除我的 API Key
+	ListUserKeys(c *gin.Context)  // 管理员查询指定用户的 API Key
+	CreateUserKey(c *gin.Context) // 管理员为服务账号创建 API Key
+	DeleteUserKey(c *gin.Context) // 管理员删除指定用户的 API Key
+}
+
+type apiKeyController struct {
+	ctr           container.Container
+	base          *BaseController
+	apiKeyService service.ApiKeyService
+}
+
+func NewApiKeyController(c container.Container) ApiKeyController {
+	return &apiKeyController{
+		ctr:           c,
+		base:          NewBaseController(c),
+		apiKeyService: service.NewApiKeyService(c.GetDB(), c.GetRedis(), c.GetLogger()),
+	}
+}
+
+// ListMyKeys 查询我的 API Key
+//
+//	@Summary		查询我的 API Key
+//	@Description	列出当前用户创建的 API Key（不含明文）
+//	@Tags			API Key
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Success		200	{object}	response.Response{data=[]response.ApiKeyResponse}
+//	@Router			/api/v1/auth/api-keys [get]
+func (h *apiKeyController) ListMyKeys(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+	h.list(c, userId)
+}
+
+// CreateMyKey 创建我的 API Key
+//
+//	@Summary		创建我的 API Key
+//	@Description	为当前用户创建 API Key，用于脚本和 CI 调用接口；通过 X-API-Key 请求头或 Authorization: Bearer ntz_... 传入。
+//	@Description	实际权限为授权范围与本人权限的交集；明文仅在创建时返回一次
+//	@Tags			API Key
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			body	body		request.ApiKeyCreateRequest	true	"API Key 信息"
+//	@Success		200		{object}	response.Response{data=response.ApiKeyCreateResponse}
+//	@Router			/api/v1/auth/api-keys [post]
+func (h *apiKeyController) CreateMyKey(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+	h.create(c, userId, userId)
+}
+
+// DeleteMyKey 删除我的 API Key
+//
+//	@Summary		删除我的 API Key
+//	@Description	删除当前用户指定的 API Key，删除后立即失效
+//	@Tags			API Key
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			id	path		int	true	"API Key 记录ID"
+//	@Success		200	{object}	response.Response
+//	@Router			/api/v1/auth/api-keys/{id} [delete]
+func (h *apiKeyController) DeleteMyKey(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+	h.delete(c, userId, "id")
+}
+
+// ListUserKeys 管理员查询指定用户的 API Key
+//
+//	@Summary		查询用户的 API Key
+//	@Description	管理员查询指定用户（含服务账号）的 API Key，需要 api_key.read 权限
+//	@Tags			API Key
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			id	path		int	true	"用户ID"
+//	@Success		200	{object}	response.Response{data=[]response.ApiKeyResponse}
+//	@Router			/api/v1/user/{id}/api-keys [get]
+func (h *apiKeyController) ListUserKeys(c *gin.Context) {
+	userId, err := utils.ParseInt64Param(c, "id", "required")
+	if err != nil {
+		response.FailCode(c, response.CodeInvalidParam, err.Error())
+		return
+	}
+	h.list(c, userId)
+}
+
+// CreateUserKey 管理员为服务账号创建 API Key
+//
+//	@Summary		为服务账号创建 API Key
+//	@Description	管理员为服务账号（userType=3）创建 API Key，需要 api_key.create 权限；实际权限为授权范围与服务账号角色权限的交集
+//	@Tags			API Key
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			id		path		int							true	"服务账号用户ID"
+//	@Param			body	body		request.ApiKeyCreateRequest	true	"API Key 信息"
+//	@Success		200		{object}	response.Response{data=response.ApiKeyCreateResponse}
+//	@Router			/api/v1/user/{id}/api-keys [post]
+func (h *apiKeyController) CreateUserKey(c *gin.Context) {
+	operatorId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+	userId, err := utils.ParseInt64Param(c, "id", "required")
+	if err != nil {
+		response.FailCode(c, response.CodeInvalidParam, err.Error())
+		return
+	}
+
+	// 个人用户的 API Key 只能由本人创建
+	user, err := (&model.User{}).FindByID(h.ctr.GetDB().WithContext(c.Request.Context()), userId)
+	if err != nil {
+		response.FailWithMsg(c, "用户不存在")
+		return
+	}
+	if user.UserType != constants.UserTypeService {
+		response.FailWithMsg(c, "只能为服务账号创建 API Key")
+		return
+	}
+	h.create(c, userId, operatorId)
+}
+
+// DeleteUserKey 管理员删除指定用户的 API Key
+//
+//	@Summary		删除用户的 API Key
+//	@Description	管理员删除指定用户的 API Key，删除后立即失效，需要 api_key.delete 权限
+//	@Tags			API Key
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			id		path		int	true	"用户ID"
+//	@Param			keyId	path		int	true	"API Key 记录ID"
+//	@Success		200		{object}	response.Response
+//	@Router			/api/v1/user/{id}/api-keys/{keyId} [delete]
+func (h *apiKeyController) DeleteUserKey(c *gin.Context) {
+	userId, err := utils.ParseInt64Param(c, "id", "required")
+	if err != nil {
+		response.FailCode(c, response.CodeInvalidParam, err.Error())
+		return
+	}
+	h.delete(c, userId, "keyId")
+}
+
+func (h *apiKeyController) list(c *gin.Context, userId int64) {
+	keys, err := h.apiKeyService.List(c.Request.Context(), userId)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	result := make([]*response.ApiKeyResponse, 0, len(keys))
+	for i := range keys {
+		result = append(result, toApiKeyResponse(&keys[i]))
+	}
+	response.Success(c, result)
+}
+
+func (h *apiKeyController) create(c *gin.Context, userId, createBy int64) {
+	var req request.ApiKeyCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+
+	key, plaintext, err := h.apiKeyService.Create(c.Request.Context(), userId, createBy, req.Name, req.Scopes, req.ExpireAt)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, &response.ApiKeyCreateResponse{
+		ApiKeyResponse: *toApiKeyResponse(key),
+		Key:            plaintext,
+	})
+}
+
+func (h *apiKeyController) delete(c *gin.Context, userId int64, param string) {
+	keyId, err := utils.ParseInt64Param(c, param, "required")
+	if err != nil {
+		response.FailCode(c, response.CodeInvalidParam, err.Error())
+		return
+	}
+
+	if err := h.apiKeyService.Delete(c.Request.Context(), userId, keyId); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, "ok")
+}
+
+// toApiKeyResponse 转换 API Key 响应
+func toApiKeyResponse(key *model.ApiKey) *response.ApiKeyResponse {
+	return &response.ApiKeyResponse{
+		Id:          key.ID,
+		Name:        key.Name,
+		KeyPrefix:   key.KeyPrefix,
+		Scopes:      strings.Split(key.Scopes, ","),
+		ExpireAt:    key.ExpireAt,
+		LastUsedAt:  key.LastUsedAt,
+		LastUsedIp:  key.LastUsedIp,
+		CreatedTime: key.CreatedTime,
+	}
+}
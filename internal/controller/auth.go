@@ -2,8 +2,6 @@ package controller
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"strings"
@@ -11,10 +9,12 @@ import (
 
 	"github.com/force-c/nai-tizi/internal/utils/idgen"
 
+	"github.com/force-c/nai-tizi/internal/constants"
 	"github.com/force-c/nai-tizi/internal/container"
 	"github.com/force-c/nai-tizi/internal/domain/model"
 	"github.com/force-c/nai-tizi/internal/domain/request"
 	"github.com/force-c/nai-tizi/internal/domain/response"
+	"github.com/force-c/nai-tizi/internal/infrastructure/tenant"
 	"github.com/force-c/nai-tizi/internal/service"
 	"github.com/force-c/nai-tizi/internal/utils"
 	"github.com/gin-gonic/gin"
@@ -28,6 +28,7 @@ type AuthController interface {
 	Logout(c *gin.Context)       // 用户登出
 	RefreshToken(c *gin.Context) // 刷新访问令牌
 	Me(c *gin.Context)           // 获取当前用户信息
+	SendSmsCode(c *gin.Context)  // 发送短信登录验证码
 }
 
 type authController struct {
@@ -37,6 +38,8 @@ type authController struct {
 	tokenManager           service.TokenManager
 	concurrentLoginManager service.ConcurrentLoginManager
 	strategyFactory        *StrategyFactory
+	mfaService             service.MfaService
+	loginRiskService       service.LoginRiskService
 	smsService             interface {
 		SendVerificationCode(ctx context.Context, phonenumber string) (string, error)
 	}
@@ -51,6 +54,11 @@ func NewAuthController(c container.Container) AuthController {
 	strategyFactory.Register(NewPasswordAuthStrategy(c))
 	strategyFactory.Register(NewXcxAuthStrategy(c))
 	strategyFactory.Register(NewEmailAuthStrategy(c))
+	strategyFactory.Register(NewMfaAuthStrategy(c))
+	strategyFactory.Register(NewWebAuthnAuthStrategy(c))
+	strategyFactory.Register(NewOidcAuthStrategy(c))
+	strategyFactory.Register(NewLdapAuthStrategy(c))
+	strategyFactory.Register(NewSmsAuthStrategy(c))
 
 	return &authController{
 		ctr:                    c,
@@ -59,14 +67,19 @@ func NewAuthController(c container.Container) AuthController {
 		tokenManager:           tokenManager,
 		concurrentLoginManager: concurrentLoginManager,
 		strategyFactory:        strategyFactory,
-		smsService:             c.GetSMS(),
+		mfaService:             service.NewMfaService(c.GetDB(), c.GetRedis(), c.GetConfig(), c.GetLogger()),
+		loginRiskService: service.NewLoginRiskService(
+			c.GetDB(), c.GetRedis(), tokenManager, c.GetGeoIP(), c.GetEmail(), c.GetWebSocketHub(), c.GetConfig(), c.GetLogger(),
+		),
+		smsService: c.GetSMS(),
 	}
 }
 
 // Login godoc
 //
 //	@Summary		用户登录
-//	@Description	支持多种登录方式：密码登录(password)、邮箱验证码(email)、微信小程序(xcx)
+//	@Description	支持多种登录方式：密码登录(password)、邮箱验证码(email)、微信小程序(xcx)、通行密钥(webauthn)、第三方登录(oidc)、域账号(ldap)、短信验证码(sms)
+//	@Description	已启用两步验证的用户返回 mfa_required=true 和 mfa_ticket，需以 grantType=mfa 提交票据和动态码完成登录
 //	@Tags			认证
 //	@Accept			json
 //	@Produce		json
@@ -81,7 +94,7 @@ func (h *authController) Login(c *gin.Context) {
 		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
 		return
 	}
-	ctx := c.Request.Context()
+	ctx := service.WithSessionMeta(c.Request.Context(), utils.GetClientIP(c), c.Request.UserAgent())
 	loginAccount := resolveLoginAccount(&req)
 
 	if req.ClientKey == "" || req.ClientSecret == "" {
@@ -89,7 +102,18 @@ func (h *authController) Login(c *gin.Context) {
 		return
 	}
 
-	client, err := h.clientService.AuthenticateClient(ctx, req.ClientKey, req.ClientSecret, req.GrantType)
+	// 两步验证是首因素登录的延续，按票据记录的原授权类型校验客户端
+	grantType := req.GrantType
+	if grantType == GrantTypeMfa {
+		ticketGrantType, err := h.mfaService.TicketGrantType(ctx, req.MfaTicket)
+		if err != nil {
+			response.FailCode(c, response.CodeUnauthorized, err.Error())
+			return
+		}
+		grantType = ticketGrantType
+	}
+
+	client, err := h.clientService.AuthenticateClient(ctx, req.ClientKey, req.ClientSecret, grantType)
 	if err != nil {
 		h.recordLoginLog(c, loginAccount, req.ClientKey, 1, err.Error())
 		response.FailCode(c, response.CodeUnauthorized, err.Error())
@@ -108,7 +132,28 @@ func (h *authController) Login(c *gin.Context) {
 		return
 	}
 
+	// 需要两步验证：仅返回票据，不签发 Token
+	if resp.MfaRequired {
+		response.Success(c, resp)
+		return
+	}
+
 	user := resp.UserInfo
+	if user.UserType == constants.UserTypeService {
+		h.recordLoginLog(c, user.Username, client.ClientId, 1, "服务账号不能登录")
+		response.FailCode(c, response.CodeUnauthorized, "服务账号不能登录，请使用 API Key 访问")
+		return
+	}
+
+	// 登录风险评估（新设备、新 IP 段、新国家、不可能的移动速度、黑名单 IP），评估失败不阻断登录
+	browser, osName := parseUserAgent(c.Request.UserAgent())
+	risk, err := h.loginRiskService.Assess(ctx, user.UserId, req.DeviceId, browser, osName)
+	if err != nil {
+		h.ctr.GetLogger().Warn("assess login risk failed", zap.Int64("userId", user.UserId), zap.Error(err))
+	}
+
+	// 首次登录、管理员重置或密码过期时提示前端跳转修改密码
+	pwdChange := service.NewPasswordPolicyService(h.ctr.GetDB(), h.ctr.GetLogger()).ChangeRequired(ctx, user.UserId)
 
 	useExisting, existingToken, err := h.concurrentLoginManager.HandleConcurrentLogin(
 		ctx, user.UserId, client.ClientId, client.Timeout,
@@ -121,23 +166,16 @@ func (h *authController) Login(c *gin.Context) {
 		h.ctr.GetLogger().Info("reuse existing token",
 			zap.String("clientId", client.ClientId),
 			zap.Int64("userId", user.UserId))
-		h.recordLoginLog(c, user.Username, client.ClientId, 0, "登录成功（复用Token）")
+		h.recordRiskLoginLog(c, user.Username, client.ClientId, 0, "登录成功（复用Token）", risk)
 
-		sysUser := &model.User{
-			ID:          user.UserId,
-			UserName:    user.Username,
-			NickName:    user.Nickname,
-			Phonenumber: user.Phonenumber,
-			Email:       user.Email,
-			Avatar:      user.Avatar,
-			UserType:    user.UserType,
-		}
+		sysUser := tokenUser(user)
 		accessToken, refreshToken, accessExpiresIn, refreshExpiresIn, err := h.tokenManager.GenerateTokenPair(ctx, sysUser, client)
 		if err != nil {
 			h.ctr.GetLogger().Error("failed to generate token pair", zap.Error(err))
 			response.FailCode(c, response.CodeServerError, "生成Token失败")
 			return
 		}
+		h.completeLoginRisk(ctx, risk, sysUser, accessToken)
 
 		response.Success(c, &LoginResponse{
 			AccessToken:      accessToken,
@@ -145,19 +183,15 @@ func (h *authController) Login(c *gin.Context) {
 			ExpiresIn:        accessExpiresIn,
 			RefreshExpiresIn: refreshExpiresIn,
 			UserInfo:         user,
+			RecoveryCodes:    resp.RecoveryCodes,
+
+			PasswordChangeRequired: pwdChange != "",
+			PasswordChangeReason:   pwdChange,
 		})
 		return
 	}
 
-	sysUser := &model.User{
-		ID:          user.UserId,
-		UserName:    user.Username,
-		NickName:    user.Nickname,
-		Phonenumber: user.Phonenumber,
-		Email:       user.Email,
-		Avatar:      user.Avatar,
-		UserType:    user.UserType,
-	}
+	sysUser := tokenUser(user)
 	accessToken, refreshToken, accessExpiresIn, refreshExpiresIn, err := h.tokenManager.GenerateTokenPair(ctx, sysUser, client)
 	if err != nil {
 		h.ctr.GetLogger().Error("failed to generate token pair", zap.Error(err))
@@ -175,7 +209,8 @@ func (h *authController) Login(c *gin.Context) {
 		zap.String("clientId", client.ClientId),
 		zap.String("grantType", req.GrantType),
 		zap.Int64("userId", user.UserId))
-	h.recordLoginLog(c, user.Username, client.ClientId, 0, "登录成功")
+	h.recordRiskLoginLog(c, user.Username, client.ClientId, 0, "登录成功", risk)
+	h.completeLoginRisk(ctx, risk, sysUser, accessToken)
 
 	response.Success(c, &LoginResponse{
 		AccessToken:      accessToken,
@@ -183,13 +218,31 @@ func (h *authController) Login(c *gin.Context) {
 		ExpiresIn:        accessExpiresIn,
 		RefreshExpiresIn: refreshExpiresIn,
 		UserInfo:         user,
+		RecoveryCodes:    resp.RecoveryCodes,
+
+		PasswordChangeRequired: pwdChange != "",
+		PasswordChangeReason:   pwdChange,
 	})
 }
 
+// tokenUser 由登录策略返回的用户信息构造签发 Token 的用户（租户ID写入 Token 和 RefreshToken）
+func tokenUser(user *UserInfo) *model.User {
+	return &model.User{
+		ID:          user.UserId,
+		UserName:    user.Username,
+		NickName:    user.Nickname,
+		Phonenumber: user.Phonenumber,
+		Email:       user.Email,
+		Avatar:      user.Avatar,
+		UserType:    user.UserType,
+		TenantId:    user.TenantId,
+	}
+}
+
 // Logout godoc
 //
 //	@Summary		用户登出
-//	@Description	使当前用户的 RefreshToken 失效，AccessToken 会在过期后自动失效
+//	@Description	使当前用户的 RefreshToken 失效，并立即吊销当前 AccessToken
 //	@Tags			认证
 //	@Accept			json
 //	@Produce		json
@@ -214,7 +267,21 @@ func (h *authController) Logout(c *gin.Context) {
 		return
 	}
 
+	// 代登录会话只结束自身，不影响用户本人在同一客户端的登录
+	if claims.Actor != nil {
+		_ = h.tokenManager.RevokeAccessToken(ctx, token)
+		if claims.SessionId != "" {
+			_ = h.tokenManager.RevokeSession(ctx, claims.UserId, claims.SessionId)
+		}
+		h.ctr.GetLogger().Info("impersonation logout",
+			zap.Int64("userId", claims.UserId),
+			zap.Int64("actorId", claims.Actor.UserId))
+		response.Success(c, "ok")
+		return
+	}
+
 	_ = h.tokenManager.InvalidateToken(ctx, claims.UserId, claims.ClientId)
+	_ = h.tokenManager.RevokeAccessToken(ctx, token)
 
 	if h.ctr.GetConfig().Auth.ShareToken {
 		_ = h.concurrentLoginManager.InvalidateUserTokens(ctx, claims.UserId, claims.ClientId)
@@ -230,7 +297,7 @@ func (h *authController) Logout(c *gin.Context) {
 // RefreshToken godoc
 //
 //	@Summary		刷新访问令牌
-//	@Description	使用 RefreshToken 获取新的 AccessToken 和 RefreshToken（轮换机制）
+//	@Description	使用 RefreshToken 获取新的 AccessToken 和 RefreshToken（轮换机制），已轮换的旧 RefreshToken 再次使用将吊销整个登录会话
 //	@Tags			认证
 //	@Accept			json
 //	@Produce		json
@@ -245,7 +312,7 @@ func (h *authController) RefreshToken(c *gin.Context) {
 		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
 		return
 	}
-	ctx := c.Request.Context()
+	ctx := service.WithSessionMeta(c.Request.Context(), utils.GetClientIP(c), c.Request.UserAgent())
 
 	client, err := h.clientService.AuthenticateClient(ctx, req.ClientKey, req.ClientSecret, "refresh")
 	if err != nil {
@@ -261,6 +328,15 @@ func (h *authController) RefreshToken(c *gin.Context) {
 		h.ctr.GetLogger().Error("refresh token failed",
 			zap.String("clientId", client.ClientId),
 			zap.Error(err))
+		var reuseErr *service.RefreshTokenReuseError
+		if errors.As(err, &reuseErr) {
+			h.recordLoginLog(c, reuseErr.UserName, client.ClientId, 1,
+				fmt.Sprintf("安全事件：RefreshToken 被重复使用，已吊销该登录会话（family=%s）", reuseErr.FamilyId))
+		}
+		if errors.Is(err, service.ErrSessionIdle) {
+			response.FailCode(c, response.CodeSessionIdle, err.Error())
+			return
+		}
 		response.FailCode(c, response.CodeUnauthorized, err.Error())
 		return
 	}
@@ -301,7 +377,72 @@ func (h *authController) Me(c *gin.Context) {
 	response.Success(c, gin.H{"userId": userId})
 }
 
+// SendSmsCode godoc
+//
+//	@Summary		发送短信登录验证码
+//	@Description	向手机号发送登录验证码（grantType=sms/xcx 使用）；同一手机号 60 秒内只能发送一次，同一 IP 每小时最多发送 10 次
+//	@Tags			认证
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.SendSmsCodeRequest	true	"手机号"
+//	@Success		200		{object}	response.Response
+//	@Router			/auth/sms/code [post]
+func (h *authController) SendSmsCode(c *gin.Context) {
+	var req request.SendSmsCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+	if h.ctr.GetSMS() == nil {
+		response.FailWithMsg(c, "短信服务未启用")
+		return
+	}
+	ctx := c.Request.Context()
+
+	if err := h.checkSmsSendLimit(ctx, req.Phonenumber, utils.GetClientIP(c)); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	if _, err := h.smsService.SendVerificationCode(ctx, req.Phonenumber); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, "ok")
+}
+
+// checkSmsSendLimit 短信发送频率限制：同一手机号 60 秒一次，同一 IP 每小时 10 次
+func (h *authController) checkSmsSendLimit(ctx context.Context, phonenumber, ip string) error {
+	rdb := h.ctr.GetRedis()
+	ipKey := "sms_send_cnt:ip:" + ip
+	count, err := rdb.Incr(ctx, ipKey).Result()
+	if err != nil {
+		h.ctr.GetLogger().Warn("failed to check sms send limit", zap.Error(err))
+		return fmt.Errorf("发送失败，请稍后再试")
+	}
+	if count == 1 {
+		_ = rdb.Expire(ctx, ipKey, time.Hour).Err()
+	}
+	if count > 10 {
+		return fmt.Errorf("发送次数过多，请稍后再试")
+	}
+
+	ok, err := rdb.SetNX(ctx, "sms_send_lock:"+phonenumber, 1, 60*time.Second).Result()
+	if err != nil {
+		h.ctr.GetLogger().Warn("failed to check sms send limit", zap.Error(err))
+		return fmt.Errorf("发送失败，请稍后再试")
+	}
+	if !ok {
+		return fmt.Errorf("发送过于频繁，请稍后再试")
+	}
+	return nil
+}
+
 func (h *authController) recordLoginLog(c *gin.Context, username, clientId string, status int32, message string) {
+	h.recordRiskLoginLog(c, username, clientId, status, message, nil)
+}
+
+// recordRiskLoginLog 记录登录日志，risk 不为 nil 时一并记录风险分和登录地点
+func (h *authController) recordRiskLoginLog(c *gin.Context, username, clientId string, status int32, message string, risk *service.LoginRiskAssessment) {
 	browser, osName := parseUserAgent(c.Request.UserAgent())
 	logEntry := &model.LoginLog{
 		ID:        idgen.MustNextID(),
@@ -312,14 +453,36 @@ func (h *authController) recordLoginLog(c *gin.Context, username, clientId strin
 		Status:    status,
 		Msg:       message,
 		LoginTime: utils.Now(),
-		TenantId:  c.GetHeader("Tenant-Id"),
+		TenantId:  tenant.FromContext(c.Request.Context()),
 		ClientId:  clientId,
 	}
-	if err := logEntry.Create(h.ctr.GetDB()); err != nil {
+	if risk != nil {
+		logEntry.LoginLocation = risk.LocationText()
+		logEntry.RiskScore = risk.Score
+		logEntry.RiskReasons = risk.ReasonCodes()
+	}
+	if err := logEntry.Create(h.ctr.GetDB().WithContext(c.Request.Context())); err != nil {
 		h.ctr.GetLogger().Error("failed to record login log", zap.Error(err))
 	}
 }
 
+// completeLoginRisk 会话建立后异步记录登录设备，风险分达到阈值时提醒用户
+func (h *authController) completeLoginRisk(ctx context.Context, risk *service.LoginRiskAssessment, user *model.User, accessToken string) {
+	if risk == nil {
+		return
+	}
+	claims, err := h.ctr.GetJWT().ValidateToken(accessToken)
+	if err != nil {
+		h.ctr.GetLogger().Warn("parse session of login risk failed", zap.Int64("userId", user.ID), zap.Error(err))
+		return
+	}
+	go func() {
+		riskCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 30*time.Second)
+		defer cancel()
+		h.loginRiskService.Complete(riskCtx, risk, user, claims.SessionId)
+	}()
+}
+
 func parseUserAgent(ua string) (browser, os string) {
 	lower := strings.ToLower(ua)
 	switch {
@@ -503,7 +666,7 @@ func (s *PasswordAuthStrategy) Login(ctx context.Context, req *LoginRequest) (*L
 		return nil, err
 	}
 	var um model.User
-	user, err := um.FindByUsername(s.ctr.GetDB(), req.Username)
+	user, err := um.FindByUsername(s.ctr.GetDB().WithContext(ctx), req.Username)
 	if err != nil {
 		s.incrementErrorCount(ctx, req.Username)
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -516,13 +679,18 @@ func (s *PasswordAuthStrategy) Login(ctx context.Context, req *LoginRequest) (*L
 		s.incrementErrorCount(ctx, req.Username)
 		return nil, fmt.Errorf("用户名或密码错误")
 	}
-	if user.Status != 0 {
-		return nil, fmt.Errorf("用户已被停用")
+	if err := service.CheckUserStatus(user.Status); err != nil {
+		return nil, err
 	}
 	s.clearErrorCount(ctx, req.Username)
+	s.rehashIfNeeded(ctx, user, req.Password)
+
+	if challenge, err := mfaChallenge(ctx, s.ctr, user, req); challenge != nil || err != nil {
+		return challenge, err
+	}
 
 	var clientModel model.AuthClient
-	client, err := clientModel.FindByClientKey(s.ctr.GetDB(), req.ClientKey)
+	client, err := clientModel.FindByClientKey(s.ctr.GetDB().WithContext(ctx), req.ClientKey)
 	if err != nil {
 		s.ctr.GetLogger().Error("failed to query client", zap.Error(err))
 		return nil, fmt.Errorf("客户端配置查询失败")
@@ -538,35 +706,40 @@ func (s *PasswordAuthStrategy) Login(ctx context.Context, req *LoginRequest) (*L
 		s.ctr.GetLogger().Error("failed to generate token", zap.Error(err))
 		return nil, fmt.Errorf("生成token失败")
 	}
-	if client.ActiveTimeout > 0 {
-		tokenHash := generateTokenHash(token)
-		activeKey := "token:active:" + tokenHash
-		_ = s.ctr.GetRedis().Set(ctx, activeKey, time.Now().Unix(), time.Duration(client.ActiveTimeout)*time.Second).Err()
-	}
-	return &LoginResponse{AccessToken: token, ExpiresIn: expiresIn, UserInfo: &UserInfo{UserId: user.ID, Username: user.UserName, Nickname: user.NickName, Phonenumber: user.Phonenumber, Avatar: user.Avatar, UserType: user.UserType}}, nil
+	return &LoginResponse{AccessToken: token, ExpiresIn: expiresIn, UserInfo: &UserInfo{UserId: user.ID, Username: user.UserName, Nickname: user.NickName, Phonenumber: user.Phonenumber, Avatar: user.Avatar, UserType: user.UserType, TenantId: user.TenantId}}, nil
 }
 func (s *PasswordAuthStrategy) checkBruteForce(ctx context.Context, username string) error {
-	key := "pwd_err_cnt:" + username
-	count, err := s.ctr.GetRedis().Get(ctx, key).Int()
-	if err != nil && err != redis.Nil {
-		s.ctr.GetLogger().Warn("failed to get password error count", zap.Error(err))
-		return nil
-	}
-	if count >= 5 {
-		ttl, _ := s.ctr.GetRedis().TTL(ctx, key).Result()
-		return fmt.Errorf("密码错误次数过多，请%d分钟后再试", int(ttl.Minutes())+1)
-	}
-	return nil
+	return loginLockout(s.ctr).Check(ctx, username, service.ClientIPFromContext(ctx))
 }
 func (s *PasswordAuthStrategy) incrementErrorCount(ctx context.Context, username string) {
-	key := "pwd_err_cnt:" + username
-	pipe := s.ctr.GetRedis().Pipeline()
-	pipe.Incr(ctx, key)
-	pipe.Expire(ctx, key, 10*time.Minute)
-	_, _ = pipe.Exec(ctx)
+	loginLockout(s.ctr).RecordFailure(ctx, username, service.ClientIPFromContext(ctx))
 }
 func (s *PasswordAuthStrategy) clearErrorCount(ctx context.Context, username string) {
-	_ = s.ctr.GetRedis().Del(ctx, "pwd_err_cnt:"+username).Err()
+	loginLockout(s.ctr).RecordSuccess(ctx, username, service.ClientIPFromContext(ctx))
+}
+
+// rehashIfNeeded 哈希算法或参数弱于当前配置（包括导入的旧系统摘要）时，用本次登录的明文按当前配置重新哈希
+// 密码本身不变，不更新密码修改时间和历史记录
+func (s *PasswordAuthStrategy) rehashIfNeeded(ctx context.Context, user *model.User, password string) {
+	if !utils.PasswordNeedsRehash(user.Password) {
+		return
+	}
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		s.ctr.GetLogger().Warn("failed to rehash password", zap.Int64("userId", user.ID), zap.Error(err))
+		return
+	}
+	var um model.User
+	if _, err := um.RehashPassword(s.ctr.GetDB().WithContext(ctx), user.ID, user.Password, hash); err != nil {
+		s.ctr.GetLogger().Warn("failed to save rehashed password", zap.Int64("userId", user.ID), zap.Error(err))
+		return
+	}
+	s.ctr.GetLogger().Info("password rehashed", zap.Int64("userId", user.ID))
+}
+
+// loginLockout 登录失败锁定服务，所有登录方式按账号、IP + 账号、IP 共用计数和锁定
+func loginLockout(c container.Container) service.LoginLockoutService {
+	return service.NewLoginLockoutService(c.GetDB(), c.GetRedis(), c.GetConfig(), c.GetLogger())
 }
 
 type XcxAuthStrategy struct {
@@ -581,9 +754,16 @@ func (s *XcxAuthStrategy) Login(ctx context.Context, req *LoginRequest) (*LoginR
 	if req.Phonenumber == "" || req.Code == "" || req.WxCode == "" {
 		return nil, fmt.Errorf("手机号、验证码和微信code不能为空")
 	}
+	ip := service.ClientIPFromContext(ctx)
+	lockout := loginLockout(s.ctr)
+	if err := lockout.Check(ctx, req.Phonenumber, ip); err != nil {
+		return nil, err
+	}
 	if err := NewCaptchaService(s.ctr.GetRedis()).ValidateSmsCode(ctx, req.Phonenumber, req.Code); err != nil {
+		lockout.RecordFailure(ctx, req.Phonenumber, ip)
 		return nil, err
 	}
+	lockout.RecordSuccess(ctx, req.Phonenumber, ip)
 	wxResp, err := s.ctr.GetWeChat().Code2Session(req.WxCode)
 	if err != nil {
 		return nil, err
@@ -592,7 +772,7 @@ func (s *XcxAuthStrategy) Login(ctx context.Context, req *LoginRequest) (*LoginR
 		return nil, fmt.Errorf("获取微信OpenID失败")
 	}
 	var um model.User
-	user, err := um.FindByPhonenumber(s.ctr.GetDB(), req.Phonenumber)
+	user, err := um.FindByPhonenumber(s.ctr.GetDB().WithContext(ctx), req.Phonenumber)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		s.ctr.GetLogger().Error("failed to query user by phonenumber", zap.Error(err))
 		return nil, fmt.Errorf("查询用户失败")
@@ -608,23 +788,23 @@ func (s *XcxAuthStrategy) Login(ctx context.Context, req *LoginRequest) (*LoginR
 			OpenId:      wxResp.OpenID,
 			UnionId:     wxResp.UnionID,
 		}
-		if err := um.Create(s.ctr.GetDB(), newUser); err != nil {
+		if err := um.Create(s.ctr.GetDB().WithContext(ctx), newUser); err != nil {
 			s.ctr.GetLogger().Error("failed to create wechat user", zap.Error(err))
 			return nil, fmt.Errorf("创建用户失败")
 		}
 		user = newUser
 	} else {
-		if err := s.ctr.GetDB().Model(&model.User{}).Where("user_id = ?", user.ID).Updates(map[string]any{"open_id": wxResp.OpenID, "union_id": wxResp.UnionID}).Error; err != nil {
+		if err := s.ctr.GetDB().WithContext(ctx).Model(&model.User{}).Where("user_id = ?", user.ID).Updates(map[string]any{"open_id": wxResp.OpenID, "union_id": wxResp.UnionID}).Error; err != nil {
 			s.ctr.GetLogger().Warn("failed to update user openid", zap.Error(err))
 		}
 		user.OpenId = wxResp.OpenID
 		user.UnionId = wxResp.UnionID
 	}
-	if user.Status != 0 {
-		return nil, fmt.Errorf("用户已被停用")
+	if err := service.CheckUserStatus(user.Status); err != nil {
+		return nil, err
 	}
 	var clientModel model.AuthClient
-	client, err := clientModel.FindByClientKey(s.ctr.GetDB(), req.ClientKey)
+	client, err := clientModel.FindByClientKey(s.ctr.GetDB().WithContext(ctx), req.ClientKey)
 	if err != nil {
 		s.ctr.GetLogger().Error("failed to query client", zap.Error(err))
 		return nil, fmt.Errorf("客户端配置查询失败")
@@ -640,13 +820,8 @@ func (s *XcxAuthStrategy) Login(ctx context.Context, req *LoginRequest) (*LoginR
 		s.ctr.GetLogger().Error("failed to generate token", zap.Error(err))
 		return nil, fmt.Errorf("生成token失败")
 	}
-	_ = um.UpdateLoginInfo(s.ctr.GetDB(), user.ID, "", time.Now().Unix())
-	if client.ActiveTimeout > 0 {
-		tokenHash := generateTokenHash(token)
-		activeKey := "token:active:" + tokenHash
-		_ = s.ctr.GetRedis().Set(ctx, activeKey, time.Now().Unix(), time.Duration(client.ActiveTimeout)*time.Second).Err()
-	}
-	return &LoginResponse{AccessToken: token, ExpiresIn: expiresIn, UserInfo: &UserInfo{UserId: user.ID, Username: user.UserName, Nickname: user.NickName, Phonenumber: user.Phonenumber, Avatar: user.Avatar, UserType: user.UserType}}, nil
+	_ = um.UpdateLoginInfo(s.ctr.GetDB().WithContext(ctx), user.ID, "", time.Now().Unix())
+	return &LoginResponse{AccessToken: token, ExpiresIn: expiresIn, UserInfo: &UserInfo{UserId: user.ID, Username: user.UserName, Nickname: user.NickName, Phonenumber: user.Phonenumber, Avatar: user.Avatar, UserType: user.UserType, TenantId: user.TenantId}}, nil
 }
 
 type EmailAuthStrategy struct {
@@ -664,12 +839,19 @@ func (s *EmailAuthStrategy) Login(ctx context.Context, req *LoginRequest) (*Logi
 		return nil, fmt.Errorf("邮箱和验证码不能为空")
 	}
 
+	ip := service.ClientIPFromContext(ctx)
+	lockout := loginLockout(s.ctr)
+	if err := lockout.Check(ctx, req.Email, ip); err != nil {
+		return nil, err
+	}
 	if err := NewCaptchaService(s.ctr.GetRedis()).ValidateEmailCode(ctx, req.Email, req.Code); err != nil {
+		lockout.RecordFailure(ctx, req.Email, ip)
 		return nil, err
 	}
+	lockout.RecordSuccess(ctx, req.Email, ip)
 
 	var um model.User
-	user, err := um.FindByEmail(s.ctr.GetDB(), req.Email)
+	user, err := um.FindByEmail(s.ctr.GetDB().WithContext(ctx), req.Email)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("邮箱或验证码错误")
@@ -678,12 +860,16 @@ func (s *EmailAuthStrategy) Login(ctx context.Context, req *LoginRequest) (*Logi
 		return nil, fmt.Errorf("查询用户失败")
 	}
 
-	if user.Status != 0 {
-		return nil, fmt.Errorf("用户已被停用")
+	if err := service.CheckUserStatus(user.Status); err != nil {
+		return nil, err
+	}
+
+	if challenge, err := mfaChallenge(ctx, s.ctr, user, req); challenge != nil || err != nil {
+		return challenge, err
 	}
 
 	var clientModel model.AuthClient
-	client, err := clientModel.FindByClientKey(s.ctr.GetDB(), req.ClientKey)
+	client, err := clientModel.FindByClientKey(s.ctr.GetDB().WithContext(ctx), req.ClientKey)
 	if err != nil {
 		s.ctr.GetLogger().Error("failed to query client", zap.Error(err))
 		return nil, fmt.Errorf("客户端配置查询失败")
@@ -701,12 +887,6 @@ func (s *EmailAuthStrategy) Login(ctx context.Context, req *LoginRequest) (*Logi
 		return nil, fmt.Errorf("生成token失败")
 	}
 
-	if client.ActiveTimeout > 0 {
-		tokenHash := generateTokenHash(token)
-		activeKey := "token:active:" + tokenHash
-		_ = s.ctr.GetRedis().Set(ctx, activeKey, time.Now().Unix(), time.Duration(client.ActiveTimeout)*time.Second).Err()
-	}
-
 	return &LoginResponse{
 		AccessToken: token,
 		ExpiresIn:   expiresIn,
@@ -718,11 +898,325 @@ func (s *EmailAuthStrategy) Login(ctx context.Context, req *LoginRequest) (*Logi
 			Email:       user.Email,
 			Avatar:      user.Avatar,
 			UserType:    user.UserType,
+			TenantId:    user.TenantId,
+		},
+	}, nil
+}
+
+// GrantTypeMfa 两步验证授权类型
+const GrantTypeMfa = "mfa"
+
+// mfaChallenge 用户已启用或所属角色强制启用两步验证时，返回待验证票据而非 Token
+// 无需两步验证时返回 (nil, nil)
+func mfaChallenge(ctx context.Context, c container.Container, user *model.User, req *LoginRequest) (*LoginResponse, error) {
+	mfaService := service.NewMfaService(c.GetDB(), c.GetRedis(), c.GetConfig(), c.GetLogger())
+
+	setupRequired := false
+	if !user.MfaEnabled {
+		required, err := mfaService.IsRequired(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("登录失败")
+		}
+		if !required {
+			return nil, nil
+		}
+		setupRequired = true
+	}
+
+	ticket, err := mfaService.IssueTicket(ctx, user, req.ClientKey, req.GrantType, setupRequired)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResponse{
+		ExpiresIn:        int64(service.MfaTicketTTL.Seconds()),
+		MfaRequired:      true,
+		MfaSetupRequired: setupRequired,
+		MfaTicket:        ticket,
+	}, nil
+}
+
+type MfaAuthStrategy struct {
+	ctr container.Container
+}
+
+func NewMfaAuthStrategy(c container.Container) *MfaAuthStrategy {
+	return &MfaAuthStrategy{ctr: c}
+}
+
+func (s *MfaAuthStrategy) GrantType() string { return GrantTypeMfa }
+
+func (s *MfaAuthStrategy) Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error) {
+	if req.MfaTicket == "" || req.Code == "" {
+		return nil, fmt.Errorf("两步验证票据和验证码不能为空")
+	}
+
+	mfaService := service.NewMfaService(s.ctr.GetDB(), s.ctr.GetRedis(), s.ctr.GetConfig(), s.ctr.GetLogger())
+	user, recoveryCodes, err := mfaService.RedeemTicket(ctx, req.MfaTicket, req.ClientKey, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginResponse{
+		UserInfo: &UserInfo{
+			UserId:      user.ID,
+			Username:    user.UserName,
+			Nickname:    user.NickName,
+			Phonenumber: user.Phonenumber,
+			Email:       user.Email,
+			Avatar:      user.Avatar,
+			UserType:    user.UserType,
+			TenantId:    user.TenantId,
+		},
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+type WebAuthnAuthStrategy struct {
+	ctr container.Container
+}
+
+func NewWebAuthnAuthStrategy(c container.Container) *WebAuthnAuthStrategy {
+	return &WebAuthnAuthStrategy{ctr: c}
+}
+
+func (s *WebAuthnAuthStrategy) GrantType() string { return "webauthn" }
+
+func (s *WebAuthnAuthStrategy) Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error) {
+	if req.ChallengeId == "" || len(req.Credential) == 0 {
+		return nil, fmt.Errorf("挑战ID和凭证不能为空")
+	}
+
+	webAuthnService := service.NewWebAuthnService(s.ctr.GetDB(), s.ctr.GetRedis(), s.ctr.GetConfig(), s.ctr.GetLogger())
+	user, err := webAuthnService.FinishLogin(ctx, req.ChallengeId, req.Credential)
+	if err != nil {
+		return nil, err
+	}
+	if err := service.CheckUserStatus(user.Status); err != nil {
+		return nil, err
+	}
+
+	return &LoginResponse{
+		UserInfo: &UserInfo{
+			UserId:      user.ID,
+			Username:    user.UserName,
+			Nickname:    user.NickName,
+			Phonenumber: user.Phonenumber,
+			Email:       user.Email,
+			Avatar:      user.Avatar,
+			UserType:    user.UserType,
+			TenantId:    user.TenantId,
+		},
+	}, nil
+}
+
+type OidcAuthStrategy struct {
+	ctr container.Container
+}
+
+func NewOidcAuthStrategy(c container.Container) *OidcAuthStrategy {
+	return &OidcAuthStrategy{ctr: c}
+}
+
+func (s *OidcAuthStrategy) GrantType() string { return "oidc" }
+
+func (s *OidcAuthStrategy) Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error) {
+	if req.Code == "" || req.State == "" {
+		return nil, fmt.Errorf("授权码和state不能为空")
+	}
+
+	user, err := newOidcService(s.ctr).FinishLogin(ctx, req.ClientKey, req.State, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if err := service.CheckUserStatus(user.Status); err != nil {
+		return nil, err
+	}
+
+	if challenge, err := mfaChallenge(ctx, s.ctr, user, req); challenge != nil || err != nil {
+		return challenge, err
+	}
+
+	return &LoginResponse{
+		UserInfo: &UserInfo{
+			UserId:      user.ID,
+			Username:    user.UserName,
+			Nickname:    user.NickName,
+			Phonenumber: user.Phonenumber,
+			Email:       user.Email,
+			Avatar:      user.Avatar,
+			UserType:    user.UserType,
+			TenantId:    user.TenantId,
+		},
+	}, nil
+}
+
+// LdapAuthStrategy 使用 LDAP / Active Directory 域账号登录
+// 与密码登录共用图形验证码和密码错误次数限制
+type LdapAuthStrategy struct {
+	ctr      container.Container
+	password *PasswordAuthStrategy
+}
+
+func NewLdapAuthStrategy(c container.Container) *LdapAuthStrategy {
+	return &LdapAuthStrategy{ctr: c, password: NewPasswordAuthStrategy(c)}
+}
+
+func (s *LdapAuthStrategy) GrantType() string { return "ldap" }
+
+func (s *LdapAuthStrategy) Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error) {
+	if req.Username == "" || req.Password == "" {
+		return nil, fmt.Errorf("用户名和密码不能为空")
+	}
+
+	if s.ctr.GetConfig().Captcha.Image.Enabled {
+		if req.Uuid != "" && req.Code != "" {
+			if err := NewCaptchaService(s.ctr.GetRedis()).ValidateCaptcha(ctx, req.Uuid, req.Code); err != nil {
+				return nil, err
+			}
+		} else {
+			return nil, fmt.Errorf("请输入图形验证码")
+		}
+	}
+
+	if err := s.password.checkBruteForce(ctx, req.Username); err != nil {
+		return nil, err
+	}
+
+	casbinService := service.NewCasbinServiceV2(s.ctr.GetCasbin(), s.ctr.GetDB(), s.ctr.GetLogger(), s.ctr.GetConfig())
+	tokenManager := service.NewTokenManager(s.ctr.GetJWT(), s.ctr.GetRedis(), s.ctr.GetLogger())
+	ldapService := service.NewLdapService(s.ctr.GetDB(), s.ctr.GetLDAP(), casbinService, tokenManager, s.ctr.GetConfig(), s.ctr.GetLogger())
+	user, err := ldapService.Authenticate(ctx, req.Username, req.Password)
+	if err != nil {
+		s.password.incrementErrorCount(ctx, req.Username)
+		return nil, err
+	}
+	if err := service.CheckUserStatus(user.Status); err != nil {
+		return nil, err
+	}
+	s.password.clearErrorCount(ctx, req.Username)
+
+	if challenge, err := mfaChallenge(ctx, s.ctr, user, req); challenge != nil || err != nil {
+		return challenge, err
+	}
+
+	return &LoginResponse{
+		UserInfo: &UserInfo{
+			UserId:      user.ID,
+			Username:    user.UserName,
+			Nickname:    user.NickName,
+			Phonenumber: user.Phonenumber,
+			Email:       user.Email,
+			Avatar:      user.Avatar,
+			UserType:    user.UserType,
+			TenantId:    user.TenantId,
 		},
 	}, nil
 }
 
-func generateTokenHash(token string) string {
-	h := sha256.Sum256([]byte(token))
-	return hex.EncodeToString(h[:])
+// SmsAuthStrategy 短信验证码登录
+// 验证码错误次数按 auth.lockout 配置计数和锁定
+type SmsAuthStrategy struct {
+	ctr container.Container
+}
+
+func NewSmsAuthStrategy(c container.Container) *SmsAuthStrategy {
+	return &SmsAuthStrategy{ctr: c}
+}
+
+func (s *SmsAuthStrategy) GrantType() string { return "sms" }
+
+func (s *SmsAuthStrategy) Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error) {
+	if req.Phonenumber == "" || req.Code == "" {
+		return nil, fmt.Errorf("手机号和验证码不能为空")
+	}
+	if len(req.Phonenumber) != 11 {
+		return nil, fmt.Errorf("手机号格式错误")
+	}
+	ip := service.ClientIPFromContext(ctx)
+
+	lockout := loginLockout(s.ctr)
+	if err := lockout.Check(ctx, req.Phonenumber, ip); err != nil {
+		return nil, err
+	}
+	if err := NewCaptchaService(s.ctr.GetRedis()).ValidateSmsCode(ctx, req.Phonenumber, req.Code); err != nil {
+		lockout.RecordFailure(ctx, req.Phonenumber, ip)
+		return nil, err
+	}
+	lockout.RecordSuccess(ctx, req.Phonenumber, ip)
+
+	var um model.User
+	user, err := um.FindByPhonenumber(s.ctr.GetDB().WithContext(ctx), req.Phonenumber)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		s.ctr.GetLogger().Error("failed to query user by phonenumber", zap.Error(err))
+		return nil, fmt.Errorf("查询用户失败")
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if user, err = s.register(ctx, req.Phonenumber); err != nil {
+			return nil, err
+		}
+	}
+	if err := service.CheckUserStatus(user.Status); err != nil {
+		return nil, err
+	}
+
+	if challenge, err := mfaChallenge(ctx, s.ctr, user, req); challenge != nil || err != nil {
+		return challenge, err
+	}
+
+	return &LoginResponse{
+		UserInfo: &UserInfo{
+			UserId:      user.ID,
+			Username:    user.UserName,
+			Nickname:    user.NickName,
+			Phonenumber: user.Phonenumber,
+			Email:       user.Email,
+			Avatar:      user.Avatar,
+			UserType:    user.UserType,
+			TenantId:    user.TenantId,
+		},
+	}, nil
+}
+
+// register 按配置为未注册的手机号自动创建用户并分配默认角色
+func (s *SmsAuthStrategy) register(ctx context.Context, phonenumber string) (*model.User, error) {
+	cfg := s.ctr.GetConfig().Auth.SmsLogin
+	if !cfg.AutoRegister {
+		return nil, fmt.Errorf("该手机号未注册")
+	}
+
+	var um model.User
+	username := phonenumber
+	if _, err := um.FindByUsername(s.ctr.GetDB().WithContext(ctx), username); err == nil {
+		username = "sms_" + phonenumber
+	}
+	newUser := &model.User{
+		OrgId:       cfg.DefaultOrgId,
+		UserName:    username,
+		NickName:    "用户" + phonenumber[len(phonenumber)-4:],
+		UserType:    constants.UserTypeApp,
+		Phonenumber: phonenumber,
+		Status:      constants.StatusNormal,
+	}
+	if err := um.Create(s.ctr.GetDB().WithContext(ctx), newUser); err != nil {
+		s.ctr.GetLogger().Error("failed to create sms user", zap.Error(err))
+		return nil, fmt.Errorf("创建用户失败")
+	}
+
+	if cfg.DefaultRoleKey != "" {
+		casbinService := service.NewCasbinServiceV2(s.ctr.GetCasbin(), s.ctr.GetDB(), s.ctr.GetLogger(), s.ctr.GetConfig())
+		roleService := service.NewRoleService(s.ctr.GetDB(), casbinService, s.ctr.GetLogger())
+		role, err := roleService.GetByRoleKey(ctx, cfg.DefaultRoleKey)
+		if err == nil {
+			err = roleService.AssignRoleToUser(ctx, newUser.ID, role.ID)
+		}
+		if err != nil {
+			s.ctr.GetLogger().Warn("failed to assign default role to sms user",
+				zap.Int64("userId", newUser.ID),
+				zap.String("roleKey", cfg.DefaultRoleKey),
+				zap.Error(err))
+		}
+	}
+
+	s.ctr.GetLogger().Info("sms user registered", zap.Int64("userId", newUser.ID))
+	return newUser, nil
 }
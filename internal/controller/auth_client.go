@@ -0,0 +1,290 @@
+package controller
+
+import (
+	"strings"
+
+	"github.com/force-c/nai-tizi/internal/container"
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/domain/request"
+	"github.com/force-c/nai-tizi/internal/domain/response"
+	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/force-c/nai-tizi/internal/utils/pagination"
+	"github.com/gin-gonic/gin"
+)
+
+// ClientController 客户端应用管理控制器接口
+type ClientController interface {
+	PageClient(c *gin.Context)   // 分页查询客户端
+	GetClient(c *gin.Context)    // 查询客户端详情
+	CreateClient(c *gin.Context) // 创建客户端
+	UpdateClient(c *gin.Context) // 更新客户端
+	DeleteClient(c *gin.Context) // 删除客户端
+	RotateSecret(c *gin.Context) // 轮换客户端密钥
+	GrantTypes(c *gin.Context)   // 可开通的授权类型
+}
+
+type clientController struct {
+	ctr           container.Container
+	base          *BaseController
+	clientService service.ClientService
+}
+
+func NewClientController(c container.Container) ClientController {
+	return &clientController{
+		ctr:           c,
+		base:          NewBaseController(c),
+		clientService: service.NewClientService(c.GetDB(), c.GetRedis(), c.GetLogger()),
+	}
+}
+
+// PageClient 分页查询客户端
+//
+//	@Summary		分页查询客户端
+//	@Description	分页查询客户端应用列表（不含密钥），需要 client.read 权限
+//	@Tags			客户端管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			body	body		request.PageClientRequest	true	"查询参数"
+//	@Success		200		{object}	response.Response{data=pagination.Page[response.ClientResponse]}
+//	@Router			/api/v1/client/page [post]
+func (h *clientController) PageClient(c *gin.Context) {
+	var req request.PageClientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+
+	page, err := h.clientService.Page(c.Request.Context(), &req.PageQuery, strings.TrimSpace(req.ClientKey), strings.TrimSpace(req.ClientName), req.Status)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	records := make([]response.ClientResponse, 0, len(page.Records))
+	for i := range page.Records {
+		records = append(records, *toClientResponse(&page.Records[i]))
+	}
+	response.Success(c, &pagination.Page[response.ClientResponse]{
+		Records: records,
+		Total:   page.Total,
+		Size:    page.Size,
+		Current: page.Current,
+		Pages:   page.Pages,
+	})
+}
+
+// GetClient 查询客户端详情
+//
+//	@Summary		查询客户端详情
+//	@Description	根据客户端ID查询客户端配置（不含密钥），需要 client.read 权限
+//	@Tags			客户端管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			clientId	path		string	true	"客户端ID"
+//	@Success		200			{object}	response.Response{data=response.ClientResponse}
+//	@Router			/api/v1/client/{clientId} [get]
+func (h *clientController) GetClient(c *gin.Context) {
+	client, err := h.clientService.GetById(c.Request.Context(), c.Param("clientId"))
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, toClientResponse(client))
+}
+
+// CreateClient 创建客户端
+//
+//	@Summary		创建客户端
+//	@Description	创建客户端应用，密钥由系统生成并只保存哈希，明文仅在创建时返回一次；需要 client.create 权限
+//	@Tags			客户端管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			body	body		request.CreateClientRequest	true	"客户端信息"
+//	@Success		200		{object}	response.Response{data=response.ClientSecretResponse}
+//	@Router			/api/v1/client [post]
+func (h *clientController) CreateClient(c *gin.Context) {
+	var req request.CreateClientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	client := newClientFromRequest(&req.ClientConfigRequest)
+	client.ClientKey = req.ClientKey
+	client.CreateBy = userId
+	client.UpdateBy = userId
+
+	secret, err := h.clientService.Create(c.Request.Context(), client)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, &response.ClientSecretResponse{
+		ClientId:     client.ClientId,
+		ClientKey:    client.ClientKey,
+		ClientSecret: secret,
+	})
+}
+
+// UpdateClient 更新客户端
+//
+//	@Summary		更新客户端
+//	@Description	更新客户端的授权类型、Token 有效期、状态和 OAuth2 配置，clientKey 与密钥不可在此修改；需要 client.update 权限。
+//	@Description	修改立即生效，已签发的 Token 在刷新时按新配置计算有效期
+//	@Tags			客户端管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			clientId	path		string						true	"客户端ID"
+//	@Param			body		body		request.UpdateClientRequest	true	"客户端配置"
+//	@Success		200			{object}	response.Response
+//	@Router			/api/v1/client/{clientId} [put]
+func (h *clientController) UpdateClient(c *gin.Context) {
+	var req request.UpdateClientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	client := newClientFromRequest(&req.ClientConfigRequest)
+	client.ClientId = c.Param("clientId")
+	client.UpdateBy = userId
+
+	if err := h.clientService.Update(c.Request.Context(), client); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, nil)
+}
+
+// DeleteClient 删除客户端
+//
+//	@Summary		删除客户端
+//	@Description	删除客户端应用，删除后使用该客户端的登录和刷新请求立即失败；需要 client.delete 权限
+//	@Tags			客户端管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			clientId	path		string	true	"客户端ID"
+//	@Success		200			{object}	response.Response
+//	@Router			/api/v1/client/{clientId} [delete]
+func (h *clientController) DeleteClient(c *gin.Context) {
+	clientId := c.Param("clientId")
+	if current, _ := h.base.GetClientId(c); current == clientId {
+		response.FailWithMsg(c, "不能删除当前登录使用的客户端")
+		return
+	}
+	if err := h.clientService.Delete(c.Request.Context(), clientId); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, nil)
+}
+
+// RotateSecret 轮换客户端密钥
+//
+//	@Summary		轮换客户端密钥
+//	@Description	生成新的客户端密钥，明文仅返回一次；旧密钥在 gracePeriod 秒内仍然有效，便于调用方切换，0 表示立即失效。
+//	@Description	需要 client.secret 权限
+//	@Tags			客户端管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			clientId	path		string								true	"客户端ID"
+//	@Param			body		body		request.RotateClientSecretRequest	true	"旧密钥保留时间"
+//	@Success		200			{object}	response.Response{data=response.ClientSecretResponse}
+//	@Router			/api/v1/client/{clientId}/secret [post]
+func (h *clientController) RotateSecret(c *gin.Context) {
+	var req request.RotateClientSecretRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	client, secret, err := h.clientService.RotateSecret(c.Request.Context(), c.Param("clientId"), req.GracePeriod, userId)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, &response.ClientSecretResponse{
+		ClientId:     client.ClientId,
+		ClientKey:    client.ClientKey,
+		ClientSecret: secret,
+		PrevExpireAt: client.PrevExpireAt,
+	})
+}
+
+// GrantTypes 可开通的授权类型
+//
+//	@Summary		可开通的授权类型
+//	@Description	返回客户端可开通的授权类型，用于管理页面选项
+//	@Tags			客户端管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Success		200	{object}	response.Response{data=[]string}
+//	@Router			/api/v1/client/grant-types [get]
+func (h *clientController) GrantTypes(c *gin.Context) {
+	response.Success(c, service.ClientGrantTypes)
+}
+
+// newClientFromRequest 将可编辑配置转换为客户端模型
+func newClientFromRequest(req *request.ClientConfigRequest) *model.AuthClient {
+	return &model.AuthClient{
+		ClientName:    strings.TrimSpace(req.ClientName),
+		GrantType:     strings.Join(req.GrantTypes, ","),
+		DeviceType:    strings.TrimSpace(req.DeviceType),
+		Status:        req.Status,
+		Timeout:       req.Timeout,
+		ActiveTimeout: req.ActiveTimeout,
+		IdleCheck:     req.IdleCheck,
+		RedirectUris:  strings.Join(req.RedirectUris, "\n"),
+		Scopes:        strings.Join(req.Scopes, " "),
+		AutoApprove:   req.AutoApprove,
+		Remark:        req.Remark,
+	}
+}
+
+// toClientResponse 转换为客户端响应，不返回密钥哈希
+func toClientResponse(client *model.AuthClient) *response.ClientResponse {
+	prevExpireAt := client.PrevExpireAt
+	if client.PrevSecret == "" {
+		prevExpireAt = 0
+	}
+	return &response.ClientResponse{
+		ClientId:      client.ClientId,
+		ClientKey:     client.ClientKey,
+		ClientName:    client.ClientName,
+		GrantTypes:    client.GrantTypes(),
+		DeviceType:    client.DeviceType,
+		Status:        client.Status,
+		Timeout:       client.Timeout,
+		ActiveTimeout: client.ActiveTimeout,
+		IdleCheck:     client.IdleCheck,
+		RedirectUris:  client.RegisteredRedirectUris(),
+		Scopes:        client.AllowedScopes(),
+		AutoApprove:   client.AutoApprove,
+		PrevExpireAt:  prevExpireAt,
+		Remark:        client.Remark,
+		CreatedTime:   client.CreatedTime,
+		UpdatedTime:   client.UpdatedTime,
+	}
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/infrastructure/jwt"
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...zap.Field)         {}
+func (nopLogger) Info(string, ...zap.Field)          {}
+func (nopLogger) Warn(string, ...zap.Field)          {}
+func (nopLogger) Error(string, ...zap.Field)         {}
+func (nopLogger) Fatal(string, ...zap.Field)         {}
+func (l nopLogger) With(...zap.Field) logging.Logger { return l }
+
+// recordHook 不连接 Redis，记录 HSET 写入的字段，其他命令直接返回空结果
+type recordHook struct {
+	hset map[string]any
+}
+
+func (h *recordHook) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (h *recordHook) ProcessHook(redis.ProcessHook) redis.ProcessHook {
+	return func(_ context.Context, cmd redis.Cmder) error {
+		if cmd.Name() == "hset" {
+			args := cmd.Args()
+			for i := 2; i+1 < len(args); i += 2 {
+				h.hset[args[i].(string)] = args[i+1]
+			}
+		}
+		return nil
+	}
+}
+
+func (h *recordHook) ProcessPipelineHook(redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(context.Context, []redis.Cmder) error { return nil }
+}
+
+// TestLogin_TokenCarriesTenant 测试非默认租户用户登录后签发的 Token 和 RefreshToken 携带其租户ID
+func TestLogin_TokenCarriesTenant(t *testing.T) {
+	hook := &recordHook{hset: make(map[string]any)}
+	rdb := redis.NewClient(&redis.Options{
+		Dialer: func(context.Context, string, string) (net.Conn, error) { return nil, net.ErrClosed },
+	})
+	rdb.AddHook(hook)
+	defer rdb.Close()
+
+	j := jwt.New("test-secret", 3600)
+	tokenManager := service.NewTokenManager(j, rdb, nopLogger{})
+	client := &model.AuthClient{ClientId: "web", DeviceType: "pc", Timeout: 7200, ActiveTimeout: 1800, IdleCheck: 1}
+
+	// 登录策略返回的租户 7 用户
+	user := &UserInfo{UserId: 42, Username: "alice", TenantId: 7}
+	accessToken, _, _, _, err := tokenManager.GenerateTokenPair(context.Background(), tokenUser(user), client)
+	if err != nil {
+		t.Fatalf("GenerateTokenPair() error = %v", err)
+	}
+
+	claims, err := j.ValidateToken(accessToken)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.TenantId != 7 {
+		t.Errorf("access token tid = %d, want 7", claims.TenantId)
+	}
+	if got := hook.hset["tenantId"]; got != int64(7) {
+		t.Errorf("refresh token tenantId = %v, want 7", got)
+	}
+}
@@ -57,6 +57,16 @@ func (b *BaseController) GetDeviceType(c *gin.Context) (string, error) {
 	return "", fmt.Errorf("设备类型不存在")
 }
 
+// GetSessionId 获取当前会话ID
+func (b *BaseController) GetSessionId(c *gin.Context) (string, error) {
+	if sessionId, exists := c.Get("sessionId"); exists {
+		if id, ok := sessionId.(string); ok && id != "" {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("会话ID不存在")
+}
+
 // CurrentUser 从JWT token解析当前用户信息（不查询数据库）
 func (b *BaseController) CurrentUser(c *gin.Context) (*model.User, error) {
 	// 先尝试从 context 中获取（middleware 可能已经解析并设置）
@@ -0,0 +1,180 @@
+package controller
+
+import (
+	"github.com/force-c/nai-tizi/internal/container"
+	"github.com/force-c/nai-tizi/internal/domain/request"
+	"github.com/force-c/nai-tizi/internal/domain/response"
+	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/force-c/nai-tizi/internal/utils"
+	"github.com/force-c/nai-tizi/internal/utils/pagination"
+	"github.com/gin-gonic/gin"
+)
+
+// ImpersonationController 代登录控制器接口
+type ImpersonationController interface {
+	Impersonate(c *gin.Context)            // 管理员以用户身份登录
+	ListMyImpersonations(c *gin.Context)   // 查询我被代登录的记录
+	ListUserImpersonations(c *gin.Context) // 管理员查询指定用户被代登录的记录
+}
+
+type impersonationController struct {
+	ctr         container.Container
+	base        *BaseController
+	service     service.ImpersonationService
+	userService service.UserService
+}
+
+func NewImpersonationController(c container.Container) ImpersonationController {
+	casbinService := service.NewCasbinServiceV2(c.GetCasbin(), c.GetDB(), c.GetLogger(), c.GetConfig())
+	return &impersonationController{
+		ctr:         c,
+		base:        NewBaseController(c),
+		service:     service.NewImpersonationService(c.GetDB(), c.GetRedis(), c.GetJWT(), casbinService, c.GetConfig(), c.GetLogger()),
+		userService: service.NewUserService(c.GetDB(), c.GetLogger()),
+	}
+}
+
+// Impersonate 管理员以用户身份登录
+//
+//	@Summary		以用户身份登录（代登录）
+//	@Description	管理员以目标用户身份签发短期 Token，用于排查用户问题，需要 user.impersonate 权限。
+//	@Description	Token 携带 act 声明标识管理员，不签发 RefreshToken；代登录期间的操作日志同时记录管理员，且不能修改密码、两步验证、管理凭证或再次代登录。
+//	@Description	不能代登录自己、服务账号、停用用户以及同样拥有代登录权限的用户，目标用户须在当前租户和数据权限范围内
+//	@Tags			代登录
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			id		path		int							true	"目标用户ID"
+//	@Param			body	body		request.ImpersonateRequest	true	"代登录原因"
+//	@Success		200		{object}	response.Response{data=response.ImpersonateResponse}
+//	@Router			/api/v1/user/{id}/impersonate [post]
+func (h *impersonationController) Impersonate(c *gin.Context) {
+	targetId, err := utils.ParseInt64Param(c, "id", "required")
+	if err != nil {
+		response.FailCode(c, response.CodeInvalidParam, err.Error())
+		return
+	}
+	var req request.ImpersonateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+
+	actorId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+	actorName, _ := h.base.GetUserName(c)
+	clientId, _ := h.base.GetClientId(c)
+	deviceType, _ := h.base.GetDeviceType(c)
+
+	ctx := service.WithSessionMeta(c.Request.Context(), utils.GetClientIP(c), c.Request.UserAgent())
+	token, err := h.service.Impersonate(ctx, &service.Impersonator{
+		UserId:     actorId,
+		UserName:   actorName,
+		ClientId:   clientId,
+		DeviceType: deviceType,
+	}, targetId, req.Reason)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	user := token.User
+	response.Success(c, &response.ImpersonateResponse{
+		AccessToken: token.AccessToken,
+		ExpiresIn:   token.ExpiresIn,
+		SessionId:   token.SessionId,
+		UserInfo: &response.UserInfo{
+			UserId:      user.ID,
+			Username:    user.UserName,
+			Nickname:    user.NickName,
+			Phonenumber: user.Phonenumber,
+			Email:       user.Email,
+			Avatar:      user.Avatar,
+			UserType:    user.UserType,
+			TenantId:    user.TenantId,
+		},
+	})
+}
+
+// ListMyImpersonations 查询我被代登录的记录
+//
+//	@Summary		查询我被代登录的记录
+//	@Description	列出管理员以当前用户身份登录的历史记录（含管理员、原因和时间）
+//	@Tags			代登录
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			pageNum		query		int	false	"页码"
+//	@Param			pageSize	query		int	false	"每页数量"
+//	@Success		200			{object}	response.Response{data=pagination.Page[response.ImpersonationLogResponse]}
+//	@Router			/api/v1/auth/impersonations [get]
+func (h *impersonationController) ListMyImpersonations(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+	h.page(c, userId)
+}
+
+// ListUserImpersonations 管理员查询指定用户被代登录的记录
+//
+//	@Summary		查询用户被代登录的记录
+//	@Description	管理员查询指定用户被代登录的历史记录，需要 user.read 权限，目标用户须在当前租户和数据权限范围内
+//	@Tags			代登录
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			id			path		int	true	"用户ID"
+//	@Param			pageNum		query		int	false	"页码"
+//	@Param			pageSize	query		int	false	"每页数量"
+//	@Success		200			{object}	response.Response{data=pagination.Page[response.ImpersonationLogResponse]}
+//	@Router			/api/v1/user/{id}/impersonations [get]
+func (h *impersonationController) ListUserImpersonations(c *gin.Context) {
+	userId, err := utils.ParseInt64Param(c, "id", "required")
+	if err != nil {
+		response.FailCode(c, response.CodeInvalidParam, err.Error())
+		return
+	}
+	// 范围外的用户视为不存在
+	if _, err := h.userService.GetById(c.Request.Context(), userId); err != nil {
+		response.NotFound(c, "用户不存在")
+		return
+	}
+	h.page(c, userId)
+}
+
+func (h *impersonationController) page(c *gin.Context, userId int64) {
+	var req request.PageImpersonationLogRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+	page, err := h.service.PageByTarget(c.Request.Context(), userId, &req.PageQuery)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	records := make([]response.ImpersonationLogResponse, 0, len(page.Records))
+	for _, log := range page.Records {
+		records = append(records, response.ImpersonationLogResponse{
+			Id:          log.ID,
+			ActorName:   log.ActorName,
+			Reason:      log.Reason,
+			Ipaddr:      log.Ipaddr,
+			ExpireAt:    log.ExpireAt,
+			CreatedTime: log.CreatedTime,
+		})
+	}
+	response.Success(c, &pagination.Page[response.ImpersonationLogResponse]{
+		Records: records,
+		Total:   page.Total,
+		Size:    page.Size,
+		Current: page.Current,
+		Pages:   page.Pages,
+	})
+}
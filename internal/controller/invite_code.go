@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/force-c/nai-tizi/internal/container"
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/domain/request"
+	"github.com/force-c/nai-tizi/internal/domain/response"
+	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/force-c/nai-tizi/internal/utils"
+	"github.com/force-c/nai-tizi/internal/utils/pagination"
+	"github.com/gin-gonic/gin"
+)
+
+// InviteCodeController 注册邀请码管理控制器接口
+type InviteCodeController interface {
+	Page(c *gin.Context)   // 分页查询邀请码
+	Create(c *gin.Context) // 生成邀请码
+	Update(c *gin.Context) // 更新邀请码
+	Delete(c *gin.Context) // 删除邀请码
+}
+
+type inviteCodeController struct {
+	ctr               container.Container
+	base              *BaseController
+	inviteCodeService service.InviteCodeService
+}
+
+func NewInviteCodeController(c container.Container) InviteCodeController {
+	return &inviteCodeController{
+		ctr:               c,
+		base:              NewBaseController(c),
+		inviteCodeService: service.NewInviteCodeService(c.GetDB(), c.GetLogger()),
+	}
+}
+
+// Page 分页查询邀请码
+//
+//	@Summary		分页查询邀请码
+//	@Description	分页查询当前租户的注册邀请码，需要 invite_code.read 权限
+//	@Tags			邀请码管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			body	body		request.PageInviteCodeRequest	true	"查询参数"
+//	@Success		200		{object}	response.Response{data=pagination.Page[response.InviteCodeResponse]}
+//	@Router			/api/v1/invite-code/page [post]
+func (h *inviteCodeController) Page(c *gin.Context) {
+	var req request.PageInviteCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+
+	page, err := h.inviteCodeService.Page(c.Request.Context(), &req)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	now := time.Now().Unix()
+	records := make([]response.InviteCodeResponse, 0, len(page.Records))
+	for i := range page.Records {
+		records = append(records, toInviteCodeResponse(&page.Records[i], now))
+	}
+	response.Success(c, &pagination.Page[response.InviteCodeResponse]{
+		Records: records,
+		Total:   page.Total,
+		Size:    page.Size,
+		Current: page.Current,
+		Pages:   page.Pages,
+	})
+}
+
+// Create 生成邀请码
+//
+//	@Summary		生成邀请码
+//	@Description	批量生成绑定组织和默认角色的注册邀请码，一次最多 100 个；需要 invite_code.create 权限
+//	@Tags			邀请码管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			body	body		request.CreateInviteCodeRequest	true	"邀请码设置"
+//	@Success		200		{object}	response.Response{data=[]response.InviteCodeResponse}
+//	@Router			/api/v1/invite-code [post]
+func (h *inviteCodeController) Create(c *gin.Context) {
+	var req request.CreateInviteCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	codes, err := h.inviteCodeService.Create(c.Request.Context(), &req, userId)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	now := time.Now().Unix()
+	list := make([]response.InviteCodeResponse, 0, len(codes))
+	for i := range codes {
+		list = append(list, toInviteCodeResponse(&codes[i], now))
+	}
+	response.Success(c, list)
+}
+
+// Update 更新邀请码
+//
+//	@Summary		更新邀请码
+//	@Description	更新邀请码的默认角色、使用次数、有效期、状态和备注，绑定的组织不可修改；需要 invite_code.update 权限
+//	@Tags			邀请码管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			id		path		int								true	"邀请码ID"
+//	@Param			body	body		request.UpdateInviteCodeRequest	true	"邀请码设置"
+//	@Success		200		{object}	response.Response
+//	@Router			/api/v1/invite-code/{id} [put]
+func (h *inviteCodeController) Update(c *gin.Context) {
+	id, err := utils.ParseInt64Param(c, "id", "required")
+	if err != nil {
+		response.FailCode(c, response.CodeInvalidParam, err.Error())
+		return
+	}
+	var req request.UpdateInviteCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	if err := h.inviteCodeService.Update(c.Request.Context(), id, &req, userId); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, nil)
+}
+
+// Delete 删除邀请码
+//
+//	@Summary		删除邀请码
+//	@Description	删除注册邀请码，已通过该邀请码注册的用户不受影响；需要 invite_code.delete 权限
+//	@Tags			邀请码管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			id	path		int	true	"邀请码ID"
+//	@Success		200	{object}	response.Response
+//	@Router			/api/v1/invite-code/{id} [delete]
+func (h *inviteCodeController) Delete(c *gin.Context) {
+	id, err := utils.ParseInt64Param(c, "id", "required")
+	if err != nil {
+		response.FailCode(c, response.CodeInvalidParam, err.Error())
+		return
+	}
+
+	if err := h.inviteCodeService.Delete(c.Request.Context(), id); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, nil)
+}
+
+// toInviteCodeResponse 转换为邀请码响应
+func toInviteCodeResponse(code *model.InviteCode, now int64) response.InviteCodeResponse {
+	return response.InviteCodeResponse{
+		ID:          code.ID,
+		Code:        code.Code,
+		OrgId:       code.OrgId,
+		RoleIds:     code.RoleIdList(),
+		MaxUses:     code.MaxUses,
+		UsedCount:   code.UsedCount,
+		ExpireAt:    code.ExpireAt,
+		Status:      code.Status,
+		Usable:      code.Usable(now),
+		Remark:      code.Remark,
+		CreatedTime: code.CreatedTime,
+	}
+}
@@ -19,17 +19,21 @@ type LoginLogController interface {
 	GetLoginLogById(ctx *gin.Context)     // 根据ID查询登录日志
 	PageLoginLog(ctx *gin.Context)        // 分页查询登录日志列表
 	CleanLoginLog(ctx *gin.Context)       // 清理登录日志
+	ListLoginLocks(ctx *gin.Context)      // 查询登录锁定
+	UnlockLogin(ctx *gin.Context)         // 解除登录锁定
 }
 
 type loginLogController struct {
 	ctr             container.Container
 	loginLogService service.LoginLogService
+	lockoutService  service.LoginLockoutService
 }
 
 func NewLoginLogController(c container.Container) LoginLogController {
 	return &loginLogController{
 		ctr:             c,
 		loginLogService: service.NewLoginLogService(c.GetDB(), c.GetLogger()),
+		lockoutService:  service.NewLoginLockoutService(c.GetDB(), c.GetRedis(), c.GetConfig(), c.GetLogger()),
 	}
 }
 
@@ -244,3 +248,63 @@ func (c *loginLogController) CleanLoginLog(ctx *gin.Context) {
 		"days":  req.Days,
 	})
 }
+
+// ListLoginLocks 查询登录锁定
+//
+//	@Summary		查询登录锁定
+//	@Description	查询当前租户因登录失败次数过多而被锁定的账号、IP + 账号和 IP，按解锁时间倒序
+//	@Tags			登录日志
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	response.Response{data=[]response.LoginLockResponse}	"查询成功"
+//	@Failure		500	{object}	response.Response										"服务器内部错误"
+//	@Router			/api/v1/loginLog/locks [get]
+//	@Security		Bearer
+func (c *loginLogController) ListLoginLocks(ctx *gin.Context) {
+	locks, err := c.lockoutService.List(ctx.Request.Context())
+	if err != nil {
+		response.Fail(ctx, err.Error())
+		return
+	}
+
+	list := make([]response.LoginLockResponse, 0, len(locks))
+	for _, l := range locks {
+		list = append(list, response.LoginLockResponse{
+			Scope:    l.Scope,
+			Subject:  l.Subject,
+			Account:  l.Account,
+			Ip:       l.Ip,
+			Level:    l.Level,
+			LockedAt: l.LockedAt,
+			Until:    l.Until,
+		})
+	}
+	response.Success(ctx, list)
+}
+
+// UnlockLogin 解除登录锁定
+//
+//	@Summary		解除登录锁定
+//	@Description	解除当前租户指定账号或 IP 的登录锁定，同时清除失败计数和锁定级别
+//	@Tags			登录日志
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		request.UnlockLoginRequest	true	"解锁请求"
+//	@Success		200		{object}	response.Response			"解锁成功"
+//	@Failure		400		{object}	response.Response			"请求参数错误"
+//	@Router			/api/v1/loginLog/locks/unlock [post]
+//	@Security		Bearer
+func (c *loginLogController) UnlockLogin(ctx *gin.Context) {
+	var req request.UnlockLoginRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(ctx, "请求参数错误: "+err.Error())
+		return
+	}
+
+	if err := c.lockoutService.Unlock(ctx.Request.Context(), req.Scope, req.Subject); err != nil {
+		response.Fail(ctx, err.Error())
+		return
+	}
+
+	response.SuccessWithMsg(ctx, "解锁成功", nil)
+}
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"github.com/force-c/nai-tizi/internal/container"
+	"github.com/force-c/nai-tizi/internal/domain/request"
+	"github.com/force-c/nai-tizi/internal/domain/response"
+	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// LoginRiskController 异常登录处理控制器接口
+type LoginRiskController interface {
+	Revoke(c *gin.Context) // "不是我本人"：注销异常登录的会话
+}
+
+type loginRiskController struct {
+	ctr              container.Container
+	loginRiskService service.LoginRiskService
+}
+
+func NewLoginRiskController(c container.Container) LoginRiskController {
+	tokenManager := service.NewTokenManager(c.GetJWT(), c.GetRedis(), c.GetLogger())
+	return &loginRiskController{
+		ctr: c,
+		loginRiskService: service.NewLoginRiskService(
+			c.GetDB(), c.GetRedis(), tokenManager, c.GetGeoIP(), c.GetEmail(), c.GetWebSocketHub(), c.GetConfig(), c.GetLogger(),
+		),
+	}
+}
+
+// Revoke 注销异常登录的会话
+//
+//	@Summary		注销异常登录的会话
+//	@Description	用户收到异常登录提醒后，通过邮件或站内消息中的"不是我本人"链接注销对应会话，并将该设备移出已知设备。
+//	@Description	链接中的 token 只能使用一次，有效期由 auth.loginRisk.revokeTokenExpire 配置；注销后建议用户立即修改密码
+//	@Tags			认证
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		request.LoginRiskRevokeRequest	true	"提醒链接中的 token"
+//	@Success		200		{object}	response.Response
+//	@Router			/auth/login-risk/revoke [post]
+func (h *loginRiskController) Revoke(c *gin.Context) {
+	var req request.LoginRiskRevokeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+
+	if err := h.loginRiskService.Revoke(c.Request.Context(), req.Token); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, "该登录已注销，请尽快修改密码")
+}
@@ -55,7 +55,7 @@ func (c *menuController) GetUserMenuTree(ctx *gin.Context) {
 		return
 	}
 
-	tree, err := c.menuService.GetUserMenuTree(userIdInt64)
+	tree, err := c.menuService.GetUserMenuTree(ctx.Request.Context(), userIdInt64)
 	if err != nil {
 		response.FailCode(ctx, response.CodeServerError, err.Error())
 		return
@@ -0,0 +1,253 @@
+package controller
+
+import (
+	"github.com/force-c/nai-tizi/internal/container"
+	"github.com/force-c/nai-tizi/internal/domain/request"
+	"github.com/force-c/nai-tizi/internal/domain/response"
+	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/force-c/nai-tizi/internal/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// MfaController 两步验证控制器接口
+type MfaController interface {
+	GetStatus(c *gin.Context)               // 查询当前用户的两步验证状态
+	Enroll(c *gin.Context)                  // 开始绑定 TOTP
+	ConfirmEnroll(c *gin.Context)           // 校验动态码完成绑定
+	Disable(c *gin.Context)                 // 关闭两步验证
+	RegenerateRecoveryCodes(c *gin.Context) // 重新生成恢复码
+	TicketEnroll(c *gin.Context)            // 登录过程中使用票据绑定 TOTP
+	ResetUserMfa(c *gin.Context)            // 管理员重置用户的两步验证
+}
+
+type mfaController struct {
+	ctr        container.Container
+	base       *BaseController
+	mfaService service.MfaService
+}
+
+func NewMfaController(c container.Container) MfaController {
+	return &mfaController{
+		ctr:        c,
+		base:       NewBaseController(c),
+		mfaService: service.NewMfaService(c.GetDB(), c.GetRedis(), c.GetConfig(), c.GetLogger()),
+	}
+}
+
+// GetStatus 查询当前用户的两步验证状态
+//
+//	@Summary		查询两步验证状态
+//	@Description	查询当前用户是否已启用两步验证、所属角色是否强制启用以及剩余恢复码数量
+//	@Tags			两步验证
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Success		200	{object}	response.Response{data=response.MfaStatusResponse}
+//	@Router			/api/v1/auth/mfa [get]
+func (h *mfaController) GetStatus(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	status, err := h.mfaService.Status(c.Request.Context(), userId)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	response.Success(c, &response.MfaStatusResponse{
+		Enabled:                status.Enabled,
+		Required:               status.Required,
+		RecoveryCodesRemaining: status.RecoveryCodesRemaining,
+	})
+}
+
+// Enroll 开始绑定 TOTP
+//
+//	@Summary		开始绑定两步验证
+//	@Description	生成 TOTP 密钥和 otpauth 绑定地址，使用认证器 App 扫码后调用确认接口完成绑定
+//	@Tags			两步验证
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Success		200	{object}	response.Response{data=response.MfaEnrollResponse}
+//	@Router			/api/v1/auth/mfa/totp/enroll [post]
+func (h *mfaController) Enroll(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	enrollment, err := h.mfaService.BeginEnroll(c.Request.Context(), userId)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	response.Success(c, &response.MfaEnrollResponse{
+		Secret:     enrollment.Secret,
+		OtpauthUri: enrollment.OtpauthUri,
+	})
+}
+
+// ConfirmEnroll 校验动态码完成绑定
+//
+//	@Summary		确认绑定两步验证
+//	@Description	校验认证器 App 生成的动态码，成功后启用两步验证并返回一次性恢复码
+//	@Tags			两步验证
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			body	body		request.MfaCodeRequest	true	"动态验证码"
+//	@Success		200		{object}	response.Response{data=response.MfaRecoveryCodesResponse}
+//	@Router			/api/v1/auth/mfa/totp/confirm [post]
+func (h *mfaController) ConfirmEnroll(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	var req request.MfaCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+
+	codes, err := h.mfaService.ConfirmEnroll(c.Request.Context(), userId, req.Code)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	response.Success(c, &response.MfaRecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// Disable 关闭两步验证
+//
+//	@Summary		关闭两步验证
+//	@Description	校验动态码或恢复码后关闭两步验证；所属角色强制启用时不可关闭
+//	@Tags			两步验证
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			body	body		request.MfaCodeRequest	true	"动态验证码或恢复码"
+//	@Success		200		{object}	response.Response
+//	@Router			/api/v1/auth/mfa/totp/disable [post]
+func (h *mfaController) Disable(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	var req request.MfaCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+
+	if err := h.mfaService.Disable(c.Request.Context(), userId, req.Code); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	response.Success(c, "ok")
+}
+
+// RegenerateRecoveryCodes 重新生成恢复码
+//
+//	@Summary		重新生成恢复码
+//	@Description	校验动态码或恢复码后生成新的一组恢复码，旧恢复码全部作废
+//	@Tags			两步验证
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			body	body		request.MfaCodeRequest	true	"动态验证码或恢复码"
+//	@Success		200		{object}	response.Response{data=response.MfaRecoveryCodesResponse}
+//	@Router			/api/v1/auth/mfa/recovery-codes [post]
+func (h *mfaController) RegenerateRecoveryCodes(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	var req request.MfaCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+
+	codes, err := h.mfaService.RegenerateRecoveryCodes(c.Request.Context(), userId, req.Code)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	response.Success(c, &response.MfaRecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// TicketEnroll 登录过程中使用票据绑定 TOTP
+//
+//	@Summary		登录时绑定两步验证
+//	@Description	所属角色强制启用两步验证但尚未绑定时，登录返回 mfa_setup_required=true 和票据；
+//	@Description	使用票据获取绑定信息，扫码后以 grantType=mfa 提交票据和动态码完成绑定并登录
+//	@Tags			两步验证
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		request.MfaTicketEnrollRequest	true	"两步验证票据"
+//	@Success		200		{object}	response.Response{data=response.MfaEnrollResponse}
+//	@Router			/auth/mfa/enroll [post]
+func (h *mfaController) TicketEnroll(c *gin.Context) {
+	var req request.MfaTicketEnrollRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+
+	enrollment, err := h.mfaService.BeginTicketEnroll(c.Request.Context(), req.MfaTicket)
+	if err != nil {
+		response.FailCode(c, response.CodeUnauthorized, err.Error())
+		return
+	}
+
+	response.Success(c, &response.MfaEnrollResponse{
+		Secret:     enrollment.Secret,
+		OtpauthUri: enrollment.OtpauthUri,
+	})
+}
+
+// ResetUserMfa 管理员重置用户的两步验证
+//
+//	@Summary		重置用户两步验证
+//	@Description	管理员清除指定用户的 TOTP 绑定和恢复码（用户丢失认证器时使用）
+//	@Tags			两步验证
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			id	path		int	true	"用户ID"
+//	@Success		200	{object}	response.Response
+//	@Router			/api/v1/user/{id}/mfa [delete]
+func (h *mfaController) ResetUserMfa(c *gin.Context) {
+	userId, err := utils.ParseInt64Param(c, "id", "required")
+	if err != nil {
+		response.FailCode(c, response.CodeInvalidParam, err.Error())
+		return
+	}
+
+	if err := h.mfaService.Reset(c.Request.Context(), userId); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	operatorId, _ := h.base.GetUserId(c)
+	h.ctr.GetLogger().Info("管理员重置两步验证",
+		zap.Int64("operatorId", operatorId),
+		zap.Int64("userId", userId))
+	response.Success(c, "ok")
+}
@@ -0,0 +1,462 @@
+package controller
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/force-c/nai-tizi/internal/container"
+	"github.com/force-c/nai-tizi/internal/domain/request"
+	"github.com/force-c/nai-tizi/internal/domain/response"
+	"github.com/force-c/nai-tizi/internal/infrastructure/jwt"
+	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// OAuthController OAuth2 授权服务控制器接口
+// /oauth/* 为标准协议端点（原样输出 RFC 规定的 JSON），/api/v1/oauth/* 为授权确认页和授权管理接口
+type OAuthController interface {
+	AuthorizeEndpoint(c *gin.Context) // 授权端点，跳转前端授权确认页
+	GetConsent(c *gin.Context)        // 获取授权确认页信息
+	Authorize(c *gin.Context)         // 用户确认或拒绝授权
+	Token(c *gin.Context)             // 令牌端点
+	Introspect(c *gin.Context)        // 令牌内省（RFC 7662）
+	Revoke(c *gin.Context)            // 令牌撤销（RFC 7009）
+	UserInfo(c *gin.Context)          // 用户信息端点
+	Metadata(c *gin.Context)          // 授权服务元数据（RFC 8414）
+	Jwks(c *gin.Context)              // Token 验证公钥（RFC 7517）
+	ListConsents(c *gin.Context)      // 查询我授权的应用
+	RevokeConsent(c *gin.Context)     // 撤销对应用的授权
+}
+
+type oauthController struct {
+	ctr          container.Container
+	base         *BaseController
+	oauthService service.OAuthService
+}
+
+func NewOAuthController(c container.Container) OAuthController {
+	return &oauthController{
+		ctr:          c,
+		base:         NewBaseController(c),
+		oauthService: service.NewOAuthService(c.GetDB(), c.GetRedis(), c.GetJWT(), c.GetConfig(), c.GetLogger()),
+	}
+}
+
+// AuthorizeEndpoint 授权端点
+//
+//	@Summary		OAuth2 授权端点
+//	@Description	第三方应用将用户重定向到此地址，服务端携带原始查询参数跳转到前端授权确认页（配置 oauth.consentPage）；
+//	@Description	用户未登录时由前端先完成登录
+//	@Tags			OAuth2
+//	@Param			query	query	request.OAuthAuthorizeRequest	true	"授权请求参数"
+//	@Success		302
+//	@Router			/oauth/authorize [get]
+func (h *oauthController) AuthorizeEndpoint(c *gin.Context) {
+	consentPage := h.ctr.GetConfig().OAuth.ConsentPage
+	if consentPage == "" {
+		c.JSON(500, &response.OAuthErrorResponse{Error: service.OAuthErrServerError, ErrorDescription: "未配置授权确认页"})
+		return
+	}
+	target, err := url.Parse(consentPage)
+	if err != nil {
+		c.JSON(500, &response.OAuthErrorResponse{Error: service.OAuthErrServerError, ErrorDescription: "授权确认页地址无效"})
+		return
+	}
+	target.RawQuery = c.Request.URL.RawQuery
+	c.Redirect(302, target.String())
+}
+
+// GetConsent 获取授权确认页信息
+//
+//	@Summary		获取授权确认页信息
+//	@Description	第三方应用将用户重定向到前端授权页，前端携带原始查询参数调用本接口校验请求并展示申请的授权范围；
+//	@Description	approved=true 表示用户此前已同意（或客户端免确认），前端可直接提交确认
+//	@Tags			OAuth2
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			query	query		request.OAuthAuthorizeRequest	true	"授权请求参数"
+//	@Success		200		{object}	response.Response{data=response.OAuthConsentResponse}
+//	@Router			/api/v1/oauth/authorize [get]
+func (h *oauthController) GetConsent(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	var req request.OAuthAuthorizeRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+
+	info, err := h.oauthService.PrepareConsent(c.Request.Context(), userId, &req)
+	if err != nil {
+		h.consentError(c, err)
+		return
+	}
+
+	scopes := make([]response.OAuthScopeResponse, 0, len(info.Scopes))
+	for _, scope := range info.Scopes {
+		scopes = append(scopes, response.OAuthScopeResponse{Scope: scope, Description: service.OAuthScopeDescription(scope)})
+	}
+	clientName := info.Client.ClientName
+	if clientName == "" {
+		clientName = info.Client.ClientKey
+	}
+	response.Success(c, &response.OAuthConsentResponse{
+		ClientId:    info.Client.ClientKey,
+		ClientName:  clientName,
+		RedirectUri: info.RedirectUri,
+		Scopes:      scopes,
+		Approved:    info.Approved,
+	})
+}
+
+// Authorize 用户确认或拒绝授权
+//
+//	@Summary		确认授权
+//	@Description	用户同意后生成一次性授权码，返回携带 code 和 state 的回调地址；拒绝时回调地址携带 error=access_denied。前端收到后直接跳转
+//	@Tags			OAuth2
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			body	body		request.OAuthConsentRequest	true	"授权请求参数及确认结果"
+//	@Success		200		{object}	response.Response{data=response.OAuthAuthorizeResponse}
+//	@Router			/api/v1/oauth/authorize [post]
+func (h *oauthController) Authorize(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	var req request.OAuthConsentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+
+	redirectUri, err := h.oauthService.Authorize(c.Request.Context(), userId, &req)
+	if err != nil {
+		h.consentError(c, err)
+		return
+	}
+
+	response.Success(c, &response.OAuthAuthorizeResponse{RedirectUri: redirectUri})
+}
+
+// Token 令牌端点
+//
+//	@Summary		OAuth2 令牌端点
+//	@Description	支持 authorization_code（必须携带 PKCE code_verifier）、client_credentials、refresh_token；
+//	@Description	客户端认证使用 HTTP Basic（client_secret_basic）或表单参数（client_secret_post），client_id 即 clientKey
+//	@Tags			OAuth2
+//	@Accept			x-www-form-urlencoded
+//	@Produce		json
+//	@Param			body	formData	request.OAuthTokenRequest	true	"令牌请求参数"
+//	@Success		200		{object}	response.OAuthTokenResponse
+//	@Failure		400		{object}	response.OAuthErrorResponse
+//	@Failure		401		{object}	response.OAuthErrorResponse
+//	@Router			/oauth/token [post]
+func (h *oauthController) Token(c *gin.Context) {
+	var req request.OAuthTokenRequest
+	if err := c.ShouldBind(&req); err != nil {
+		h.protocolError(c, &service.OAuthError{Code: service.OAuthErrInvalidRequest, Description: "grant_type 不能为空"})
+		return
+	}
+	clientKey, clientSecret := clientCredentials(c, req.ClientId, req.ClientSecret)
+
+	token, err := h.oauthService.Token(c.Request.Context(), clientKey, clientSecret, &req)
+	if err != nil {
+		h.protocolError(c, err)
+		return
+	}
+
+	noStore(c)
+	c.JSON(200, &response.OAuthTokenResponse{
+		AccessToken:  token.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    token.ExpiresIn,
+		RefreshToken: token.RefreshToken,
+		Scope:        token.Scope,
+	})
+}
+
+// Introspect 令牌内省
+//
+//	@Summary		OAuth2 令牌内省
+//	@Description	RFC 7662，资源服务使用自身客户端凭证查询令牌是否有效；刷新令牌仅对其所属客户端可见
+//	@Tags			OAuth2
+//	@Accept			x-www-form-urlencoded
+//	@Produce		json
+//	@Param			body	formData	request.OAuthTokenHintRequest	true	"待内省的令牌"
+//	@Success		200		{object}	response.OAuthIntrospectionResponse
+//	@Failure		401		{object}	response.OAuthErrorResponse
+//	@Router			/oauth/introspect [post]
+func (h *oauthController) Introspect(c *gin.Context) {
+	var req request.OAuthTokenHintRequest
+	if err := c.ShouldBind(&req); err != nil {
+		h.protocolError(c, &service.OAuthError{Code: service.OAuthErrInvalidRequest, Description: "token 不能为空"})
+		return
+	}
+	clientKey, clientSecret := clientCredentials(c, req.ClientId, req.ClientSecret)
+
+	result, err := h.oauthService.Introspect(c.Request.Context(), clientKey, clientSecret, req.Token, req.TokenTypeHint)
+	if err != nil {
+		h.protocolError(c, err)
+		return
+	}
+
+	noStore(c)
+	c.JSON(200, &response.OAuthIntrospectionResponse{
+		Active:    result.Active,
+		Scope:     result.Scope,
+		ClientId:  result.ClientKey,
+		Username:  result.Username,
+		TokenType: result.TokenType,
+		Exp:       result.ExpiresAt,
+		Iat:       result.IssuedAt,
+		Sub:       result.Subject,
+		Iss:       result.Issuer,
+		Jti:       result.Jti,
+	})
+}
+
+// Revoke 令牌撤销
+//
+//	@Summary		OAuth2 令牌撤销
+//	@Description	RFC 7009，客户端撤销自己持有的访问令牌或刷新令牌；令牌无效或不属于该客户端时同样返回 200
+//	@Tags			OAuth2
+//	@Accept			x-www-form-urlencoded
+//	@Produce		json
+//	@Param			body	formData	request.OAuthTokenHintRequest	true	"待撤销的令牌"
+//	@Success		200
+//	@Failure		401	{object}	response.OAuthErrorResponse
+//	@Router			/oauth/revoke [post]
+func (h *oauthController) Revoke(c *gin.Context) {
+	var req request.OAuthTokenHintRequest
+	if err := c.ShouldBind(&req); err != nil {
+		h.protocolError(c, &service.OAuthError{Code: service.OAuthErrInvalidRequest, Description: "token 不能为空"})
+		return
+	}
+	clientKey, clientSecret := clientCredentials(c, req.ClientId, req.ClientSecret)
+
+	if err := h.oauthService.Revoke(c.Request.Context(), clientKey, clientSecret, req.Token, req.TokenTypeHint); err != nil {
+		h.protocolError(c, err)
+		return
+	}
+	c.Status(200)
+}
+
+// UserInfo 用户信息端点
+//
+//	@Summary		OAuth2 用户信息
+//	@Description	使用 OAuth2 访问令牌查询授权用户信息，返回字段随授予的范围（profile/email/phone）变化
+//	@Tags			OAuth2
+//	@Produce		json
+//	@Security		Bearer
+//	@Success		200	{object}	object{sub=string,preferred_username=string,name=string,picture=string,email=string,phone_number=string}
+//	@Failure		401	{object}	response.OAuthErrorResponse
+//	@Router			/oauth/userinfo [get]
+func (h *oauthController) UserInfo(c *gin.Context) {
+	token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if token == "" {
+		c.Header("WWW-Authenticate", `Bearer error="invalid_request"`)
+		c.JSON(401, &response.OAuthErrorResponse{Error: service.OAuthErrInvalidRequest, ErrorDescription: "缺少访问令牌"})
+		return
+	}
+
+	user, scope, err := h.oauthService.UserInfo(c.Request.Context(), token)
+	if err != nil {
+		c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+		c.JSON(401, &response.OAuthErrorResponse{Error: "invalid_token", ErrorDescription: err.Error()})
+		return
+	}
+
+	info := gin.H{"sub": strconv.FormatInt(user.ID, 10)}
+	if service.HasOAuthScope(scope, service.OAuthScopeProfile) {
+		info["preferred_username"] = user.UserName
+		info["name"] = user.NickName
+		info["picture"] = user.Avatar
+	}
+	if service.HasOAuthScope(scope, service.OAuthScopeEmail) {
+		info["email"] = user.Email
+	}
+	if service.HasOAuthScope(scope, service.OAuthScopePhone) {
+		info["phone_number"] = user.Phonenumber
+	}
+	noStore(c)
+	c.JSON(200, info)
+}
+
+// Metadata 授权服务元数据
+//
+//	@Summary		OAuth2 授权服务元数据
+//	@Description	RFC 8414，供客户端自动发现各端点地址
+//	@Tags			OAuth2
+//	@Produce		json
+//	@Success		200	{object}	object
+//	@Router			/.well-known/oauth-authorization-server [get]
+func (h *oauthController) Metadata(c *gin.Context) {
+	issuer := strings.TrimSuffix(h.ctr.GetConfig().OAuth.Issuer, "/")
+	if issuer == "" {
+		scheme := "http"
+		if c.Request.TLS != nil {
+			scheme = "https"
+		}
+		if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+			scheme = proto
+		}
+		issuer = scheme + "://" + c.Request.Host
+	}
+
+	metadata := gin.H{
+		"issuer":                                issuer,
+		"authorization_endpoint":                issuer + "/oauth/authorize",
+		"token_endpoint":                        issuer + "/oauth/token",
+		"introspection_endpoint":                issuer + "/oauth/introspect",
+		"revocation_endpoint":                   issuer + "/oauth/revoke",
+		"userinfo_endpoint":                     issuer + "/oauth/userinfo",
+		"response_types_supported":              []string{"code"},
+		"grant_types_supported":                 []string{service.OAuthGrantAuthorizationCode, service.OAuthGrantClientCredentials, service.OAuthGrantRefreshToken},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"scopes_supported":                      []string{service.OAuthScopeProfile, service.OAuthScopeEmail, service.OAuthScopePhone, service.OAuthScopeApi},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post"},
+	}
+	if jwt.IsAsymmetric(h.ctr.GetConfig().JWT.Algorithm) {
+		metadata["jwks_uri"] = issuer + "/.well-known/jwks.json"
+	}
+	c.JSON(200, metadata)
+}
+
+// Jwks Token 验证公钥
+//
+//	@Summary		JWKS 公钥集合
+//	@Description	RFC 7517，返回当前用于验证访问令牌的公钥（含轮换重叠期内的旧密钥和即将启用的新密钥）；
+//	@Description	使用 HS256 签名时返回空集合
+//	@Tags			OAuth2
+//	@Produce		json
+//	@Success		200	{object}	jwt.JSONWebKeySet
+//	@Router			/.well-known/jwks.json [get]
+func (h *oauthController) Jwks(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(200, h.ctr.GetJWT().Keyring().JWKS())
+}
+
+// ListConsents 查询我授权的应用
+//
+//	@Summary		查询我授权的应用
+//	@Description	列出当前用户已授权的第三方应用及授予的范围
+//	@Tags			OAuth2
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Success		200	{object}	response.Response{data=[]response.OAuthConsentGrantResponse}
+//	@Router			/api/v1/oauth/consents [get]
+func (h *oauthController) ListConsents(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	grants, err := h.oauthService.ListConsents(c.Request.Context(), userId)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	result := make([]response.OAuthConsentGrantResponse, 0, len(grants))
+	for _, grant := range grants {
+		result = append(result, response.OAuthConsentGrantResponse{
+			ClientId:    grant.ClientKey,
+			ClientName:  grant.ClientName,
+			Scopes:      grant.Scopes,
+			CreatedTime: grant.CreatedTime,
+			UpdatedTime: grant.UpdatedTime,
+		})
+	}
+	response.Success(c, result)
+}
+
+// RevokeConsent 撤销对应用的授权
+//
+//	@Summary		撤销应用授权
+//	@Description	撤销当前用户对指定应用的授权，该应用持有的刷新令牌立即失效，下次登录需重新确认授权
+//	@Tags			OAuth2
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			clientId	path		string	true	"客户端Key"
+//	@Success		200			{object}	response.Response
+//	@Router			/api/v1/oauth/consents/{clientId} [delete]
+func (h *oauthController) RevokeConsent(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+	clientKey := c.Param("clientId")
+	if clientKey == "" {
+		response.FailCode(c, response.CodeInvalidParam, "客户端ID不能为空")
+		return
+	}
+
+	if err := h.oauthService.RevokeConsent(c.Request.Context(), userId, clientKey); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, "ok")
+}
+
+// consentError 授权确认页错误：请求本身不合法时不回调第三方，直接由前端展示
+func (h *oauthController) consentError(c *gin.Context, err error) {
+	var oauthErr *service.OAuthError
+	if errors.As(err, &oauthErr) && oauthErr.Code != service.OAuthErrServerError {
+		response.FailCode(c, response.CodeInvalidParam, oauthErr.Description)
+		return
+	}
+	response.FailWithMsg(c, err.Error())
+}
+
+// protocolError 按 RFC 6749 5.2 输出错误
+func (h *oauthController) protocolError(c *gin.Context, err error) {
+	var oauthErr *service.OAuthError
+	if !errors.As(err, &oauthErr) {
+		h.ctr.GetLogger().Error("OAuth2 请求处理失败", zap.Error(err))
+		oauthErr = &service.OAuthError{Code: service.OAuthErrServerError, Description: "服务器内部错误"}
+	}
+	if oauthErr.Code == service.OAuthErrInvalidClient {
+		c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+	}
+	noStore(c)
+	c.JSON(oauthErr.StatusCode(), &response.OAuthErrorResponse{
+		Error:            oauthErr.Code,
+		ErrorDescription: oauthErr.Description,
+	})
+}
+
+// clientCredentials 读取客户端凭证，优先使用 HTTP Basic 认证（凭证按 RFC 6749 2.3.1 进行表单编码）
+func clientCredentials(c *gin.Context, formClientId, formClientSecret string) (string, string) {
+	if key, secret, ok := c.Request.BasicAuth(); ok {
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+		if unescaped, err := url.QueryUnescape(secret); err == nil {
+			secret = unescaped
+		}
+		return key, secret
+	}
+	return formClientId, formClientSecret
+}
+
+// noStore 令牌相关响应禁止缓存
+func noStore(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+}
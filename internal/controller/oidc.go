@@ -0,0 +1,240 @@
+package controller
+
+import (
+	"github.com/force-c/nai-tizi/internal/container"
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/domain/request"
+	"github.com/force-c/nai-tizi/internal/domain/response"
+	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/force-c/nai-tizi/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// OidcController 第三方 OIDC 登录控制器接口
+type OidcController interface {
+	Providers(c *gin.Context)      // 查询可用的提供方（公开）
+	Authorize(c *gin.Context)      // 获取登录授权地址（公开）
+	ListIdentities(c *gin.Context) // 查询我绑定的第三方账号
+	LinkAuthorize(c *gin.Context)  // 获取绑定授权地址
+	Link(c *gin.Context)           // 完成绑定
+	UnlinkIdentity(c *gin.Context) // 解除绑定
+}
+
+type oidcController struct {
+	ctr           container.Container
+	base          *BaseController
+	clientService service.ClientService
+	oidcService   service.OidcService
+}
+
+func NewOidcController(c container.Container) OidcController {
+	return &oidcController{
+		ctr:           c,
+		base:          NewBaseController(c),
+		clientService: service.NewClientService(c.GetDB(), c.GetRedis(), c.GetLogger()),
+		oidcService:   newOidcService(c),
+	}
+}
+
+// newOidcService 创建第三方登录服务（自动创建用户时需分配角色）
+func newOidcService(c container.Container) service.OidcService {
+	casbinService := service.NewCasbinServiceV2(c.GetCasbin(), c.GetDB(), c.GetLogger(), c.GetConfig())
+	roleService := service.NewRoleService(c.GetDB(), casbinService, c.GetLogger())
+	return service.NewOidcService(c.GetDB(), c.GetRedis(), c.GetOIDC(), roleService, c.GetConfig(), c.GetLogger())
+}
+
+// Providers 查询可用的第三方登录提供方
+//
+//	@Summary		查询第三方登录提供方
+//	@Description	返回已配置的 OIDC 提供方，用于登录页展示第三方登录按钮
+//	@Tags			第三方登录
+//	@Produce		json
+//	@Success		200	{object}	response.Response{data=[]response.OidcProviderResponse}
+//	@Router			/auth/oidc/providers [get]
+func (h *oidcController) Providers(c *gin.Context) {
+	providers := h.oidcService.Providers()
+	result := make([]*response.OidcProviderResponse, 0, len(providers))
+	for _, p := range providers {
+		result = append(result, &response.OidcProviderResponse{Name: p.Name, DisplayName: p.DisplayName})
+	}
+	response.Success(c, result)
+}
+
+// Authorize 获取第三方登录授权地址
+//
+//	@Summary		获取第三方登录授权地址
+//	@Description	前端跳转到 authorizeUrl，提供方回调后以 grantType=oidc 提交 code 和 state 完成登录；客户端需开通 oidc 授权类型
+//	@Tags			第三方登录
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		request.OidcAuthorizeRequest	true	"客户端信息及提供方"
+//	@Success		200		{object}	response.Response{data=response.OidcAuthorizeResponse}
+//	@Router			/auth/oidc/authorize [post]
+func (h *oidcController) Authorize(c *gin.Context) {
+	var req request.OidcAuthorizeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+	ctx := c.Request.Context()
+
+	if _, err := h.clientService.AuthenticateClient(ctx, req.ClientKey, req.ClientSecret, "oidc"); err != nil {
+		response.FailCode(c, response.CodeUnauthorized, err.Error())
+		return
+	}
+
+	authorizeUrl, state, err := h.oidcService.BeginLogin(ctx, req.Provider, req.ClientKey)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, &response.OidcAuthorizeResponse{AuthorizeUrl: authorizeUrl, State: state})
+}
+
+// ListIdentities 查询我绑定的第三方账号
+//
+//	@Summary		查询我绑定的第三方账号
+//	@Description	列出当前用户已绑定的 OIDC 身份
+//	@Tags			第三方登录
+//	@Produce		json
+//	@Security		Bearer
+//	@Success		200	{object}	response.Response{data=[]response.UserIdentityResponse}
+//	@Router			/api/v1/auth/oidc/identities [get]
+func (h *oidcController) ListIdentities(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	identities, err := h.oidcService.ListIdentities(c.Request.Context(), userId)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	displayNames := make(map[string]string)
+	for _, p := range h.oidcService.Providers() {
+		displayNames[p.Name] = p.DisplayName
+	}
+	result := make([]*response.UserIdentityResponse, 0, len(identities))
+	for i := range identities {
+		result = append(result, toUserIdentityResponse(&identities[i], displayNames))
+	}
+	response.Success(c, result)
+}
+
+// LinkAuthorize 获取绑定第三方账号的授权地址
+//
+//	@Summary		获取绑定授权地址
+//	@Description	前端跳转到 authorizeUrl，提供方回调后提交 code 和 state 到 /api/v1/auth/oidc/link 完成绑定
+//	@Tags			第三方登录
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			body	body		request.OidcLinkAuthorizeRequest	true	"提供方"
+//	@Success		200		{object}	response.Response{data=response.OidcAuthorizeResponse}
+//	@Router			/api/v1/auth/oidc/link/authorize [post]
+func (h *oidcController) LinkAuthorize(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	var req request.OidcLinkAuthorizeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+
+	authorizeUrl, state, err := h.oidcService.BeginLink(c.Request.Context(), userId, req.Provider)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, &response.OidcAuthorizeResponse{AuthorizeUrl: authorizeUrl, State: state})
+}
+
+// Link 完成绑定第三方账号
+//
+//	@Summary		完成绑定第三方账号
+//	@Description	校验提供方返回的 ID Token 后将第三方身份绑定到当前用户
+//	@Tags			第三方登录
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			body	body		request.OidcLinkRequest	true	"授权码及 state"
+//	@Success		200		{object}	response.Response{data=response.UserIdentityResponse}
+//	@Router			/api/v1/auth/oidc/link [post]
+func (h *oidcController) Link(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	var req request.OidcLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+
+	identity, err := h.oidcService.FinishLink(c.Request.Context(), userId, req.State, req.Code)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	displayNames := make(map[string]string)
+	for _, p := range h.oidcService.Providers() {
+		displayNames[p.Name] = p.DisplayName
+	}
+	response.Success(c, toUserIdentityResponse(identity, displayNames))
+}
+
+// UnlinkIdentity 解除绑定第三方账号
+//
+//	@Summary		解除绑定第三方账号
+//	@Description	解除后无法再通过该第三方账号登录；未设置密码的账号不能解除唯一的绑定
+//	@Tags			第三方登录
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			id	path		int	true	"绑定记录ID"
+//	@Success		200	{object}	response.Response
+//	@Router			/api/v1/auth/oidc/identities/{id} [delete]
+func (h *oidcController) UnlinkIdentity(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+	identityId, err := utils.ParseInt64Param(c, "id", "required")
+	if err != nil {
+		response.FailCode(c, response.CodeInvalidParam, err.Error())
+		return
+	}
+
+	if err := h.oidcService.Unlink(c.Request.Context(), userId, identityId); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, "ok")
+}
+
+// toUserIdentityResponse 转换第三方身份响应
+func toUserIdentityResponse(identity *model.UserIdentity, displayNames map[string]string) *response.UserIdentityResponse {
+	displayName := displayNames[identity.Provider]
+	if displayName == "" {
+		displayName = identity.Provider
+	}
+	return &response.UserIdentityResponse{
+		Id:          identity.ID,
+		Provider:    identity.Provider,
+		DisplayName: displayName,
+		Email:       identity.Email,
+		Nickname:    identity.Nickname,
+		LastLoginAt: identity.LastLoginAt,
+		CreatedTime: identity.CreatedTime,
+	}
+}
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"github.com/force-c/nai-tizi/internal/container"
+	"github.com/force-c/nai-tizi/internal/domain/request"
+	"github.com/force-c/nai-tizi/internal/domain/response"
+	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/force-c/nai-tizi/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// PasswordResetController 找回密码控制器接口
+type PasswordResetController interface {
+	Forgot(c *gin.Context) // 申请找回密码
+	Verify(c *gin.Context) // 校验邮件链接或短信验证码
+	Reset(c *gin.Context)  // 设置新密码
+}
+
+type passwordResetController struct {
+	ctr                  container.Container
+	passwordResetService service.PasswordResetService
+}
+
+func NewPasswordResetController(c container.Container) PasswordResetController {
+	tokenManager := service.NewTokenManager(c.GetJWT(), c.GetRedis(), c.GetLogger())
+	return &passwordResetController{
+		ctr: c,
+		passwordResetService: service.NewPasswordResetService(
+			c.GetDB(), c.GetRedis(), tokenManager, c.GetEmail(), c.GetSMS(), c.GetConfig(), c.GetLogger(),
+		),
+	}
+}
+
+// Forgot 申请找回密码
+//
+//	@Summary		申请找回密码
+//	@Description	向账号绑定的邮箱发送重置链接，或向手机号发送验证码；账号是否存在均返回成功。
+//	@Description	同一账号 60 秒内只能申请一次，同一 IP 每小时最多 10 次
+//	@Tags			找回密码
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		request.PasswordForgotRequest	true	"找回方式和账号"
+//	@Success		200		{object}	response.Response
+//	@Router			/auth/password/forgot [post]
+func (h *passwordResetController) Forgot(c *gin.Context) {
+	var req request.PasswordForgotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+
+	if err := h.passwordResetService.Request(c.Request.Context(), req.Channel, req.Account, utils.GetClientIP(c)); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, "如果账号存在，重置信息已发送，请注意查收")
+}
+
+// Verify 校验找回密码凭证
+//
+//	@Summary		校验找回密码凭证
+//	@Description	校验邮件链接中的 token 或短信验证码，返回设置新密码使用的重置令牌；短信验证码最多尝试 5 次
+//	@Tags			找回密码
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		request.PasswordResetVerifyRequest	true	"凭证"
+//	@Success		200		{object}	response.Response{data=response.PasswordResetVerifyResponse}
+//	@Router			/auth/password/verify [post]
+func (h *passwordResetController) Verify(c *gin.Context) {
+	var req request.PasswordResetVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+	if req.Channel == service.PasswordResetChannelSms && req.Account == "" {
+		response.FailCode(c, response.CodeInvalidParam, "手机号不能为空")
+		return
+	}
+
+	token, err := h.passwordResetService.Verify(c.Request.Context(), req.Channel, req.Account, req.Code)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, &response.PasswordResetVerifyResponse{ResetToken: token})
+}
+
+// Reset 设置新密码
+//
+//	@Summary		设置新密码
+//	@Description	使用重置令牌设置新密码，新密码需符合密码策略；成功后令牌失效，该用户所有已登录会话被吊销
+//	@Tags			找回密码
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		request.PasswordResetRequest	true	"重置令牌和新密码"
+//	@Success		200		{object}	response.Response
+//	@Router			/auth/password/reset [post]
+func (h *passwordResetController) Reset(c *gin.Context) {
+	var req request.PasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+
+	if err := h.passwordResetService.Reset(c.Request.Context(), req.ResetToken, req.NewPassword); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, "密码已重置，请重新登录")
+}
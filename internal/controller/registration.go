@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"github.com/force-c/nai-tizi/internal/constants"
+	"github.com/force-c/nai-tizi/internal/container"
+	"github.com/force-c/nai-tizi/internal/domain/request"
+	"github.com/force-c/nai-tizi/internal/domain/response"
+	"github.com/force-c/nai-tizi/internal/infrastructure/captcha"
+	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/force-c/nai-tizi/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// RegistrationController 自助注册控制器接口
+type RegistrationController interface {
+	Options(c *gin.Context)       // 注册页面选项
+	SendEmailCode(c *gin.Context) // 发送注册邮箱验证码
+	Register(c *gin.Context)      // 自助注册
+	Approve(c *gin.Context)       // 审批自助注册用户
+}
+
+type registrationController struct {
+	ctr                 container.Container
+	base                *BaseController
+	registrationService service.RegistrationService
+}
+
+func NewRegistrationController(c container.Container) RegistrationController {
+	casbinService := service.NewCasbinServiceV2(c.GetCasbin(), c.GetDB(), c.GetLogger(), c.GetConfig())
+	roleService := service.NewRoleService(c.GetDB(), casbinService, c.GetLogger())
+	return &registrationController{
+		ctr:  c,
+		base: NewBaseController(c),
+		registrationService: service.NewRegistrationService(
+			c.GetDB(), c.GetRedis(), c.GetCaptchaManager(), c.GetEmail(), roleService, c.GetLogger(),
+		),
+	}
+}
+
+// Options 注册页面选项
+//
+//	@Summary		注册页面选项
+//	@Description	返回当前租户是否开放注册、支持的注册方式、允许的邮箱域名以及是否需要图形验证码
+//	@Tags			自助注册
+//	@Produce		json
+//	@Success		200	{object}	response.Response{data=response.RegistrationOptionsResponse}
+//	@Router			/auth/register/options [get]
+func (h *registrationController) Options(c *gin.Context) {
+	settings, err := h.registrationService.Settings(c.Request.Context())
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	captchaManager := h.ctr.GetCaptchaManager()
+	response.Success(c, &response.RegistrationOptionsResponse{
+		Enabled:        settings.Enabled,
+		OpenSignup:     settings.Enabled && settings.OpenSignup,
+		InviteSignup:   settings.Enabled && settings.InviteSignup,
+		AllowedDomains: settings.AllowedDomains,
+		Captcha:        settings.Captcha && captchaManager != nil && captchaManager.IsEnabled(captcha.CaptchaTypeImage),
+	})
+}
+
+// SendEmailCode 发送注册邮箱验证码
+//
+//	@Summary		发送注册邮箱验证码
+//	@Description	向注册邮箱发送 6 位验证码，10 分钟内有效；同一邮箱 60 秒内只能发送一次，同一 IP 每小时最多 20 次（与注册共用）
+//	@Tags			自助注册
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		request.RegisterEmailCodeRequest	true	"邮箱和图形验证码"
+//	@Success		200		{object}	response.Response
+//	@Router			/auth/register/email-code [post]
+func (h *registrationController) SendEmailCode(c *gin.Context) {
+	var req request.RegisterEmailCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+
+	if err := h.registrationService.SendEmailCode(c.Request.Context(), &req, utils.GetClientIP(c)); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, "验证码已发送，请注意查收")
+}
+
+// Register 自助注册
+//
+//	@Summary		自助注册
+//	@Description	填写邀请码时加入邀请码绑定的组织并获得其默认角色；否则为开放注册，必须填写并验证邮箱，且邮箱域名须在允许列表中。
+//	@Description	填写邮箱时必须提供邮箱验证码。注册到开启审批的组织时账号处于待审批状态，审批通过前不能登录
+//	@Tags			自助注册
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		request.RegisterRequest	true	"注册信息"
+//	@Success		200		{object}	response.Response{data=response.RegisterResponse}
+//	@Router			/auth/register [post]
+func (h *registrationController) Register(c *gin.Context) {
+	var req request.RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+
+	user, err := h.registrationService.Register(c.Request.Context(), &req, utils.GetClientIP(c))
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, &response.RegisterResponse{
+		UserId:   user.ID,
+		UserName: user.UserName,
+		Pending:  user.Status == constants.StatusPending,
+	})
+}
+
+// Approve 审批自助注册用户
+//
+//	@Summary		审批自助注册用户
+//	@Description	审批待审批状态的自助注册用户：通过后可以登录；拒绝将删除该注册，用户名和邮箱可重新注册。
+//	@Description	用户填写了邮箱时会邮件通知审批结果；需要 user.approve 权限
+//	@Tags			用户管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			id		path		int							true	"用户ID"
+//	@Param			body	body		request.ApproveUserRequest	true	"审批结果"
+//	@Success		200		{object}	response.Response
+//	@Router			/api/v1/user/{id}/approve [post]
+func (h *registrationController) Approve(c *gin.Context) {
+	userId, err := utils.ParseInt64Param(c, "id", "required")
+	if err != nil {
+		response.FailCode(c, response.CodeInvalidParam, err.Error())
+		return
+	}
+	var req request.ApproveUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+	operator, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	if err := h.registrationService.Approve(c.Request.Context(), userId, req.Approved, req.Reason, operator); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, nil)
+}
@@ -64,13 +64,15 @@ func (c *roleController) CreateRole(ctx *gin.Context) {
 	userId, _ := ctx.Get("userId")
 
 	role := &model.Role{
-		RoleKey:   req.RoleKey,
-		RoleName:  req.RoleName,
-		Sort:      req.Sort,
-		Status:    req.Status,
-		DataScope: req.DataScope,
-		IsSystem:  false,
-		Remark:    req.Remark,
+		RoleKey:    req.RoleKey,
+		RoleName:   req.RoleName,
+		Sort:       req.Sort,
+		Status:     req.Status,
+		DataScope:  req.DataScope,
+		OrgIds:     req.OrgIds,
+		RequireMfa: req.RequireMfa,
+		IsSystem:   false,
+		Remark:     req.Remark,
 	}
 	role.CreateBy = userId.(int64)
 
@@ -105,12 +107,14 @@ func (c *roleController) UpdateRole(ctx *gin.Context) {
 	userId, _ := ctx.Get("userId")
 
 	role := &model.Role{
-		ID:        req.RoleId,
-		RoleName:  req.RoleName,
-		Sort:      req.Sort,
-		Status:    req.Status,
-		DataScope: req.DataScope,
-		Remark:    req.Remark,
+		ID:         req.RoleId,
+		RoleName:   req.RoleName,
+		Sort:       req.Sort,
+		Status:     req.Status,
+		DataScope:  req.DataScope,
+		OrgIds:     req.OrgIds,
+		RequireMfa: req.RequireMfa,
+		Remark:     req.Remark,
 	}
 	role.UpdateBy = userId.(int64)
 
@@ -386,4 +390,3 @@ func (c *roleController) GetRolePermissions(ctx *gin.Context) {
 
 	response.Success(ctx, permissions)
 }
-
@@ -0,0 +1,270 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/force-c/nai-tizi/internal/container"
+	"github.com/force-c/nai-tizi/internal/domain/response"
+	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/force-c/nai-tizi/internal/utils"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// SessionController 在线会话控制器接口
+type SessionController interface {
+	ListMySessions(c *gin.Context)        // 查询当前用户的会话列表
+	RevokeMySession(c *gin.Context)       // 注销当前用户的指定会话
+	RevokeMyOtherSessions(c *gin.Context) // 注销当前用户的其他会话
+	ListUserSessions(c *gin.Context)      // 管理员查询指定用户的会话列表
+	RevokeUserSession(c *gin.Context)     // 管理员注销指定用户的指定会话
+	RevokeUserSessions(c *gin.Context)    // 管理员注销指定用户的全部会话
+}
+
+type sessionController struct {
+	ctr          container.Container
+	base         *BaseController
+	tokenManager service.TokenManager
+	userService  service.UserService
+}
+
+func NewSessionController(c container.Container) SessionController {
+	return &sessionController{
+		ctr:          c,
+		base:         NewBaseController(c),
+		tokenManager: service.NewTokenManager(c.GetJWT(), c.GetRedis(), c.GetLogger()),
+		userService:  service.NewUserService(c.GetDB(), c.GetLogger()),
+	}
+}
+
+// ListMySessions 查询当前用户的会话列表
+//
+//	@Summary		查询我的在线会话
+//	@Description	列出当前用户所有活跃的登录会话，current=true 表示当前请求所在会话
+//	@Tags			在线会话
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Success		200	{object}	response.Response{data=[]response.SessionResponse}
+//	@Router			/api/v1/auth/sessions [get]
+func (h *sessionController) ListMySessions(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+	currentSessionId, _ := h.base.GetSessionId(c)
+
+	sessions, err := h.tokenManager.ListSessions(c.Request.Context(), userId)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	response.Success(c, toSessionResponses(sessions, currentSessionId))
+}
+
+// RevokeMySession 注销当前用户的指定会话
+//
+//	@Summary		注销我的指定会话
+//	@Description	远程注销当前用户的某个登录会话，该会话的 AccessToken 和 RefreshToken 立即失效
+//	@Tags			在线会话
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			sessionId	path		string	true	"会话ID"
+//	@Success		200			{object}	response.Response
+//	@Router			/api/v1/auth/sessions/{sessionId} [delete]
+func (h *sessionController) RevokeMySession(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+	sessionId := c.Param("sessionId")
+	if sessionId == "" {
+		response.FailCode(c, response.CodeInvalidParam, "会话ID不能为空")
+		return
+	}
+
+	if err := h.tokenManager.RevokeSession(c.Request.Context(), userId, sessionId); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	h.ctr.GetLogger().Info("用户注销会话", zap.Int64("userId", userId), zap.String("sessionId", sessionId))
+	response.Success(c, "ok")
+}
+
+// RevokeMyOtherSessions 注销当前用户的其他会话
+//
+//	@Summary		注销我的其他会话
+//	@Description	保留当前会话，注销当前用户的其余所有登录会话
+//	@Tags			在线会话
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Success		200	{object}	response.Response{data=object{count=int}}
+//	@Router			/api/v1/auth/sessions/others [delete]
+func (h *sessionController) RevokeMyOtherSessions(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+	currentSessionId, err := h.base.GetSessionId(c)
+	if err != nil {
+		response.FailWithMsg(c, "无法识别当前会话，请重新登录后再试")
+		return
+	}
+
+	ctx := c.Request.Context()
+	sessions, err := h.tokenManager.ListSessions(ctx, userId)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	count := 0
+	for _, session := range sessions {
+		if session.SessionId == currentSessionId {
+			continue
+		}
+		if err := h.tokenManager.RevokeSession(ctx, userId, session.SessionId); err != nil {
+			h.ctr.GetLogger().Warn("注销会话失败", zap.String("sessionId", session.SessionId), zap.Error(err))
+			continue
+		}
+		count++
+	}
+
+	h.ctr.GetLogger().Info("用户注销其他会话", zap.Int64("userId", userId), zap.Int("count", count))
+	response.Success(c, gin.H{"count": count})
+}
+
+// ListUserSessions 管理员查询指定用户的会话列表
+//
+//	@Summary		查询用户在线会话
+//	@Description	管理员查询指定用户所有活跃的登录会话，目标用户须在当前租户和数据权限范围内
+//	@Tags			在线会话
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			userId	path		int	true	"用户ID"
+//	@Success		200		{object}	response.Response{data=[]response.SessionResponse}
+//	@Router			/api/v1/session/user/{userId} [get]
+func (h *sessionController) ListUserSessions(c *gin.Context) {
+	userId, ok := h.targetUserId(c)
+	if !ok {
+		return
+	}
+	currentSessionId, _ := h.base.GetSessionId(c)
+
+	sessions, err := h.tokenManager.ListSessions(c.Request.Context(), userId)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	response.Success(c, toSessionResponses(sessions, currentSessionId))
+}
+
+// RevokeUserSession 管理员注销指定用户的指定会话
+//
+//	@Summary		强制下线用户会话
+//	@Description	管理员注销指定用户的某个登录会话，目标用户须在当前租户和数据权限范围内
+//	@Tags			在线会话
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			userId		path		int		true	"用户ID"
+//	@Param			sessionId	path		string	true	"会话ID"
+//	@Success		200			{object}	response.Response
+//	@Router			/api/v1/session/user/{userId}/{sessionId} [delete]
+func (h *sessionController) RevokeUserSession(c *gin.Context) {
+	userId, ok := h.targetUserId(c)
+	if !ok {
+		return
+	}
+	sessionId := c.Param("sessionId")
+	if sessionId == "" {
+		response.FailCode(c, response.CodeInvalidParam, "会话ID不能为空")
+		return
+	}
+
+	if err := h.tokenManager.RevokeSession(c.Request.Context(), userId, sessionId); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	operatorId, _ := h.base.GetUserId(c)
+	h.ctr.GetLogger().Info("管理员强制下线会话",
+		zap.Int64("operatorId", operatorId),
+		zap.Int64("userId", userId),
+		zap.String("sessionId", sessionId))
+	response.Success(c, "ok")
+}
+
+// RevokeUserSessions 管理员注销指定用户的全部会话
+//
+//	@Summary		强制下线用户
+//	@Description	管理员注销指定用户的全部登录会话，该用户此前签发的所有 Token 立即失效；目标用户须在当前租户和数据权限范围内
+//	@Tags			在线会话
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			userId	path		int	true	"用户ID"
+//	@Success		200		{object}	response.Response
+//	@Router			/api/v1/session/user/{userId} [delete]
+func (h *sessionController) RevokeUserSessions(c *gin.Context) {
+	userId, ok := h.targetUserId(c)
+	if !ok {
+		return
+	}
+
+	if err := h.tokenManager.RevokeUserTokens(c.Request.Context(), userId); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	operatorId, _ := h.base.GetUserId(c)
+	h.ctr.GetLogger().Info("管理员强制下线用户",
+		zap.Int64("operatorId", operatorId),
+		zap.Int64("userId", userId))
+	response.Success(c, "ok")
+}
+
+// targetUserId 解析管理员操作的目标用户，目标用户不在当前租户或数据权限范围内时返回 404
+func (h *sessionController) targetUserId(c *gin.Context) (int64, bool) {
+	userId, err := utils.ParseInt64Param(c, "userId", "required")
+	if err != nil {
+		response.FailCode(c, response.CodeInvalidParam, err.Error())
+		return 0, false
+	}
+	if _, err := h.userService.GetById(c.Request.Context(), userId); err != nil {
+		response.NotFound(c, "用户不存在")
+		return 0, false
+	}
+	return userId, true
+}
+
+// toSessionResponses 将会话列表转换为响应结构
+func toSessionResponses(sessions []*service.Session, currentSessionId string) []response.SessionResponse {
+	result := make([]response.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		browser, osName := parseUserAgent(session.UserAgent)
+		result = append(result, response.SessionResponse{
+			SessionId:       session.SessionId,
+			ClientId:        session.ClientId,
+			DeviceType:      session.DeviceType,
+			Ipaddr:          session.Ipaddr,
+			UserAgent:       session.UserAgent,
+			Browser:         browser,
+			Os:              osName,
+			CreatedTime:     utils.LocalTime(time.Unix(session.CreatedAt, 0)),
+			LastRefreshTime: utils.LocalTime(time.Unix(session.LastRefreshAt, 0)),
+			Current:         currentSessionId != "" && session.SessionId == currentSessionId,
+			ActorName:       session.ActorName,
+		})
+	}
+	return result
+}
@@ -0,0 +1,281 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/force-c/nai-tizi/internal/container"
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/domain/request"
+	"github.com/force-c/nai-tizi/internal/domain/response"
+	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/force-c/nai-tizi/internal/utils"
+	"github.com/force-c/nai-tizi/internal/utils/pagination"
+	"github.com/gin-gonic/gin"
+)
+
+// TenantController 租户管理控制器接口（平台运营使用）
+type TenantController interface {
+	Page(c *gin.Context)    // 分页查询租户
+	Get(c *gin.Context)     // 查询租户详情
+	Create(c *gin.Context)  // 开通租户
+	Update(c *gin.Context)  // 更新租户
+	Suspend(c *gin.Context) // 停用租户
+	Resume(c *gin.Context)  // 恢复租户
+	Delete(c *gin.Context)  // 删除租户
+}
+
+type tenantController struct {
+	ctr           container.Container
+	base          *BaseController
+	tenantService service.TenantService
+}
+
+func NewTenantController(c container.Container) TenantController {
+	casbinService := service.NewCasbinServiceV2(c.GetCasbin(), c.GetDB(), c.GetLogger(), c.GetConfig())
+	return &tenantController{
+		ctr:           c,
+		base:          NewBaseController(c),
+		tenantService: service.NewTenantService(c.GetDB(), c.GetRedis(), casbinService, service.NewTokenManager(c.GetJWT(), c.GetRedis(), c.GetLogger()), c.GetStorageManager(), c.GetConfig(), c.GetLogger()),
+	}
+}
+
+// Page 分页查询租户
+//
+//	@Summary		分页查询租户
+//	@Description	分页查询租户列表，仅平台租户可访问，需要 tenant.read 权限
+//	@Tags			租户管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			body	body		request.PageTenantRequest	true	"查询参数"
+//	@Success		200		{object}	response.Response{data=pagination.Page[response.TenantResponse]}
+//	@Router			/api/v1/tenant/page [post]
+func (h *tenantController) Page(c *gin.Context) {
+	var req request.PageTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+
+	page, err := h.tenantService.Page(c.Request.Context(), &req)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	now := time.Now().Unix()
+	records := make([]response.TenantResponse, 0, len(page.Records))
+	for i := range page.Records {
+		records = append(records, toTenantResponse(&page.Records[i], now))
+	}
+	response.Success(c, &pagination.Page[response.TenantResponse]{
+		Records: records,
+		Total:   page.Total,
+		Size:    page.Size,
+		Current: page.Current,
+		Pages:   page.Pages,
+	})
+}
+
+// Get 查询租户详情
+//
+//	@Summary		查询租户详情
+//	@Description	查询租户详情，仅平台租户可访问，需要 tenant.read 权限
+//	@Tags			租户管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			id	path		int	true	"租户ID"
+//	@Success		200	{object}	response.Response{data=response.TenantResponse}
+//	@Router			/api/v1/tenant/{id} [get]
+func (h *tenantController) Get(c *gin.Context) {
+	id, err := utils.ParseInt64Param(c, "id", "required")
+	if err != nil {
+		response.FailCode(c, response.CodeInvalidParam, err.Error())
+		return
+	}
+
+	t, err := h.tenantService.GetById(c.Request.Context(), id)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, toTenantResponse(t, time.Now().Unix()))
+}
+
+// Create 开通租户
+//
+//	@Summary		开通租户
+//	@Description	开通租户：创建根组织和租户管理员，从模板租户复制角色、角色菜单、Casbin 权限、字典和参数配置；仅多租户模式可用，需要 tenant.create 权限
+//	@Tags			租户管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			body	body		request.CreateTenantRequest	true	"租户信息"
+//	@Success		200		{object}	response.Response{data=response.TenantOnboardResponse}
+//	@Router			/api/v1/tenant [post]
+func (h *tenantController) Create(c *gin.Context) {
+	var req request.CreateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	result, err := h.tenantService.Create(c.Request.Context(), &req, userId)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, response.TenantOnboardResponse{
+		Tenant:        toTenantResponse(result.Tenant, time.Now().Unix()),
+		RootOrgId:     result.RootOrg.ID,
+		AdminUserId:   result.AdminUser.ID,
+		AdminUserName: result.AdminUser.UserName,
+		RoleCount:     result.RoleCount,
+		PolicyCount:   result.PolicyCount,
+	})
+}
+
+// Update 更新租户
+//
+//	@Summary		更新租户
+//	@Description	更新租户名称、套餐、到期时间和联系人，租户编码不可修改；需要 tenant.update 权限
+//	@Tags			租户管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			id		path		int							true	"租户ID"
+//	@Param			body	body		request.UpdateTenantRequest	true	"租户信息"
+//	@Success		200		{object}	response.Response
+//	@Router			/api/v1/tenant/{id} [put]
+func (h *tenantController) Update(c *gin.Context) {
+	id, err := utils.ParseInt64Param(c, "id", "required")
+	if err != nil {
+		response.FailCode(c, response.CodeInvalidParam, err.Error())
+		return
+	}
+	var req request.UpdateTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	if err := h.tenantService.Update(c.Request.Context(), id, &req, userId); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, nil)
+}
+
+// Suspend 停用租户
+//
+//	@Summary		停用租户
+//	@Description	停用租户，租户用户无法登录，已签发的 Token 和 API Key 立即失效；默认租户不能停用；需要 tenant.update 权限
+//	@Tags			租户管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			id	path		int	true	"租户ID"
+//	@Success		200	{object}	response.Response
+//	@Router			/api/v1/tenant/{id}/suspend [post]
+func (h *tenantController) Suspend(c *gin.Context) {
+	id, err := utils.ParseInt64Param(c, "id", "required")
+	if err != nil {
+		response.FailCode(c, response.CodeInvalidParam, err.Error())
+		return
+	}
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	if err := h.tenantService.Suspend(c.Request.Context(), id, userId); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, nil)
+}
+
+// Resume 恢复租户
+//
+//	@Summary		恢复租户
+//	@Description	恢复已停用的租户；需要 tenant.update 权限
+//	@Tags			租户管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			id	path		int	true	"租户ID"
+//	@Success		200	{object}	response.Response
+//	@Router			/api/v1/tenant/{id}/resume [post]
+func (h *tenantController) Resume(c *gin.Context) {
+	id, err := utils.ParseInt64Param(c, "id", "required")
+	if err != nil {
+		response.FailCode(c, response.CodeInvalidParam, err.Error())
+		return
+	}
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	if err := h.tenantService.Resume(c.Request.Context(), id, userId); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, nil)
+}
+
+// Delete 删除租户
+//
+//	@Summary		删除租户
+//	@Description	删除租户并清除其用户、组织、角色、字典、参数、日志、附件文件等全部数据和权限策略，不可恢复；默认租户和模板租户不能删除；需要 tenant.delete 权限
+//	@Tags			租户管理
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			id	path		int	true	"租户ID"
+//	@Success		200	{object}	response.Response
+//	@Router			/api/v1/tenant/{id} [delete]
+func (h *tenantController) Delete(c *gin.Context) {
+	id, err := utils.ParseInt64Param(c, "id", "required")
+	if err != nil {
+		response.FailCode(c, response.CodeInvalidParam, err.Error())
+		return
+	}
+
+	if err := h.tenantService.Delete(c.Request.Context(), id); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, nil)
+}
+
+// toTenantResponse 转换为租户响应
+func toTenantResponse(t *model.Tenant, now int64) response.TenantResponse {
+	return response.TenantResponse{
+		ID:           t.ID,
+		TenantCode:   t.TenantCode,
+		TenantName:   t.TenantName,
+		Plan:         t.Plan,
+		ExpireAt:     t.ExpireAt,
+		Status:       t.Status,
+		Active:       t.Active(now),
+		ContactName:  t.ContactName,
+		ContactPhone: t.ContactPhone,
+		AdminUserId:  t.AdminUserId,
+		Remark:       t.Remark,
+		CreatedTime:  t.CreatedTime,
+	}
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"github.com/force-c/nai-tizi/internal/constants"
 	"github.com/force-c/nai-tizi/internal/container"
 	"github.com/force-c/nai-tizi/internal/domain/request"
 	"github.com/force-c/nai-tizi/internal/domain/response"
@@ -25,16 +26,18 @@ type UserController interface {
 }
 
 type userController struct {
-	ctr         container.Container
-	base        *BaseController
-	userService service.UserService
+	ctr          container.Container
+	base         *BaseController
+	userService  service.UserService
+	tokenManager service.TokenManager
 }
 
 func NewUserController(c container.Container) UserController {
 	return &userController{
-		ctr:         c,
-		base:        NewBaseController(c),
-		userService: service.NewUserService(c.GetDB(), c.GetLogger()),
+		ctr:          c,
+		base:         NewBaseController(c),
+		userService:  service.NewUserService(c.GetDB(), c.GetLogger()),
+		tokenManager: service.NewTokenManager(c.GetJWT(), c.GetRedis(), c.GetLogger()),
 	}
 }
 
@@ -113,6 +116,11 @@ func (h *userController) Update(c *gin.Context) {
 		return
 	}
 
+	// 停用用户后立即吊销其所有 Token
+	if req.Status == constants.StatusDisabled {
+		h.revokeUserTokens(c, userId)
+	}
+
 	response.Success(c, "ok")
 }
 
@@ -140,6 +148,7 @@ func (h *userController) Delete(c *gin.Context) {
 		response.FailWithMsg(c, err.Error())
 		return
 	}
+	h.revokeUserTokens(c, userId)
 
 	response.Success(c, "ok")
 }
@@ -163,11 +172,16 @@ func (h *userController) BatchDelete(c *gin.Context) {
 		return
 	}
 
-	if err := h.userService.BatchDelete(c.Request.Context(), req.IDs); err != nil {
+	deleted, err := h.userService.BatchDelete(c.Request.Context(), req.IDs)
+	if err != nil {
 		h.ctr.GetLogger().Error("批量删除用户失败", zap.Error(err))
 		response.FailWithMsg(c, err.Error())
 		return
 	}
+	// 只吊销实际删除的用户，数据权限范围外的用户不受影响
+	for _, id := range deleted {
+		h.revokeUserTokens(c, id)
+	}
 
 	response.Success(c, "ok")
 }
@@ -279,6 +293,7 @@ func (h *userController) ResetPassword(c *gin.Context) {
 		response.FailWithMsg(c, err.Error())
 		return
 	}
+	h.revokeUserTokens(c, userId)
 
 	response.Success(c, "ok")
 }
@@ -339,5 +354,15 @@ func (h *userController) ChangePassword(c *gin.Context) {
 		return
 	}
 
+	// 修改密码后所有已登录会话（包括当前会话）需重新登录
+	h.revokeUserTokens(c, currentUserId)
+
 	response.Success(c, "ok")
 }
+
+// revokeUserTokens 吊销用户的所有 Token，失败仅记录日志
+func (h *userController) revokeUserTokens(c *gin.Context, userId int64) {
+	if err := h.tokenManager.RevokeUserTokens(c.Request.Context(), userId); err != nil {
+		h.ctr.GetLogger().Warn("吊销用户Token失败", zap.Int64("userId", userId), zap.Error(err))
+	}
+}
@@ -0,0 +1,243 @@
+package controller
+
+import (
+	"github.com/force-c/nai-tizi/internal/container"
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/domain/request"
+	"github.com/force-c/nai-tizi/internal/domain/response"
+	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/force-c/nai-tizi/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// WebAuthnController 通行密钥控制器接口
+type WebAuthnController interface {
+	LoginOptions(c *gin.Context)     // 获取登录参数（公开）
+	RegisterOptions(c *gin.Context)  // 获取注册参数
+	Register(c *gin.Context)         // 完成注册
+	ListCredentials(c *gin.Context)  // 查询我的通行密钥
+	RenameCredential(c *gin.Context) // 重命名通行密钥
+	DeleteCredential(c *gin.Context) // 删除通行密钥
+}
+
+type webAuthnController struct {
+	ctr             container.Container
+	base            *BaseController
+	clientService   service.ClientService
+	webAuthnService service.WebAuthnService
+}
+
+func NewWebAuthnController(c container.Container) WebAuthnController {
+	return &webAuthnController{
+		ctr:             c,
+		base:            NewBaseController(c),
+		clientService:   service.NewClientService(c.GetDB(), c.GetRedis(), c.GetLogger()),
+		webAuthnService: service.NewWebAuthnService(c.GetDB(), c.GetRedis(), c.GetConfig(), c.GetLogger()),
+	}
+}
+
+// LoginOptions 获取通行密钥登录参数
+//
+//	@Summary		获取通行密钥登录参数
+//	@Description	生成登录挑战，前端将 options 传给 navigator.credentials.get()，
+//	@Description	再以 grantType=webauthn 提交 challengeId 和返回的凭证完成登录；客户端需开通 webauthn 授权类型
+//	@Tags			通行密钥
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		request.WebAuthnLoginOptionsRequest	true	"客户端信息及用户名（可选）"
+//	@Success		200		{object}	response.Response{data=response.WebAuthnLoginOptionsResponse}
+//	@Router			/auth/webauthn/login/options [post]
+func (h *webAuthnController) LoginOptions(c *gin.Context) {
+	var req request.WebAuthnLoginOptionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+	ctx := c.Request.Context()
+
+	if _, err := h.clientService.AuthenticateClient(ctx, req.ClientKey, req.ClientSecret, "webauthn"); err != nil {
+		response.FailCode(c, response.CodeUnauthorized, err.Error())
+		return
+	}
+
+	challengeId, assertion, err := h.webAuthnService.BeginLogin(ctx, req.Username)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	response.Success(c, &response.WebAuthnLoginOptionsResponse{
+		ChallengeId: challengeId,
+		Options:     assertion,
+	})
+}
+
+// RegisterOptions 获取通行密钥注册参数
+//
+//	@Summary		获取通行密钥注册参数
+//	@Description	生成注册挑战，前端将返回值传给 navigator.credentials.create()
+//	@Tags			通行密钥
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Success		200	{object}	response.Response{data=object}
+//	@Router			/api/v1/auth/webauthn/register/options [post]
+func (h *webAuthnController) RegisterOptions(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	creation, err := h.webAuthnService.BeginRegistration(c.Request.Context(), userId)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	response.Success(c, creation)
+}
+
+// Register 完成通行密钥注册
+//
+//	@Summary		完成通行密钥注册
+//	@Description	提交 navigator.credentials.create() 返回的凭证，校验通过后保存
+//	@Tags			通行密钥
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			body	body		request.WebAuthnRegisterRequest	true	"注册凭证"
+//	@Success		200		{object}	response.Response{data=response.WebAuthnCredentialResponse}
+//	@Router			/api/v1/auth/webauthn/register [post]
+func (h *webAuthnController) Register(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	var req request.WebAuthnRegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+
+	credential, err := h.webAuthnService.FinishRegistration(c.Request.Context(), userId, req.Name, req.Credential)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	response.Success(c, toWebAuthnCredentialResponse(credential))
+}
+
+// ListCredentials 查询我的通行密钥
+//
+//	@Summary		查询我的通行密钥
+//	@Description	列出当前用户已注册的通行密钥
+//	@Tags			通行密钥
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Success		200	{object}	response.Response{data=[]response.WebAuthnCredentialResponse}
+//	@Router			/api/v1/auth/webauthn/credentials [get]
+func (h *webAuthnController) ListCredentials(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+
+	credentials, err := h.webAuthnService.ListCredentials(c.Request.Context(), userId)
+	if err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+
+	result := make([]*response.WebAuthnCredentialResponse, 0, len(credentials))
+	for i := range credentials {
+		result = append(result, toWebAuthnCredentialResponse(&credentials[i]))
+	}
+	response.Success(c, result)
+}
+
+// RenameCredential 重命名通行密钥
+//
+//	@Summary		重命名通行密钥
+//	@Description	修改当前用户指定通行密钥的名称
+//	@Tags			通行密钥
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			id		path		int								true	"凭证记录ID"
+//	@Param			body	body		request.WebAuthnRenameRequest	true	"新名称"
+//	@Success		200		{object}	response.Response
+//	@Router			/api/v1/auth/webauthn/credentials/{id} [put]
+func (h *webAuthnController) RenameCredential(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+	credentialId, err := utils.ParseInt64Param(c, "id", "required")
+	if err != nil {
+		response.FailCode(c, response.CodeInvalidParam, err.Error())
+		return
+	}
+
+	var req request.WebAuthnRenameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.FailCode(c, response.CodeInvalidParam, "参数错误: "+err.Error())
+		return
+	}
+
+	if err := h.webAuthnService.RenameCredential(c.Request.Context(), userId, credentialId, req.Name); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, "ok")
+}
+
+// DeleteCredential 删除通行密钥
+//
+//	@Summary		删除通行密钥
+//	@Description	删除当前用户指定的通行密钥，删除后该凭证无法再用于登录
+//	@Tags			通行密钥
+//	@Accept			json
+//	@Produce		json
+//	@Security		Bearer
+//	@Param			id	path		int	true	"凭证记录ID"
+//	@Success		200	{object}	response.Response
+//	@Router			/api/v1/auth/webauthn/credentials/{id} [delete]
+func (h *webAuthnController) DeleteCredential(c *gin.Context) {
+	userId, err := h.base.GetUserId(c)
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		return
+	}
+	credentialId, err := utils.ParseInt64Param(c, "id", "required")
+	if err != nil {
+		response.FailCode(c, response.CodeInvalidParam, err.Error())
+		return
+	}
+
+	if err := h.webAuthnService.DeleteCredential(c.Request.Context(), userId, credentialId); err != nil {
+		response.FailWithMsg(c, err.Error())
+		return
+	}
+	response.Success(c, "ok")
+}
+
+// toWebAuthnCredentialResponse 转换凭证响应
+func toWebAuthnCredentialResponse(credential *model.WebAuthnCredential) *response.WebAuthnCredentialResponse {
+	return &response.WebAuthnCredentialResponse{
+		Id:             credential.ID,
+		Name:           credential.Name,
+		Aaguid:         credential.Aaguid,
+		Transports:     credential.Transports,
+		BackupEligible: credential.BackupEligible,
+		BackupState:    credential.BackupState,
+		LastUsedAt:     credential.LastUsedAt,
+		CreatedTime:    credential.CreatedTime,
+	}
+}
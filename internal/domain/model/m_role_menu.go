@@ -10,7 +10,7 @@ type MRoleMenu struct {
 	Id          int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`            // 使用分布式ID
 	RoleId      int64           `gorm:"column:role_id;not null;index:idx_role_menu" json:"roleId"` // 角色ID
 	MenuId      int64           `gorm:"column:menu_id;not null;index:idx_role_menu" json:"menuId"` // 菜单ID
-	TenantId    int64           `gorm:"column:tenant_id;not null;default:1" json:"tenantId"`       // 租户ID（预留多租户）
+	TenantId    int64           `gorm:"column:tenant_id;not null;default:1" json:"tenantId"`       // 租户ID
 	CreateBy    int64           `gorm:"column:create_by" json:"createBy"`                          // 创建人
 	UpdateBy    int64           `gorm:"column:update_by" json:"updateBy"`                          // 更新人
 	CreatedTime utils.LocalTime `gorm:"column:created_time;autoCreateTime" json:"createdTime"`
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"github.com/force-c/nai-tizi/internal/utils"
+	"gorm.io/gorm"
+)
+
+// MRoleOrg 角色自定义数据权限组织关联表（映射表，仅数据范围为自定义的角色使用）
+type MRoleOrg struct {
+	Id          int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`           // 使用分布式ID
+	RoleId      int64           `gorm:"column:role_id;not null;index:idx_role_org" json:"roleId"` // 角色ID
+	OrgId       int64           `gorm:"column:org_id;not null;index:idx_role_org" json:"orgId"`   // 组织ID
+	TenantId    int64           `gorm:"column:tenant_id;not null;default:1" json:"tenantId"`      // 租户ID
+	CreateBy    int64           `gorm:"column:create_by" json:"createBy"`                         // 创建人
+	UpdateBy    int64           `gorm:"column:update_by" json:"updateBy"`                         // 更新人
+	CreatedTime utils.LocalTime `gorm:"column:created_time;autoCreateTime" json:"createdTime"`
+	UpdatedTime utils.LocalTime `gorm:"column:updated_time;autoUpdateTime" json:"updatedTime"`
+	DeletedAt   gorm.DeletedAt  `gorm:"column:deleted_at;index" json:"-"`
+}
+
+func (*MRoleOrg) TableName() string { return "m_role_org" }
+
+// FindOrgIdsByRoleIds 查询角色自定义数据权限包含的组织ID（去重）
+func (m *MRoleOrg) FindOrgIdsByRoleIds(db *gorm.DB, roleIds []int64) ([]int64, error) {
+	var orgIds []int64
+	if len(roleIds) == 0 {
+		return orgIds, nil
+	}
+	err := db.Model(&MRoleOrg{}).Where("role_id IN ?", roleIds).Distinct().Pluck("org_id", &orgIds).Error
+	return orgIds, err
+}
+
+// DeleteByRoleId 删除角色的所有组织关联
+func (m *MRoleOrg) DeleteByRoleId(db *gorm.DB, roleId int64) error {
+	return db.Where("role_id = ?", roleId).Delete(&MRoleOrg{}).Error
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"github.com/force-c/nai-tizi/internal/utils"
+	"gorm.io/gorm"
+)
+
+// ApiKey 个人访问令牌 / API Key（仅存储哈希）
+type ApiKey struct {
+	ID          int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`                 // 记录ID（使用分布式ID）
+	TenantId    int64           `gorm:"column:tenant_id;not null;default:1;index" json:"tenantId"`      // 租户ID
+	UserId      int64           `gorm:"column:user_id;not null;index" json:"userId"`                    // 所属用户ID（个人用户或服务账号）
+	Name        string          `gorm:"column:name;type:varchar(64);not null" json:"name"`              // 名称
+	KeyPrefix   string          `gorm:"column:key_prefix;type:varchar(16);not null" json:"keyPrefix"`   // 明文前缀（用于识别，不可用于认证）
+	KeyHash     string          `gorm:"column:key_hash;type:varchar(64);uniqueIndex;not null" json:"-"` // 明文的 SHA-256 哈希
+	Scopes      string          `gorm:"column:scopes;type:text;not null" json:"scopes"`                 // 授权范围（逗号分隔的权限资源）
+	ExpireAt    int64           `gorm:"column:expire_at;not null;default:0" json:"expireAt"`            // 过期时间（时间戳），0 表示永不过期
+	LastUsedAt  int64           `gorm:"column:last_used_at;default:0" json:"lastUsedAt"`                // 最后使用时间（时间戳）
+	LastUsedIp  string          `gorm:"column:last_used_ip;type:varchar(128)" json:"lastUsedIp"`        // 最后使用IP
+	CreateBy    int64           `gorm:"column:create_by" json:"createBy"`                               // 创建者
+	CreatedTime utils.LocalTime `gorm:"column:created_time;autoCreateTime" json:"createdTime"`
+	UpdatedTime utils.LocalTime `gorm:"column:updated_time;autoUpdateTime" json:"updatedTime"`
+}
+
+func (*ApiKey) TableName() string { return "s_api_key" }
+
+// FindByHash 根据哈希查询
+func (*ApiKey) FindByHash(db *gorm.DB, keyHash string) (*ApiKey, error) {
+	var key ApiKey
+	err := db.Where("key_hash = ?", keyHash).First(&key).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// FindByUserId 查询用户的所有 API Key
+func (*ApiKey) FindByUserId(db *gorm.DB, userId int64) ([]ApiKey, error) {
+	var keys []ApiKey
+	err := db.Where("user_id = ?", userId).Order("created_time DESC").Find(&keys).Error
+	return keys, err
+}
+
+// CountByUserId 统计用户的 API Key 数量
+func (*ApiKey) CountByUserId(db *gorm.DB, userId int64) (int64, error) {
+	var count int64
+	err := db.Model(&ApiKey{}).Where("user_id = ?", userId).Count(&count).Error
+	return count, err
+}
+
+// Create 创建 API Key
+func (k *ApiKey) Create(db *gorm.DB) error {
+	return db.Create(k).Error
+}
+
+// TouchUsed 更新最后使用时间和IP
+func (*ApiKey) TouchUsed(db *gorm.DB, id int64, usedAt int64, ip string) error {
+	return db.Model(&ApiKey{}).Where("id = ?", id).Updates(map[string]any{"last_used_at": usedAt, "last_used_ip": ip}).Error
+}
+
+// Delete 删除 API Key（仅限指定用户的记录）
+func (*ApiKey) Delete(db *gorm.DB, userId, id int64) (int64, error) {
+	tx := db.Where("id = ? AND user_id = ?", id, userId).Delete(&ApiKey{})
+	return tx.RowsAffected, tx.Error
+}
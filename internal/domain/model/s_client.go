@@ -2,8 +2,11 @@ package model
 
 import (
 	"crypto/md5"
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/hex"
 	"strings"
+	"time"
 
 	"github.com/force-c/nai-tizi/internal/utils"
 
@@ -14,12 +17,19 @@ import (
 type AuthClient struct {
 	ClientId      string          `gorm:"column:client_id;type:varchar(64);primaryKey;comment:客户端ID" json:"clientId"`
 	ClientKey     string          `gorm:"column:client_key;type:varchar(32);uniqueIndex;not null;comment:客户端Key" json:"clientKey"`
-	ClientSecret  string          `gorm:"column:client_secret;type:varchar(255);not null;comment:客户端秘钥" json:"clientSecret"`
+	ClientSecret  string          `gorm:"column:client_secret;type:varchar(255);not null;comment:客户端秘钥(哈希)" json:"clientSecret"`
+	PrevSecret    string          `gorm:"column:prev_secret;type:varchar(255);comment:轮换前的旧秘钥(哈希)" json:"prevSecret"`
+	PrevExpireAt  int64           `gorm:"column:prev_expire_at;default:0;comment:旧秘钥失效时间(时间戳)" json:"prevExpireAt"`
 	GrantType     string          `gorm:"column:grant_type;type:varchar(255);comment:授权类型(逗号分隔)" json:"grantType"`
 	DeviceType    string          `gorm:"column:device_type;type:varchar(32);comment:设备类型" json:"deviceType"`
 	Status        int             `gorm:"column:status;default:0;comment:状态(0正常 1停用)" json:"status"`
 	Timeout       int64           `gorm:"column:timeout;default:604800;comment:固定超时时间(秒),默认7天" json:"timeout"`
 	ActiveTimeout int64           `gorm:"column:active_timeout;default:1800;comment:活动超时时间(秒),默认30分钟" json:"activeTimeout"`
+	IdleCheck     int             `gorm:"column:idle_check;default:0;comment:空闲超时检测(0启用 1关闭)" json:"idleCheck"`
+	ClientName    string          `gorm:"column:client_name;type:varchar(100);comment:客户端名称(授权页展示)" json:"clientName"`
+	RedirectUris  string          `gorm:"column:redirect_uris;type:text;comment:OAuth2回调地址(逗号或换行分隔)" json:"redirectUris"`
+	Scopes        string          `gorm:"column:scopes;type:varchar(500);comment:OAuth2允许的授权范围(空格或逗号分隔)" json:"scopes"`
+	AutoApprove   bool            `gorm:"column:auto_approve;default:false;comment:OAuth2是否跳过用户授权确认" json:"autoApprove"`
 	Remark        string          `gorm:"column:remark;type:varchar(500);comment:备注" json:"remark"`
 	CreateBy      int64           `gorm:"column:create_by;comment:创建者" json:"createBy"`
 	CreatedTime   utils.LocalTime `gorm:"column:created_time;autoCreateTime;comment:创建时间" json:"createdTime"`
@@ -33,6 +43,16 @@ func (*AuthClient) TableName() string {
 	return "s_auth_client"
 }
 
+// ClientSecretHashPrefix 客户端密钥哈希前缀，不带前缀的为历史明文密钥
+const ClientSecretHashPrefix = "$sha256$"
+
+// HashClientSecret 计算客户端密钥哈希
+// 密钥由系统随机生成、熵足够高，使用 SHA-256 即可，避免每次登录都执行慢哈希
+func HashClientSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return ClientSecretHashPrefix + hex.EncodeToString(sum[:])
+}
+
 // GenerateClientId 生成客户端ID (MD5(clientKey + clientSecret))
 func GenerateClientId(clientKey, clientSecret string) string {
 	data := clientKey + clientSecret
@@ -57,18 +77,57 @@ func (c *AuthClient) BeforeCreate(tx *gorm.DB) error {
 
 // IsGrantTypeSupported 检查是否支持指定的授权类型
 func (c *AuthClient) IsGrantTypeSupported(grantType string) bool {
-	if c.GrantType == "" {
+	for _, t := range c.GrantTypes() {
+		if t == grantType {
+			return true
+		}
+	}
+	return false
+}
+
+// GrantTypes 允许的授权类型
+func (c *AuthClient) GrantTypes() []string {
+	return splitClientList(c.GrantType)
+}
+
+// IsRedirectUriAllowed 检查回调地址是否已登记（精确匹配）
+func (c *AuthClient) IsRedirectUriAllowed(redirectUri string) bool {
+	if redirectUri == "" {
 		return false
 	}
-	types := strings.Split(c.GrantType, ",")
-	for _, t := range types {
-		if strings.TrimSpace(t) == grantType {
+	for _, uri := range c.RegisteredRedirectUris() {
+		if uri == redirectUri {
 			return true
 		}
 	}
 	return false
 }
 
+// RegisteredRedirectUris 已登记的 OAuth2 回调地址
+func (c *AuthClient) RegisteredRedirectUris() []string {
+	return splitClientList(c.RedirectUris)
+}
+
+// AllowedScopes 允许申请的 OAuth2 授权范围
+func (c *AuthClient) AllowedScopes() []string {
+	return splitClientList(c.Scopes)
+}
+
+// splitClientList 按逗号、空白拆分配置列表并去除空项
+func splitClientList(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\r' || r == '\t'
+	})
+}
+
+// IdleTimeout 会话空闲超时时间（秒），超过 ActiveTimeout 无请求即失效；关闭检测时返回 0
+func (c *AuthClient) IdleTimeout() int64 {
+	if c.IdleCheck != 0 || c.ActiveTimeout <= 0 {
+		return 0
+	}
+	return c.ActiveTimeout
+}
+
 // IsActive 检查客户端是否启用
 func (c *AuthClient) IsActive() bool {
 	return c.Status == 0
@@ -118,6 +177,14 @@ func (c *AuthClient) Update(db *gorm.DB) error {
 	return db.Model(&AuthClient{}).Where("client_id = ?", c.ClientId).Updates(c).Error
 }
 
+// UpgradeLegacySecret 将历史明文密钥替换为哈希（仅当库中仍为该明文时更新）
+func (*AuthClient) UpgradeLegacySecret(db *gorm.DB, clientId, secret string) (bool, error) {
+	result := db.Model(&AuthClient{}).
+		Where("client_id = ? AND client_secret = ?", clientId, secret).
+		Update("client_secret", HashClientSecret(secret))
+	return result.RowsAffected > 0, result.Error
+}
+
 // Delete 删除客户端
 func (*AuthClient) Delete(db *gorm.DB, clientId string) error {
 	return db.Where("client_id = ?", clientId).Delete(&AuthClient{}).Error
@@ -151,6 +218,29 @@ func (*AuthClient) List(db *gorm.DB, pageNum, pageSize int, status *int, clientK
 }
 
 // VerifySecret 验证客户端密钥
+// 轮换后旧密钥在 PrevExpireAt 之前仍然有效，便于调用方平滑切换
 func (c *AuthClient) VerifySecret(secret string) bool {
-	return c.ClientSecret == secret
+	if secret == "" {
+		return false
+	}
+	if matchClientSecret(c.ClientSecret, secret) {
+		return true
+	}
+	return c.PrevSecret != "" && c.PrevExpireAt > time.Now().Unix() && matchClientSecret(c.PrevSecret, secret)
+}
+
+// IsSecretHashed 当前密钥是否已哈希存储（历史数据为明文，认证通过后自动升级）
+func (c *AuthClient) IsSecretHashed() bool {
+	return strings.HasPrefix(c.ClientSecret, ClientSecretHashPrefix)
+}
+
+// matchClientSecret 常量时间比较密钥，兼容历史明文密钥
+func matchClientSecret(stored, secret string) bool {
+	if stored == "" {
+		return false
+	}
+	if strings.HasPrefix(stored, ClientSecretHashPrefix) {
+		return subtle.ConstantTimeCompare([]byte(stored), []byte(HashClientSecret(secret))) == 1
+	}
+	return subtle.ConstantTimeCompare([]byte(stored), []byte(secret)) == 1
 }
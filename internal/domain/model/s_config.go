@@ -9,17 +9,17 @@ import (
 
 // Config 配置表
 type Config struct {
-	ID          int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`        // 配置ID（使用分布式ID）
-	TenantID    int64           `gorm:"column:tenant_id;default:1" json:"tenantId"`            // 租户ID（预留多租户，默认1）
-	Name        string          `gorm:"column:name;not null" json:"name"`                      // 配置名称
-	Code        string          `gorm:"column:code;not null;index" json:"code"`                // 配置编码
-	Data        json.RawMessage `gorm:"column:data;type:jsonb" json:"data"`                    // 配置数据（JSON格式）
-	Remark      string          `gorm:"column:remark" json:"remark"`                           // 备注
-	CreateBy    int64           `gorm:"column:create_by" json:"createBy"`                      // 创建者
-	CreatedTime utils.LocalTime `gorm:"column:created_time;autoCreateTime" json:"createdTime"` // 创建时间
-	UpdateBy    int64           `gorm:"column:update_by" json:"updateBy"`                      // 更新者
-	UpdatedTime utils.LocalTime `gorm:"column:updated_time;autoUpdateTime" json:"updatedTime"` // 更新时间
-	DeletedAt   gorm.DeletedAt  `gorm:"column:deleted_at;index" json:"-"`                      // 删除时间
+	ID          int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`            // 配置ID（使用分布式ID）
+	TenantID    int64           `gorm:"column:tenant_id;not null;default:1;index" json:"tenantId"` // 租户ID
+	Name        string          `gorm:"column:name;not null" json:"name"`                          // 配置名称
+	Code        string          `gorm:"column:code;not null;index" json:"code"`                    // 配置编码
+	Data        json.RawMessage `gorm:"column:data;type:jsonb" json:"data"`                        // 配置数据（JSON格式）
+	Remark      string          `gorm:"column:remark" json:"remark"`                               // 备注
+	CreateBy    int64           `gorm:"column:create_by" json:"createBy"`                          // 创建者
+	CreatedTime utils.LocalTime `gorm:"column:created_time;autoCreateTime" json:"createdTime"`     // 创建时间
+	UpdateBy    int64           `gorm:"column:update_by" json:"updateBy"`                          // 更新者
+	UpdatedTime utils.LocalTime `gorm:"column:updated_time;autoUpdateTime" json:"updatedTime"`     // 更新时间
+	DeletedAt   gorm.DeletedAt  `gorm:"column:deleted_at;index" json:"-"`                          // 删除时间
 }
 
 func (*Config) TableName() string {
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"github.com/force-c/nai-tizi/internal/utils"
+	"gorm.io/gorm"
+)
+
+// ImpersonationLog 代登录记录（管理员以用户身份登录）
+type ImpersonationLog struct {
+	ID          int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`              // 记录ID（使用分布式ID）
+	TenantId    int64           `gorm:"column:tenant_id;not null;default:1;index" json:"tenantId"`   // 租户ID
+	ActorId     int64           `gorm:"column:actor_id;not null;index" json:"actorId"`               // 管理员用户ID
+	ActorName   string          `gorm:"column:actor_name;type:varchar(64)" json:"actorName"`         // 管理员用户名
+	TargetId    int64           `gorm:"column:target_id;not null;index" json:"targetId"`             // 被代登录的用户ID
+	TargetName  string          `gorm:"column:target_name;type:varchar(64)" json:"targetName"`       // 被代登录的用户名
+	Reason      string          `gorm:"column:reason;type:varchar(500);not null" json:"reason"`      // 代登录原因（如工单号）
+	SessionId   string          `gorm:"column:session_id;type:varchar(64)" json:"sessionId"`         // 代登录会话ID
+	Ipaddr      string          `gorm:"column:ipaddr;type:varchar(128)" json:"ipaddr"`               // 管理员IP
+	UserAgent   string          `gorm:"column:user_agent;type:varchar(500)" json:"userAgent"`        // 管理员 User-Agent
+	ExpireAt    int64           `gorm:"column:expire_at;not null" json:"expireAt"`                   // Token 过期时间（时间戳）
+	CreatedTime utils.LocalTime `gorm:"column:created_time;autoCreateTime;index" json:"createdTime"` // 代登录时间
+}
+
+func (*ImpersonationLog) TableName() string { return "s_impersonation_log" }
+
+// Create 创建代登录记录
+func (l *ImpersonationLog) Create(db *gorm.DB) error {
+	return db.Create(l).Error
+}
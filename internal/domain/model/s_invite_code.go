@@ -0,0 +1,103 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/force-c/nai-tizi/internal/utils"
+	"gorm.io/gorm"
+)
+
+// InviteCode 注册邀请码
+// 使用邀请码注册的用户加入邀请码绑定的组织并获得默认角色
+type InviteCode struct {
+	ID          int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`                // 邀请码ID（使用分布式ID）
+	TenantID    int64           `gorm:"column:tenant_id;default:1;index" json:"tenantId"`              // 租户ID
+	Code        string          `gorm:"column:code;type:varchar(32);uniqueIndex;not null" json:"code"` // 邀请码
+	OrgId       int64           `gorm:"column:org_id;not null" json:"orgId"`                           // 注册用户所属组织ID
+	RoleIds     string          `gorm:"column:role_ids;type:varchar(512)" json:"-"`                    // 注册用户默认角色ID（逗号分隔）
+	MaxUses     int             `gorm:"column:max_uses;default:1" json:"maxUses"`                      // 最多可使用次数，0 表示不限
+	UsedCount   int             `gorm:"column:used_count;default:0" json:"usedCount"`                  // 已使用次数
+	ExpireAt    int64           `gorm:"column:expire_at;default:0" json:"expireAt"`                    // 过期时间（时间戳），0 表示永不过期
+	Status      int32           `gorm:"column:status;default:0" json:"status"`                         // 状态：0正常 1停用
+	Remark      string          `gorm:"column:remark" json:"remark"`                                   // 备注
+	CreateBy    int64           `gorm:"column:create_by" json:"createBy"`                              // 创建人
+	UpdateBy    int64           `gorm:"column:update_by" json:"updateBy"`                              // 更新人
+	CreatedTime utils.LocalTime `gorm:"column:created_time;autoCreateTime" json:"createdTime"`         // 创建时间
+	UpdatedTime utils.LocalTime `gorm:"column:updated_time;autoUpdateTime" json:"updatedTime"`         // 更新时间
+}
+
+func (*InviteCode) TableName() string { return "s_invite_code" }
+
+// FindByID 根据ID查询邀请码
+func (*InviteCode) FindByID(db *gorm.DB, id int64) (*InviteCode, error) {
+	var code InviteCode
+	err := db.Where("id = ?", id).First(&code).Error
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+// FindByCode 根据邀请码查询
+func (*InviteCode) FindByCode(db *gorm.DB, tenantId int64, code string) (*InviteCode, error) {
+	var out InviteCode
+	err := db.Where("tenant_id = ? AND code = ?", tenantId, code).First(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Create 创建邀请码
+func (c *InviteCode) Create(db *gorm.DB) error {
+	return db.Create(c).Error
+}
+
+// Update 更新邀请码
+func (*InviteCode) Update(db *gorm.DB, id int64, updates map[string]any) error {
+	return db.Model(&InviteCode{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// Delete 删除邀请码（物理删除）
+func (*InviteCode) Delete(db *gorm.DB, id int64) (int64, error) {
+	result := db.Where("id = ?", id).Delete(&InviteCode{})
+	return result.RowsAffected, result.Error
+}
+
+// Consume 使用一次邀请码，仅在邀请码正常、未过期且未用完时成功，返回是否使用成功
+func (*InviteCode) Consume(db *gorm.DB, id int64, now int64) (bool, error) {
+	result := db.Model(&InviteCode{}).
+		Where("id = ? AND status = 0", id).
+		Where("(max_uses = 0 OR used_count < max_uses)").
+		Where("(expire_at = 0 OR expire_at > ?)", now).
+		Update("used_count", gorm.Expr("used_count + 1"))
+	return result.RowsAffected > 0, result.Error
+}
+
+// Usable 邀请码当前是否可用
+func (c *InviteCode) Usable(now int64) bool {
+	return c.Status == 0 &&
+		(c.MaxUses == 0 || c.UsedCount < c.MaxUses) &&
+		(c.ExpireAt == 0 || c.ExpireAt > now)
+}
+
+// RoleIdList 默认角色ID列表
+func (c *InviteCode) RoleIdList() []int64 {
+	ids := make([]int64, 0)
+	for _, s := range strings.Split(c.RoleIds, ",") {
+		if id, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64); err == nil && id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// SetRoleIds 设置默认角色ID列表
+func (c *InviteCode) SetRoleIds(ids []int64) {
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, strconv.FormatInt(id, 10))
+	}
+	c.RoleIds = strings.Join(parts, ",")
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"github.com/force-c/nai-tizi/internal/utils"
+	"gorm.io/gorm"
+)
+
+// JwtKey JWT 签名密钥（jwt.keySource=database 时使用）
+type JwtKey struct {
+	ID          int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`              // 记录ID（使用分布式ID）
+	Kid         string          `gorm:"column:kid;type:varchar(64);uniqueIndex;not null" json:"kid"` // 密钥ID
+	Algorithm   string          `gorm:"column:algorithm;type:varchar(16);not null" json:"algorithm"` // 签名算法
+	PrivateKey  string          `gorm:"column:private_key;type:text;not null" json:"-"`              // PEM 私钥
+	ActivateAt  int64           `gorm:"column:activate_at;not null;default:0" json:"activateAt"`     // 开始用于签名的时间（时间戳）
+	ExpireAt    int64           `gorm:"column:expire_at;not null;default:0" json:"expireAt"`         // 停止用于验证的时间（时间戳），0 表示未过期
+	CreatedTime utils.LocalTime `gorm:"column:created_time;autoCreateTime" json:"createdTime"`
+	UpdatedTime utils.LocalTime `gorm:"column:updated_time;autoUpdateTime" json:"updatedTime"`
+}
+
+func (*JwtKey) TableName() string { return "s_jwt_key" }
+
+// FindValid 查询未过期的密钥，按启用时间升序
+func (*JwtKey) FindValid(db *gorm.DB, now int64) ([]JwtKey, error) {
+	var keys []JwtKey
+	err := db.Where("expire_at = 0 OR expire_at > ?", now).Order("activate_at ASC").Find(&keys).Error
+	return keys, err
+}
+
+// Create 创建密钥
+func (k *JwtKey) Create(db *gorm.DB) error {
+	return db.Create(k).Error
+}
+
+// ExpireBefore 为启用时间早于 activateAt 且未设置过期时间的密钥设置过期时间
+func (*JwtKey) ExpireBefore(db *gorm.DB, activateAt, expireAt int64) error {
+	return db.Model(&JwtKey{}).
+		Where("activate_at < ? AND expire_at = 0", activateAt).
+		Update("expire_at", expireAt).Error
+}
+
+// DeleteExpired 删除已过期的密钥
+func (*JwtKey) DeleteExpired(db *gorm.DB, now int64) (int64, error) {
+	tx := db.Where("expire_at > 0 AND expire_at <= ?", now).Delete(&JwtKey{})
+	return tx.RowsAffected, tx.Error
+}
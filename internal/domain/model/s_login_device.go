@@ -0,0 +1,74 @@
+package model
+
+import (
+	"github.com/force-c/nai-tizi/internal/utils"
+	"gorm.io/gorm"
+)
+
+// LoginDevice 用户已知登录设备（用于登录风险评估）
+type LoginDevice struct {
+	ID          int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`                                                       // 记录ID（使用分布式ID）
+	TenantId    int64           `gorm:"column:tenant_id;not null;default:1;index" json:"tenantId"`                                            // 租户ID
+	UserId      int64           `gorm:"column:user_id;not null;uniqueIndex:uk_login_device_user_fp,priority:1" json:"userId"`                 // 用户ID
+	Fingerprint string          `gorm:"column:fingerprint;type:varchar(64);not null;uniqueIndex:uk_login_device_user_fp,priority:2" json:"-"` // 设备指纹（SHA256）
+	Browser     string          `gorm:"column:browser;type:varchar(64)" json:"browser"`                                                       // 浏览器类型
+	Os          string          `gorm:"column:os;type:varchar(64)" json:"os"`                                                                 // 操作系统
+	LastIp      string          `gorm:"column:last_ip;type:varchar(64)" json:"lastIp"`                                                        // 最近登录IP
+	IpRange     string          `gorm:"column:ip_range;type:varchar(64)" json:"ipRange"`                                                      // 最近登录IP段（IPv4 /24，IPv6 /48）
+	Country     string          `gorm:"column:country;type:varchar(8)" json:"country"`                                                        // 最近登录国家代码
+	City        string          `gorm:"column:city;type:varchar(128)" json:"city"`                                                            // 最近登录城市
+	Latitude    float64         `gorm:"column:latitude;default:0" json:"-"`                                                                   // 最近登录纬度
+	Longitude   float64         `gorm:"column:longitude;default:0" json:"-"`                                                                  // 最近登录经度
+	HasCoord    bool            `gorm:"column:has_coord;default:false" json:"-"`                                                              // 是否记录了经纬度
+	LoginCount  int64           `gorm:"column:login_count;default:0" json:"loginCount"`                                                       // 登录次数
+	LastLoginAt int64           `gorm:"column:last_login_at;index" json:"lastLoginAt"`                                                        // 最近登录时间（时间戳）
+	CreatedTime utils.LocalTime `gorm:"column:created_time;autoCreateTime" json:"createdTime"`                                                // 首次登录时间
+}
+
+func (*LoginDevice) TableName() string { return "s_login_device" }
+
+// FindRecent 查询用户在 since（时间戳）之后登录过的设备，按最近登录时间倒序
+func (*LoginDevice) FindRecent(db *gorm.DB, userId int64, since int64) ([]LoginDevice, error) {
+	var devices []LoginDevice
+	err := db.Where("user_id = ? AND last_login_at >= ?", userId, since).
+		Order("last_login_at DESC").
+		Find(&devices).Error
+	return devices, err
+}
+
+// FindByFingerprint 根据设备指纹查询
+func (*LoginDevice) FindByFingerprint(db *gorm.DB, userId int64, fingerprint string) (*LoginDevice, error) {
+	var device LoginDevice
+	err := db.Where("user_id = ? AND fingerprint = ?", userId, fingerprint).First(&device).Error
+	if err != nil {
+		return nil, err
+	}
+	return &device, nil
+}
+
+// Create 记录新设备
+func (d *LoginDevice) Create(db *gorm.DB) error {
+	return db.Create(d).Error
+}
+
+// Touch 更新设备最近一次登录信息并累加登录次数
+func (d *LoginDevice) Touch(db *gorm.DB) error {
+	return db.Model(&LoginDevice{}).Where("id = ?", d.ID).Updates(map[string]any{
+		"browser":       d.Browser,
+		"os":            d.Os,
+		"last_ip":       d.LastIp,
+		"ip_range":      d.IpRange,
+		"country":       d.Country,
+		"city":          d.City,
+		"latitude":      d.Latitude,
+		"longitude":     d.Longitude,
+		"has_coord":     d.HasCoord,
+		"last_login_at": d.LastLoginAt,
+		"login_count":   gorm.Expr("login_count + 1"),
+	}).Error
+}
+
+// DeleteByFingerprint 删除用户的指定设备（"不是我本人"时移出已知设备）
+func (*LoginDevice) DeleteByFingerprint(db *gorm.DB, userId int64, fingerprint string) error {
+	return db.Where("user_id = ? AND fingerprint = ?", userId, fingerprint).Delete(&LoginDevice{}).Error
+}
@@ -7,17 +7,19 @@ import (
 
 // LoginLog 登录日志
 type LoginLog struct {
-	ID            int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"` // 日志ID（使用分布式ID）
-	UserName      string          `gorm:"column:user_name;index" json:"userName"`         // 用户名
-	Ipaddr        string          `gorm:"column:ipaddr" json:"ipaddr"`                    // 登录IP
-	LoginLocation string          `gorm:"column:login_location" json:"loginLocation"`     // 登录地点
-	Browser       string          `gorm:"column:browser" json:"browser"`                  // 浏览器类型
-	Os            string          `gorm:"column:os" json:"os"`                            // 操作系统
-	Status        int32           `gorm:"column:status;default:0" json:"status"`          // 登录状态：0成功 1失败
-	Msg           string          `gorm:"column:msg" json:"msg"`                          // 提示消息
-	LoginTime     utils.LocalTime `gorm:"column:login_time;index" json:"loginTime"`       // 登录时间
-	TenantId      string          `gorm:"column:tenant_id" json:"tenantId"`               // 租户ID
-	ClientId      string          `gorm:"column:client_id" json:"clientId"`               // 客户端ID
+	ID            int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`            // 日志ID（使用分布式ID）
+	UserName      string          `gorm:"column:user_name;index" json:"userName"`                    // 用户名
+	Ipaddr        string          `gorm:"column:ipaddr" json:"ipaddr"`                               // 登录IP
+	LoginLocation string          `gorm:"column:login_location" json:"loginLocation"`                // 登录地点
+	Browser       string          `gorm:"column:browser" json:"browser"`                             // 浏览器类型
+	Os            string          `gorm:"column:os" json:"os"`                                       // 操作系统
+	Status        int32           `gorm:"column:status;default:0" json:"status"`                     // 登录状态：0成功 1失败
+	Msg           string          `gorm:"column:msg" json:"msg"`                                     // 提示消息
+	LoginTime     utils.LocalTime `gorm:"column:login_time;index" json:"loginTime"`                  // 登录时间
+	TenantId      int64           `gorm:"column:tenant_id;not null;default:1;index" json:"tenantId"` // 租户ID
+	ClientId      string          `gorm:"column:client_id" json:"clientId"`                          // 客户端ID
+	RiskScore     int             `gorm:"column:risk_score;default:0" json:"riskScore"`              // 登录风险分（0-100）
+	RiskReasons   string          `gorm:"column:risk_reasons" json:"riskReasons"`                    // 命中的风险因素（逗号分隔）
 }
 
 func (*LoginLog) TableName() string {
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"github.com/force-c/nai-tizi/internal/utils"
+	"gorm.io/gorm"
+)
+
+// OAuthConsent 用户对第三方客户端的 OAuth2 授权记录
+type OAuthConsent struct {
+	ID          int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`                                                      // 记录ID（使用分布式ID）
+	TenantId    int64           `gorm:"column:tenant_id;not null;default:1;index" json:"tenantId"`                                           // 租户ID
+	UserId      int64           `gorm:"column:user_id;not null;uniqueIndex:uk_oauth_consent_user_client" json:"userId"`                      // 授权用户ID
+	ClientId    string          `gorm:"column:client_id;type:varchar(64);not null;uniqueIndex:uk_oauth_consent_user_client" json:"clientId"` // 客户端ID
+	Scope       string          `gorm:"column:scope;type:varchar(500)" json:"scope"`                                                         // 已同意的授权范围（空格分隔）
+	CreatedTime utils.LocalTime `gorm:"column:created_time;autoCreateTime" json:"createdTime"`
+	UpdatedTime utils.LocalTime `gorm:"column:updated_time;autoUpdateTime" json:"updatedTime"`
+}
+
+func (*OAuthConsent) TableName() string { return "s_oauth_consent" }
+
+// Find 查询用户对指定客户端的授权记录
+func (*OAuthConsent) Find(db *gorm.DB, userId int64, clientId string) (*OAuthConsent, error) {
+	var consent OAuthConsent
+	err := db.Where("user_id = ? AND client_id = ?", userId, clientId).First(&consent).Error
+	if err != nil {
+		return nil, err
+	}
+	return &consent, nil
+}
+
+// FindByUserId 查询用户的所有授权记录
+func (*OAuthConsent) FindByUserId(db *gorm.DB, userId int64) ([]OAuthConsent, error) {
+	var consents []OAuthConsent
+	err := db.Where("user_id = ?", userId).Order("updated_time DESC").Find(&consents).Error
+	return consents, err
+}
+
+// Create 创建授权记录
+func (c *OAuthConsent) Create(db *gorm.DB) error {
+	return db.Create(c).Error
+}
+
+// UpdateScope 更新已同意的授权范围
+func (*OAuthConsent) UpdateScope(db *gorm.DB, id int64, scope string) error {
+	return db.Model(&OAuthConsent{}).Where("id = ?", id).Update("scope", scope).Error
+}
+
+// Delete 撤销授权记录
+func (*OAuthConsent) Delete(db *gorm.DB, userId int64, clientId string) (int64, error) {
+	tx := db.Where("user_id = ? AND client_id = ?", userId, clientId).Delete(&OAuthConsent{})
+	return tx.RowsAffected, tx.Error
+}
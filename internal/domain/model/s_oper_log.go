@@ -7,23 +7,26 @@ import (
 
 // OperLog 操作日志
 type OperLog struct {
-	ID            int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"` // 日志ID（使用分布式ID）
-	Title         string          `gorm:"column:title" json:"title"`                      // 模块标题
-	BusinessType  string          `gorm:"column:business_type" json:"businessType"`       // 业务类型
-	Method        string          `gorm:"column:method" json:"method"`                    // 调用方法
-	RequestMethod string          `gorm:"column:request_method" json:"requestMethod"`     // 请求方式：GET/POST
-	DeviceType    string          `gorm:"column:device_type" json:"deviceType"`           // 终端类型：web/ios/android/wechat
-	OperName      string          `gorm:"column:oper_name" json:"operName"`               // 操作者
-	OperUrl       string          `gorm:"column:oper_url" json:"operUrl"`                 // 请求URL
-	OperIp        string          `gorm:"column:oper_ip" json:"operIp"`                   // 操作IP
-	OperLocation  string          `gorm:"column:oper_location" json:"operLocation"`       // 操作地点
-	OperParam     string          `gorm:"column:oper_param" json:"operParam"`             // 请求参数
-	JsonResult    string          `gorm:"column:json_result" json:"jsonResult"`           // 返回结果
-	Status        string          `gorm:"column:status" json:"status"`                    // 操作状态：0成功 1失败
-	ErrorMsg      string          `gorm:"column:error_msg" json:"errorMsg"`               // 错误信息
-	OperTime      utils.LocalTime `gorm:"column:oper_time;index" json:"operTime"`         // 操作时间
-	CostTime      int64           `gorm:"column:cost_time" json:"costTime"`               // 耗时（毫秒）
-	UserAgent     string          `gorm:"column:user_agent" json:"userAgent"`             // UA
+	ID            int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`            // 日志ID（使用分布式ID）
+	TenantId      int64           `gorm:"column:tenant_id;not null;default:1;index" json:"tenantId"` // 租户ID
+	Title         string          `gorm:"column:title" json:"title"`                                 // 模块标题
+	BusinessType  string          `gorm:"column:business_type" json:"businessType"`                  // 业务类型
+	Method        string          `gorm:"column:method" json:"method"`                               // 调用方法
+	RequestMethod string          `gorm:"column:request_method" json:"requestMethod"`                // 请求方式：GET/POST
+	DeviceType    string          `gorm:"column:device_type" json:"deviceType"`                      // 终端类型：web/ios/android/wechat
+	OperName      string          `gorm:"column:oper_name" json:"operName"`                          // 操作者
+	OperUserId    int64           `gorm:"column:oper_user_id;index" json:"operUserId"`               // 操作者用户ID（用于数据权限过滤）
+	ActorName     string          `gorm:"column:actor_name" json:"actorName"`                        // 代登录的管理员（管理员以用户身份操作时记录，格式同操作者）
+	OperUrl       string          `gorm:"column:oper_url" json:"operUrl"`                            // 请求URL
+	OperIp        string          `gorm:"column:oper_ip" json:"operIp"`                              // 操作IP
+	OperLocation  string          `gorm:"column:oper_location" json:"operLocation"`                  // 操作地点
+	OperParam     string          `gorm:"column:oper_param" json:"operParam"`                        // 请求参数
+	JsonResult    string          `gorm:"column:json_result" json:"jsonResult"`                      // 返回结果
+	Status        string          `gorm:"column:status" json:"status"`                               // 操作状态：0成功 1失败
+	ErrorMsg      string          `gorm:"column:error_msg" json:"errorMsg"`                          // 错误信息
+	OperTime      utils.LocalTime `gorm:"column:oper_time;index" json:"operTime"`                    // 操作时间
+	CostTime      int64           `gorm:"column:cost_time" json:"costTime"`                          // 耗时（毫秒）
+	UserAgent     string          `gorm:"column:user_agent" json:"userAgent"`                        // UA
 }
 
 func (*OperLog) TableName() string {
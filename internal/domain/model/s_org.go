@@ -10,20 +10,22 @@ import (
 
 // Org 系统组织表（多租户）
 type Org struct {
-	ID          int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`   // 组织ID（使用分布式ID）
-	ParentId    int64           `gorm:"column:parent_id;default:0;index" json:"parentId"` // 父组织ID（0表示根组织）
-	Ancestors   string          `gorm:"column:ancestors" json:"ancestors"`                // 祖级列表（逗号分隔，例如: "0,1,2"）
-	OrgName     string          `gorm:"column:org_name;not null" json:"orgName"`          // 组织名称
-	OrgCode     string          `gorm:"column:org_code;uniqueIndex" json:"orgCode"`       // 组织编码（唯一）
-	OrgType     string          `gorm:"column:org_type;default:'company'" json:"orgType"` // 组织类型：company公司 department部门 group集团
-	Leader      string          `gorm:"column:leader" json:"leader"`                      // 负责人
-	Phone       string          `gorm:"column:phone" json:"phone"`                        // 联系电话
-	Email       string          `gorm:"column:email" json:"email"`                        // 邮箱
-	Status      int32           `gorm:"column:status;default:0" json:"status"`            // 状态：0正常 1停用
-	Sort        int64           `gorm:"column:sort;default:0" json:"sort"`                // 显示顺序
-	Remark      string          `gorm:"column:remark" json:"remark"`                      // 备注
-	CreateBy    int64           `gorm:"column:create_by" json:"createBy"`                 // 创建人
-	UpdateBy    int64           `gorm:"column:update_by" json:"updateBy"`                 // 更新人
+	ID          int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`                                                // 组织ID（使用分布式ID）
+	TenantId    int64           `gorm:"column:tenant_id;not null;default:1;uniqueIndex:uk_org_tenant_code,priority:1" json:"tenantId"` // 租户ID
+	ParentId    int64           `gorm:"column:parent_id;default:0;index" json:"parentId"`                                              // 父组织ID（0表示根组织）
+	Ancestors   string          `gorm:"column:ancestors" json:"ancestors"`                                                             // 祖级列表（逗号分隔，例如: "0,1,2"）
+	OrgName     string          `gorm:"column:org_name;not null" json:"orgName"`                                                       // 组织名称
+	OrgCode     string          `gorm:"column:org_code;uniqueIndex:uk_org_tenant_code,priority:2" json:"orgCode"`                      // 组织编码（租户内唯一）
+	OrgType     string          `gorm:"column:org_type;default:'company'" json:"orgType"`                                              // 组织类型：company公司 department部门 group集团
+	Leader      string          `gorm:"column:leader" json:"leader"`                                                                   // 负责人
+	Phone       string          `gorm:"column:phone" json:"phone"`                                                                     // 联系电话
+	Email       string          `gorm:"column:email" json:"email"`                                                                     // 邮箱
+	Status      int32           `gorm:"column:status;default:0" json:"status"`                                                         // 状态：0正常 1停用
+	RegApproval bool            `gorm:"column:reg_approval;default:false" json:"regApproval"`                                          // 自助注册到该组织的用户是否需要管理员审批
+	Sort        int64           `gorm:"column:sort;default:0" json:"sort"`                                                             // 显示顺序
+	Remark      string          `gorm:"column:remark" json:"remark"`                                                                   // 备注
+	CreateBy    int64           `gorm:"column:create_by" json:"createBy"`                                                              // 创建人
+	UpdateBy    int64           `gorm:"column:update_by" json:"updateBy"`                                                              // 更新人
 	CreatedTime utils.LocalTime `gorm:"column:created_time;autoCreateTime" json:"createdTime"`
 	UpdatedTime utils.LocalTime `gorm:"column:updated_time;autoUpdateTime" json:"updatedTime"`
 	DeletedAt   gorm.DeletedAt  `gorm:"column:deleted_at;index" json:"-"`
@@ -160,6 +162,24 @@ func (o *Org) BuildAncestors(db *gorm.DB, parentId int64) (string, error) {
 	return parent.Ancestors + "," + fmt.Sprint(parentId), nil
 }
 
+// FindSelfAndDescendantIds 查询组织及其所有下级组织的ID（基于祖级列表匹配）
+func (o *Org) FindSelfAndDescendantIds(db *gorm.DB, orgId int64) ([]int64, error) {
+	var orgIds []int64
+	err := db.Model(&Org{}).
+		Where("id = ? OR ',' || ancestors || ',' LIKE ?", orgId, fmt.Sprintf("%%,%d,%%", orgId)).
+		Pluck("id", &orgIds).Error
+	return orgIds, err
+}
+
+// UpdateDescendantAncestors 组织移动后同步更新所有下级组织的祖级列表
+func (o *Org) UpdateDescendantAncestors(db *gorm.DB, orgId int64, oldAncestors, newAncestors string) error {
+	oldPrefix := fmt.Sprintf("%s,%d", oldAncestors, orgId)
+	newPrefix := fmt.Sprintf("%s,%d", newAncestors, orgId)
+	return db.Model(&Org{}).
+		Where("ancestors = ? OR ancestors LIKE ?", oldPrefix, oldPrefix+",%").
+		Update("ancestors", gorm.Expr("? || SUBSTRING(ancestors FROM ?)", newPrefix, len(oldPrefix)+1)).Error
+}
+
 // IsActive 判断组织是否激活
 func (o *Org) IsActive() bool {
 	return o.Status == 0
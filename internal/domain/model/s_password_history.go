@@ -0,0 +1,38 @@
+package model
+
+import (
+	"github.com/force-c/nai-tizi/internal/utils"
+	"gorm.io/gorm"
+)
+
+// PasswordHistory 用户历史密码（用于禁止重复使用最近的密码）
+type PasswordHistory struct {
+	ID          int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`            // 记录ID（使用分布式ID）
+	TenantId    int64           `gorm:"column:tenant_id;not null;default:1;index" json:"tenantId"` // 租户ID
+	UserId      int64           `gorm:"column:user_id;not null;index" json:"userId"`               // 用户ID
+	Password    string          `gorm:"column:password;not null" json:"-"`                         // 密码哈希
+	CreatedTime utils.LocalTime `gorm:"column:created_time;autoCreateTime" json:"createdTime"`     // 设置时间
+}
+
+func (*PasswordHistory) TableName() string { return "s_password_history" }
+
+// FindRecent 查询用户最近的 limit 条历史密码
+func (*PasswordHistory) FindRecent(db *gorm.DB, userId int64, limit int) ([]PasswordHistory, error) {
+	var records []PasswordHistory
+	err := db.Where("user_id = ?", userId).Order("created_time DESC, id DESC").Limit(limit).Find(&records).Error
+	return records, err
+}
+
+// Create 记录历史密码
+func (h *PasswordHistory) Create(db *gorm.DB) error {
+	return db.Create(h).Error
+}
+
+// Prune 只保留用户最近的 keep 条历史密码
+func (*PasswordHistory) Prune(db *gorm.DB, userId int64, keep int) error {
+	recent := db.Model(&PasswordHistory{}).Select("id").
+		Where("user_id = ?", userId).
+		Order("created_time DESC, id DESC").
+		Limit(keep)
+	return db.Where("user_id = ? AND id NOT IN (?)", userId, recent).Delete(&PasswordHistory{}).Error
+}
@@ -7,20 +7,23 @@ import (
 
 // Role 系统角色表
 type Role struct {
-	ID          int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`      // 角色ID（使用分布式ID）
-	RoleKey     string          `gorm:"column:role_key;uniqueIndex;not null" json:"roleKey"` // 角色标识（唯一，用于权限匹配）
-	RoleName    string          `gorm:"column:role_name;not null" json:"roleName"`           // 角色名称
-	Sort        int64           `gorm:"column:sort;default:0" json:"sort"`                   // 显示顺序
-	Status      int32           `gorm:"column:status;default:0" json:"status"`               // 状态：0正常 1停用
-	DataScope   int32           `gorm:"column:data_scope;default:1" json:"dataScope"`        // 数据范围：1全部 2自定义 3本组织 4本组织及以下 5仅本人
-	IsSystem    bool            `gorm:"column:is_system;default:false" json:"isSystem"`      // 是否系统内置角色（内置角色不可删除）
-	TenantId    int64           `gorm:"column:tenant_id;not null;default:1" json:"tenantId"` // 租户ID（预留多租户）
-	Remark      string          `gorm:"column:remark" json:"remark"`                         // 备注
-	CreateBy    int64           `gorm:"column:create_by" json:"createBy"`                    // 创建人
-	UpdateBy    int64           `gorm:"column:update_by" json:"updateBy"`                    // 更新人
+	ID          int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`                                                // 角色ID（使用分布式ID）
+	RoleKey     string          `gorm:"column:role_key;not null;uniqueIndex:uk_role_tenant_key,priority:2" json:"roleKey"`             // 角色标识（租户内唯一，用于权限匹配）
+	RoleName    string          `gorm:"column:role_name;not null" json:"roleName"`                                                     // 角色名称
+	Sort        int64           `gorm:"column:sort;default:0" json:"sort"`                                                             // 显示顺序
+	Status      int32           `gorm:"column:status;default:0" json:"status"`                                                         // 状态：0正常 1停用
+	DataScope   int32           `gorm:"column:data_scope;default:1" json:"dataScope"`                                                  // 数据范围：1全部 2自定义 3本组织 4本组织及以下 5仅本人
+	IsSystem    bool            `gorm:"column:is_system;default:false" json:"isSystem"`                                                // 是否系统内置角色（内置角色不可删除）
+	RequireMfa  bool            `gorm:"column:require_mfa;default:false" json:"requireMfa"`                                            // 是否强制该角色用户启用两步验证
+	TenantId    int64           `gorm:"column:tenant_id;not null;default:1;uniqueIndex:uk_role_tenant_key,priority:1" json:"tenantId"` // 租户ID
+	Remark      string          `gorm:"column:remark" json:"remark"`                                                                   // 备注
+	CreateBy    int64           `gorm:"column:create_by" json:"createBy"`                                                              // 创建人
+	UpdateBy    int64           `gorm:"column:update_by" json:"updateBy"`                                                              // 更新人
 	CreatedTime utils.LocalTime `gorm:"column:created_time;autoCreateTime" json:"createdTime"`
 	UpdatedTime utils.LocalTime `gorm:"column:updated_time;autoUpdateTime" json:"updatedTime"`
 	DeletedAt   gorm.DeletedAt  `gorm:"column:deleted_at;index" json:"-"`
+
+	OrgIds []int64 `gorm:"-" json:"orgIds,omitempty"` // 自定义数据权限的组织ID（仅数据范围为自定义时有效）
 }
 
 func (*Role) TableName() string { return "s_role" }
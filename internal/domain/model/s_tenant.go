@@ -0,0 +1,75 @@
+package model
+
+import (
+	"github.com/force-c/nai-tizi/internal/utils"
+	"gorm.io/gorm"
+)
+
+// Tenant 租户（平台级数据，不区分租户）
+// 多租户模式下按请求头中的租户ID或子域名中的租户编码识别当前租户
+type Tenant struct {
+	ID           int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`                             // 租户ID（使用分布式ID）
+	TenantCode   string          `gorm:"column:tenant_code;type:varchar(64);uniqueIndex;not null" json:"tenantCode"` // 租户编码（用于子域名）
+	TenantName   string          `gorm:"column:tenant_name;not null" json:"tenantName"`                              // 租户名称
+	Plan         string          `gorm:"column:plan;type:varchar(32)" json:"plan"`                                   // 套餐
+	ExpireAt     int64           `gorm:"column:expire_at;default:0" json:"expireAt"`                                 // 到期时间（时间戳），0 表示永不过期
+	Status       int32           `gorm:"column:status;default:0" json:"status"`                                      // 状态：0正常 1停用
+	ContactName  string          `gorm:"column:contact_name" json:"contactName"`                                     // 联系人
+	ContactPhone string          `gorm:"column:contact_phone" json:"contactPhone"`                                   // 联系电话
+	AdminUserId  int64           `gorm:"column:admin_user_id" json:"adminUserId"`                                    // 开通时创建的租户管理员ID
+	Remark       string          `gorm:"column:remark" json:"remark"`                                                // 备注
+	CreateBy     int64           `gorm:"column:create_by" json:"createBy"`                                           // 创建人
+	UpdateBy     int64           `gorm:"column:update_by" json:"updateBy"`                                           // 更新人
+	CreatedTime  utils.LocalTime `gorm:"column:created_time;autoCreateTime" json:"createdTime"`                      // 创建时间
+	UpdatedTime  utils.LocalTime `gorm:"column:updated_time;autoUpdateTime" json:"updatedTime"`                      // 更新时间
+	DeletedAt    gorm.DeletedAt  `gorm:"column:deleted_at;index" json:"-"`                                           // 软删除
+}
+
+func (*Tenant) TableName() string { return "s_tenant" }
+
+// FindByID 根据ID查询租户
+func (*Tenant) FindByID(db *gorm.DB, id int64) (*Tenant, error) {
+	var t Tenant
+	err := db.Where("id = ?", id).First(&t).Error
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Active 租户是否可用（未停用且未到期）
+func (t *Tenant) Active(now int64) bool {
+	return t.Status == 0 && (t.ExpireAt == 0 || t.ExpireAt > now)
+}
+
+// CheckCodeExists 检查租户编码是否存在（含已删除的租户，避免子域名被复用）
+func (*Tenant) CheckCodeExists(db *gorm.DB, code string) (bool, error) {
+	var count int64
+	err := db.Unscoped().Model(&Tenant{}).Where("tenant_code = ?", code).Count(&count).Error
+	return count > 0, err
+}
+
+// FindByCode 根据租户编码查询租户
+func (*Tenant) FindByCode(db *gorm.DB, code string) (*Tenant, error) {
+	var t Tenant
+	err := db.Where("tenant_code = ?", code).First(&t).Error
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Create 创建租户
+func (t *Tenant) Create(db *gorm.DB) error {
+	return db.Create(t).Error
+}
+
+// Update 更新租户
+func (*Tenant) Update(db *gorm.DB, id int64, updates map[string]interface{}) error {
+	return db.Model(&Tenant{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// Delete 删除租户（软删除，保留租户编码防止被复用）
+func (*Tenant) Delete(db *gorm.DB, id int64) error {
+	return db.Where("id = ?", id).Delete(&Tenant{}).Error
+}
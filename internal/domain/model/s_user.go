@@ -8,25 +8,31 @@ import (
 
 // User 系统用户
 type User struct {
-	ID          int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`        // 用户ID（使用分布式ID）
-	OrgId       int64           `gorm:"column:org_id;not null;index" json:"orgId"`             // 所属组织ID
-	UserName    string          `gorm:"column:user_name;uniqueIndex;not null" json:"userName"` // 用户名（登录账号）
-	NickName    string          `gorm:"column:nick_name" json:"nickName"`                      // 昵称（显示名称）
-	UserType    int32           `gorm:"column:user_type;default:0" json:"userType"`            // 用户类型：0系统用户 1微信用户 2APP用户
-	Email       string          `gorm:"column:email" json:"email"`                             // 邮箱
-	Phonenumber string          `gorm:"column:phonenumber" json:"phonenumber"`                 // 手机号
-	Sex         int32           `gorm:"column:sex;default:2" json:"sex"`                       // 性别：0男 1女 2未知
-	Avatar      string          `gorm:"column:avatar" json:"avatar"`                           // 头像URL
-	Password    string          `gorm:"column:password" json:"-"`                              // 密码（加密）
-	Status      int32           `gorm:"column:status;default:0" json:"status"`                 // 状态：0正常 1停用
-	Sort        int64           `gorm:"column:sort;default:0" json:"sort"`                     // 排序字段
-	LoginIp     string          `gorm:"column:login_ip" json:"loginIp"`                        // 最后登录IP
-	LoginDate   int64           `gorm:"column:login_date" json:"loginDate"`                    // 最后登录时间（时间戳）
-	OpenId      string          `gorm:"column:open_id" json:"openId"`                          // 微信OpenID
-	UnionId     string          `gorm:"column:union_id" json:"unionId"`                        // 微信UnionID
-	Remark      string          `gorm:"column:remark" json:"remark"`                           // 备注
-	CreateBy    int64           `gorm:"column:create_by" json:"createBy"`                      // 创建人
-	UpdateBy    int64           `gorm:"column:update_by" json:"updateBy"`                      // 更新人
+	ID          int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`                                                 // 用户ID（使用分布式ID）
+	OrgId       int64           `gorm:"column:org_id;not null;index" json:"orgId"`                                                      // 所属组织ID
+	TenantId    int64           `gorm:"column:tenant_id;not null;default:1;uniqueIndex:uk_user_tenant_name,priority:1" json:"tenantId"` // 租户ID
+	UserName    string          `gorm:"column:user_name;not null;uniqueIndex:uk_user_tenant_name,priority:2" json:"userName"`           // 用户名（登录账号，租户内唯一）
+	NickName    string          `gorm:"column:nick_name" json:"nickName"`                                                               // 昵称（显示名称）
+	UserType    int32           `gorm:"column:user_type;default:0" json:"userType"`                                                     // 用户类型：0系统用户 1微信用户 2APP用户
+	Email       string          `gorm:"column:email" json:"email"`                                                                      // 邮箱
+	Phonenumber string          `gorm:"column:phonenumber" json:"phonenumber"`                                                          // 手机号
+	Sex         int32           `gorm:"column:sex;default:2" json:"sex"`                                                                // 性别：0男 1女 2未知
+	Avatar      string          `gorm:"column:avatar" json:"avatar"`                                                                    // 头像URL
+	Password    string          `gorm:"column:password" json:"-"`                                                                       // 密码（加密）
+	PwdUpdateAt int64           `gorm:"column:pwd_update_at;default:0" json:"pwdUpdateAt"`                                              // 密码最后修改时间（时间戳），0 表示未知
+	PwdChange   string          `gorm:"column:pwd_change;type:varchar(16)" json:"-"`                                                    // 待强制修改密码的原因：first_login 首次登录 reset 管理员重置，空表示无需修改
+	Status      int32           `gorm:"column:status;default:0" json:"status"`                                                          // 状态：0正常 1停用 2待审批
+	Sort        int64           `gorm:"column:sort;default:0" json:"sort"`                                                              // 排序字段
+	LoginIp     string          `gorm:"column:login_ip" json:"loginIp"`                                                                 // 最后登录IP
+	LoginDate   int64           `gorm:"column:login_date" json:"loginDate"`                                                             // 最后登录时间（时间戳）
+	OpenId      string          `gorm:"column:open_id" json:"openId"`                                                                   // 微信OpenID
+	UnionId     string          `gorm:"column:union_id" json:"unionId"`                                                                 // 微信UnionID
+	Remark      string          `gorm:"column:remark" json:"remark"`                                                                    // 备注
+	MfaEnabled  bool            `gorm:"column:mfa_enabled;default:false" json:"mfaEnabled"`                                             // 是否已启用两步验证（TOTP）
+	MfaSecret   string          `gorm:"column:mfa_secret" json:"-"`                                                                     // TOTP 密钥（Base32）
+	MfaRecovery string          `gorm:"column:mfa_recovery;type:text" json:"-"`                                                         // 恢复码哈希列表（JSON 数组，已使用的会被移除）
+	CreateBy    int64           `gorm:"column:create_by" json:"createBy"`                                                               // 创建人
+	UpdateBy    int64           `gorm:"column:update_by" json:"updateBy"`                                                               // 更新人
 	CreatedTime utils.LocalTime `gorm:"column:created_time;autoCreateTime" json:"createdTime"`
 	UpdatedTime utils.LocalTime `gorm:"column:updated_time;autoUpdateTime" json:"updatedTime"`
 	DeletedAt   gorm.DeletedAt  `gorm:"column:deleted_at;index" json:"-"`
@@ -227,6 +233,21 @@ func (u *User) UpdatePassword(db *gorm.DB, userId int64, hashedPassword string)
 	return db.Model(&User{}).Where("id = ?", userId).Update("password", hashedPassword).Error
 }
 
+// UpdatePasswordWithState 更新密码及修改时间、强制修改标记
+func (u *User) UpdatePasswordWithState(db *gorm.DB, userId int64, hashedPassword string, updateAt int64, pwdChange string) error {
+	return db.Model(&User{}).Where("id = ?", userId).Updates(map[string]any{
+		"password":      hashedPassword,
+		"pwd_update_at": updateAt,
+		"pwd_change":    pwdChange,
+	}).Error
+}
+
+// RehashPassword 替换为新算法生成的哈希（密码本身不变），仅当哈希未被并发修改时生效
+func (u *User) RehashPassword(db *gorm.DB, userId int64, oldHash, newHash string) (int64, error) {
+	result := db.Model(&User{}).Where("id = ? AND password = ?", userId, oldHash).Update("password", newHash)
+	return result.RowsAffected, result.Error
+}
+
 // ClearPassword 清空密码字段（用于返回给前端）
 func (u *User) ClearPassword() {
 	u.Password = ""
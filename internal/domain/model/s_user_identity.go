@@ -0,0 +1,74 @@
+package model
+
+import (
+	"github.com/force-c/nai-tizi/internal/utils"
+	"gorm.io/gorm"
+)
+
+// UserIdentity 用户绑定的外部身份（OIDC 提供方、LDAP 目录）
+type UserIdentity struct {
+	ID          int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`                                                                                            // 记录ID（使用分布式ID）
+	TenantId    int64           `gorm:"column:tenant_id;not null;default:1;index" json:"tenantId"`                                                                                 // 租户ID
+	UserId      int64           `gorm:"column:user_id;not null;uniqueIndex:uk_user_identity_user_provider" json:"userId"`                                                          // 所属用户ID
+	Provider    string          `gorm:"column:provider;type:varchar(64);not null;uniqueIndex:uk_user_identity_subject;uniqueIndex:uk_user_identity_user_provider" json:"provider"` // 提供方标识
+	Subject     string          `gorm:"column:subject;type:varchar(255);not null;uniqueIndex:uk_user_identity_subject" json:"-"`                                                   // 提供方用户唯一标识（sub）
+	Email       string          `gorm:"column:email;type:varchar(255)" json:"email"`                                                                                               // 绑定时提供方返回的邮箱
+	Nickname    string          `gorm:"column:nickname;type:varchar(64)" json:"nickname"`                                                                                          // 绑定时提供方返回的昵称
+	LastLoginAt int64           `gorm:"column:last_login_at;default:0" json:"lastLoginAt"`                                                                                         // 最后登录时间（时间戳）
+	CreatedTime utils.LocalTime `gorm:"column:created_time;autoCreateTime" json:"createdTime"`
+	UpdatedTime utils.LocalTime `gorm:"column:updated_time;autoUpdateTime" json:"updatedTime"`
+}
+
+func (*UserIdentity) TableName() string { return "s_user_identity" }
+
+// FindBySubject 根据提供方和 sub 查询绑定记录
+func (*UserIdentity) FindBySubject(db *gorm.DB, provider, subject string) (*UserIdentity, error) {
+	var identity UserIdentity
+	err := db.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// FindByUserId 查询用户绑定的所有身份
+func (*UserIdentity) FindByUserId(db *gorm.DB, userId int64) ([]UserIdentity, error) {
+	var identities []UserIdentity
+	err := db.Where("user_id = ?", userId).Order("created_time ASC").Find(&identities).Error
+	return identities, err
+}
+
+// FindByProvider 查询指定提供方的所有绑定记录
+func (*UserIdentity) FindByProvider(db *gorm.DB, provider string) ([]UserIdentity, error) {
+	var identities []UserIdentity
+	err := db.Where("provider = ?", provider).Order("id ASC").Find(&identities).Error
+	return identities, err
+}
+
+// CountByUserId 统计用户绑定的身份数量
+func (*UserIdentity) CountByUserId(db *gorm.DB, userId int64) (int64, error) {
+	var count int64
+	err := db.Model(&UserIdentity{}).Where("user_id = ?", userId).Count(&count).Error
+	return count, err
+}
+
+// Create 创建绑定记录
+func (i *UserIdentity) Create(db *gorm.DB) error {
+	return db.Create(i).Error
+}
+
+// TouchLogin 更新最后登录时间
+func (*UserIdentity) TouchLogin(db *gorm.DB, id int64, loginAt int64) error {
+	return db.Model(&UserIdentity{}).Where("id = ?", id).Update("last_login_at", loginAt).Error
+}
+
+// Delete 解除绑定（仅限本人的记录）
+func (*UserIdentity) Delete(db *gorm.DB, userId, id int64) (int64, error) {
+	tx := db.Where("id = ? AND user_id = ?", id, userId).Delete(&UserIdentity{})
+	return tx.RowsAffected, tx.Error
+}
+
+// DeleteById 删除绑定记录
+func (*UserIdentity) DeleteById(db *gorm.DB, id int64) error {
+	return db.Where("id = ?", id).Delete(&UserIdentity{}).Error
+}
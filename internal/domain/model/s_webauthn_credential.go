@@ -0,0 +1,70 @@
+package model
+
+import (
+	"github.com/force-c/nai-tizi/internal/utils"
+	"gorm.io/gorm"
+)
+
+// WebAuthnCredential 通行密钥（WebAuthn）凭证
+type WebAuthnCredential struct {
+	ID              int64           `gorm:"column:id;primaryKey" autogen:"int64" json:"id"`                        // 凭证记录ID（使用分布式ID）
+	TenantId        int64           `gorm:"column:tenant_id;not null;default:1;index" json:"tenantId"`             // 租户ID
+	UserId          int64           `gorm:"column:user_id;not null;index" json:"userId"`                           // 所属用户ID
+	CredentialId    string          `gorm:"column:credential_id;type:varchar(1024);uniqueIndex;not null" json:"-"` // 凭证ID（Base64URL）
+	PublicKey       []byte          `gorm:"column:public_key;not null" json:"-"`                                   // 凭证公钥（COSE 编码）
+	AttestationType string          `gorm:"column:attestation_type;type:varchar(32)" json:"attestationType"`       // 证明类型
+	Transports      string          `gorm:"column:transports;type:varchar(255)" json:"transports"`                 // 支持的传输方式（逗号分隔）
+	Aaguid          string          `gorm:"column:aaguid;type:varchar(64)" json:"aaguid"`                          // 认证器型号标识
+	SignCount       int64           `gorm:"column:sign_count;default:0" json:"signCount"`                          // 签名计数器
+	BackupEligible  bool            `gorm:"column:backup_eligible;default:false" json:"backupEligible"`            // 是否支持同步备份
+	BackupState     bool            `gorm:"column:backup_state;default:false" json:"backupState"`                  // 是否已同步备份
+	Name            string          `gorm:"column:name;type:varchar(64)" json:"name"`                              // 凭证名称（用户自定义）
+	LastUsedAt      int64           `gorm:"column:last_used_at;default:0" json:"lastUsedAt"`                       // 最后使用时间（时间戳）
+	CreatedTime     utils.LocalTime `gorm:"column:created_time;autoCreateTime" json:"createdTime"`
+	UpdatedTime     utils.LocalTime `gorm:"column:updated_time;autoUpdateTime" json:"updatedTime"`
+}
+
+func (*WebAuthnCredential) TableName() string { return "s_webauthn_credential" }
+
+// FindByUserId 查询用户的所有凭证
+func (*WebAuthnCredential) FindByUserId(db *gorm.DB, userId int64) ([]WebAuthnCredential, error) {
+	var credentials []WebAuthnCredential
+	err := db.Where("user_id = ?", userId).Order("created_time DESC").Find(&credentials).Error
+	return credentials, err
+}
+
+// FindByCredentialId 根据凭证ID查询
+func (*WebAuthnCredential) FindByCredentialId(db *gorm.DB, credentialId string) (*WebAuthnCredential, error) {
+	var credential WebAuthnCredential
+	err := db.Where("credential_id = ?", credentialId).First(&credential).Error
+	if err != nil {
+		return nil, err
+	}
+	return &credential, nil
+}
+
+// Create 创建凭证
+func (c *WebAuthnCredential) Create(db *gorm.DB) error {
+	return db.Create(c).Error
+}
+
+// UpdateSignCount 登录成功后更新签名计数器和使用时间
+func (*WebAuthnCredential) UpdateSignCount(db *gorm.DB, id int64, signCount int64, backupState bool, usedAt int64) error {
+	return db.Model(&WebAuthnCredential{}).Where("id = ?", id).Updates(map[string]any{
+		"sign_count":   signCount,
+		"backup_state": backupState,
+		"last_used_at": usedAt,
+	}).Error
+}
+
+// Rename 重命名凭证
+func (*WebAuthnCredential) Rename(db *gorm.DB, userId, id int64, name string) (int64, error) {
+	result := db.Model(&WebAuthnCredential{}).Where("id = ? AND user_id = ?", id, userId).Update("name", name)
+	return result.RowsAffected, result.Error
+}
+
+// Delete 删除凭证（物理删除）
+func (*WebAuthnCredential) Delete(db *gorm.DB, userId, id int64) (int64, error) {
+	result := db.Where("id = ? AND user_id = ?", id, userId).Delete(&WebAuthnCredential{})
+	return result.RowsAffected, result.Error
+}
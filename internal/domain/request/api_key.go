@@ -0,0 +1,8 @@
+package request
+
+// ApiKeyCreateRequest 创建 API Key 请求
+type ApiKeyCreateRequest struct {
+	Name     string   `json:"name" binding:"required,max=64" example:"CI 部署"`                   // 名称
+	Scopes   []string `json:"scopes" binding:"required,min=1" example:"user.read,attachment.*"` // 授权范围（权限资源，支持 module.*、*.read 通配）
+	ExpireAt int64    `json:"expireAt" example:"1767225600"`                                    // 过期时间（时间戳），0 表示永不过期
+}
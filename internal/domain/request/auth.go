@@ -1,22 +1,38 @@
 package request
 
+import "encoding/json"
+
 // LoginRequest 登录请求
 //
 //	@Description	统一登录请求参数，根据 grantType 使用不同的字段组合
 type LoginRequest struct {
 	// 客户端认证（必填）
-	ClientKey    string `json:"clientKey" binding:"required" example:"web-admin"`                           // 客户端Key
-	ClientSecret string `json:"clientSecret" binding:"required" example:"web-secret-2024"`                  // 客户端密钥
-	GrantType    string `json:"grantType" binding:"required" example:"password" enums:"password,email,xcx"` // 授权类型：password-密码登录, email-邮箱验证码, xcx-微信小程序
+	ClientKey    string `json:"clientKey" binding:"required" example:"web-admin"`                                                      // 客户端Key
+	ClientSecret string `json:"clientSecret" binding:"required" example:"web-secret-2024"`                                             // 客户端密钥
+	GrantType    string `json:"grantType" binding:"required" example:"password" enums:"password,email,xcx,mfa,webauthn,oidc,ldap,sms"` // 授权类型：password-密码登录, email-邮箱验证码, xcx-微信小程序, mfa-两步验证, webauthn-通行密钥, oidc-第三方登录, ldap-域账号, sms-短信验证码
 
 	// 用户凭证（根据 grantType 选填）
-	Username    string `json:"username" example:"admin"`             // 用户名（password 必填）
-	Password    string `json:"password" example:"admin123"`          // 密码（password 必填）
-	Code        string `json:"code" example:"123456"`                // 验证码（email/xcx 必填）
-	Phonenumber string `json:"phonenumber" example:"13800138000"`    // 手机号（xcx 必填）
+	Username    string `json:"username" example:"admin"`             // 用户名（password/ldap 必填）
+	Password    string `json:"password" example:"admin123"`          // 密码（password/ldap 必填）
+	Code        string `json:"code" example:"123456"`                // 验证码（email/xcx/sms 必填；mfa 时为动态验证码或恢复码；oidc 时为提供方回调返回的授权码）
+	Phonenumber string `json:"phonenumber" example:"13800138000"`    // 手机号（xcx/sms 必填）
 	Email       string `json:"email" example:"admin@example.com"`    // 邮箱（email 必填）
 	WxCode      string `json:"wxCode" example:"wx-code-from-wechat"` // 微信code（xcx 必填）
 	Uuid        string `json:"uuid" example:"captcha-uuid-12345"`    // 图形验证码UUID（password 可选）
+	MfaTicket   string `json:"mfaTicket" example:"bWZhLXRpY2tldA=="` // 两步验证票据（mfa 必填）
+	State       string `json:"state"`                                // 提供方回调返回的 state（oidc 必填）
+	DeviceId    string `json:"deviceId"`                             // 设备标识（可选，客户端本地持久化的随机ID，用于识别新设备；为空时按 User-Agent 识别）
+
+	// 通行密钥（webauthn 必填）
+	ChallengeId string          `json:"challengeId" example:"0b6f3c9e-6a4f-4d8e-9a51-1c2f3e4d5a6b"` // 登录挑战ID
+	Credential  json.RawMessage `json:"credential" swaggertype:"object"`                            // navigator.credentials.get() 返回的凭证（JSON）
+}
+
+// WebAuthnLoginOptionsRequest 通行密钥登录参数请求
+type WebAuthnLoginOptionsRequest struct {
+	ClientKey    string `json:"clientKey" binding:"required" example:"web-admin"`          // 客户端Key
+	ClientSecret string `json:"clientSecret" binding:"required" example:"web-secret-2024"` // 客户端密钥
+	Username     string `json:"username" example:"admin"`                                  // 用户名（可选，为空时使用可发现凭证登录）
 }
 
 // SendSmsCodeRequest 发送短信验证码请求
@@ -0,0 +1,42 @@
+package request
+
+import "github.com/force-c/nai-tizi/internal/utils/pagination"
+
+// PageClientRequest 查询客户端列表请求
+type PageClientRequest struct {
+	pagination.PageQuery        // 嵌入分页参数
+	ClientKey            string `json:"clientKey" example:"pc"`    // 客户端Key（模糊查询）
+	ClientName           string `json:"clientName" example:"管理后台"` // 客户端名称（模糊查询）
+	Status               *int   `json:"status" example:"0"`        // 状态：0正常 1停用，不传表示不过滤
+}
+
+// ClientConfigRequest 客户端可编辑配置
+type ClientConfigRequest struct {
+	ClientName    string   `json:"clientName" binding:"max=100" example:"管理后台"`                              // 客户端名称（授权页展示）
+	GrantTypes    []string `json:"grantTypes" binding:"required,min=1" example:"password,refresh"`           // 允许的授权类型
+	DeviceType    string   `json:"deviceType" binding:"max=32" example:"pc"`                                 // 设备类型
+	Status        int      `json:"status" binding:"oneof=0 1" example:"0"`                                   // 状态：0正常 1停用
+	Timeout       int64    `json:"timeout" binding:"required,min=60,max=31536000" example:"604800"`          // RefreshToken 有效期（秒）
+	ActiveTimeout int64    `json:"activeTimeout" binding:"required,min=60,max=31536000" example:"1800"`      // AccessToken 有效期及空闲超时时间（秒）
+	IdleCheck     int      `json:"idleCheck" binding:"oneof=0 1" example:"0"`                                // 空闲超时检测：0启用 1关闭
+	RedirectUris  []string `json:"redirectUris" binding:"max=20" example:"https://app.example.com/callback"` // OAuth2 回调地址
+	Scopes        []string `json:"scopes" binding:"max=50" example:"profile,email"`                          // OAuth2 允许的授权范围
+	AutoApprove   bool     `json:"autoApprove" example:"false"`                                              // OAuth2 是否跳过用户授权确认
+	Remark        string   `json:"remark" binding:"max=500" example:"PC 管理后台"`                               // 备注
+}
+
+// CreateClientRequest 创建客户端请求（密钥由系统生成）
+type CreateClientRequest struct {
+	ClientKey string `json:"clientKey" binding:"required,max=32" example:"pc"` // 客户端Key（唯一，字母、数字、下划线或中划线，创建后不可修改）
+	ClientConfigRequest
+}
+
+// UpdateClientRequest 更新客户端请求
+type UpdateClientRequest struct {
+	ClientConfigRequest
+}
+
+// RotateClientSecretRequest 轮换客户端密钥请求
+type RotateClientSecretRequest struct {
+	GracePeriod int64 `json:"gracePeriod" binding:"min=0,max=2592000" example:"86400"` // 旧密钥保留时间（秒），0 表示立即失效，最长 30 天
+}
@@ -0,0 +1,13 @@
+package request
+
+import "github.com/force-c/nai-tizi/internal/utils/pagination"
+
+// ImpersonateRequest 代登录请求
+type ImpersonateRequest struct {
+	Reason string `json:"reason" binding:"required,max=500" example:"工单 #1024：用户反馈订单页面显示异常"` // 代登录原因（记录在审计日志中，目标用户可见）
+}
+
+// PageImpersonationLogRequest 代登录记录查询请求
+type PageImpersonationLogRequest struct {
+	pagination.PageQuery // 嵌入分页参数
+}
@@ -11,7 +11,6 @@ type CreateLoginLogRequest struct {
 	Os            string `json:"os"`                          // 操作系统
 	Status        int32  `json:"status"`                      // 登录状态：0成功 1失败
 	Msg           string `json:"msg"`                         // 提示消息
-	TenantId      string `json:"tenantId"`                    // 租户ID
 	ClientId      string `json:"clientId"`                    // 客户端ID
 }
 
@@ -25,7 +24,6 @@ type UpdateLoginLogRequest struct {
 	Os            string `json:"os"`                    // 操作系统
 	Status        int32  `json:"status"`                // 登录状态：0成功 1失败
 	Msg           string `json:"msg"`                   // 提示消息
-	TenantId      string `json:"tenantId"`              // 租户ID
 	ClientId      string `json:"clientId"`              // 客户端ID
 }
 
@@ -37,14 +35,21 @@ type BatchDeleteLoginLogRequest struct {
 // PageLoginLogRequest 登录日志列表查询请求
 type PageLoginLogRequest struct {
 	pagination.PageQuery        // 嵌入分页参数
-	UserName             string `json:"userName"`  // 用户名（可选,模糊查询）
-	Ipaddr               string `json:"ipaddr"`    // 登录IP（可选,模糊查询）
-	Status               *int32 `json:"status"`    // 登录状态（可选,nil表示全部,0成功 1失败）
-	StartTime            string `json:"startTime"` // 开始时间（可选）
-	EndTime              string `json:"endTime"`   // 结束时间（可选）
+	UserName             string `json:"userName"`     // 用户名（可选,模糊查询）
+	Ipaddr               string `json:"ipaddr"`       // 登录IP（可选,模糊查询）
+	Status               *int32 `json:"status"`       // 登录状态（可选,nil表示全部,0成功 1失败）
+	StartTime            string `json:"startTime"`    // 开始时间（可选）
+	EndTime              string `json:"endTime"`      // 结束时间（可选）
+	MinRiskScore         int    `json:"minRiskScore"` // 最低风险分（可选，大于 0 时只查询风险分不低于该值的记录）
 }
 
 // CleanLoginLogRequest 清理登录日志请求
 type CleanLoginLogRequest struct {
 	Days int `json:"days" binding:"required,min=1"` // 清理多少天之前的日志
 }
+
+// UnlockLoginRequest 解除登录锁定请求
+type UnlockLoginRequest struct {
+	Scope   string `json:"scope" binding:"required,oneof=account ip_account ip" example:"account"` // 锁定维度：account 账号 / ip_account IP+账号 / ip IP
+	Subject string `json:"subject" binding:"required" example:"admin"`                             // 锁定对象（与列表返回的 subject 一致）
+}
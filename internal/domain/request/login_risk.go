@@ -0,0 +1,6 @@
+package request
+
+// LoginRiskRevokeRequest "不是我本人"注销会话请求
+type LoginRiskRevokeRequest struct {
+	Token string `json:"token" binding:"required,max=128"` // 异常登录提醒链接中的 token
+}
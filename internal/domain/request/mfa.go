@@ -0,0 +1,11 @@
+package request
+
+// MfaCodeRequest 两步验证动态码请求
+type MfaCodeRequest struct {
+	Code string `json:"code" binding:"required" example:"123456"` // 动态验证码（6位）或恢复码
+}
+
+// MfaTicketEnrollRequest 使用登录票据绑定两步验证请求
+type MfaTicketEnrollRequest struct {
+	MfaTicket string `json:"mfaTicket" binding:"required" example:"bWZhLXRpY2tldA=="` // 登录返回的两步验证票据
+}
@@ -0,0 +1,40 @@
+package request
+
+// OAuthAuthorizeRequest OAuth2 授权请求（授权码模式）
+//
+//	@Description	字段与 RFC 6749 授权端点参数一致，前端授权页将回调到的查询参数原样透传
+type OAuthAuthorizeRequest struct {
+	ResponseType        string `form:"response_type" json:"response_type" binding:"required" example:"code"`                                          // 响应类型，固定为 code
+	ClientId            string `form:"client_id" json:"client_id" binding:"required" example:"internal-app"`                                          // 客户端Key
+	RedirectUri         string `form:"redirect_uri" json:"redirect_uri" example:"https://app.example.com/callback"`                                   // 回调地址（客户端仅登记一个时可省略）
+	Scope               string `form:"scope" json:"scope" example:"profile email"`                                                                    // 授权范围（空格分隔，为空时申请客户端允许的全部范围）
+	State               string `form:"state" json:"state" example:"af0ifjsldkj"`                                                                      // 客户端状态值，原样回传
+	CodeChallenge       string `form:"code_challenge" json:"code_challenge" binding:"required" example:"E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"` // PKCE 挑战值
+	CodeChallengeMethod string `form:"code_challenge_method" json:"code_challenge_method" binding:"required" example:"S256"`                          // PKCE 挑战方法，仅支持 S256
+}
+
+// OAuthConsentRequest OAuth2 授权确认请求
+type OAuthConsentRequest struct {
+	OAuthAuthorizeRequest
+	Approve bool `json:"approve" example:"true"` // 是否同意授权
+}
+
+// OAuthTokenRequest OAuth2 令牌请求（application/x-www-form-urlencoded）
+type OAuthTokenRequest struct {
+	GrantType    string `form:"grant_type" binding:"required" example:"authorization_code"` // 授权类型：authorization_code / client_credentials / refresh_token
+	Code         string `form:"code"`                                                       // 授权码（authorization_code 必填）
+	RedirectUri  string `form:"redirect_uri"`                                               // 回调地址（须与授权请求一致）
+	CodeVerifier string `form:"code_verifier"`                                              // PKCE 校验值（authorization_code 必填）
+	RefreshToken string `form:"refresh_token"`                                              // 刷新令牌（refresh_token 必填）
+	Scope        string `form:"scope"`                                                      // 授权范围（client_credentials / refresh_token 可选）
+	ClientId     string `form:"client_id"`                                                  // 客户端Key（未使用 HTTP Basic 认证时必填）
+	ClientSecret string `form:"client_secret"`                                              // 客户端密钥（未使用 HTTP Basic 认证时必填）
+}
+
+// OAuthTokenHintRequest OAuth2 令牌内省/撤销请求（application/x-www-form-urlencoded）
+type OAuthTokenHintRequest struct {
+	Token         string `form:"token" binding:"required"` // 待内省或撤销的令牌
+	TokenTypeHint string `form:"token_type_hint"`          // 令牌类型提示：access_token / refresh_token
+	ClientId      string `form:"client_id"`                // 客户端Key（未使用 HTTP Basic 认证时必填）
+	ClientSecret  string `form:"client_secret"`            // 客户端密钥（未使用 HTTP Basic 认证时必填）
+}
@@ -0,0 +1,19 @@
+package request
+
+// OidcAuthorizeRequest 第三方登录授权地址请求
+type OidcAuthorizeRequest struct {
+	ClientKey    string `json:"clientKey" binding:"required" example:"web-admin"`          // 客户端Key
+	ClientSecret string `json:"clientSecret" binding:"required" example:"web-secret-2024"` // 客户端密钥
+	Provider     string `json:"provider" binding:"required" example:"keycloak"`            // 提供方标识
+}
+
+// OidcLinkAuthorizeRequest 绑定第三方账号授权地址请求
+type OidcLinkAuthorizeRequest struct {
+	Provider string `json:"provider" binding:"required" example:"keycloak"` // 提供方标识
+}
+
+// OidcLinkRequest 完成绑定第三方账号请求
+type OidcLinkRequest struct {
+	Code  string `json:"code" binding:"required"`  // 提供方回调返回的授权码
+	State string `json:"state" binding:"required"` // 提供方回调返回的 state
+}
@@ -51,6 +51,7 @@ type PageOperLogRequest struct {
 	pagination.PageQuery         // 嵌入分页参数
 	Title                string  `json:"title"`        // 模块标题（可选,模糊查询）
 	OperName             string  `json:"operName"`     // 操作者（可选,模糊查询）
+	ActorName            string  `json:"actorName"`    // 代登录的管理员（可选,模糊查询）
 	BusinessType         string  `json:"businessType"` // 业务类型（可选）
 	Status               *string `json:"status"`       // 操作状态（可选,nil表示全部,"0"成功 "1"失败）
 	StartTime            string  `json:"startTime"`    // 开始时间（可选）
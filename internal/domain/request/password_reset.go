@@ -0,0 +1,20 @@
+package request
+
+// PasswordForgotRequest 申请找回密码请求
+type PasswordForgotRequest struct {
+	Channel string `json:"channel" binding:"required,oneof=email sms" example:"email"`    // 找回方式：email 邮箱链接 / sms 短信验证码
+	Account string `json:"account" binding:"required,max=128" example:"user@example.com"` // 邮箱或手机号
+}
+
+// PasswordResetVerifyRequest 校验找回密码凭证请求
+type PasswordResetVerifyRequest struct {
+	Channel string `json:"channel" binding:"required,oneof=email sms" example:"sms"` // 找回方式
+	Account string `json:"account" example:"13800138000"`                            // 手机号（sms 必填）
+	Code    string `json:"code" binding:"required" example:"123456"`                 // 短信验证码或邮件链接中的 token
+}
+
+// PasswordResetRequest 设置新密码请求
+type PasswordResetRequest struct {
+	ResetToken  string `json:"resetToken" binding:"required"`                       // 重置令牌（verify 接口返回）
+	NewPassword string `json:"newPassword" binding:"required" example:"Abc@123456"` // 新密码
+}
@@ -0,0 +1,55 @@
+package request
+
+import "github.com/force-c/nai-tizi/internal/utils/pagination"
+
+// RegisterEmailCodeRequest 发送注册邮箱验证码请求
+type RegisterEmailCodeRequest struct {
+	Email       string `json:"email" binding:"required,email,max=128" example:"user@example.com"` // 邮箱
+	CaptchaId   string `json:"captchaId" example:"captcha-uuid-12345"`                            // 图形验证码ID（注册设置开启验证码时必填）
+	CaptchaCode string `json:"captchaCode" example:"a8k2"`                                        // 图形验证码
+}
+
+// RegisterRequest 自助注册请求
+type RegisterRequest struct {
+	UserName    string `json:"userName" binding:"required,min=3,max=20,alphanum" example:"zhangsan"` // 用户名（字母和数字）
+	NickName    string `json:"nickName" binding:"omitempty,max=30" example:"张三"`                     // 昵称，为空时使用用户名
+	Password    string `json:"password" binding:"required" example:"Abc@123456"`                     // 密码（按组织密码策略校验）
+	Email       string `json:"email" binding:"omitempty,email,max=128" example:"user@example.com"`   // 邮箱（开放注册必填）
+	EmailCode   string `json:"emailCode" example:"123456"`                                           // 邮箱验证码（填写邮箱时必填）
+	InviteCode  string `json:"inviteCode" binding:"omitempty,max=32" example:"K7Q2M9XD"`             // 邀请码（邀请码注册时必填）
+	CaptchaId   string `json:"captchaId" example:"captcha-uuid-12345"`                               // 图形验证码ID（注册设置开启验证码时必填）
+	CaptchaCode string `json:"captchaCode" example:"a8k2"`                                           // 图形验证码
+}
+
+// ApproveUserRequest 审批自助注册用户请求
+type ApproveUserRequest struct {
+	Approved bool   `json:"approved"`                                      // true 通过 / false 拒绝（拒绝将删除该注册）
+	Reason   string `json:"reason" binding:"omitempty,max=200" example:""` // 拒绝原因（会通过邮件告知用户）
+}
+
+// PageInviteCodeRequest 分页查询邀请码请求
+type PageInviteCodeRequest struct {
+	pagination.PageQuery
+	Code   string `json:"code"`   // 邀请码（可选，模糊查询）
+	OrgId  int64  `json:"orgId"`  // 组织ID（可选）
+	Status *int32 `json:"status"` // 状态（可选，nil 表示全部）
+}
+
+// CreateInviteCodeRequest 生成邀请码请求
+type CreateInviteCodeRequest struct {
+	OrgId    int64   `json:"orgId" binding:"required" example:"1"`                  // 注册用户所属组织ID
+	RoleIds  []int64 `json:"roleIds"`                                               // 注册用户默认角色ID
+	MaxUses  int     `json:"maxUses" binding:"min=0,max=100000" example:"1"`        // 每个邀请码最多可使用次数，0 表示不限
+	ExpireAt int64   `json:"expireAt" example:"1767196800"`                         // 过期时间（时间戳），0 表示永不过期
+	Count    int     `json:"count" binding:"omitempty,min=1,max=100" example:"1"`   // 批量生成数量，默认 1
+	Remark   string  `json:"remark" binding:"omitempty,max=200" example:"2026 届校招"` // 备注
+}
+
+// UpdateInviteCodeRequest 更新邀请码请求
+type UpdateInviteCodeRequest struct {
+	RoleIds  []int64 `json:"roleIds"`                              // 注册用户默认角色ID
+	MaxUses  int     `json:"maxUses" binding:"min=0,max=100000"`   // 最多可使用次数，0 表示不限
+	ExpireAt int64   `json:"expireAt"`                             // 过期时间（时间戳），0 表示永不过期
+	Status   int32   `json:"status" binding:"omitempty,oneof=0 1"` // 状态：0正常 1停用
+	Remark   string  `json:"remark" binding:"omitempty,max=200"`   // 备注
+}
@@ -11,22 +11,26 @@ type PageRoleRequest struct {
 
 // CreateRoleRequest 创建角色请求
 type CreateRoleRequest struct {
-	RoleKey   string `json:"roleKey" binding:"required" example:"user_manager"` // 角色标识（唯一）
-	RoleName  string `json:"roleName" binding:"required" example:"用户管理员"`       // 角色名称
-	Sort      int64  `json:"sort" example:"1"`                                  // 显示顺序
-	Status    int32  `json:"status" example:"0"`                                // 状态：0正常 1停用
-	DataScope int32  `json:"dataScope" example:"2"`                             // 数据范围：1全部 2自定义 3本组织 4本组织及以下 5仅本人
-	Remark    string `json:"remark" example:"负责用户管理"`                           // 备注
+	RoleKey    string  `json:"roleKey" binding:"required" example:"user_manager"`         // 角色标识（唯一）
+	RoleName   string  `json:"roleName" binding:"required" example:"用户管理员"`               // 角色名称
+	Sort       int64   `json:"sort" example:"1"`                                          // 显示顺序
+	Status     int32   `json:"status" example:"0"`                                        // 状态：0正常 1停用
+	DataScope  int32   `json:"dataScope" binding:"omitempty,oneof=1 2 3 4 5" example:"2"` // 数据范围：1全部 2自定义 3本组织 4本组织及以下 5仅本人，默认全部
+	OrgIds     []int64 `json:"orgIds" example:"1,2"`                                      // 自定义数据权限的组织ID（数据范围为自定义时必填）
+	RequireMfa bool    `json:"requireMfa" example:"false"`                                // 是否强制启用两步验证
+	Remark     string  `json:"remark" example:"负责用户管理"`                                   // 备注
 }
 
 // UpdateRoleRequest 更新角色请求
 type UpdateRoleRequest struct {
-	RoleId    int64  `json:"roleId" binding:"required" example:"1"`       // 角色ID
-	RoleName  string `json:"roleName" binding:"required" example:"用户管理员"` // 角色名称
-	Sort      int64  `json:"sort" example:"1"`                            // 显示顺序
-	Status    int32  `json:"status" example:"0"`                          // 状态：0正常 1停用
-	DataScope int32  `json:"dataScope" example:"2"`                       // 数据范围
-	Remark    string `json:"remark" example:"负责用户管理"`                     // 备注
+	RoleId     int64   `json:"roleId" binding:"required" example:"1"`                     // 角色ID
+	RoleName   string  `json:"roleName" binding:"required" example:"用户管理员"`               // 角色名称
+	Sort       int64   `json:"sort" example:"1"`                                          // 显示顺序
+	Status     int32   `json:"status" example:"0"`                                        // 状态：0正常 1停用
+	DataScope  int32   `json:"dataScope" binding:"omitempty,oneof=1 2 3 4 5" example:"2"` // 数据范围：1全部 2自定义 3本组织 4本组织及以下 5仅本人，默认全部
+	OrgIds     []int64 `json:"orgIds" example:"1,2"`                                      // 自定义数据权限的组织ID（数据范围为自定义时必填）
+	RequireMfa bool    `json:"requireMfa" example:"false"`                                // 是否强制启用两步验证
+	Remark     string  `json:"remark" example:"负责用户管理"`                                   // 备注
 }
 
 // AssignRoleToUserRequest 为用户分配角色请求
@@ -43,4 +47,3 @@ type AddRolePermissionRequest struct {
 	Resource string `json:"resource" binding:"required" example:"user.*"`      // 资源路径（支持通配符）
 	Action   string `json:"action" binding:"required" example:"write"`         // 操作类型（支持通配符）
 }
-
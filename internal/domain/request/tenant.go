@@ -0,0 +1,36 @@
+package request
+
+import "github.com/force-c/nai-tizi/internal/utils/pagination"
+
+// PageTenantRequest 分页查询租户请求
+type PageTenantRequest struct {
+	pagination.PageQuery
+	TenantCode string `json:"tenantCode"` // 租户编码（可选，模糊查询）
+	TenantName string `json:"tenantName"` // 租户名称（可选，模糊查询）
+	Status     *int32 `json:"status"`     // 状态（可选，nil 表示全部）
+}
+
+// CreateTenantRequest 开通租户请求
+type CreateTenantRequest struct {
+	TenantCode   string `json:"tenantCode" binding:"required,min=2,max=31" example:"acme"`     // 租户编码（小写字母、数字和中划线，用于子域名）
+	TenantName   string `json:"tenantName" binding:"required,max=100" example:"Acme 公司"`       // 租户名称
+	Plan         string `json:"plan" binding:"omitempty,max=32" example:"standard"`            // 套餐
+	ExpireAt     int64  `json:"expireAt" example:"1798732800"`                                 // 到期时间（时间戳），0 表示永不过期
+	ContactName  string `json:"contactName" binding:"omitempty,max=50" example:"张三"`           // 联系人
+	ContactPhone string `json:"contactPhone" binding:"omitempty,max=20" example:"13800000000"` // 联系电话
+	Remark       string `json:"remark" binding:"omitempty,max=200"`                            // 备注
+
+	AdminUserName string `json:"adminUserName" binding:"required,max=64" example:"admin"` // 租户管理员用户名
+	AdminPassword string `json:"adminPassword" binding:"required" example:"Admin@123"`    // 租户管理员初始密码（按模板租户的密码策略校验）
+	AdminNickName string `json:"adminNickName" binding:"omitempty,max=64" example:"管理员"`  // 租户管理员昵称
+}
+
+// UpdateTenantRequest 更新租户请求（租户编码不可修改）
+type UpdateTenantRequest struct {
+	TenantName   string `json:"tenantName" binding:"required,max=100"`   // 租户名称
+	Plan         string `json:"plan" binding:"omitempty,max=32"`         // 套餐
+	ExpireAt     int64  `json:"expireAt"`                                // 到期时间（时间戳），0 表示永不过期
+	ContactName  string `json:"contactName" binding:"omitempty,max=50"`  // 联系人
+	ContactPhone string `json:"contactPhone" binding:"omitempty,max=20"` // 联系电话
+	Remark       string `json:"remark" binding:"omitempty,max=200"`      // 备注
+}
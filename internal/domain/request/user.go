@@ -8,7 +8,7 @@ type CreateUserRequest struct {
 	UserName    string `json:"userName" binding:"required,min=3,max=20"`
 	NickName    string `json:"nickName" binding:"required"`
 	Password    string `json:"password" binding:"required,min=6"`
-	UserType    int32  `json:"userType"` // 用户类型：0系统用户 1微信用户 2APP用户
+	UserType    int32  `json:"userType"` // 用户类型：0系统用户 1微信用户 2APP用户 3服务账号
 	Email       string `json:"email" binding:"omitempty,email"`
 	Phonenumber string `json:"phonenumber" binding:"omitempty,len=11"`
 	Sex         int32  `json:"sex" binding:"omitempty,oneof=0 1 2"` // 性别：0男 1女 2未知
@@ -25,7 +25,7 @@ type UpdateUserRequest struct {
 	OrgId       int64  `json:"orgId"`
 	UserName    string `json:"userName" binding:"omitempty,min=3,max=20"`
 	NickName    string `json:"nickName"`
-	UserType    int32  `json:"userType"` // 用户类型：0系统用户 1微信用户 2APP用户
+	UserType    int32  `json:"userType"` // 用户类型：0系统用户 1微信用户 2APP用户 3服务账号
 	Email       string `json:"email" binding:"omitempty,email"`
 	Phonenumber string `json:"phonenumber" binding:"omitempty,len=11"`
 	Sex         int32  `json:"sex" binding:"omitempty,oneof=0 1 2"` // 性别：0男 1女 2未知
@@ -45,12 +45,30 @@ type PageUsersRequest struct {
 	pagination.PageQuery        // 嵌入分页参数
 	UserName             string `json:"username"`
 	Phonenumber          string `json:"phonenumber"`
-	Status               int32  `json:"status" binding:"omitempty,oneof=0 1"` // 状态：0正常 1停用
+	Status               int32  `json:"status" binding:"omitempty,oneof=0 1 2"` // 状态：0正常 1停用 2待审批
+}
+
+// ImportUserRequest 批量导入的用户，可直接导入其他系统的密码哈希
+type ImportUserRequest struct {
+	OrgId        int64  `json:"orgId" binding:"required"`
+	UserName     string `json:"userName" binding:"required,min=3,max=20"`
+	NickName     string `json:"nickName" binding:"required"`
+	Password     string `json:"password" binding:"required_without=PasswordHash,omitempty,min=6"`
+	PasswordHash string `json:"passwordHash"` // 其他系统的密码哈希（bcrypt / argon2id / scrypt / $legacy$ 加盐摘要），提供时不校验密码策略，首次登录后自动升级为当前算法
+	UserType     int32  `json:"userType"`     // 用户类型：0系统用户 1微信用户 2APP用户 3服务账号
+	Email        string `json:"email" binding:"omitempty,email"`
+	Phonenumber  string `json:"phonenumber" binding:"omitempty,len=11"`
+	Sex          int32  `json:"sex" binding:"omitempty,oneof=0 1 2"` // 性别：0男 1女 2未知
+	Avatar       string `json:"avatar"`
+	Status       int32  `json:"status" binding:"omitempty,oneof=0 1"` // 状态：0正常 1停用
+	Remark       string `json:"remark"`
+	CreateBy     int64  `json:"-"` // 从上下文获取，不从 JSON 解析
+	UpdateBy     int64  `json:"-"` // 从上下文获取，不从 JSON 解析
 }
 
 // BatchImportUsersRequest 批量导入用户请求
 type BatchImportUsersRequest struct {
-	Users []CreateUserRequest `json:"users" binding:"required,min=1,dive"`
+	Users []ImportUserRequest `json:"users" binding:"required,min=1,dive"`
 }
 
 // ResetPasswordRequest 重置密码请求
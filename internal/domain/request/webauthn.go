@@ -0,0 +1,14 @@
+package request
+
+import "encoding/json"
+
+// WebAuthnRegisterRequest 完成通行密钥注册请求
+type WebAuthnRegisterRequest struct {
+	Name       string          `json:"name" example:"我的 MacBook"`                          // 凭证名称（可选）
+	Credential json.RawMessage `json:"credential" binding:"required" swaggertype:"object"` // navigator.credentials.create() 返回的凭证（JSON）
+}
+
+// WebAuthnRenameRequest 重命名通行密钥请求
+type WebAuthnRenameRequest struct {
+	Name string `json:"name" binding:"required,max=64" example:"办公室 YubiKey"` // 凭证名称
+}
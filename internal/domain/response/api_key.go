@@ -0,0 +1,21 @@
+package response
+
+import "github.com/force-c/nai-tizi/internal/utils"
+
+// ApiKeyResponse API Key 响应（不含明文）
+type ApiKeyResponse struct {
+	Id          int64           `json:"id" example:"1"`                          // 记录ID
+	Name        string          `json:"name" example:"CI 部署"`                    // 名称
+	KeyPrefix   string          `json:"keyPrefix" example:"ntz_Ab3dE9xQ"`        // 明文前缀（用于识别）
+	Scopes      []string        `json:"scopes" example:"user.read,attachment.*"` // 授权范围
+	ExpireAt    int64           `json:"expireAt" example:"1767225600"`           // 过期时间（时间戳），0 表示永不过期
+	LastUsedAt  int64           `json:"lastUsedAt" example:"1700000000"`         // 最后使用时间（时间戳）
+	LastUsedIp  string          `json:"lastUsedIp" example:"10.0.0.8"`           // 最后使用IP
+	CreatedTime utils.LocalTime `json:"createdTime"`                             // 创建时间
+}
+
+// ApiKeyCreateResponse 创建 API Key 响应
+type ApiKeyCreateResponse struct {
+	ApiKeyResponse
+	Key string `json:"key" example:"ntz_Ab3dE9xQ..."` // API Key 明文，仅在创建时返回一次，请妥善保存
+}
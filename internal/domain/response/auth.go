@@ -9,6 +9,16 @@ type LoginResponse struct {
 	ExpiresIn        int64     `json:"expires_in" example:"1800"`                                      // AccessToken 过期时间（秒）
 	RefreshExpiresIn int64     `json:"refresh_expires_in" example:"604800"`                            // RefreshToken 过期时间（秒）
 	UserInfo         *UserInfo `json:"user_info"`                                                      // 用户信息
+
+	// 两步验证（用户已启用或所属角色强制启用时返回，此时不签发 Token）
+	MfaRequired      bool     `json:"mfa_required,omitempty" example:"false"`       // 是否需要两步验证
+	MfaSetupRequired bool     `json:"mfa_setup_required,omitempty" example:"false"` // 是否需要先绑定两步验证
+	MfaTicket        string   `json:"mfa_ticket,omitempty"`                         // 两步验证票据（grantType=mfa 时提交）
+	RecoveryCodes    []string `json:"recovery_codes,omitempty"`                     // 登录时完成绑定返回的恢复码
+
+	// 密码策略（需要修改密码时返回，前端应跳转修改密码页面）
+	PasswordChangeRequired bool   `json:"password_change_required,omitempty" example:"false"`     // 是否需要修改密码
+	PasswordChangeReason   string `json:"password_change_reason,omitempty" example:"first_login"` // 原因：first_login 首次登录 / reset 管理员重置 / expired 密码过期
 }
 
 // RefreshTokenRequest 刷新令牌请求
@@ -41,4 +51,5 @@ type UserInfo struct {
 	Email       string `json:"email" example:"admin@example.com"`               // 邮箱
 	Avatar      string `json:"avatar" example:"https://example.com/avatar.jpg"` // 头像URL
 	UserType    int32  `json:"userType" example:"0"`                            // 用户类型：0系统用户 1微信用户 2APP用户
+	TenantId    int64  `json:"tenantId" example:"1"`                            // 租户ID
 }
@@ -0,0 +1,31 @@
+package response
+
+import "github.com/force-c/nai-tizi/internal/utils"
+
+// ClientResponse 客户端响应（不含密钥）
+type ClientResponse struct {
+	ClientId      string          `json:"clientId" example:"e5cd7e4891bf95d1d19206ce24a7b32e"` // 客户端ID
+	ClientKey     string          `json:"clientKey" example:"pc"`                              // 客户端Key
+	ClientName    string          `json:"clientName" example:"管理后台"`                           // 客户端名称
+	GrantTypes    []string        `json:"grantTypes" example:"password,refresh"`               // 允许的授权类型
+	DeviceType    string          `json:"deviceType" example:"pc"`                             // 设备类型
+	Status        int             `json:"status" example:"0"`                                  // 状态：0正常 1停用
+	Timeout       int64           `json:"timeout" example:"604800"`                            // RefreshToken 有效期（秒）
+	ActiveTimeout int64           `json:"activeTimeout" example:"1800"`                        // AccessToken 有效期及空闲超时时间（秒）
+	IdleCheck     int             `json:"idleCheck" example:"0"`                               // 空闲超时检测：0启用 1关闭
+	RedirectUris  []string        `json:"redirectUris" example:"https://app.example.com/cb"`   // OAuth2 回调地址
+	Scopes        []string        `json:"scopes" example:"profile,email"`                      // OAuth2 允许的授权范围
+	AutoApprove   bool            `json:"autoApprove" example:"false"`                         // OAuth2 是否跳过用户授权确认
+	PrevExpireAt  int64           `json:"prevExpireAt" example:"1700086400"`                   // 旧密钥失效时间（时间戳），0 表示没有仍有效的旧密钥
+	Remark        string          `json:"remark" example:"PC 管理后台"`                            // 备注
+	CreatedTime   utils.LocalTime `json:"createdTime"`                                         // 创建时间
+	UpdatedTime   utils.LocalTime `json:"updatedTime"`                                         // 更新时间
+}
+
+// ClientSecretResponse 客户端密钥响应（创建或轮换时返回）
+type ClientSecretResponse struct {
+	ClientId     string `json:"clientId" example:"e5cd7e4891bf95d1d19206ce24a7b32e"` // 客户端ID
+	ClientKey    string `json:"clientKey" example:"pc"`                              // 客户端Key
+	ClientSecret string `json:"clientSecret" example:"Xq3...="`                      // 客户端密钥明文，仅返回一次，请妥善保存
+	PrevExpireAt int64  `json:"prevExpireAt" example:"1700086400"`                   // 旧密钥失效时间（时间戳），0 表示旧密钥已失效
+}
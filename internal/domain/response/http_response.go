@@ -9,6 +9,7 @@ const (
 	CodeForbidden       = 403
 	CodeNotFound        = 404
 	CodeTimeout         = 408
+	CodeSessionIdle     = 419 // 会话空闲超时，须重新登录（RefreshToken 同时失效，无需尝试刷新）
 	CodeTooManyRequests = 429
 	CodeServerError     = 500
 	CodeInvalidParam    = 400
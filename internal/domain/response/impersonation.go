@@ -0,0 +1,23 @@
+package response
+
+import "github.com/force-c/nai-tizi/internal/utils"
+
+// ImpersonateResponse 代登录响应
+//
+//	@Description	以用户身份签发的短期 Token，不签发 RefreshToken，过期后需重新代登录
+type ImpersonateResponse struct {
+	AccessToken string    `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // 访问令牌（携带 act 声明）
+	ExpiresIn   int64     `json:"expires_in" example:"900"`                                       // AccessToken 过期时间（秒）
+	SessionId   string    `json:"session_id"`                                                     // 代登录会话ID
+	UserInfo    *UserInfo `json:"user_info"`                                                      // 被代登录的用户信息
+}
+
+// ImpersonationLogResponse 代登录记录响应
+type ImpersonationLogResponse struct {
+	Id          int64           `json:"id" example:"1"`                         // 记录ID
+	ActorName   string          `json:"actorName" example:"support01"`          // 代登录的管理员
+	Reason      string          `json:"reason" example:"工单 #1024：用户反馈订单页面显示异常"` // 代登录原因
+	Ipaddr      string          `json:"ipaddr" example:"10.0.0.8"`              // 管理员IP
+	ExpireAt    int64           `json:"expireAt" example:"1700000900"`          // Token 过期时间（时间戳）
+	CreatedTime utils.LocalTime `json:"createdTime"`                            // 代登录时间
+}
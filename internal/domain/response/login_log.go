@@ -1,6 +1,8 @@
 package response
 
 import (
+	"strings"
+
 	"github.com/force-c/nai-tizi/internal/domain/model"
 	"github.com/force-c/nai-tizi/internal/utils"
 )
@@ -16,8 +18,10 @@ type LoginLogResponse struct {
 	Status        int32           `json:"status"`        // 登录状态：0成功 1失败
 	Msg           string          `json:"msg"`           // 提示消息
 	LoginTime     utils.LocalTime `json:"loginTime"`     // 登录时间
-	TenantId      string          `json:"tenantId"`      // 租户ID
+	TenantId      int64           `json:"tenantId"`      // 租户ID
 	ClientId      string          `json:"clientId"`      // 客户端ID
+	RiskScore     int             `json:"riskScore"`     // 登录风险分（0-100）
+	RiskReasons   []string        `json:"riskReasons"`   // 命中的风险因素：new_device 新设备 / new_ip_range 新 IP 段 / new_country 新国家 / impossible_travel 不可能的移动速度 / blocked_ip 黑名单 IP
 }
 
 // LoginLogListResponse 登录日志列表响应
@@ -40,7 +44,17 @@ func ToLoginLogResponse(log *model.LoginLog) LoginLogResponse {
 		LoginTime:     log.LoginTime,
 		TenantId:      log.TenantId,
 		ClientId:      log.ClientId,
+		RiskScore:     log.RiskScore,
+		RiskReasons:   splitRiskReasons(log.RiskReasons),
+	}
+}
+
+// splitRiskReasons 拆分风险因素编码
+func splitRiskReasons(reasons string) []string {
+	if reasons == "" {
+		return []string{}
 	}
+	return strings.Split(reasons, ",")
 }
 
 // ToLoginLogListResponse 转换为登录日志列表响应
@@ -54,3 +68,14 @@ func ToLoginLogListResponse(logs []model.LoginLog, total int64) LoginLogListResp
 		List:  list,
 	}
 }
+
+// LoginLockResponse 登录锁定记录响应
+type LoginLockResponse struct {
+	Scope    string `json:"scope" example:"ip_account"`       // 锁定维度：account 账号 / ip_account IP+账号 / ip IP
+	Subject  string `json:"subject" example:"10.0.0.8|admin"` // 锁定对象
+	Account  string `json:"account" example:"admin"`          // 触发锁定的账号
+	Ip       string `json:"ip" example:"10.0.0.8"`            // 触发锁定的 IP
+	Level    int    `json:"level" example:"1"`                // 锁定级别（第几次锁定，级别越高锁定越久）
+	LockedAt int64  `json:"lockedAt" example:"1700000000"`    // 锁定时间（时间戳）
+	Until    int64  `json:"until" example:"1700000300"`       // 解锁时间（时间戳）
+}
@@ -0,0 +1,19 @@
+package response
+
+// MfaStatusResponse 两步验证状态响应
+type MfaStatusResponse struct {
+	Enabled                bool `json:"enabled" example:"true"`              // 是否已启用
+	Required               bool `json:"required" example:"false"`            // 所属角色是否强制启用
+	RecoveryCodesRemaining int  `json:"recoveryCodesRemaining" example:"10"` // 剩余可用恢复码数量
+}
+
+// MfaEnrollResponse 两步验证绑定响应
+type MfaEnrollResponse struct {
+	Secret     string `json:"secret" example:"JBSWY3DPEHPK3PXP"`                                     // TOTP 密钥（可手动输入认证器）
+	OtpauthUri string `json:"otpauthUri" example:"otpauth://totp/NTZ:admin?secret=JBSWY3DPEHPK3PXP"` // 绑定地址（前端生成二维码）
+}
+
+// MfaRecoveryCodesResponse 恢复码响应
+type MfaRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"` // 一次性恢复码（仅展示这一次，请妥善保存）
+}
@@ -0,0 +1,61 @@
+package response
+
+import "github.com/force-c/nai-tizi/internal/utils"
+
+// OAuthTokenResponse OAuth2 令牌响应（RFC 6749 5.1）
+type OAuthTokenResponse struct {
+	AccessToken  string `json:"access_token"`            // 访问令牌
+	TokenType    string `json:"token_type"`              // 令牌类型，固定为 Bearer
+	ExpiresIn    int64  `json:"expires_in"`              // 访问令牌有效期（秒）
+	RefreshToken string `json:"refresh_token,omitempty"` // 刷新令牌（客户端开通 refresh_token 时返回）
+	Scope        string `json:"scope,omitempty"`         // 实际授予的范围
+}
+
+// OAuthErrorResponse OAuth2 错误响应（RFC 6749 5.2）
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`                       // 错误码
+	ErrorDescription string `json:"error_description,omitempty"` // 错误描述
+}
+
+// OAuthIntrospectionResponse 令牌内省响应（RFC 7662 2.2）
+type OAuthIntrospectionResponse struct {
+	Active    bool   `json:"active"`               // 令牌是否有效
+	Scope     string `json:"scope,omitempty"`      // 授权范围
+	ClientId  string `json:"client_id,omitempty"`  // 令牌所属客户端Key
+	Username  string `json:"username,omitempty"`   // 用户名
+	TokenType string `json:"token_type,omitempty"` // 令牌类型
+	Exp       int64  `json:"exp,omitempty"`        // 过期时间
+	Iat       int64  `json:"iat,omitempty"`        // 签发时间
+	Sub       string `json:"sub,omitempty"`        // 主体（用户ID；客户端模式为客户端Key）
+	Iss       string `json:"iss,omitempty"`        // 签发方
+	Jti       string `json:"jti,omitempty"`        // 令牌ID
+}
+
+// OAuthScopeResponse 授权范围说明
+type OAuthScopeResponse struct {
+	Scope       string `json:"scope" example:"profile"`        // 范围标识
+	Description string `json:"description" example:"读取你的基本资料"` // 范围说明
+}
+
+// OAuthConsentResponse 授权确认页信息
+type OAuthConsentResponse struct {
+	ClientId    string               `json:"clientId" example:"internal-app"` // 客户端Key
+	ClientName  string               `json:"clientName" example:"内部应用"`       // 客户端名称
+	RedirectUri string               `json:"redirectUri"`                     // 授权完成后的回调地址
+	Scopes      []OAuthScopeResponse `json:"scopes"`                          // 申请的授权范围
+	Approved    bool                 `json:"approved" example:"false"`        // 是否已授权过（为 true 时前端可直接提交确认）
+}
+
+// OAuthAuthorizeResponse 授权确认结果
+type OAuthAuthorizeResponse struct {
+	RedirectUri string `json:"redirectUri" example:"https://app.example.com/callback?code=xxx&state=af0ifjsldkj"` // 前端跳转地址（携带授权码或错误信息）
+}
+
+// OAuthConsentGrantResponse 已授权的第三方应用
+type OAuthConsentGrantResponse struct {
+	ClientId    string          `json:"clientId" example:"internal-app"` // 客户端Key
+	ClientName  string          `json:"clientName" example:"内部应用"`       // 客户端名称
+	Scopes      []string        `json:"scopes"`                          // 已授予的范围
+	CreatedTime utils.LocalTime `json:"createdTime"`                     // 首次授权时间
+	UpdatedTime utils.LocalTime `json:"updatedTime"`                     // 最近授权时间
+}
@@ -0,0 +1,26 @@
+package response
+
+import "github.com/force-c/nai-tizi/internal/utils"
+
+// OidcProviderResponse 第三方登录提供方
+type OidcProviderResponse struct {
+	Name        string `json:"name" example:"keycloak"`        // 提供方标识
+	DisplayName string `json:"displayName" example:"Keycloak"` // 显示名称
+}
+
+// OidcAuthorizeResponse 第三方登录授权地址响应
+type OidcAuthorizeResponse struct {
+	AuthorizeUrl string `json:"authorizeUrl"` // 跳转到提供方的授权地址
+	State        string `json:"state"`        // 授权请求标识（回调时原样返回）
+}
+
+// UserIdentityResponse 已绑定的第三方身份
+type UserIdentityResponse struct {
+	Id          int64           `json:"id" example:"1"`                    // 绑定记录ID
+	Provider    string          `json:"provider" example:"keycloak"`       // 提供方标识
+	DisplayName string          `json:"displayName" example:"Keycloak"`    // 提供方显示名称
+	Email       string          `json:"email" example:"alice@example.com"` // 提供方返回的邮箱
+	Nickname    string          `json:"nickname" example:"Alice"`          // 提供方返回的昵称
+	LastLoginAt int64           `json:"lastLoginAt" example:"1700000000"`  // 最后登录时间（时间戳）
+	CreatedTime utils.LocalTime `json:"createdTime"`                       // 绑定时间
+}
@@ -14,6 +14,7 @@ type OperLogResponse struct {
 	RequestMethod string          `json:"requestMethod"` // 请求方式：GET/POST
 	DeviceType    string          `json:"deviceType"`    // 终端类型：web/ios/android/wechat
 	OperName      string          `json:"operName"`      // 操作者
+	ActorName     string          `json:"actorName"`     // 代登录的管理员（为空表示本人操作）
 	OperUrl       string          `json:"operUrl"`       // 请求URL
 	OperIp        string          `json:"operIp"`        // 操作IP
 	OperLocation  string          `json:"operLocation"`  // 操作地点
@@ -42,6 +43,7 @@ func ToOperLogResponse(log *model.OperLog) OperLogResponse {
 		RequestMethod: log.RequestMethod,
 		DeviceType:    log.DeviceType,
 		OperName:      log.OperName,
+		ActorName:     log.ActorName,
 		OperUrl:       log.OperUrl,
 		OperIp:        log.OperIp,
 		OperLocation:  log.OperLocation,
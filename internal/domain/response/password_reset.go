@@ -0,0 +1,6 @@
+package response
+
+// PasswordResetVerifyResponse 校验找回密码凭证响应
+type PasswordResetVerifyResponse struct {
+	ResetToken string `json:"resetToken"` // 重置令牌，提交新密码时使用
+}
@@ -0,0 +1,34 @@
+package response
+
+import "github.com/force-c/nai-tizi/internal/utils"
+
+// RegistrationOptionsResponse 注册页面选项
+type RegistrationOptionsResponse struct {
+	Enabled        bool     `json:"enabled"`        // 是否开放注册
+	OpenSignup     bool     `json:"openSignup"`     // 是否允许无邀请码注册（须验证邮箱）
+	InviteSignup   bool     `json:"inviteSignup"`   // 是否允许邀请码注册
+	AllowedDomains []string `json:"allowedDomains"` // 开放注册允许的邮箱域名，空表示不限制
+	Captcha        bool     `json:"captcha"`        // 是否需要图形验证码
+}
+
+// RegisterResponse 注册结果
+type RegisterResponse struct {
+	UserId   int64  `json:"userId"`   // 用户ID
+	UserName string `json:"userName"` // 用户名
+	Pending  bool   `json:"pending"`  // 是否等待管理员审批，审批通过前不能登录
+}
+
+// InviteCodeResponse 邀请码
+type InviteCodeResponse struct {
+	ID          int64           `json:"id"`          // 邀请码ID
+	Code        string          `json:"code"`        // 邀请码
+	OrgId       int64           `json:"orgId"`       // 注册用户所属组织ID
+	RoleIds     []int64         `json:"roleIds"`     // 注册用户默认角色ID
+	MaxUses     int             `json:"maxUses"`     // 最多可使用次数，0 表示不限
+	UsedCount   int             `json:"usedCount"`   // 已使用次数
+	ExpireAt    int64           `json:"expireAt"`    // 过期时间（时间戳），0 表示永不过期
+	Status      int32           `json:"status"`      // 状态：0正常 1停用
+	Usable      bool            `json:"usable"`      // 当前是否可用
+	Remark      string          `json:"remark"`      // 备注
+	CreatedTime utils.LocalTime `json:"createdTime"` // 创建时间
+}
@@ -0,0 +1,20 @@
+package response
+
+import "github.com/force-c/nai-tizi/internal/utils"
+
+// SessionResponse 登录会话响应
+//
+//	@Description	用户当前的活跃登录会话
+type SessionResponse struct {
+	SessionId       string          `json:"sessionId" example:"0b7f3c1e-5a2d-4f8e-9c6b-1d2e3f4a5b6c"` // 会话ID
+	ClientId        string          `json:"clientId"`                                                 // 客户端ID
+	DeviceType      string          `json:"deviceType" example:"pc"`                                  // 设备类型
+	Ipaddr          string          `json:"ipaddr" example:"127.0.0.1"`                               // 登录IP
+	UserAgent       string          `json:"userAgent"`                                                // User-Agent
+	Browser         string          `json:"browser" example:"Chrome"`                                 // 浏览器
+	Os              string          `json:"os" example:"Windows"`                                     // 操作系统
+	CreatedTime     utils.LocalTime `json:"createdTime"`                                              // 登录时间
+	LastRefreshTime utils.LocalTime `json:"lastRefreshTime"`                                          // 最近刷新时间
+	Current         bool            `json:"current"`                                                  // 是否为当前请求所在会话
+	ActorName       string          `json:"actorName,omitempty" example:"admin"`                      // 代登录的管理员（非代登录会话为空）
+}
@@ -0,0 +1,29 @@
+package response
+
+import "github.com/force-c/nai-tizi/internal/utils"
+
+// TenantResponse 租户
+type TenantResponse struct {
+	ID           int64           `json:"id"`           // 租户ID
+	TenantCode   string          `json:"tenantCode"`   // 租户编码
+	TenantName   string          `json:"tenantName"`   // 租户名称
+	Plan         string          `json:"plan"`         // 套餐
+	ExpireAt     int64           `json:"expireAt"`     // 到期时间（时间戳），0 表示永不过期
+	Status       int32           `json:"status"`       // 状态：0正常 1停用
+	Active       bool            `json:"active"`       // 当前是否可用（未停用且未到期）
+	ContactName  string          `json:"contactName"`  // 联系人
+	ContactPhone string          `json:"contactPhone"` // 联系电话
+	AdminUserId  int64           `json:"adminUserId"`  // 租户管理员ID
+	Remark       string          `json:"remark"`       // 备注
+	CreatedTime  utils.LocalTime `json:"createdTime"`  // 创建时间
+}
+
+// TenantOnboardResponse 开通租户结果
+type TenantOnboardResponse struct {
+	Tenant        TenantResponse `json:"tenant"`        // 租户
+	RootOrgId     int64          `json:"rootOrgId"`     // 根组织ID
+	AdminUserId   int64          `json:"adminUserId"`   // 租户管理员ID
+	AdminUserName string         `json:"adminUserName"` // 租户管理员用户名
+	RoleCount     int            `json:"roleCount"`     // 复制的角色数量
+	PolicyCount   int            `json:"policyCount"`   // 复制的权限策略数量
+}
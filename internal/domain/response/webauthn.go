@@ -0,0 +1,21 @@
+package response
+
+import "github.com/force-c/nai-tizi/internal/utils"
+
+// WebAuthnLoginOptionsResponse 通行密钥登录参数响应
+type WebAuthnLoginOptionsResponse struct {
+	ChallengeId string `json:"challengeId" example:"0b6f3c9e-6a4f-4d8e-9a51-1c2f3e4d5a6b"` // 登录挑战ID（登录时原样提交）
+	Options     any    `json:"options"`                                                    // navigator.credentials.get() 参数
+}
+
+// WebAuthnCredentialResponse 通行密钥凭证响应
+type WebAuthnCredentialResponse struct {
+	Id             int64           `json:"id" example:"1"`                       // 凭证记录ID
+	Name           string          `json:"name" example:"我的 MacBook"`            // 凭证名称
+	Aaguid         string          `json:"aaguid"`                               // 认证器型号标识
+	Transports     string          `json:"transports" example:"internal,hybrid"` // 支持的传输方式
+	BackupEligible bool            `json:"backupEligible" example:"true"`        // 是否支持同步备份
+	BackupState    bool            `json:"backupState" example:"true"`           // 是否已同步备份
+	LastUsedAt     int64           `json:"lastUsedAt" example:"1700000000"`      // 最后使用时间（时间戳）
+	CreatedTime    utils.LocalTime `json:"createdTime"`                          // 注册时间
+}
@@ -0,0 +1,391 @@
+package casbinsync
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	"github.com/force-c/nai-tizi/internal/config"
+	"github.com/force-c/nai-tizi/internal/infrastructure/metrics"
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// publishTimeout 发布消息的超时时间
+const publishTimeout = 3 * time.Second
+
+// 策略变更操作
+const (
+	opAdd            = "add"             // 添加策略
+	opRemove         = "remove"          // 删除策略
+	opRemoveFiltered = "remove_filtered" // 按字段删除策略
+	opReload         = "reload"          // 全量重新加载
+)
+
+// watchers 已创建的 Watcher（按 Enforcer 索引），Enforcer 没有公开获取 Watcher 的方法
+var (
+	watchersMu sync.RWMutex
+	watchers   = make(map[*casbin.Enforcer]*Watcher)
+)
+
+// message 策略变更消息
+type message struct {
+	Instance    string     `json:"instance"`              // 发布者实例ID（忽略自己发布的消息）
+	Version     int64      `json:"version"`               // 策略版本（全局递增）
+	Op          string     `json:"op"`                    // 操作
+	Sec         string     `json:"sec,omitempty"`         // p / g
+	Ptype       string     `json:"ptype,omitempty"`       // 策略类型
+	Rules       [][]string `json:"rules,omitempty"`       // 添加/删除的策略
+	FieldIndex  int        `json:"fieldIndex,omitempty"`  // 按字段删除的起始字段
+	FieldValues []string   `json:"fieldValues,omitempty"` // 按字段删除的字段值
+}
+
+// Watcher 基于 Redis 发布订阅的 Casbin 策略同步（实现 persist.WatcherEx）
+//
+// Enforcer 的 AddPolicy / RemovePolicy / RemoveFilteredPolicy 等变更在写库后通知 Watcher，
+// Watcher 递增全局策略版本并广播变更，其他副本收到后增量应用到内存中的策略。
+// 版本不连续（漏收消息）、应用失败或定期校验发现版本落后时全量重新加载。
+type Watcher struct {
+	rdb        *redis.Client
+	enforcer   *casbin.Enforcer
+	logger     logging.Logger
+	channel    string
+	versionKey string
+	instance   string
+	interval   time.Duration
+	reload     func() error // 全量重新加载，默认 enforcer.LoadPolicy
+
+	mu       sync.Mutex
+	version  int64 // 本副本已应用的策略版本
+	behindAt int64 // 上次校验发现落后时的本地版本，-1 表示未落后
+
+	reloadCh chan string // 异步全量重新加载（原因）
+	quit     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewWatcher 创建策略同步器，须在 Enforcer 加载策略之前创建，以当前全局版本作为基线
+func NewWatcher(rdb *redis.Client, enforcer *casbin.Enforcer, cfg config.CasbinWatcher, logger logging.Logger) (*Watcher, error) {
+	w := &Watcher{
+		rdb:        rdb,
+		enforcer:   enforcer,
+		logger:     logger,
+		channel:    cfg.Channel,
+		versionKey: cfg.Channel + ":version",
+		instance:   newInstanceId(),
+		interval:   time.Duration(cfg.CheckInterval) * time.Second,
+		reload:     enforcer.LoadPolicy,
+		behindAt:   -1,
+		reloadCh:   make(chan string, 1),
+		quit:       make(chan struct{}),
+	}
+
+	version, err := w.globalVersion(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read casbin policy version: %w", err)
+	}
+	w.version = version
+
+	watchersMu.Lock()
+	watchers[enforcer] = w
+	watchersMu.Unlock()
+	return w, nil
+}
+
+// NotifyReload 通知其他副本全量重新加载 Enforcer 的策略（本副本直接调用 LoadPolicy 后使用），未启用同步时直接返回
+func NotifyReload(enforcer *casbin.Enforcer) error {
+	watchersMu.RLock()
+	w := watchers[enforcer]
+	watchersMu.RUnlock()
+	if w == nil {
+		return nil
+	}
+	return w.Update()
+}
+
+// newInstanceId 生成实例ID（主机名 + 随机数）
+func newInstanceId() string {
+	host, _ := os.Hostname()
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return host + "-" + hex.EncodeToString(b)
+}
+
+func (w *Watcher) Name() string {
+	return "Casbin Watcher"
+}
+
+func (w *Watcher) Start() error {
+	go w.run()
+	return nil
+}
+
+func (w *Watcher) Stop() error {
+	w.stopOnce.Do(func() {
+		close(w.quit)
+		watchersMu.Lock()
+		if watchers[w.enforcer] == w {
+			delete(watchers, w.enforcer)
+		}
+		watchersMu.Unlock()
+	})
+	return nil
+}
+
+// run 订阅策略变更并定期校验版本
+func (w *Watcher) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub := w.rdb.Subscribe(ctx, w.channel)
+	defer sub.Close()
+	ch := sub.Channel()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.logger.Info("casbin watcher started", zap.String("channel", w.channel), zap.String("instance", w.instance), zap.Int64("version", w.version))
+	for {
+		select {
+		case <-w.quit:
+			w.logger.Info("casbin watcher stopped")
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			w.handle(msg.Payload)
+		case reason := <-w.reloadCh:
+			w.mu.Lock()
+			w.reloadLocked(reason, 0)
+			w.mu.Unlock()
+		case <-ticker.C:
+			w.check()
+		}
+	}
+}
+
+// handle 处理其他副本发布的策略变更
+func (w *Watcher) handle(payload string) {
+	var msg message
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+		metrics.CasbinPolicySyncTotal.WithLabelValues("receive", "invalid").Inc()
+		w.logger.Warn("casbin 策略同步消息格式错误", zap.Error(err))
+		return
+	}
+	if msg.Instance == w.instance {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	switch {
+	case msg.Version <= w.version:
+		// 已包含在之前的全量加载中
+		metrics.CasbinPolicySyncTotal.WithLabelValues("receive", "stale").Inc()
+	case msg.Op == opReload:
+		metrics.CasbinPolicySyncTotal.WithLabelValues("receive", "reload").Inc()
+		w.reloadLocked("requested", msg.Version)
+	case msg.Version != w.version+1:
+		metrics.CasbinPolicySyncTotal.WithLabelValues("receive", "gap").Inc()
+		w.logger.Warn("casbin 策略版本不连续，全量重新加载", zap.Int64("local", w.version), zap.Int64("received", msg.Version))
+		w.reloadLocked("gap", msg.Version)
+	default:
+		if err := w.apply(&msg); err != nil {
+			metrics.CasbinPolicySyncTotal.WithLabelValues("receive", "error").Inc()
+			w.logger.Warn("casbin 策略增量同步失败，全量重新加载", zap.String("op", msg.Op), zap.Error(err))
+			w.reloadLocked("apply_error", msg.Version)
+			return
+		}
+		w.version = msg.Version
+		metrics.CasbinPolicySyncTotal.WithLabelValues("receive", "applied").Inc()
+	}
+}
+
+// apply 将策略变更应用到内存中的策略（不写库、不再次广播）
+func (w *Watcher) apply(msg *message) error {
+	m := w.enforcer.GetModel()
+	var (
+		op      model.PolicyOp
+		changed [][]string
+		err     error
+	)
+	switch msg.Op {
+	case opAdd:
+		op = model.PolicyAdd
+		changed, err = m.AddPoliciesWithAffected(msg.Sec, msg.Ptype, msg.Rules)
+	case opRemove:
+		op = model.PolicyRemove
+		changed, err = m.RemovePoliciesWithAffected(msg.Sec, msg.Ptype, msg.Rules)
+	case opRemoveFiltered:
+		op = model.PolicyRemove
+		_, changed, err = m.RemoveFilteredPolicy(msg.Sec, msg.Ptype, msg.FieldIndex, msg.FieldValues...)
+	default:
+		return fmt.Errorf("unknown op: %s", msg.Op)
+	}
+	if err != nil {
+		return err
+	}
+
+	// 角色继承关系变更需要更新角色管理器
+	if msg.Sec == "g" && len(changed) > 0 {
+		return w.enforcer.BuildIncrementalRoleLinks(op, msg.Ptype, changed)
+	}
+	return nil
+}
+
+// check 定期校验策略版本，连续两次校验都落后时全量重新加载（兜底漏收的消息）
+func (w *Watcher) check() {
+	global, err := w.globalVersion(context.Background())
+	if err != nil {
+		w.logger.Warn("读取 casbin 策略版本失败", zap.Error(err))
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	drift := global - w.version
+	if drift <= 0 {
+		metrics.CasbinPolicyVersionDrift.Set(0)
+		w.behindAt = -1
+		return
+	}
+	metrics.CasbinPolicyVersionDrift.Set(float64(drift))
+	if w.behindAt == w.version {
+		w.logger.Warn("casbin 策略版本落后，全量重新加载", zap.Int64("local", w.version), zap.Int64("global", global))
+		w.reloadLocked("drift", global)
+		return
+	}
+	// 消息可能仍在途中，下次校验仍落后再重新加载
+	w.behindAt = w.version
+}
+
+// reloadLocked 全量重新加载策略，调用方须持有 w.mu
+// 先读取全局版本再加载，加载期间发生的变更会在随后的消息中增量应用（重复应用无副作用）
+func (w *Watcher) reloadLocked(reason string, atLeast int64) {
+	global, err := w.globalVersion(context.Background())
+	if err != nil {
+		global = atLeast
+	}
+	if err := w.reload(); err != nil {
+		metrics.CasbinPolicyReloadTotal.WithLabelValues("error").Inc()
+		w.logger.Error("casbin 策略全量重新加载失败", zap.String("reason", reason), zap.Error(err))
+		return
+	}
+	metrics.CasbinPolicyReloadTotal.WithLabelValues(reason).Inc()
+	if global > w.version {
+		w.version = global
+	}
+	w.behindAt = -1
+	metrics.CasbinPolicyVersionDrift.Set(0)
+	w.logger.Info("casbin 策略全量重新加载", zap.String("reason", reason), zap.Int64("version", w.version))
+}
+
+// requestReload 异步全量重新加载（在 Enforcer 变更回调中不能同步加载）
+func (w *Watcher) requestReload(reason string) {
+	select {
+	case w.reloadCh <- reason:
+	default:
+	}
+}
+
+// globalVersion 读取全局策略版本，不存在时为 0
+func (w *Watcher) globalVersion(ctx context.Context) (int64, error) {
+	version, err := w.rdb.Get(ctx, w.versionKey).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return version, err
+}
+
+// publish 递增全局版本并广播本副本的策略变更
+// 变更已写库，广播失败只记录日志，不影响调用方；其他副本通过版本校验兜底
+func (w *Watcher) publish(msg *message) error {
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	version, err := w.rdb.Incr(ctx, w.versionKey).Result()
+	if err != nil {
+		metrics.CasbinPolicySyncTotal.WithLabelValues("publish", "error").Inc()
+		w.logger.Error("递增 casbin 策略版本失败", zap.String("op", msg.Op), zap.Error(err))
+		return nil
+	}
+	msg.Instance = w.instance
+	msg.Version = version
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil
+	}
+	if err := w.rdb.Publish(ctx, w.channel, data).Err(); err != nil {
+		metrics.CasbinPolicySyncTotal.WithLabelValues("publish", "error").Inc()
+		w.logger.Error("广播 casbin 策略变更失败", zap.String("op", msg.Op), zap.Error(err))
+	} else {
+		metrics.CasbinPolicySyncTotal.WithLabelValues("publish", "ok").Inc()
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if version == w.version+1 {
+		w.version = version
+	} else if version > w.version {
+		// 本副本漏掉了其他副本的变更
+		w.requestReload("gap")
+	}
+	return nil
+}
+
+// SetUpdateCallback 实现 persist.Watcher（WatcherEx 不使用回调）
+func (w *Watcher) SetUpdateCallback(func(string)) error {
+	return nil
+}
+
+// Update 通知其他副本全量重新加载
+func (w *Watcher) Update() error {
+	return w.publish(&message{Op: opReload})
+}
+
+// Close 停止同步
+func (w *Watcher) Close() {
+	_ = w.Stop()
+}
+
+// UpdateForAddPolicy 广播添加策略
+func (w *Watcher) UpdateForAddPolicy(sec, ptype string, params ...string) error {
+	return w.publish(&message{Op: opAdd, Sec: sec, Ptype: ptype, Rules: [][]string{params}})
+}
+
+// UpdateForRemovePolicy 广播删除策略
+func (w *Watcher) UpdateForRemovePolicy(sec, ptype string, params ...string) error {
+	return w.publish(&message{Op: opRemove, Sec: sec, Ptype: ptype, Rules: [][]string{params}})
+}
+
+// UpdateForRemoveFilteredPolicy 广播按字段删除策略
+func (w *Watcher) UpdateForRemoveFilteredPolicy(sec, ptype string, fieldIndex int, fieldValues ...string) error {
+	return w.publish(&message{Op: opRemoveFiltered, Sec: sec, Ptype: ptype, FieldIndex: fieldIndex, FieldValues: fieldValues})
+}
+
+// UpdateForSavePolicy 广播全量保存（其他副本全量重新加载）
+func (w *Watcher) UpdateForSavePolicy(model.Model) error {
+	return w.publish(&message{Op: opReload})
+}
+
+// UpdateForAddPolicies 广播批量添加策略
+func (w *Watcher) UpdateForAddPolicies(sec string, ptype string, rules ...[]string) error {
+	return w.publish(&message{Op: opAdd, Sec: sec, Ptype: ptype, Rules: rules})
+}
+
+// UpdateForRemovePolicies 广播批量删除策略
+func (w *Watcher) UpdateForRemovePolicies(sec string, ptype string, rules ...[]string) error {
+	return w.publish(&message{Op: opRemove, Sec: sec, Ptype: ptype, Rules: rules})
+}
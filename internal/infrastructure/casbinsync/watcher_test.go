@@ -0,0 +1,175 @@
+package casbinsync
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...zap.Field)         {}
+func (nopLogger) Info(string, ...zap.Field)          {}
+func (nopLogger) Warn(string, ...zap.Field)          {}
+func (nopLogger) Error(string, ...zap.Field)         {}
+func (nopLogger) Fatal(string, ...zap.Field)         {}
+func (l nopLogger) With(...zap.Field) logging.Logger { return l }
+
+const testModel = `
+[request_definition]
+r = sub, dom, obj, act
+
+[policy_definition]
+p = sub, dom, obj, act
+
+[role_definition]
+g = _, _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub, r.dom) && r.dom == p.dom && keyMatch(r.obj, p.obj) && keyMatch(r.act, p.act)
+`
+
+// newTestWatcher 创建不连接 Redis 的 Watcher（全局版本读取失败，重新加载只计数）
+func newTestWatcher(t *testing.T, version int64) (*Watcher, *int) {
+	t.Helper()
+	m, err := model.NewModelFromString(testModel)
+	if err != nil {
+		t.Fatalf("NewModelFromString() error = %v", err)
+	}
+	e, err := casbin.NewEnforcer(m)
+	if err != nil {
+		t.Fatalf("NewEnforcer() error = %v", err)
+	}
+
+	reloads := 0
+	w := &Watcher{
+		rdb:        redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1}),
+		enforcer:   e,
+		logger:     nopLogger{},
+		channel:    "casbin:policy",
+		versionKey: "casbin:policy:version",
+		instance:   "self",
+		reload:     func() error { reloads++; return nil },
+		version:    version,
+		behindAt:   -1,
+		reloadCh:   make(chan string, 1),
+		quit:       make(chan struct{}),
+	}
+	t.Cleanup(func() { _ = w.rdb.Close() })
+	return w, &reloads
+}
+
+func payload(t *testing.T, msg message) string {
+	t.Helper()
+	data, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	return string(data)
+}
+
+// TestWatcher_ApplyIncremental 测试增量应用其他副本的策略变更
+func TestWatcher_ApplyIncremental(t *testing.T) {
+	w, reloads := newTestWatcher(t, 5)
+
+	w.handle(payload(t, message{Instance: "peer", Version: 6, Op: opAdd, Sec: "p", Ptype: "p",
+		Rules: [][]string{{"role::admin", "tenant::1", "user.*", "*"}}}))
+	w.handle(payload(t, message{Instance: "peer", Version: 7, Op: opAdd, Sec: "g", Ptype: "g",
+		Rules: [][]string{{"user::1", "role::admin", "tenant::1"}}}))
+
+	if ok, _ := w.enforcer.Enforce("user::1", "tenant::1", "user.create", "write"); !ok {
+		t.Fatal("Enforce() = false after add, want true")
+	}
+	if ok, _ := w.enforcer.Enforce("user::1", "tenant::2", "user.create", "write"); ok {
+		t.Fatal("Enforce() = true in other tenant, want false")
+	}
+
+	w.handle(payload(t, message{Instance: "peer", Version: 8, Op: opRemove, Sec: "g", Ptype: "g",
+		Rules: [][]string{{"user::1", "role::admin", "tenant::1"}}}))
+	if ok, _ := w.enforcer.Enforce("user::1", "tenant::1", "user.create", "write"); ok {
+		t.Fatal("Enforce() = true after remove, want false")
+	}
+
+	w.handle(payload(t, message{Instance: "peer", Version: 9, Op: opRemoveFiltered, Sec: "p", Ptype: "p",
+		FieldIndex: 1, FieldValues: []string{"tenant::1"}}))
+	if n, _ := w.enforcer.GetPolicy(); len(n) != 0 {
+		t.Fatalf("GetPolicy() = %v, want empty", n)
+	}
+
+	if w.version != 9 {
+		t.Errorf("version = %d, want 9", w.version)
+	}
+	if *reloads != 0 {
+		t.Errorf("reloads = %d, want 0", *reloads)
+	}
+}
+
+// TestWatcher_IgnoreSelfAndStale 测试忽略自己发布的消息和过期消息
+func TestWatcher_IgnoreSelfAndStale(t *testing.T) {
+	w, reloads := newTestWatcher(t, 5)
+	rule := [][]string{{"role::admin", "tenant::1", "*", "*"}}
+
+	w.handle(payload(t, message{Instance: "self", Version: 6, Op: opAdd, Sec: "p", Ptype: "p", Rules: rule}))
+	w.handle(payload(t, message{Instance: "peer", Version: 5, Op: opAdd, Sec: "p", Ptype: "p", Rules: rule}))
+	w.handle("not json")
+
+	if policies, _ := w.enforcer.GetPolicy(); len(policies) != 0 {
+		t.Fatalf("GetPolicy() = %v, want empty", policies)
+	}
+	if w.version != 5 || *reloads != 0 {
+		t.Errorf("version = %d, reloads = %d, want 5, 0", w.version, *reloads)
+	}
+}
+
+// TestWatcher_ReloadOnGap 测试版本不连续和重新加载请求时全量加载
+func TestWatcher_ReloadOnGap(t *testing.T) {
+	w, reloads := newTestWatcher(t, 5)
+
+	w.handle(payload(t, message{Instance: "peer", Version: 8, Op: opAdd, Sec: "p", Ptype: "p",
+		Rules: [][]string{{"role::admin", "tenant::1", "*", "*"}}}))
+	if *reloads != 1 || w.version != 8 {
+		t.Fatalf("after gap: reloads = %d, version = %d, want 1, 8", *reloads, w.version)
+	}
+
+	w.handle(payload(t, message{Instance: "peer", Version: 9, Op: opReload}))
+	if *reloads != 2 || w.version != 9 {
+		t.Fatalf("after reload: reloads = %d, version = %d, want 2, 9", *reloads, w.version)
+	}
+
+	// 增量应用失败时回退到全量加载
+	w.handle(payload(t, message{Instance: "peer", Version: 10, Op: "unknown"}))
+	if *reloads != 3 || w.version != 10 {
+		t.Fatalf("after apply error: reloads = %d, version = %d, want 3, 10", *reloads, w.version)
+	}
+}
+
+// TestNotifyReload 测试按 Enforcer 查找 Watcher 广播重新加载，Watcher 停止后不再广播
+func TestNotifyReload(t *testing.T) {
+	w, _ := newTestWatcher(t, 5)
+	if err := NotifyReload(w.enforcer); err != nil {
+		t.Fatalf("NotifyReload() without watcher error = %v", err)
+	}
+
+	watchersMu.Lock()
+	watchers[w.enforcer] = w
+	watchersMu.Unlock()
+	if err := NotifyReload(w.enforcer); err != nil {
+		t.Fatalf("NotifyReload() error = %v", err)
+	}
+
+	_ = w.Stop()
+	watchersMu.RLock()
+	_, ok := watchers[w.enforcer]
+	watchersMu.RUnlock()
+	if ok {
+		t.Error("watcher still registered after Stop()")
+	}
+}
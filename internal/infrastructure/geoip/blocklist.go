@@ -0,0 +1,91 @@
+package geoip
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"strings"
+)
+
+// Blocklist IP 黑名单（TOR 出口节点、已知恶意 IP 等）
+// 文件每行一个 IP 或 CIDR，# 开头为注释，空行忽略
+type Blocklist struct {
+	addrs    map[netip.Addr]struct{}
+	prefixes []netip.Prefix
+}
+
+// OpenBlocklist 加载黑名单文件
+func OpenBlocklist(path string) (*Blocklist, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseBlocklist(f)
+}
+
+// ParseBlocklist 从文本内容解析黑名单
+func ParseBlocklist(r io.Reader) (*Blocklist, error) {
+	b := &Blocklist{addrs: make(map[netip.Addr]struct{})}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		if strings.Contains(text, "/") {
+			prefix, err := netip.ParsePrefix(text)
+			if err != nil {
+				return nil, fmt.Errorf("第 %d 行 CIDR 格式错误: %s", line, text)
+			}
+			b.prefixes = append(b.prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(text)
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 行 IP 格式错误: %s", line, text)
+		}
+		b.addrs[addr.Unmap()] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Contains 检查 IP 是否命中黑名单
+func (b *Blocklist) Contains(ip string) bool {
+	if b == nil {
+		return false
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	if _, ok := b.addrs[addr]; ok {
+		return true
+	}
+	for _, prefix := range b.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Len 黑名单条目数量
+func (b *Blocklist) Len() int {
+	if b == nil {
+		return 0
+	}
+	return len(b.addrs) + len(b.prefixes)
+}
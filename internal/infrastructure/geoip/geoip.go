@@ -0,0 +1,213 @@
+package geoip
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/netip"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"go.uber.org/zap"
+)
+
+// Location IP 归属地
+type Location struct {
+	Country   string  // 国家代码（ISO 3166-1 alpha-2）
+	Region    string  // 省/州
+	City      string  // 城市
+	Latitude  float64 // 纬度
+	Longitude float64 // 经度
+	HasCoord  bool    // 是否包含经纬度（国家库不含）
+}
+
+// String 展示用的归属地文本
+func (l *Location) String() string {
+	parts := make([]string, 0, 3)
+	for _, p := range []string{l.Country, l.Region, l.City} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+type ipRange struct {
+	start, end netip.Addr
+	loc        *Location
+}
+
+// Database 离线 IP 归属地库
+// 支持 DB-IP 的 CSV 格式（https://db-ip.com/db/lite.php），同时包含 IPv4 和 IPv6：
+//   - country lite：ip_start,ip_end,country
+//   - city lite：ip_start,ip_end,continent,country,stateprov,city,latitude,longitude
+type Database struct {
+	ranges []ipRange
+}
+
+// OpenDatabase 加载 CSV 归属地库
+func OpenDatabase(path string) (*Database, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseDatabase(f)
+}
+
+// ParseDatabase 从 CSV 内容解析归属地库
+func ParseDatabase(r io.Reader) (*Database, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	db := &Database{}
+	countries := make(map[string]*Location)
+	line := 0
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 行格式错误: %w", line, err)
+		}
+		if len(record) < 3 {
+			return nil, fmt.Errorf("第 %d 行字段数不足", line)
+		}
+		start, err1 := netip.ParseAddr(strings.TrimSpace(record[0]))
+		end, err2 := netip.ParseAddr(strings.TrimSpace(record[1]))
+		if err1 != nil || err2 != nil {
+			// 跳过表头
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("第 %d 行 IP 格式错误", line)
+		}
+		start, end = start.Unmap(), end.Unmap()
+		if start.Is4() != end.Is4() || end.Less(start) {
+			return nil, fmt.Errorf("第 %d 行 IP 范围无效", line)
+		}
+
+		var loc *Location
+		if len(record) >= 8 {
+			loc = &Location{Country: record[3], Region: record[4], City: record[5]}
+			lat, errLat := strconv.ParseFloat(record[6], 64)
+			lon, errLon := strconv.ParseFloat(record[7], 64)
+			if errLat == nil && errLon == nil {
+				loc.Latitude, loc.Longitude, loc.HasCoord = lat, lon, true
+			}
+		} else {
+			country := record[2]
+			if loc = countries[country]; loc == nil {
+				loc = &Location{Country: country}
+				countries[country] = loc
+			}
+		}
+		db.ranges = append(db.ranges, ipRange{start: start, end: end, loc: loc})
+	}
+
+	sort.Slice(db.ranges, func(i, j int) bool {
+		return db.ranges[i].start.Less(db.ranges[j].start)
+	})
+	return db, nil
+}
+
+// Lookup 查询 IP 归属地，未收录时返回 nil
+func (d *Database) Lookup(ip string) *Location {
+	if d == nil {
+		return nil
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return nil
+	}
+	addr = addr.Unmap()
+	// 找到起始地址不大于 addr 的最后一个范围
+	i := sort.Search(len(d.ranges), func(i int) bool {
+		return addr.Less(d.ranges[i].start)
+	}) - 1
+	if i < 0 {
+		return nil
+	}
+	r := d.ranges[i]
+	if r.end.Less(addr) || r.start.Is4() != addr.Is4() {
+		return nil
+	}
+	return r.loc
+}
+
+// Len 收录的 IP 范围数量
+func (d *Database) Len() int {
+	if d == nil {
+		return 0
+	}
+	return len(d.ranges)
+}
+
+// Distance 计算两个经纬度之间的球面距离（公里）
+func Distance(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadius = 6371.0
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// Config 归属地与黑名单配置
+type Config struct {
+	DatabaseFile  string // 归属地库文件，为空时不加载
+	BlocklistFile string // 黑名单文件，为空时不加载
+}
+
+// Manager 归属地库与 IP 黑名单
+type Manager struct {
+	db        *Database
+	blocklist *Blocklist
+}
+
+// NewManager 加载归属地库和黑名单，文件加载失败时返回错误
+func NewManager(cfg Config, logger logging.Logger) (*Manager, error) {
+	m := &Manager{}
+	if cfg.DatabaseFile != "" {
+		db, err := OpenDatabase(cfg.DatabaseFile)
+		if err != nil {
+			return nil, fmt.Errorf("加载 IP 归属地库失败: %w", err)
+		}
+		m.db = db
+		logger.Info("GeoIP database loaded", zap.String("file", cfg.DatabaseFile), zap.Int("ranges", db.Len()))
+	}
+	if cfg.BlocklistFile != "" {
+		blocklist, err := OpenBlocklist(cfg.BlocklistFile)
+		if err != nil {
+			return nil, fmt.Errorf("加载 IP 黑名单失败: %w", err)
+		}
+		m.blocklist = blocklist
+		logger.Info("IP blocklist loaded", zap.String("file", cfg.BlocklistFile), zap.Int("entries", blocklist.Len()))
+	}
+	return m, nil
+}
+
+// Lookup 查询 IP 归属地，未加载归属地库或未收录时返回 nil
+func (m *Manager) Lookup(ip string) *Location {
+	if m == nil {
+		return nil
+	}
+	return m.db.Lookup(ip)
+}
+
+// IsBlocked 检查 IP 是否在黑名单中
+func (m *Manager) IsBlocked(ip string) bool {
+	if m == nil {
+		return false
+	}
+	return m.blocklist.Contains(ip)
+}
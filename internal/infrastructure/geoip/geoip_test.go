@@ -0,0 +1,148 @@
+package geoip
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+const cityCSV = `ip_start,ip_end,continent,country,stateprov,city,latitude,longitude
+1.0.0.0,1.0.0.255,OC,AU,Queensland,Brisbane,-27.4679,153.028
+8.8.8.0,8.8.8.255,NA,US,California,Mountain View,37.4056,-122.0775
+2001:db8::,2001:db8::ffff,EU,DE,Berlin,Berlin,52.52,13.405
+114.114.114.0,114.114.114.255,AS,CN,Jiangsu,Nanjing,32.0617,118.7778
+`
+
+const countryCSV = `8.8.8.0,8.8.8.255,US
+1.0.0.0,1.0.0.255,AU
+1.0.1.0,1.0.3.255,CN
+`
+
+// TestDatabase_LookupCity 测试 city 格式归属地查询
+func TestDatabase_LookupCity(t *testing.T) {
+	db, err := ParseDatabase(strings.NewReader(cityCSV))
+	if err != nil {
+		t.Fatalf("ParseDatabase() error = %v", err)
+	}
+	if db.Len() != 4 {
+		t.Fatalf("Len() = %d, want 4", db.Len())
+	}
+
+	tests := []struct {
+		ip      string
+		country string
+		city    string
+	}{
+		{"1.0.0.0", "AU", "Brisbane"},
+		{"1.0.0.255", "AU", "Brisbane"},
+		{"8.8.8.8", "US", "Mountain View"},
+		{"::ffff:8.8.8.8", "US", "Mountain View"},
+		{"114.114.114.114", "CN", "Nanjing"},
+		{"2001:db8::1", "DE", "Berlin"},
+	}
+	for _, tt := range tests {
+		loc := db.Lookup(tt.ip)
+		if loc == nil {
+			t.Errorf("Lookup(%s) = nil", tt.ip)
+			continue
+		}
+		if loc.Country != tt.country || loc.City != tt.city || !loc.HasCoord {
+			t.Errorf("Lookup(%s) = %+v, want %s/%s", tt.ip, loc, tt.country, tt.city)
+		}
+	}
+
+	for _, ip := range []string{"1.0.1.0", "0.255.255.255", "9.9.9.9", "2001:db9::1", "invalid", ""} {
+		if loc := db.Lookup(ip); loc != nil {
+			t.Errorf("Lookup(%s) = %+v, want nil", ip, loc)
+		}
+	}
+}
+
+// TestDatabase_LookupCountry 测试 country 格式归属地查询（无表头、无经纬度、乱序）
+func TestDatabase_LookupCountry(t *testing.T) {
+	db, err := ParseDatabase(strings.NewReader(countryCSV))
+	if err != nil {
+		t.Fatalf("ParseDatabase() error = %v", err)
+	}
+	loc := db.Lookup("1.0.2.3")
+	if loc == nil || loc.Country != "CN" || loc.HasCoord {
+		t.Fatalf("Lookup(1.0.2.3) = %+v, want CN without coordinates", loc)
+	}
+	if loc := db.Lookup("1.0.0.1"); loc == nil || loc.Country != "AU" {
+		t.Fatalf("Lookup(1.0.0.1) = %+v, want AU", loc)
+	}
+}
+
+// TestDatabase_ParseError 测试格式错误
+func TestDatabase_ParseError(t *testing.T) {
+	bad := []string{
+		"1.0.0.0,1.0.0.255,AU\nbad,1.0.1.255,CN\n",
+		"1.0.0.255,1.0.0.0,AU\n",
+		"1.0.0.0,2001:db8::,AU\n",
+		"1.0.0.0,1.0.0.255\n",
+	}
+	for _, content := range bad {
+		if _, err := ParseDatabase(strings.NewReader(content)); err == nil {
+			t.Errorf("ParseDatabase(%q) expected error", content)
+		}
+	}
+}
+
+// TestNilManager 测试未启用时的空实现
+func TestNilManager(t *testing.T) {
+	var m *Manager
+	if m.Lookup("8.8.8.8") != nil || m.IsBlocked("8.8.8.8") {
+		t.Fatal("nil manager should not match")
+	}
+	m = &Manager{}
+	if m.Lookup("8.8.8.8") != nil || m.IsBlocked("8.8.8.8") {
+		t.Fatal("empty manager should not match")
+	}
+}
+
+// TestBlocklist 测试黑名单匹配
+func TestBlocklist(t *testing.T) {
+	content := `# TOR exit nodes
+185.220.101.1
+185.220.100.0/22   # 整段
+2001:db8:dead::/48
+
+  10.0.0.1
+`
+	b, err := ParseBlocklist(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("ParseBlocklist() error = %v", err)
+	}
+	if b.Len() != 4 {
+		t.Fatalf("Len() = %d, want 4", b.Len())
+	}
+
+	blocked := []string{"185.220.101.1", "185.220.103.254", "::ffff:185.220.101.1", "2001:db8:dead:1::1", "10.0.0.1"}
+	for _, ip := range blocked {
+		if !b.Contains(ip) {
+			t.Errorf("Contains(%s) = false, want true", ip)
+		}
+	}
+	allowed := []string{"185.220.104.1", "10.0.0.2", "2001:db8:beef::1", "invalid"}
+	for _, ip := range allowed {
+		if b.Contains(ip) {
+			t.Errorf("Contains(%s) = true, want false", ip)
+		}
+	}
+
+	if _, err := ParseBlocklist(strings.NewReader("185.220.100.0/33\n")); err == nil {
+		t.Error("ParseBlocklist() expected error for invalid CIDR")
+	}
+}
+
+// TestDistance 测试球面距离计算
+func TestDistance(t *testing.T) {
+	// 北京 -> 上海约 1068 公里
+	d := Distance(39.9042, 116.4074, 31.2304, 121.4737)
+	if math.Abs(d-1068) > 10 {
+		t.Errorf("Distance(Beijing, Shanghai) = %.1f, want ~1068", d)
+	}
+	if d := Distance(31.2304, 121.4737, 31.2304, 121.4737); d != 0 {
+		t.Errorf("Distance(same point) = %f, want 0", d)
+	}
+}
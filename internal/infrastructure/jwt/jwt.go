@@ -1,13 +1,15 @@
 package jwt
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 type Jwt struct {
-	secret        []byte
+	keyring       *Keyring
 	defaultExpire time.Duration
 }
 
@@ -16,25 +18,55 @@ type Claims struct {
 	UserName   string `json:"userName"`
 	ClientId   string `json:"clientId"`
 	DeviceType string `json:"deviceType"`
+	TenantId   int64  `json:"tid,omitempty"`   // 租户ID（多租户模式下以此为准，请求指定的租户须与之一致）
+	SessionId  string `json:"sid,omitempty"`   // 会话ID（同一会话刷新 Token 时保持不变）
+	Scope      string `json:"scope,omitempty"` // OAuth2 授权范围（空格分隔），仅 OAuth2 授权签发的 Token 携带
+	Idle       int64  `json:"idle,omitempty"`  // 空闲超时（秒），大于 0 时会话超过该时长无请求即失效
+	Actor      *Actor `json:"act,omitempty"`   // 代登录的管理员，仅管理员以用户身份登录签发的 Token 携带
+	IssuedAtMs int64  `json:"iatms,omitempty"` // 签发时间（毫秒），iat 只有秒级精度，吊销判断以此为准
 	jwt.RegisteredClaims
 }
 
+// Actor 代登录（以用户身份登录）的实际操作者
+type Actor struct {
+	UserId   int64  `json:"userId"`
+	UserName string `json:"userName"`
+}
+
 func New(secret string, expireSeconds int64) *Jwt {
 	exp := time.Duration(expireSeconds) * time.Second
 	if expireSeconds <= 0 {
 		exp = 2 * time.Hour
 	}
-	return &Jwt{secret: []byte(secret), defaultExpire: exp}
+	return &Jwt{keyring: NewKeyring(secret), defaultExpire: exp}
 }
 
+// Keyring 返回密钥环，用于加载非对称密钥和输出 JWKS
+func (s *Jwt) Keyring() *Keyring { return s.keyring }
+
 func (s *Jwt) GenerateToken(userId int64, userName, clientId, deviceType string, expireSeconds ...int64) (string, int64, error) {
+	return s.GenerateTokenWithClaims(Claims{UserId: userId, UserName: userName, ClientId: clientId, DeviceType: deviceType}, expireSeconds...)
+}
+
+// GenerateTokenWithClaims 使用自定义业务声明生成 Token，jti/签发时间/过期时间由此方法统一填充
+func (s *Jwt) GenerateTokenWithClaims(claims Claims, expireSeconds ...int64) (string, int64, error) {
 	expire := s.defaultExpire
 	if len(expireSeconds) > 0 && expireSeconds[0] > 0 {
 		expire = time.Duration(expireSeconds[0]) * time.Second
 	}
-	claims := Claims{UserId: userId, UserName: userName, ClientId: clientId, DeviceType: deviceType, RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(expire)), IssuedAt: jwt.NewNumericDate(time.Now()), Issuer: "NTZ-go"}}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenStr, err := token.SignedString(s.secret)
+	// 每个 Token 携带唯一的 jti，用于服务端吊销
+	now := time.Now()
+	claims.IssuedAtMs = now.UnixMilli()
+	claims.RegisteredClaims = jwt.RegisteredClaims{ID: uuid.NewString(), ExpiresAt: jwt.NewNumericDate(now.Add(expire)), IssuedAt: jwt.NewNumericDate(now), Issuer: "NTZ-go"}
+	key, err := s.keyring.signingKey()
+	if err != nil {
+		return "", 0, err
+	}
+	token := jwt.NewWithClaims(key.method(), claims)
+	if key.Kid != "" {
+		token.Header["kid"] = key.Kid
+	}
+	tokenStr, err := token.SignedString(key.signKey)
 	if err != nil {
 		return "", 0, err
 	}
@@ -42,7 +74,18 @@ func (s *Jwt) GenerateToken(userId int64, userName, clientId, deviceType string,
 }
 
 func (s *Jwt) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) { return s.secret, nil })
+	// 按 kid 选择验证密钥，并要求 Token 的 alg 与密钥算法一致，防止算法混淆攻击
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := s.keyring.verificationKey(kid, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if token.Method.Alg() != key.Algorithm {
+			return nil, fmt.Errorf("unexpected signing method: %s", token.Method.Alg())
+		}
+		return key.verifyKey, nil
+	}, jwt.WithValidMethods([]string{AlgHS256, AlgRS256, AlgES256, AlgEdDSA}))
 	if err != nil {
 		return nil, err
 	}
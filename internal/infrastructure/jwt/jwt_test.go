@@ -0,0 +1,214 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+)
+
+// TestGenerateToken_UniqueJTI 测试每个 Token 都携带唯一的 jti
+func TestGenerateToken_UniqueJTI(t *testing.T) {
+	j := New("test-secret", 3600)
+
+	seen := make(map[string]bool)
+	for i := 0; i < 10; i++ {
+		token, _, err := j.GenerateToken(1, "admin", "client", "pc")
+		if err != nil {
+			t.Fatalf("failed to generate token: %v", err)
+		}
+		claims, err := j.ValidateToken(token)
+		if err != nil {
+			t.Fatalf("failed to validate token: %v", err)
+		}
+		if claims.ID == "" {
+			t.Fatal("expected jti to be set")
+		}
+		if seen[claims.ID] {
+			t.Fatalf("duplicate jti: %s", claims.ID)
+		}
+		seen[claims.ID] = true
+	}
+}
+
+// TestGenerateToken_IssuedAtMs 测试 Token 携带与 iat 一致的毫秒签发时间
+func TestGenerateToken_IssuedAtMs(t *testing.T) {
+	j := New("test-secret", 3600)
+	before := time.Now().UnixMilli()
+	token, _, err := j.GenerateToken(1, "admin", "client", "pc")
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	after := time.Now().UnixMilli()
+
+	claims, err := j.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("failed to validate token: %v", err)
+	}
+	if claims.IssuedAtMs < before || claims.IssuedAtMs > after {
+		t.Errorf("iatms = %d, want in [%d, %d]", claims.IssuedAtMs, before, after)
+	}
+	if claims.IssuedAtMs/1000 != claims.IssuedAt.Unix() {
+		t.Errorf("iatms = %d, iat = %d, want same second", claims.IssuedAtMs, claims.IssuedAt.Unix())
+	}
+}
+
+// TestValidateToken_WrongSecret 测试使用错误密钥验证失败
+func TestValidateToken_WrongSecret(t *testing.T) {
+	token, _, err := New("secret-a", 3600).GenerateToken(1, "admin", "client", "pc")
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	if _, err := New("secret-b", 3600).ValidateToken(token); err == nil {
+		t.Error("expected validation to fail with wrong secret")
+	}
+}
+
+func newKeyringJwt(t *testing.T, alg string, kids ...string) (*Jwt, []*Key) {
+	t.Helper()
+	j := New("test-secret", 3600)
+	keys := make([]*Key, 0, len(kids))
+	for _, kid := range kids {
+		data, err := GenerateKey(alg)
+		if err != nil {
+			t.Fatalf("failed to generate %s key: %v", alg, err)
+		}
+		key, err := ParsePrivateKeyPEM(kid, alg, data)
+		if err != nil {
+			t.Fatalf("failed to parse %s key: %v", alg, err)
+		}
+		keys = append(keys, key)
+	}
+	if err := j.Keyring().Replace(keys, kids[0]); err != nil {
+		t.Fatalf("failed to load keys: %v", err)
+	}
+	return j, keys
+}
+
+// TestAsymmetric_RoundTrip 测试非对称算法签发的 Token 携带 kid 并能通过验证
+func TestAsymmetric_RoundTrip(t *testing.T) {
+	for _, alg := range []string{AlgRS256, AlgES256, AlgEdDSA} {
+		t.Run(alg, func(t *testing.T) {
+			j, _ := newKeyringJwt(t, alg, "k1")
+			token, _, err := j.GenerateToken(1, "admin", "client", "pc")
+			if err != nil {
+				t.Fatalf("failed to generate token: %v", err)
+			}
+			parsed, _, err := gojwt.NewParser().ParseUnverified(token, &Claims{})
+			if err != nil {
+				t.Fatalf("failed to parse token: %v", err)
+			}
+			if parsed.Header["kid"] != "k1" || parsed.Header["alg"] != alg {
+				t.Errorf("unexpected header: %v", parsed.Header)
+			}
+			if _, err := j.ValidateToken(token); err != nil {
+				t.Errorf("failed to validate token: %v", err)
+			}
+		})
+	}
+}
+
+// TestKeyring_Rotation 测试轮换后旧密钥在过期前仍可验证，过期后拒绝
+func TestKeyring_Rotation(t *testing.T) {
+	j, keys := newKeyringJwt(t, AlgES256, "old", "new")
+	oldToken, _, err := j.GenerateToken(1, "admin", "client", "pc")
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+
+	if err := j.Keyring().Replace(keys, "new"); err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+	if _, err := j.ValidateToken(oldToken); err != nil {
+		t.Errorf("expected old token to be valid during overlap: %v", err)
+	}
+
+	keys[0].ExpireAt = time.Now().Add(-time.Second)
+	if _, err := j.ValidateToken(oldToken); err == nil {
+		t.Error("expected token signed by expired key to be rejected")
+	}
+	if got := len(j.Keyring().JWKS().Keys); got != 1 {
+		t.Errorf("expected expired key to be removed from jwks, got %d keys", got)
+	}
+}
+
+// TestKeyring_LegacyHMAC 测试切换到非对称签名后仍接受旧的 HMAC Token
+func TestKeyring_LegacyHMAC(t *testing.T) {
+	legacyToken, _, err := New("test-secret", 3600).GenerateToken(1, "admin", "client", "pc")
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	j, _ := newKeyringJwt(t, AlgRS256, "k1")
+	if _, err := j.ValidateToken(legacyToken); err != nil {
+		t.Errorf("expected legacy hmac token to be accepted: %v", err)
+	}
+}
+
+// TestValidateToken_AlgorithmConfusion 测试以公钥作为 HMAC 密钥伪造的 Token 被拒绝
+func TestValidateToken_AlgorithmConfusion(t *testing.T) {
+	j, _ := newKeyringJwt(t, AlgRS256, "k1")
+	jwk := j.Keyring().JWKS().Keys[0]
+
+	forged := gojwt.NewWithClaims(gojwt.SigningMethodHS256, Claims{UserId: 1})
+	forged.Header["kid"] = "k1"
+	token, err := forged.SignedString([]byte(jwk.N))
+	if err != nil {
+		t.Fatalf("failed to sign forged token: %v", err)
+	}
+	if _, err := j.ValidateToken(token); err == nil {
+		t.Error("expected hs256 token with rsa kid to be rejected")
+	}
+
+	none := gojwt.NewWithClaims(gojwt.SigningMethodNone, Claims{UserId: 1})
+	token, err = none.SignedString(gojwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("failed to sign none token: %v", err)
+	}
+	if _, err := j.ValidateToken(token); err == nil {
+		t.Error("expected unsigned token to be rejected")
+	}
+}
+
+// TestParsePrivateKeyPEM_AlgorithmMismatch 测试私钥类型与算法不匹配时报错
+func TestParsePrivateKeyPEM_AlgorithmMismatch(t *testing.T) {
+	data, err := GenerateKey(AlgEdDSA)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	if _, err := ParsePrivateKeyPEM("k1", AlgRS256, data); err == nil {
+		t.Error("expected ed25519 key to be rejected for RS256")
+	}
+}
+
+// TestGenerateTokenWithClaims_Actor 测试代登录 Token 携带管理员信息，普通 Token 不携带
+func TestGenerateTokenWithClaims_Actor(t *testing.T) {
+	j := New("test-secret", 3600)
+
+	token, _, err := j.GenerateTokenWithClaims(Claims{
+		UserId:   2,
+		UserName: "alice",
+		Actor:    &Actor{UserId: 1, UserName: "admin"},
+	}, 900)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	claims, err := j.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("failed to validate token: %v", err)
+	}
+	if claims.UserId != 2 || claims.Actor == nil || claims.Actor.UserId != 1 || claims.Actor.UserName != "admin" {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+
+	token, _, err = j.GenerateToken(2, "alice", "client", "pc")
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	claims, err = j.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("failed to validate token: %v", err)
+	}
+	if claims.Actor != nil {
+		t.Fatalf("expected no actor, got %+v", claims.Actor)
+	}
+}
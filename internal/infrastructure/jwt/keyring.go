@@ -0,0 +1,275 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 支持的签名算法
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgES256 = "ES256"
+	AlgEdDSA = "EdDSA"
+)
+
+// IsAsymmetric 判断算法是否为非对称算法
+func IsAsymmetric(alg string) bool {
+	return alg == AlgRS256 || alg == AlgES256 || alg == AlgEdDSA
+}
+
+// Key 签名密钥，Kid 为空表示不带 kid 的 HMAC 兼容密钥
+type Key struct {
+	Kid       string
+	Algorithm string
+	ExpireAt  time.Time // 停止用于验证的时间，零值表示不过期
+
+	signKey   any
+	verifyKey any
+}
+
+// newHMACKey 创建 HMAC 密钥
+func newHMACKey(kid string, secret []byte) *Key {
+	return &Key{Kid: kid, Algorithm: AlgHS256, signKey: secret, verifyKey: secret}
+}
+
+// ParsePrivateKeyPEM 解析 PEM 格式私钥（PKCS#8 / PKCS#1 / SEC1），并校验与算法匹配
+func ParsePrivateKeyPEM(kid, alg string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %s: invalid pem data", kid)
+	}
+
+	var (
+		priv any
+		err  error
+	)
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		priv, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		priv, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		priv, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("key %s: %w", kid, err)
+	}
+
+	key := &Key{Kid: kid, Algorithm: alg, signKey: priv}
+	switch k := priv.(type) {
+	case *rsa.PrivateKey:
+		if alg != AlgRS256 {
+			return nil, fmt.Errorf("key %s: rsa key cannot be used with %s", kid, alg)
+		}
+		if k.N.BitLen() < 2048 {
+			return nil, fmt.Errorf("key %s: rsa key must be at least 2048 bits", kid)
+		}
+		key.verifyKey = &k.PublicKey
+	case *ecdsa.PrivateKey:
+		if alg != AlgES256 || k.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("key %s: ecdsa key must use P-256 with ES256", kid)
+		}
+		key.verifyKey = &k.PublicKey
+	case ed25519.PrivateKey:
+		if alg != AlgEdDSA {
+			return nil, fmt.Errorf("key %s: ed25519 key cannot be used with %s", kid, alg)
+		}
+		key.verifyKey = k.Public()
+	default:
+		return nil, fmt.Errorf("key %s: unsupported private key type %T", kid, priv)
+	}
+	return key, nil
+}
+
+// LoadPrivateKeyFile 从文件加载 PEM 格式私钥
+func LoadPrivateKeyFile(kid, alg, path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("key %s: %w", kid, err)
+	}
+	return ParsePrivateKeyPEM(kid, alg, data)
+}
+
+// GenerateKey 按算法生成新的私钥，返回 PKCS#8 PEM 编码
+func GenerateKey(alg string) ([]byte, error) {
+	var (
+		priv crypto.Signer
+		err  error
+	)
+	switch alg {
+	case AlgRS256:
+		priv, err = rsa.GenerateKey(rand.Reader, 2048)
+	case AlgES256:
+		priv, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case AlgEdDSA:
+		_, priv, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported asymmetric algorithm: %s", alg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+func (k *Key) method() jwt.SigningMethod {
+	return jwt.GetSigningMethod(k.Algorithm)
+}
+
+func (k *Key) expired(now time.Time) bool {
+	return !k.ExpireAt.IsZero() && !now.Before(k.ExpireAt)
+}
+
+// Keyring 密钥环：一个签名密钥 + 多个验证密钥（按 kid 区分），支持运行时替换
+type Keyring struct {
+	mu     sync.RWMutex
+	legacy *Key            // 不带 kid 的 HMAC 密钥（config.JWT.Secret），用于兼容旧 Token
+	keys   map[string]*Key // 带 kid 的密钥
+	active string          // 当前签名密钥的 kid，为空时使用 legacy
+}
+
+// NewKeyring 创建密钥环，legacySecret 为空时不接受不带 kid 的 Token
+func NewKeyring(legacySecret string) *Keyring {
+	r := &Keyring{keys: make(map[string]*Key)}
+	if legacySecret != "" {
+		r.legacy = newHMACKey("", []byte(legacySecret))
+	}
+	return r
+}
+
+// Replace 替换全部带 kid 的密钥，activeKid 为空表示使用 HMAC 兼容密钥签名
+func (r *Keyring) Replace(keys []*Key, activeKid string) error {
+	m := make(map[string]*Key, len(keys))
+	for _, k := range keys {
+		if k.Kid == "" {
+			return errors.New("key id is required")
+		}
+		if _, ok := m[k.Kid]; ok {
+			return fmt.Errorf("duplicate key id: %s", k.Kid)
+		}
+		m[k.Kid] = k
+	}
+	if activeKid != "" {
+		if _, ok := m[activeKid]; !ok {
+			return fmt.Errorf("active key %s not found", activeKid)
+		}
+	} else if r.legacy == nil {
+		return errors.New("no signing key available")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys = m
+	r.active = activeKid
+	return nil
+}
+
+// ActiveKid 返回当前签名密钥的 kid
+func (r *Keyring) ActiveKid() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.active
+}
+
+func (r *Keyring) signingKey() (*Key, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.active != "" {
+		return r.keys[r.active], nil
+	}
+	if r.legacy == nil {
+		return nil, errors.New("no signing key available")
+	}
+	return r.legacy, nil
+}
+
+func (r *Keyring) verificationKey(kid string, now time.Time) (*Key, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if kid == "" {
+		if r.legacy == nil {
+			return nil, errors.New("token without kid is not accepted")
+		}
+		return r.legacy, nil
+	}
+	k, ok := r.keys[kid]
+	if !ok || k.expired(now) {
+		return nil, fmt.Errorf("unknown key id: %s", kid)
+	}
+	return k, nil
+}
+
+// JSONWebKey RFC 7517 公钥
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JSONWebKeySet RFC 7517 公钥集合
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWKS 返回所有未过期非对称密钥的公钥（包含尚未启用签名的新密钥，便于验证方提前缓存）
+func (r *Keyring) JWKS() JSONWebKeySet {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	set := JSONWebKeySet{Keys: make([]JSONWebKey, 0, len(r.keys))}
+	for _, k := range r.keys {
+		if k.expired(now) {
+			continue
+		}
+		jwk := JSONWebKey{Use: "sig", Kid: k.Kid, Alg: k.Algorithm}
+		switch pub := k.verifyKey.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case *ecdsa.PublicKey:
+			jwk.Kty = "EC"
+			jwk.Crv = "P-256"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub.X.FillBytes(make([]byte, 32)))
+			jwk.Y = base64.RawURLEncoding.EncodeToString(pub.Y.FillBytes(make([]byte, 32)))
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			// HMAC 密钥不公开
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+	return set
+}
@@ -76,4 +76,30 @@ var (
 			Help: "Number of database connections in use",
 		},
 	)
+
+	// CasbinPolicyVersionDrift Casbin 策略版本漂移（全局最新版本 - 本副本已应用版本）
+	CasbinPolicyVersionDrift = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "casbin_policy_version_drift",
+			Help: "Casbin policy versions this replica is behind the latest published version",
+		},
+	)
+
+	// CasbinPolicySyncTotal Casbin 策略同步消息数
+	CasbinPolicySyncTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "casbin_policy_sync_total",
+			Help: "Total number of casbin policy sync messages",
+		},
+		[]string{"direction", "result"},
+	)
+
+	// CasbinPolicyReloadTotal Casbin 策略全量重新加载次数
+	CasbinPolicyReloadTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "casbin_policy_reload_total",
+			Help: "Total number of casbin policy full reloads",
+		},
+		[]string{"reason"},
+	)
 )
@@ -0,0 +1,31 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/force-c/nai-tizi/internal/service"
+	"go.uber.org/zap"
+)
+
+// JwtKeyJob 按周期轮换数据库中的 JWT 签名密钥，并重新加载密钥环（使各实例获得其他实例生成的新密钥）
+type JwtKeyJob struct {
+	jwtKeyService service.JwtKeyService
+	logger        logging.Logger
+}
+
+func NewJwtKeyJob(jwtKeyService service.JwtKeyService, logger logging.Logger) *JwtKeyJob {
+	return &JwtKeyJob{jwtKeyService: jwtKeyService, logger: logger}
+}
+func (j *JwtKeyJob) Run() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if _, err := j.jwtKeyService.RotateIfDue(ctx); err != nil {
+		j.logger.Error("jwt key rotation failed", zap.Error(err))
+	}
+	if err := j.jwtKeyService.Load(ctx); err != nil {
+		j.logger.Error("jwt key reload failed", zap.Error(err))
+	}
+}
+func (j *JwtKeyJob) Schedule() string { return "0 * * * * *" }
@@ -0,0 +1,35 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/force-c/nai-tizi/internal/service"
+	"go.uber.org/zap"
+)
+
+// LdapSyncJob 同步 LDAP 目录账号，停用目录中已删除的用户
+type LdapSyncJob struct {
+	ldapService service.LdapService
+	spec        string
+	logger      logging.Logger
+}
+
+func NewLdapSyncJob(ldapService service.LdapService, spec string, logger logging.Logger) *LdapSyncJob {
+	return &LdapSyncJob{ldapService: ldapService, spec: spec, logger: logger}
+}
+func (j *LdapSyncJob) Run() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+	result, err := j.ldapService.SyncUsers(ctx)
+	if err != nil {
+		j.logger.Error("ldap sync job failed", zap.Error(err))
+		return
+	}
+	j.logger.Info("ldap sync job completed",
+		zap.Int("total", result.Total),
+		zap.Int("updated", result.Updated),
+		zap.Int("disabled", result.Disabled))
+}
+func (j *LdapSyncJob) Schedule() string { return j.spec }
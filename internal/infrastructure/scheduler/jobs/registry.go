@@ -17,6 +17,8 @@ func RegisterJobs(
 	db *gorm.DB,
 	redis *redis.Client,
 	retryManager *retry.Manager,
+	ldapSync *LdapSyncJob,
+	jwtKey *JwtKeyJob,
 	logger logging.Logger,
 ) error {
 	// 1. 数据清理任务
@@ -33,6 +35,20 @@ func RegisterJobs(
 		}
 	}
 
+	// 3. LDAP 目录同步任务
+	if ldapSync != nil {
+		if err := sched.AddJob(ldapSync.Schedule(), "ldap-sync", ldapSync.Run); err != nil {
+			return fmt.Errorf("failed to add ldap-sync job: %w", err)
+		}
+	}
+
+	// 4. JWT 密钥轮换任务
+	if jwtKey != nil {
+		if err := sched.AddJob(jwtKey.Schedule(), "jwt-key", jwtKey.Run); err != nil {
+			return fmt.Errorf("failed to add jwt-key job: %w", err)
+		}
+	}
+
 	logger.Info("all jobs registered successfully", zap.Int("count", sched.GetJobCount()))
 	return nil
 }
@@ -0,0 +1,96 @@
+package tenant
+
+import (
+	"errors"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Column 租户字段列名，模型包含该列即视为租户数据
+const Column = "tenant_id"
+
+// ErrCrossTenant 写入的数据不属于当前租户
+var ErrCrossTenant = errors.New("不能写入其他租户的数据")
+
+// Plugin GORM 多租户插件
+// 查询/更新/删除租户数据时自动追加 tenant_id = 当前租户 条件，创建时自动填充 tenant_id；
+// 租户ID取自 statement 的 context（请使用 db.WithContext(ctx)），未设置时为默认租户，
+// 通过 WithoutTenant 标记的 context 不做处理。Raw/Exec 原生 SQL 不经过模型解析，需自行带上租户条件
+type Plugin struct{}
+
+// NewPlugin 创建多租户插件
+func NewPlugin() *Plugin {
+	return &Plugin{}
+}
+
+// Name 插件名称
+func (p *Plugin) Name() string {
+	return "tenant"
+}
+
+// Initialize 注册回调
+func (p *Plugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().Before("gorm:create").Register("tenant:create", p.fillTenant); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("tenant:query", p.addPredicate); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("tenant:update", p.addPredicate); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("tenant:delete", p.addPredicate); err != nil {
+		return err
+	}
+	return cb.Row().Before("gorm:row").Register("tenant:row", p.addPredicate)
+}
+
+// addPredicate 追加租户过滤条件
+func (p *Plugin) addPredicate(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil || stmt.Schema.LookUpField(Column) == nil || Skipped(stmt.Context) {
+		return
+	}
+	stmt.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: Column}, Value: FromContext(stmt.Context)},
+	}})
+}
+
+// fillTenant 创建时填充租户ID，已指定其他租户时拒绝写入
+func (p *Plugin) fillTenant(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil || Skipped(stmt.Context) {
+		return
+	}
+	field := stmt.Schema.LookUpField(Column)
+	if field == nil {
+		return
+	}
+	tenantId := FromContext(stmt.Context)
+
+	fill := func(rv reflect.Value) {
+		value, zero := field.ValueOf(stmt.Context, rv)
+		if zero {
+			if err := field.Set(stmt.Context, rv, tenantId); err != nil {
+				_ = db.AddError(err)
+			}
+			return
+		}
+		if id, ok := value.(int64); !ok || id != tenantId {
+			_ = db.AddError(ErrCrossTenant)
+		}
+	}
+
+	rv := reflect.Indirect(stmt.ReflectValue)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			fill(reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		fill(rv)
+	}
+}
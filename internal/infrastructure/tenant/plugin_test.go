@@ -0,0 +1,149 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// tenantUser 租户数据
+type tenantUser struct {
+	ID       int64
+	TenantId int64
+	UserName string
+}
+
+func (tenantUser) TableName() string { return "s_user" }
+
+// platformMenu 平台级数据（无 tenant_id）
+type platformMenu struct {
+	ID       int64
+	MenuName string
+}
+
+func (platformMenu) TableName() string { return "s_menu" }
+
+func newDryRunDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true, SkipDefaultTransaction: true})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(NewPlugin()))
+	return db
+}
+
+func TestPlugin_QueryFiltersByContextTenant(t *testing.T) {
+	db := newDryRunDB(t)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var users []tenantUser
+		return tx.WithContext(WithTenantId(context.Background(), 2)).Where("user_name = ?", "alice").Find(&users)
+	})
+	assert.Equal(t, `SELECT * FROM "s_user" WHERE user_name = 'alice' AND "s_user"."tenant_id" = 2`, sql)
+
+	// 按主键查询其他租户的数据同样带上当前租户条件，无法读取
+	sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var user tenantUser
+		return tx.WithContext(WithTenantId(context.Background(), 3)).First(&user, 100)
+	})
+	assert.Contains(t, sql, `"s_user"."tenant_id" = 3`)
+	assert.Contains(t, sql, `"s_user"."id" = 100`)
+}
+
+func TestPlugin_DefaultTenantWithoutContext(t *testing.T) {
+	db := newDryRunDB(t)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var count int64
+		return tx.Model(&tenantUser{}).Count(&count)
+	})
+	assert.Contains(t, sql, `"s_user"."tenant_id" = 1`)
+}
+
+func TestPlugin_UpdateAndDeleteFiltered(t *testing.T) {
+	db := newDryRunDB(t)
+	ctx := WithTenantId(context.Background(), 2)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.WithContext(ctx).Model(&tenantUser{}).Where("id = ?", 100).Update("user_name", "bob")
+	})
+	assert.Contains(t, sql, `UPDATE "s_user" SET "user_name"='bob' WHERE id = 100 AND "s_user"."tenant_id" = 2`)
+
+	sql = db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.WithContext(ctx).Where("id = ?", 100).Delete(&tenantUser{})
+	})
+	assert.Equal(t, `DELETE FROM "s_user" WHERE id = 100 AND "s_user"."tenant_id" = 2`, sql)
+}
+
+func TestPlugin_CreateFillsTenant(t *testing.T) {
+	db := newDryRunDB(t)
+
+	user := tenantUser{ID: 1, UserName: "alice"}
+	require.NoError(t, db.WithContext(WithTenantId(context.Background(), 2)).Create(&user).Error)
+	assert.Equal(t, int64(2), user.TenantId)
+
+	users := []tenantUser{{ID: 2, UserName: "bob"}, {ID: 3, UserName: "carol", TenantId: 2}}
+	require.NoError(t, db.WithContext(WithTenantId(context.Background(), 2)).Create(&users).Error)
+	assert.Equal(t, int64(2), users[0].TenantId)
+	assert.Equal(t, int64(2), users[1].TenantId)
+}
+
+func TestPlugin_CreateRejectsOtherTenant(t *testing.T) {
+	db := newDryRunDB(t)
+
+	user := tenantUser{ID: 1, UserName: "mallory", TenantId: 3}
+	err := db.WithContext(WithTenantId(context.Background(), 2)).Create(&user).Error
+	assert.ErrorIs(t, err, ErrCrossTenant)
+}
+
+func TestPlugin_WithoutTenantSkips(t *testing.T) {
+	db := newDryRunDB(t)
+	ctx := WithoutTenant(WithTenantId(context.Background(), 2))
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var users []tenantUser
+		return tx.WithContext(ctx).Find(&users)
+	})
+	assert.Equal(t, `SELECT * FROM "s_user"`, sql)
+
+	// 平台级操作可写入任意租户
+	user := tenantUser{ID: 1, UserName: "alice", TenantId: 3}
+	require.NoError(t, db.WithContext(ctx).Create(&user).Error)
+	assert.Equal(t, int64(3), user.TenantId)
+}
+
+func TestPlugin_PlatformModelUntouched(t *testing.T) {
+	db := newDryRunDB(t)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var menus []platformMenu
+		return tx.WithContext(WithTenantId(context.Background(), 2)).Find(&menus)
+	})
+	assert.Equal(t, `SELECT * FROM "s_menu"`, sql)
+}
+
+func TestPlugin_AliasedTable(t *testing.T) {
+	db := newDryRunDB(t)
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var users []tenantUser
+		return tx.WithContext(WithTenantId(context.Background(), 2)).Table("s_user u").Where("u.user_name = ?", "alice").Find(&users)
+	})
+	assert.Equal(t, `SELECT * FROM s_user u WHERE u.user_name = 'alice' AND "u"."tenant_id" = 2`, sql)
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, DefaultTenantId, FromContext(ctx))
+	_, ok := IdFromContext(ctx)
+	assert.False(t, ok)
+
+	ctx = WithTenantId(ctx, 5)
+	id, ok := IdFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), id)
+	assert.False(t, Skipped(ctx))
+	assert.True(t, Skipped(WithoutTenant(ctx)))
+}
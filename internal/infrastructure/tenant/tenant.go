@@ -0,0 +1,40 @@
+package tenant
+
+import "context"
+
+// DefaultTenantId 默认租户ID（单一企业模式下所有数据都属于该租户）
+const DefaultTenantId int64 = 1
+
+type tenantKey struct{}
+
+type skipKey struct{}
+
+// WithTenantId 将当前请求的租户ID写入 context
+func WithTenantId(ctx context.Context, tenantId int64) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantId)
+}
+
+// IdFromContext 从 context 读取租户ID，ok 表示是否显式设置
+func IdFromContext(ctx context.Context) (int64, bool) {
+	tenantId, ok := ctx.Value(tenantKey{}).(int64)
+	return tenantId, ok && tenantId > 0
+}
+
+// FromContext 从 context 读取租户ID，未设置时为默认租户
+func FromContext(ctx context.Context) int64 {
+	if tenantId, ok := IdFromContext(ctx); ok {
+		return tenantId
+	}
+	return DefaultTenantId
+}
+
+// WithoutTenant 标记为平台级操作（如跨租户的后台任务、按全局唯一凭证定位租户），跳过租户过滤
+func WithoutTenant(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipKey{}, true)
+}
+
+// Skipped 判断 context 是否跳过租户过滤
+func Skipped(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipKey{}).(bool)
+	return skip
+}
@@ -0,0 +1,299 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	goldap "github.com/go-ldap/ldap/v3"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrUserNotFound 目录中不存在该用户
+	ErrUserNotFound = errors.New("ldap user not found")
+
+	// ErrInvalidCredentials 用户名或密码错误
+	ErrInvalidCredentials = errors.New("ldap invalid credentials")
+)
+
+// Attributes 目录属性映射
+type Attributes struct {
+	Username string
+	Nickname string
+	Email    string
+	Phone    string
+	Org      string
+}
+
+// Config LDAP 连接配置
+type Config struct {
+	URL                string
+	StartTLS           bool
+	InsecureSkipVerify bool
+	BindDN             string
+	BindPassword       string
+	BaseDN             string
+	UserFilter         string // 含一个 %s 占位符
+	Attributes         Attributes
+	GroupAttribute     string
+	PoolSize           int
+	Timeout            time.Duration
+}
+
+// Entry 目录中的用户条目
+type Entry struct {
+	DN       string
+	Username string
+	Nickname string
+	Email    string
+	Phone    string
+	Org      string
+	Groups   []string // 所属组 DN
+}
+
+// Manager LDAP 客户端，维护以服务账号绑定的连接池
+type Manager struct {
+	cfg    Config
+	tls    *tls.Config
+	logger logging.Logger
+	idle   chan *goldap.Conn // 空闲连接
+	slots  chan struct{}     // 限制同时打开的连接数
+}
+
+// NewManager 创建 LDAP 客户端，连接在首次使用时建立
+func NewManager(cfg Config, logger logging.Logger) (*Manager, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps") {
+		return nil, fmt.Errorf("invalid ldap url: %s", cfg.URL)
+	}
+	if cfg.StartTLS && u.Scheme == "ldaps" {
+		return nil, fmt.Errorf("startTLS cannot be used with ldaps://")
+	}
+	if cfg.PoolSize <= 0 {
+		cfg.PoolSize = 5
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	return &Manager{
+		cfg: cfg,
+		tls: &tls.Config{
+			ServerName:         u.Hostname(),
+			InsecureSkipVerify: cfg.InsecureSkipVerify,
+			MinVersion:         tls.VersionTLS12,
+		},
+		logger: logger,
+		idle:   make(chan *goldap.Conn, cfg.PoolSize),
+		slots:  make(chan struct{}, cfg.PoolSize),
+	}, nil
+}
+
+// Authenticate 查询用户并以其 DN 和密码绑定校验
+func (m *Manager) Authenticate(ctx context.Context, username, password string) (*Entry, error) {
+	// 空密码会被服务端视为匿名绑定而成功，必须拒绝
+	if username == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := m.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := m.search(conn, username)
+	if err != nil {
+		m.release(conn, err)
+		return nil, err
+	}
+
+	bindErr := conn.Bind(entry.DN, password)
+	// 用户绑定后连接身份已改变，需重新以服务账号绑定才能放回连接池
+	var restoreErr error
+	if m.cfg.BindDN != "" {
+		restoreErr = conn.Bind(m.cfg.BindDN, m.cfg.BindPassword)
+	} else {
+		restoreErr = errors.New("anonymous connection cannot be restored")
+	}
+	m.release(conn, restoreErr)
+
+	if bindErr != nil {
+		if goldap.IsErrorWithCode(bindErr, goldap.LDAPResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		m.logger.Warn("ldap user bind failed", zap.String("dn", entry.DN), zap.Error(bindErr))
+		return nil, fmt.Errorf("ldap bind: %w", bindErr)
+	}
+	return entry, nil
+}
+
+// Lookup 按用户名查询用户条目（用于同步）
+func (m *Manager) Lookup(ctx context.Context, username string) (*Entry, error) {
+	conn, err := m.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := m.search(conn, username)
+	m.release(conn, err)
+	return entry, err
+}
+
+// Close 关闭所有空闲连接
+func (m *Manager) Close() {
+	for {
+		select {
+		case conn := <-m.idle:
+			_ = conn.Close()
+		default:
+			return
+		}
+	}
+}
+
+// search 按过滤器查询唯一的用户条目
+func (m *Manager) search(conn *goldap.Conn, username string) (*Entry, error) {
+	attrs := m.cfg.Attributes
+	attributes := []string{m.cfg.GroupAttribute}
+	for _, a := range []string{attrs.Username, attrs.Nickname, attrs.Email, attrs.Phone, attrs.Org} {
+		if a != "" {
+			attributes = append(attributes, a)
+		}
+	}
+
+	req := goldap.NewSearchRequest(
+		m.cfg.BaseDN,
+		goldap.ScopeWholeSubtree,
+		goldap.NeverDerefAliases,
+		2, // 只需判断是否唯一
+		int(m.cfg.Timeout.Seconds()),
+		false,
+		fmt.Sprintf(m.cfg.UserFilter, goldap.EscapeFilter(username)),
+		attributes,
+		nil,
+	)
+	result, err := conn.Search(req)
+	if err != nil && !goldap.IsErrorWithCode(err, goldap.LDAPResultSizeLimitExceeded) {
+		if goldap.IsErrorWithCode(err, goldap.LDAPResultNoSuchObject) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("ldap search: %w", err)
+	}
+	if result == nil || len(result.Entries) == 0 {
+		return nil, ErrUserNotFound
+	}
+	if len(result.Entries) > 1 {
+		return nil, fmt.Errorf("ldap search returned multiple entries for %s", username)
+	}
+
+	e := result.Entries[0]
+	entry := &Entry{
+		DN:       e.DN,
+		Username: e.GetAttributeValue(attrs.Username),
+		Nickname: e.GetAttributeValue(attrs.Nickname),
+		Email:    e.GetAttributeValue(attrs.Email),
+		Phone:    e.GetAttributeValue(attrs.Phone),
+		Groups:   e.GetAttributeValues(m.cfg.GroupAttribute),
+	}
+	if attrs.Org != "" {
+		entry.Org = e.GetAttributeValue(attrs.Org)
+	}
+	if entry.Username == "" {
+		entry.Username = username
+	}
+	return entry, nil
+}
+
+// acquire 从连接池获取连接，池中无空闲连接时新建（受连接数上限约束）
+func (m *Manager) acquire(ctx context.Context) (*goldap.Conn, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.Timeout)
+	defer cancel()
+	select {
+	case m.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("ldap pool exhausted: %w", ctx.Err())
+	}
+
+	for {
+		select {
+		case conn := <-m.idle:
+			if conn.IsClosing() {
+				_ = conn.Close()
+				continue
+			}
+			return conn, nil
+		default:
+		}
+		conn, err := m.dial()
+		if err != nil {
+			<-m.slots
+			return nil, err
+		}
+		return conn, nil
+	}
+}
+
+// release 归还连接；操作出现网络错误或连接状态不可复用时关闭
+func (m *Manager) release(conn *goldap.Conn, err error) {
+	defer func() { <-m.slots }()
+	if (err != nil && !errors.Is(err, ErrUserNotFound)) || conn.IsClosing() {
+		_ = conn.Close()
+		return
+	}
+	select {
+	case m.idle <- conn:
+	default:
+		_ = conn.Close()
+	}
+}
+
+// dial 建立连接，按配置执行 StartTLS 并以服务账号绑定
+func (m *Manager) dial() (*goldap.Conn, error) {
+	conn, err := goldap.DialURL(m.cfg.URL,
+		goldap.DialWithDialer(&net.Dialer{Timeout: m.cfg.Timeout}),
+		goldap.DialWithTLSConfig(m.tls),
+	)
+	if err != nil {
+		m.logger.Error("ldap dial failed", zap.String("url", m.cfg.URL), zap.Error(err))
+		return nil, fmt.Errorf("ldap dial: %w", err)
+	}
+	conn.SetTimeout(m.cfg.Timeout)
+
+	if m.cfg.StartTLS {
+		if err := conn.StartTLS(m.tls); err != nil {
+			_ = conn.Close()
+			m.logger.Error("ldap starttls failed", zap.String("url", m.cfg.URL), zap.Error(err))
+			return nil, fmt.Errorf("ldap starttls: %w", err)
+		}
+	}
+
+	if m.cfg.BindDN != "" {
+		if err := conn.Bind(m.cfg.BindDN, m.cfg.BindPassword); err != nil {
+			_ = conn.Close()
+			m.logger.Error("ldap service account bind failed", zap.String("bindDN", m.cfg.BindDN), zap.Error(err))
+			return nil, fmt.Errorf("ldap service bind: %w", err)
+		}
+	}
+	return conn, nil
+}
+
+// GroupName 返回组 DN 的首个 RDN 值（如 cn=admins,ou=groups,... -> admins）
+func GroupName(dn string) string {
+	parsed, err := goldap.ParseDN(dn)
+	if err != nil || len(parsed.RDNs) == 0 || len(parsed.RDNs[0].Attributes) == 0 {
+		return dn
+	}
+	return parsed.RDNs[0].Attributes[0].Value
+}
+
+// MatchGroup 判断组 DN 是否与配置的组（DN 或 CN）匹配，不区分大小写
+func MatchGroup(groupDN, configured string) bool {
+	if strings.EqualFold(groupDN, configured) {
+		return true
+	}
+	return !strings.Contains(configured, "=") && strings.EqualFold(GroupName(groupDN), configured)
+}
@@ -0,0 +1,207 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+)
+
+// ClaimMapping ID Token 声明到系统用户字段的映射，为空时使用标准声明名
+type ClaimMapping struct {
+	Username string // 默认 preferred_username
+	Nickname string // 默认 name
+	Email    string // 默认 email
+	Phone    string // 默认 phone_number
+	Avatar   string // 默认 picture
+}
+
+// ProviderConfig 上游 OIDC 提供方配置
+type ProviderConfig struct {
+	Name         string // 提供方标识
+	DisplayName  string // 显示名称
+	Issuer       string // 发行方地址（用于自动发现）
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string   // 回调地址（前端页面）
+	Scopes       []string // 申请的范围，须包含 openid
+	ClaimMapping ClaimMapping
+}
+
+// Identity 经过 ID Token 校验的上游身份
+type Identity struct {
+	Provider      string
+	Subject       string
+	Username      string
+	Nickname      string
+	Email         string
+	EmailVerified bool
+	Phone         string
+	Avatar        string
+}
+
+// provider 已完成发现的提供方
+type provider struct {
+	oauth2   oauth2.Config
+	verifier *gooidc.IDTokenVerifier
+}
+
+// Manager 管理多个上游 OIDC 提供方
+// 提供方元数据在首次使用时通过 /.well-known/openid-configuration 发现并缓存，签名公钥由 JWKS 自动轮换
+type Manager struct {
+	configs   map[string]ProviderConfig
+	order     []string
+	logger    logging.Logger
+	client    *http.Client
+	mu        sync.Mutex
+	providers map[string]*provider
+}
+
+// NewManager 创建 OIDC 提供方管理器
+func NewManager(configs []ProviderConfig, logger logging.Logger) (*Manager, error) {
+	m := &Manager{
+		configs:   make(map[string]ProviderConfig, len(configs)),
+		order:     make([]string, 0, len(configs)),
+		logger:    logger,
+		client:    &http.Client{Timeout: 10 * time.Second},
+		providers: make(map[string]*provider),
+	}
+	for _, cfg := range configs {
+		if cfg.Name == "" || cfg.Issuer == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+			return nil, fmt.Errorf("oidc provider name, issuer, clientId and redirectUrl are required")
+		}
+		if _, exists := m.configs[cfg.Name]; exists {
+			return nil, fmt.Errorf("duplicate oidc provider: %s", cfg.Name)
+		}
+		if len(cfg.Scopes) == 0 {
+			cfg.Scopes = []string{gooidc.ScopeOpenID, "profile", "email"}
+		}
+		if cfg.DisplayName == "" {
+			cfg.DisplayName = cfg.Name
+		}
+		m.configs[cfg.Name] = cfg
+		m.order = append(m.order, cfg.Name)
+	}
+	return m, nil
+}
+
+// Providers 返回已配置的提供方（按配置顺序）
+func (m *Manager) Providers() []ProviderConfig {
+	result := make([]ProviderConfig, 0, len(m.order))
+	for _, name := range m.order {
+		result = append(result, m.configs[name])
+	}
+	return result
+}
+
+// AuthCodeURL 生成跳转到提供方的授权地址（授权码模式 + PKCE + nonce）
+func (m *Manager) AuthCodeURL(ctx context.Context, name, state, nonce, codeVerifier string) (string, error) {
+	p, err := m.getProvider(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	return p.oauth2.AuthCodeURL(state, gooidc.Nonce(nonce), oauth2.S256ChallengeOption(codeVerifier)), nil
+}
+
+// Exchange 使用授权码换取 ID Token，通过提供方 JWKS 校验签名、发行方、受众、有效期和 nonce 后返回身份
+func (m *Manager) Exchange(ctx context.Context, name, code, codeVerifier, nonce string) (*Identity, error) {
+	p, err := m.getProvider(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, m.client)
+	token, err := p.oauth2.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		m.logger.Warn("oidc code exchange failed", zap.String("provider", name), zap.Error(err))
+		return nil, fmt.Errorf("授权码无效或已过期")
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, fmt.Errorf("提供方未返回 ID Token")
+	}
+
+	idToken, err := p.verifier.Verify(gooidc.ClientContext(ctx, m.client), rawIDToken)
+	if err != nil {
+		m.logger.Warn("oidc id token verification failed", zap.String("provider", name), zap.Error(err))
+		return nil, fmt.Errorf("ID Token 校验失败")
+	}
+	if idToken.Nonce != nonce {
+		return nil, fmt.Errorf("ID Token 校验失败")
+	}
+
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("解析 ID Token 失败")
+	}
+	return mapIdentity(name, idToken.Subject, claims, m.configs[name].ClaimMapping), nil
+}
+
+// getProvider 获取已发现的提供方，首次调用时执行发现
+func (m *Manager) getProvider(ctx context.Context, name string) (*provider, error) {
+	cfg, ok := m.configs[name]
+	if !ok {
+		return nil, fmt.Errorf("不支持的登录提供方: %s", name)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if p, ok := m.providers[name]; ok {
+		return p, nil
+	}
+
+	// 发现结果（含 JWKS 远程密钥集）在进程内长期复用，不绑定请求上下文
+	discoveryCtx := gooidc.ClientContext(context.Background(), m.client)
+	op, err := gooidc.NewProvider(discoveryCtx, cfg.Issuer)
+	if err != nil {
+		m.logger.Error("oidc discovery failed", zap.String("provider", name), zap.String("issuer", cfg.Issuer), zap.Error(err))
+		return nil, fmt.Errorf("登录提供方暂不可用")
+	}
+
+	p := &provider{
+		oauth2: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			Endpoint:     op.Endpoint(),
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       cfg.Scopes,
+		},
+		verifier: op.Verifier(&gooidc.Config{ClientID: cfg.ClientID}),
+	}
+	m.providers[name] = p
+	return p, nil
+}
+
+// mapIdentity 按映射规则从 ID Token 声明中提取身份信息
+func mapIdentity(providerName, subject string, claims map[string]any, mapping ClaimMapping) *Identity {
+	identity := &Identity{
+		Provider: providerName,
+		Subject:  subject,
+		Username: stringClaim(claims, mapping.Username, "preferred_username"),
+		Nickname: stringClaim(claims, mapping.Nickname, "name"),
+		Email:    stringClaim(claims, mapping.Email, "email"),
+		Phone:    stringClaim(claims, mapping.Phone, "phone_number"),
+		Avatar:   stringClaim(claims, mapping.Avatar, "picture"),
+	}
+	if verified, ok := claims["email_verified"].(bool); ok {
+		identity.EmailVerified = verified
+	}
+	return identity
+}
+
+// stringClaim 读取字符串声明，未配置映射时使用默认声明名
+func stringClaim(claims map[string]any, name, fallback string) string {
+	if name == "" {
+		name = fallback
+	}
+	if v, ok := claims[name].(string); ok {
+		return v
+	}
+	return ""
+}
@@ -0,0 +1,218 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...zap.Field)         {}
+func (nopLogger) Info(string, ...zap.Field)          {}
+func (nopLogger) Warn(string, ...zap.Field)          {}
+func (nopLogger) Error(string, ...zap.Field)         {}
+func (nopLogger) Fatal(string, ...zap.Field)         {}
+func (l nopLogger) With(...zap.Field) logging.Logger { return l }
+
+// fakeProvider 进程内的 OIDC 提供方，支持发现、JWKS 和令牌端点
+type fakeProvider struct {
+	server     *httptest.Server
+	jwksKey    *rsa.PrivateKey // JWKS 中公布的密钥
+	signingKey *rsa.PrivateKey // 实际签发 ID Token 使用的密钥
+	nonce      string
+	claims     jwt.MapClaims
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	f := &fakeProvider{jwksKey: key, signingKey: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                f.server.URL,
+			"authorization_endpoint":                f.server.URL + "/authorize",
+			"token_endpoint":                        f.server.URL + "/token",
+			"jwks_uri":                              f.server.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		pub := f.jwksKey.PublicKey
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test-key",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.PostForm.Get("code") != "good-code" || r.PostForm.Get("code_verifier") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		claims := jwt.MapClaims{
+			"iss":   f.server.URL,
+			"sub":   "user-123",
+			"aud":   "test-client",
+			"iat":   time.Now().Unix(),
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"nonce": f.nonce,
+		}
+		for k, v := range f.claims {
+			claims[k] = v
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "test-key"
+		idToken, err := token.SignedString(f.signingKey)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "upstream-access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     idToken,
+		})
+	})
+	f.server = httptest.NewServer(mux)
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func newTestManager(t *testing.T, f *fakeProvider, mapping ClaimMapping) *Manager {
+	m, err := NewManager([]ProviderConfig{{
+		Name:         "fake",
+		Issuer:       f.server.URL,
+		ClientID:     "test-client",
+		ClientSecret: "test-secret",
+		RedirectURL:  "http://localhost/callback",
+		ClaimMapping: mapping,
+	}}, nopLogger{})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	return m
+}
+
+// TestAuthCodeURL_IncludesPKCEAndNonce 测试授权地址携带 state、nonce 和 PKCE 挑战
+func TestAuthCodeURL_IncludesPKCEAndNonce(t *testing.T) {
+	f := newFakeProvider(t)
+	m := newTestManager(t, f, ClaimMapping{})
+
+	authURL, err := m.AuthCodeURL(context.Background(), "fake", "state-1", "nonce-1", "verifier-verifier-verifier-verifier-verifier")
+	if err != nil {
+		t.Fatalf("failed to build auth url: %v", err)
+	}
+	if !strings.HasPrefix(authURL, f.server.URL+"/authorize?") {
+		t.Fatalf("unexpected auth url: %s", authURL)
+	}
+	u, _ := url.Parse(authURL)
+	q := u.Query()
+	if q.Get("state") != "state-1" || q.Get("nonce") != "nonce-1" {
+		t.Errorf("state or nonce missing: %s", authURL)
+	}
+	if q.Get("code_challenge") == "" || q.Get("code_challenge_method") != "S256" {
+		t.Errorf("pkce challenge missing: %s", authURL)
+	}
+	if !strings.Contains(q.Get("scope"), "openid") {
+		t.Errorf("expected openid scope, got %q", q.Get("scope"))
+	}
+}
+
+// TestExchange_MapsClaims 测试 ID Token 校验通过后按映射提取身份
+func TestExchange_MapsClaims(t *testing.T) {
+	f := newFakeProvider(t)
+	f.nonce = "nonce-1"
+	f.claims = jwt.MapClaims{
+		"login":          "alice",
+		"name":           "Alice",
+		"email":          "alice@example.com",
+		"email_verified": true,
+	}
+	m := newTestManager(t, f, ClaimMapping{Username: "login"})
+
+	identity, err := m.Exchange(context.Background(), "fake", "good-code", "verifier", "nonce-1")
+	if err != nil {
+		t.Fatalf("exchange failed: %v", err)
+	}
+	if identity.Provider != "fake" || identity.Subject != "user-123" {
+		t.Errorf("unexpected identity: %+v", identity)
+	}
+	if identity.Username != "alice" || identity.Nickname != "Alice" {
+		t.Errorf("claim mapping not applied: %+v", identity)
+	}
+	if identity.Email != "alice@example.com" || !identity.EmailVerified {
+		t.Errorf("email not mapped: %+v", identity)
+	}
+}
+
+// TestExchange_RejectsNonceMismatch 测试 nonce 不一致时拒绝
+func TestExchange_RejectsNonceMismatch(t *testing.T) {
+	f := newFakeProvider(t)
+	f.nonce = "other-nonce"
+	m := newTestManager(t, f, ClaimMapping{})
+
+	if _, err := m.Exchange(context.Background(), "fake", "good-code", "verifier", "nonce-1"); err == nil {
+		t.Fatal("expected nonce mismatch to be rejected")
+	}
+}
+
+// TestExchange_RejectsUnknownSigningKey 测试签名密钥不在 JWKS 中时拒绝
+func TestExchange_RejectsUnknownSigningKey(t *testing.T) {
+	f := newFakeProvider(t)
+	f.nonce = "nonce-1"
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	f.signingKey = other
+	m := newTestManager(t, f, ClaimMapping{})
+
+	if _, err := m.Exchange(context.Background(), "fake", "good-code", "verifier", "nonce-1"); err == nil {
+		t.Fatal("expected forged id token to be rejected")
+	}
+}
+
+// TestExchange_RejectsInvalidCode 测试授权码无效时返回错误
+func TestExchange_RejectsInvalidCode(t *testing.T) {
+	f := newFakeProvider(t)
+	m := newTestManager(t, f, ClaimMapping{})
+
+	if _, err := m.Exchange(context.Background(), "fake", "bad-code", "verifier", ""); err == nil {
+		t.Fatal("expected invalid code to be rejected")
+	}
+}
+
+// TestNewManager_RejectsDuplicateProvider 测试重复的提供方标识
+func TestNewManager_RejectsDuplicateProvider(t *testing.T) {
+	cfg := ProviderConfig{Name: "dup", Issuer: "https://issuer", ClientID: "id", RedirectURL: "https://cb"}
+	if _, err := NewManager([]ProviderConfig{cfg, cfg}, nopLogger{}); err == nil {
+		t.Fatal("expected duplicate provider to be rejected")
+	}
+}
@@ -1,35 +1,46 @@
 package middleware
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/force-c/nai-tizi/internal/config"
 	"github.com/force-c/nai-tizi/internal/domain/model"
 	"github.com/force-c/nai-tizi/internal/domain/response"
 	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/force-c/nai-tizi/internal/utils"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
 // Auth 认证中间件
-// 1. 从配置的请求头读取 AccessToken
-// 2. 验证 AccessToken
-// 3. 查询用户的组织ID
-// 4. 设置用户信息到 context
-func Auth(tokenManager service.TokenManager, cfg *config.Config, db *gorm.DB) gin.HandlerFunc {
+// 1. 从配置的请求头读取 AccessToken（或 API Key）
+// 2. 验证 AccessToken，并刷新会话活动时间（空闲超时返回 CodeSessionIdle，Redis 异常时拒绝请求）
+// 3. 以 Token 中的租户为准（多租户模式下请求指定的租户须与之一致）
+// 4. 查询用户的组织ID
+// 5. 设置用户信息到 context
+func Auth(tokenManager service.TokenManager, apiKeyService service.ApiKeyService, tenants service.TenantResolver, cfg *config.Config, db *gorm.DB) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 从配置的请求头读取 Token
 		tokenHeader := cfg.Auth.TokenHeader
-		token := c.GetHeader(tokenHeader)
+		token := strings.TrimPrefix(c.GetHeader(tokenHeader), "Bearer ")
+
+		// API Key：专用请求头，或 Token 请求头中带 ntz_ 前缀的值
+		apiKey := c.GetHeader(cfg.Auth.ApiKeyHeader)
+		if apiKey == "" && strings.HasPrefix(token, service.ApiKeyPrefix) {
+			apiKey = token
+		}
+		if apiKey != "" {
+			authApiKey(c, apiKeyService, tenants, cfg.MultiTenant.Enabled, apiKey)
+			return
+		}
+
 		if token == "" {
 			response.Unauthorized(c, "未登录")
 			c.Abort()
 			return
 		}
 
-		// 去除 "Bearer " 前缀
-		token = strings.TrimPrefix(token, "Bearer ")
-
 		// 验证 AccessToken
 		claims, err := tokenManager.ValidateAccessToken(c.Request.Context(), token)
 		if err != nil {
@@ -38,6 +49,24 @@ func Auth(tokenManager service.TokenManager, cfg *config.Config, db *gorm.DB) gi
 			return
 		}
 
+		// 滑动空闲超时：刷新会话活动记录，超时后 Token 在自然过期前即失效
+		if err := tokenManager.TouchSession(c.Request.Context(), claims); err != nil {
+			code := response.CodeServerError
+			if errors.Is(err, service.ErrSessionIdle) {
+				code = response.CodeSessionIdle
+			}
+			response.FailCode(c, code, err.Error())
+			c.Abort()
+			return
+		}
+
+		// OAuth2 授权签发的 Token 须代表用户且授予 api 范围才能访问系统接口
+		if claims.Scope != "" && (claims.UserId == 0 || !service.HasOAuthScope(claims.Scope, service.OAuthScopeApi)) {
+			response.Forbidden(c, "Token 未授予访问系统接口的权限")
+			c.Abort()
+			return
+		}
+
 		// 可选：验证请求头中的 clientId 是否与 Token 中的一致
 		headerClientId := c.GetHeader("clientid")
 		if headerClientId == "" {
@@ -49,9 +78,20 @@ func Auth(tokenManager service.TokenManager, cfg *config.Config, db *gorm.DB) gi
 			return
 		}
 
+		// 以 Token 中的租户为准
+		if !bindTenant(c, cfg.MultiTenant.Enabled, claims.TenantId) {
+			response.Unauthorized(c, "租户与Token不匹配")
+			c.Abort()
+			return
+		}
+		// 租户停用、到期或删除后已签发的 Token 立即失效
+		if cfg.MultiTenant.Enabled && !checkTenant(c, tenants, c.GetInt64("tenantId")) {
+			return
+		}
+
 		// 查询用户的组织ID
 		var user model.User
-		if err := db.Select("org_id").Where("id = ?", claims.UserId).First(&user).Error; err == nil {
+		if err := db.WithContext(c.Request.Context()).Select("org_id").Where("id = ?", claims.UserId).First(&user).Error; err == nil {
 			// 设置用户的组织ID到 context
 			c.Set("orgId", user.OrgId)
 		}
@@ -61,6 +101,61 @@ func Auth(tokenManager service.TokenManager, cfg *config.Config, db *gorm.DB) gi
 		c.Set("userName", claims.UserName)
 		c.Set("clientId", claims.ClientId)
 		c.Set("deviceType", claims.DeviceType)
+		c.Set("sessionId", claims.SessionId)
+		if claims.Actor != nil {
+			c.Set("actorId", claims.Actor.UserId)
+			c.Set("actorName", claims.Actor.UserName)
+		}
+		c.Next()
+	}
+}
+
+// authApiKey 使用 API Key 认证，授权范围写入 context 供 Permission 中间件与用户权限取交集
+func authApiKey(c *gin.Context, apiKeyService service.ApiKeyService, tenants service.TenantResolver, multiTenant bool, apiKey string) {
+	key, user, err := apiKeyService.Authenticate(c.Request.Context(), apiKey, utils.GetClientIP(c))
+	if err != nil {
+		response.Unauthorized(c, err.Error())
+		c.Abort()
+		return
+	}
+	if !bindTenant(c, multiTenant, user.TenantId) {
+		response.Unauthorized(c, "租户与API Key不匹配")
+		c.Abort()
+		return
+	}
+	if multiTenant && !checkTenant(c, tenants, c.GetInt64("tenantId")) {
+		return
+	}
+
+	c.Set("orgId", user.OrgId)
+	c.Set("userId", user.ID)
+	c.Set("userName", user.UserName)
+	c.Set("deviceType", "api")
+	c.Set("apiKeyId", key.ID)
+	c.Set("apiKeyScopes", strings.Split(key.Scopes, ","))
+	c.Next()
+}
+
+// DenyImpersonation 拒绝代登录会话访问（用于修改密码、两步验证、凭证管理及再次代登录等接口），须在 Auth 之后使用
+func DenyImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get("actorId"); ok {
+			response.Forbidden(c, "代登录会话不允许执行该操作")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// DenyApiKey 拒绝 API Key 访问（用于凭证管理等仅限用户本人交互操作的接口），须在 Auth 之后使用
+func DenyApiKey() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get("apiKeyId"); ok {
+			response.Forbidden(c, "该接口不允许使用 API Key 访问")
+			c.Abort()
+			return
+		}
 		c.Next()
 	}
 }
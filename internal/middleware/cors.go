@@ -24,7 +24,7 @@ func CORS() gin.HandlerFunc {
 		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, PATCH, OPTIONS")
 
 		// 允许的请求头
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With, X-Client-Key, X-Client-Secret")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With, X-Client-Key, X-Client-Secret, Tenant-Id")
 
 		// 允许浏览器访问的响应头
 		c.Header("Access-Control-Expose-Headers", "Content-Length, Content-Type, Authorization")
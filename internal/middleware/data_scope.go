@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"github.com/force-c/nai-tizi/internal/domain/response"
+	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// DataScope 数据权限中间件
+// 按当前用户所有角色的数据范围（全部/自定义/本组织/本组织及以下/仅本人）解析可见范围，
+// 写入请求 context，Service 层通过 UserDataScope、OrgDataScope 等 GORM Scope 自动过滤
+//
+// 注意: 此中间件必须在 Auth 中间件之后使用，因为需要从 context 中获取 userId 和 orgId
+func DataScope(dataScopeService service.DataScopeService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIdVal, exists := c.Get("userId")
+		if !exists {
+			response.Forbidden(c, "用户信息不存在")
+			c.Abort()
+			return
+		}
+		userId, ok := userIdVal.(int64)
+		if !ok {
+			response.Forbidden(c, "用户ID格式错误")
+			c.Abort()
+			return
+		}
+
+		scope, err := dataScopeService.Resolve(c.Request.Context(), userId, c.GetString("userName"), c.GetInt64("orgId"))
+		if err != nil {
+			response.InternalServerError(c, "数据权限解析失败: "+err.Error())
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(service.WithDataScope(c.Request.Context(), scope))
+		c.Next()
+	}
+}
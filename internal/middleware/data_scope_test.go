@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// fakeDataScope 返回固定的数据权限范围
+type fakeDataScope struct {
+	scope *service.DataScope
+	err   error
+}
+
+func (f *fakeDataScope) Resolve(_ context.Context, userId int64, userName string, orgId int64) (*service.DataScope, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	scope := *f.scope
+	scope.UserId, scope.UserName, scope.OrgId = userId, userName, orgId
+	return &scope, nil
+}
+
+func runDataScope(t *testing.T, svc service.DataScopeService) (*service.DataScope, int) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, r := gin.CreateTestContext(w)
+	var got *service.DataScope
+	r.GET("/test", func(c *gin.Context) {
+		c.Set("userId", int64(7))
+		c.Set("userName", "alice")
+		c.Set("orgId", int64(3))
+		c.Next()
+	}, DataScope(svc), func(c *gin.Context) {
+		got = service.DataScopeFromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+	c.Request = httptest.NewRequest("GET", "/test", nil)
+	r.HandleContext(c)
+	return got, w.Code
+}
+
+func TestDataScope_WritesScopeToRequestContext(t *testing.T) {
+	got, code := runDataScope(t, &fakeDataScope{scope: &service.DataScope{OrgIds: []int64{3, 4}}})
+	assert.Equal(t, http.StatusOK, code)
+	require.NotNil(t, got)
+	assert.Equal(t, int64(7), got.UserId)
+	assert.Equal(t, "alice", got.UserName)
+	assert.Equal(t, int64(3), got.OrgId)
+	assert.True(t, got.AllowsOrg(4))
+	assert.False(t, got.AllowsOrg(5))
+}
+
+func TestDataScope_ResolveErrorAborts(t *testing.T) {
+	got, _ := runDataScope(t, &fakeDataScope{err: errors.New("db down")})
+	assert.Nil(t, got)
+}
+
+func TestUserDataScope_SQL(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	toSQL := func(scope *service.DataScope) string {
+		ctx := context.Background()
+		if scope != nil {
+			ctx = service.WithDataScope(ctx, scope)
+		}
+		return db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+			var users []model.User
+			return tx.Scopes(service.UserDataScope(ctx)).Find(&users)
+		})
+	}
+
+	tests := []struct {
+		name     string
+		scope    *service.DataScope
+		contains string
+		excludes string
+	}{
+		{"no scope in context", nil, "", "org_id IN"},
+		{"all data", &service.DataScope{All: true}, "", "org_id IN"},
+		{"org and self", &service.DataScope{UserId: 7, OrgIds: []int64{3, 4}, Self: true}, "(org_id IN (3,4) OR id = 7)", ""},
+		{"org only", &service.DataScope{UserId: 7, OrgIds: []int64{3}}, "org_id IN (3)", "id = 7"},
+		{"self only", &service.DataScope{UserId: 7, Self: true}, "id = 7", "org_id IN"},
+		{"custom without orgs", &service.DataScope{UserId: 7}, "1 = 0", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql := toSQL(tt.scope)
+			assert.Contains(t, sql, tt.contains)
+			if tt.excludes != "" {
+				assert.NotContains(t, sql, tt.excludes)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/infrastructure/tenant"
 	logging "github.com/force-c/nai-tizi/internal/logger"
 	"github.com/force-c/nai-tizi/internal/utils"
 	"github.com/force-c/nai-tizi/internal/utils/idgen"
@@ -82,8 +83,8 @@ func (w *OperLogWriter) batchWrite() {
 			return
 		}
 
-		// 批量插入
-		if err := w.db.Create(&buffer).Error; err != nil {
+		// 批量插入（同一批次可能包含多个租户的日志，租户ID已在记录时填充）
+		if err := w.db.WithContext(tenant.WithoutTenant(context.Background())).Create(&buffer).Error; err != nil {
 			w.logger.Error("批量写入操作日志失败",
 				zap.Error(err),
 				zap.Int("count", len(buffer)))
@@ -164,6 +165,12 @@ func OperationLog(db *gorm.DB, logger logging.Logger) gin.HandlerFunc {
 		userName := getStringValue(c, "userName")
 		operName := formatOperName(userId, userName)
 
+		// 代登录会话：同时记录实际操作的管理员
+		actorName := ""
+		if actorId := getStringValue(c, "actorId"); actorId != "" {
+			actorName = formatOperName(actorId, getStringValue(c, "actorName"))
+		}
+
 		// 获取终端类型（从 token 中解析）
 		deviceType := getStringValue(c, "deviceType")
 		if deviceType == "" {
@@ -182,6 +189,9 @@ func OperationLog(db *gorm.DB, logger logging.Logger) gin.HandlerFunc {
 			RequestMethod: c.Request.Method,
 			DeviceType:    deviceType,
 			OperName:      operName,
+			OperUserId:    c.GetInt64("userId"),
+			TenantId:      tenant.FromContext(c.Request.Context()),
+			ActorName:     actorName,
 			OperUrl:       c.Request.URL.Path,
 			OperIp:        utils.GetClientIP(c),
 			OperParam:     truncate(operParam, 2000),
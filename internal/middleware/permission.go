@@ -1,6 +1,9 @@
 package middleware
 
 import (
+	"sort"
+	"sync"
+
 	"github.com/force-c/nai-tizi/internal/domain/response"
 	"github.com/force-c/nai-tizi/internal/service"
 	"github.com/gin-gonic/gin"
@@ -17,6 +20,7 @@ import (
 //
 // 注意: 此中间件必须在 Auth 中间件之后使用，因为需要从 context 中获取 userId
 func Permission(casbinService service.CasbinServiceV2, resource string) gin.HandlerFunc {
+	registerResources(resource)
 	return func(c *gin.Context) {
 		// 从 context 获取用户信息（由 Auth 中间件设置）
 		userIdVal, exists := c.Get("userId")
@@ -32,6 +36,13 @@ func Permission(casbinService service.CasbinServiceV2, resource string) gin.Hand
 			return
 		}
 
+		// API Key 访问时，权限为 Key 的授权范围与所属用户权限的交集
+		if !apiKeyAllows(c, resource) {
+			response.Forbidden(c, "API Key 未授予该权限")
+			c.Abort()
+			return
+		}
+
 		// 从资源字符串中解析 action
 		// 格式: "resource.action"，例如 "org.read", "org.create"
 		// *.read = read 操作, 其他 = write 操作
@@ -61,6 +72,7 @@ func Permission(casbinService service.CasbinServiceV2, resource string) gin.Hand
 // PermissionAny 任意权限检查中间件（满足其中一个权限即可）
 // 使用方式: PermissionAny(casbinService, []string{"user.read", "user.create"})
 func PermissionAny(casbinService service.CasbinServiceV2, resources []string) gin.HandlerFunc {
+	registerResources(resources...)
 	return func(c *gin.Context) {
 		userIdVal, exists := c.Get("userId")
 		if !exists {
@@ -77,6 +89,9 @@ func PermissionAny(casbinService service.CasbinServiceV2, resources []string) gi
 
 		// 检查是否满足任意一个权限
 		for _, resource := range resources {
+			if !apiKeyAllows(c, resource) {
+				continue
+			}
 			// 从资源字符串中解析 action
 			action := "write"
 			if len(resource) > 5 && resource[len(resource)-5:] == ".read" {
@@ -101,6 +116,7 @@ func PermissionAny(casbinService service.CasbinServiceV2, resources []string) gi
 // PermissionAll 所有权限检查中间件（必须满足所有权限）
 // 使用方式: PermissionAll(casbinService, []string{"user.read", "user.update"})
 func PermissionAll(casbinService service.CasbinServiceV2, resources []string) gin.HandlerFunc {
+	registerResources(resources...)
 	return func(c *gin.Context) {
 		userIdVal, exists := c.Get("userId")
 		if !exists {
@@ -117,6 +133,11 @@ func PermissionAll(casbinService service.CasbinServiceV2, resources []string) gi
 
 		// 检查是否满足所有权限
 		for _, resource := range resources {
+			if !apiKeyAllows(c, resource) {
+				response.Forbidden(c, "API Key 未授予该权限")
+				c.Abort()
+				return
+			}
 			// 从资源字符串中解析 action
 			action := "write"
 			if len(resource) > 5 && resource[len(resource)-5:] == ".read" {
@@ -134,3 +155,40 @@ func PermissionAll(casbinService service.CasbinServiceV2, resources []string) gi
 		c.Next()
 	}
 }
+
+// 路由注册时使用的权限资源（启动时检查是否都有菜单按钮声明）
+var (
+	permissionResourcesMu sync.Mutex
+	permissionResources   = make(map[string]struct{})
+)
+
+// registerResources 记录路由使用的权限资源
+func registerResources(list ...string) {
+	permissionResourcesMu.Lock()
+	defer permissionResourcesMu.Unlock()
+	for _, resource := range list {
+		permissionResources[resource] = struct{}{}
+	}
+}
+
+// RegisteredResources 返回已注册路由通过 Permission 系列中间件使用的权限资源（已排序）
+func RegisteredResources() []string {
+	permissionResourcesMu.Lock()
+	defer permissionResourcesMu.Unlock()
+	list := make([]string, 0, len(permissionResources))
+	for resource := range permissionResources {
+		list = append(list, resource)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// apiKeyAllows 请求使用 API Key 认证时判断其授权范围是否包含资源，非 API Key 请求始终返回 true
+func apiKeyAllows(c *gin.Context, resource string) bool {
+	scopes, ok := c.Get("apiKeyScopes")
+	if !ok {
+		return true
+	}
+	list, _ := scopes.([]string)
+	return service.ApiKeyScopesAllow(list, resource)
+}
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeCasbin 按资源白名单判断用户权限
+type fakeCasbin struct {
+	service.CasbinServiceV2
+	allowed map[string]bool
+}
+
+func (f *fakeCasbin) CheckPermission(_ context.Context, _ int64, resource, _ string) (bool, error) {
+	return f.allowed[resource], nil
+}
+
+func runPermission(handler gin.HandlerFunc, scopes []string) bool {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, r := gin.CreateTestContext(w)
+	passed := false
+	r.GET("/test", func(c *gin.Context) {
+		c.Set("userId", int64(1))
+		if scopes != nil {
+			c.Set("apiKeyId", int64(100))
+			c.Set("apiKeyScopes", scopes)
+		}
+		c.Next()
+	}, handler, func(c *gin.Context) { passed = true })
+	c.Request = httptest.NewRequest("GET", "/test", nil)
+	r.HandleContext(c)
+	return passed
+}
+
+func TestPermission_ApiKeyScopesIntersectUserPermissions(t *testing.T) {
+	casbin := &fakeCasbin{allowed: map[string]bool{"user.read": true, "user.create": true}}
+
+	tests := []struct {
+		name     string
+		resource string
+		scopes   []string
+		expected bool
+	}{
+		{"user token uses user permissions", "user.create", nil, true},
+		{"scope and permission both granted", "user.read", []string{"user.read"}, true},
+		{"wildcard scope", "user.create", []string{"user.*"}, true},
+		{"action wildcard scope", "user.read", []string{"*.read"}, true},
+		{"permission granted but out of scope", "user.create", []string{"user.read"}, false},
+		{"in scope but user lacks permission", "role.read", []string{"*.read"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, runPermission(Permission(casbin, tt.resource), tt.scopes))
+		})
+	}
+}
+
+func TestPermissionAnyAll_ApiKeyScopes(t *testing.T) {
+	casbin := &fakeCasbin{allowed: map[string]bool{"user.read": true, "user.create": true}}
+
+	assert.True(t, runPermission(PermissionAny(casbin, []string{"user.create", "user.read"}), []string{"user.read"}))
+	assert.False(t, runPermission(PermissionAny(casbin, []string{"user.create"}), []string{"user.read"}))
+	assert.False(t, runPermission(PermissionAll(casbin, []string{"user.create", "user.read"}), []string{"user.read"}))
+}
+
+func TestRegisteredResources(t *testing.T) {
+	casbin := &fakeCasbin{}
+	Permission(casbin, "zz_test.update")
+	PermissionAny(casbin, []string{"zz_test.read", "zz_test.update"})
+	PermissionAll(casbin, []string{"zz_test.create"})
+
+	var registered []string
+	for _, resource := range RegisteredResources() {
+		if strings.HasPrefix(resource, "zz_test.") {
+			registered = append(registered, resource)
+		}
+	}
+	assert.Equal(t, []string{"zz_test.create", "zz_test.read", "zz_test.update"}, registered)
+}
+
+func TestDenyApiKey(t *testing.T) {
+	assert.True(t, runPermission(DenyApiKey(), nil))
+	assert.False(t, runPermission(DenyApiKey(), []string{"*"}))
+}
+
+func TestDenyImpersonation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	run := func(impersonated bool) bool {
+		w := httptest.NewRecorder()
+		c, r := gin.CreateTestContext(w)
+		passed := false
+		r.POST("/test", func(c *gin.Context) {
+			c.Set("userId", int64(2))
+			if impersonated {
+				c.Set("actorId", int64(1))
+			}
+			c.Next()
+		}, DenyImpersonation(), func(c *gin.Context) { passed = true })
+		c.Request = httptest.NewRequest("POST", "/test", nil)
+		r.HandleContext(c)
+		return passed
+	}
+
+	assert.True(t, run(false), "user session should pass")
+	assert.False(t, run(true), "impersonated session should be denied")
+}
@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/force-c/nai-tizi/internal/config"
+	"github.com/force-c/nai-tizi/internal/domain/response"
+	"github.com/force-c/nai-tizi/internal/infrastructure/tenant"
+	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// tenantExplicitKey 请求是否显式指定了租户（请求头或子域名）
+const tenantExplicitKey = "tenantExplicit"
+
+var errTenantInvalid = errors.New("租户ID无效")
+
+// tenantResolver 按请求头、子域名识别租户
+type tenantResolver struct {
+	header     string
+	baseDomain string
+	tenants    service.TenantResolver
+}
+
+// Tenant 租户识别中间件
+// 单一企业模式下固定为默认租户；多租户模式下依次从请求头（租户ID）、子域名（租户编码）识别租户，都未指定时为默认租户。
+// 显式指定的租户已停用、到期时拒绝访问（登录接口同样被拦截）。
+// 识别结果写入请求 context，GORM 多租户插件据此自动过滤数据；登录后以 Token 中的租户为准（见 Auth）
+func Tenant(cfg *config.Config, tenants service.TenantResolver) gin.HandlerFunc {
+	if !cfg.MultiTenant.Enabled {
+		return func(c *gin.Context) {
+			setTenant(c, tenant.DefaultTenantId)
+			c.Next()
+		}
+	}
+
+	r := &tenantResolver{
+		header:     cfg.MultiTenant.Header,
+		baseDomain: strings.ToLower(strings.TrimPrefix(cfg.MultiTenant.BaseDomain, ".")),
+		tenants:    tenants,
+	}
+	return func(c *gin.Context) {
+		tenantId, explicit, err := r.resolve(c)
+		if err != nil {
+			response.BadRequest(c, err.Error())
+			c.Abort()
+			return
+		}
+		if explicit {
+			if !checkTenant(c, tenants, tenantId) {
+				return
+			}
+			c.Set(tenantExplicitKey, true)
+		}
+		setTenant(c, tenantId)
+		c.Next()
+	}
+}
+
+// resolve 识别租户，explicit 表示请求显式指定了租户
+func (r *tenantResolver) resolve(c *gin.Context) (int64, bool, error) {
+	if value := strings.TrimSpace(c.GetHeader(r.header)); value != "" {
+		tenantId, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || tenantId <= 0 {
+			return 0, false, errTenantInvalid
+		}
+		return tenantId, true, nil
+	}
+
+	if code := r.subdomain(c.Request.Host); code != "" {
+		tenantId, err := r.tenants.ResolveCode(c.Request.Context(), code)
+		if err != nil {
+			return 0, false, err
+		}
+		return tenantId, true, nil
+	}
+	return tenant.DefaultTenantId, false, nil
+}
+
+// subdomain 提取主域名下一级子域名作为租户编码，如 acme.example.com -> acme
+func (r *tenantResolver) subdomain(host string) string {
+	if r.baseDomain == "" {
+		return ""
+	}
+	host = strings.ToLower(host)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	code, ok := strings.CutSuffix(host, "."+r.baseDomain)
+	if !ok || code == "" || strings.Contains(code, ".") || code == "www" {
+		return ""
+	}
+	return code
+}
+
+// setTenant 将租户ID写入 gin context 和请求 context
+func setTenant(c *gin.Context, tenantId int64) {
+	c.Set("tenantId", tenantId)
+	c.Request = c.Request.WithContext(tenant.WithTenantId(c.Request.Context(), tenantId))
+}
+
+// bindTenant 认证后以凭证所属租户为准，请求显式指定的租户与之不一致时返回 false
+func bindTenant(c *gin.Context, multiTenant bool, tenantId int64) bool {
+	if tenantId <= 0 {
+		tenantId = tenant.DefaultTenantId
+	}
+	if multiTenant && c.GetBool(tenantExplicitKey) && c.GetInt64("tenantId") != tenantId {
+		return false
+	}
+	setTenant(c, tenantId)
+	return true
+}
+
+// checkTenant 检查租户是否可用，停用、到期或不存在时返回 403 并中止请求
+func checkTenant(c *gin.Context, tenants service.TenantResolver, tenantId int64) bool {
+	if err := tenants.CheckActive(c.Request.Context(), tenantId); err != nil {
+		response.Forbidden(c, err.Error())
+		c.Abort()
+		return false
+	}
+	return true
+}
+
+// PlatformTenant 仅允许平台（默认租户）用户访问，用于租户管理等平台运营接口，须在 Auth 之后使用
+func PlatformTenant() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if tenant.FromContext(c.Request.Context()) != tenant.DefaultTenantId {
+			response.Forbidden(c, "仅平台管理员可访问")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
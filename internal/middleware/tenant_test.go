@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/force-c/nai-tizi/internal/config"
+	"github.com/force-c/nai-tizi/internal/domain/response"
+	"github.com/force-c/nai-tizi/internal/infrastructure/tenant"
+	"github.com/force-c/nai-tizi/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTenants 租户状态：编码 -> ID，停用的租户ID
+type fakeTenants struct {
+	codes     map[string]int64
+	suspended map[int64]bool
+}
+
+func (f *fakeTenants) ResolveCode(_ context.Context, code string) (int64, error) {
+	if id, ok := f.codes[code]; ok {
+		return id, nil
+	}
+	return 0, service.ErrTenantNotFound
+}
+
+func (f *fakeTenants) CheckActive(_ context.Context, tenantId int64) error {
+	if f.suspended[tenantId] {
+		return service.ErrTenantSuspended
+	}
+	return nil
+}
+
+var testTenants = &fakeTenants{
+	codes:     map[string]int64{"acme": 2, "frozen": 4},
+	suspended: map[int64]bool{4: true},
+}
+
+// resultCode 中间件拒绝时返回响应体中的业务码，否则返回 HTTP 状态码
+func resultCode(w *httptest.ResponseRecorder) int {
+	var resp response.Response
+	if w.Body.Len() > 0 && json.Unmarshal(w.Body.Bytes(), &resp) == nil {
+		return resp.Code
+	}
+	return w.Code
+}
+
+// runTenant 执行租户中间件，claimTenant 大于等于 0 时模拟认证后绑定凭证租户
+func runTenant(t *testing.T, cfg *config.Config, header string, claimTenant int64) (int64, int) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	var got int64
+	r.Use(Tenant(cfg, testTenants))
+	r.GET("/test", func(c *gin.Context) {
+		if claimTenant >= 0 && !bindTenant(c, cfg.MultiTenant.Enabled, claimTenant) {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		got = tenant.FromContext(c.Request.Context())
+		assert.Equal(t, got, c.GetInt64("tenantId"))
+		c.Status(http.StatusOK)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	if header != "" {
+		req.Header.Set("Tenant-Id", header)
+	}
+	r.ServeHTTP(w, req)
+	return got, resultCode(w)
+}
+
+func multiTenantConfig() *config.Config {
+	return &config.Config{MultiTenant: config.MultiTenant{Enabled: true, Header: "Tenant-Id", BaseDomain: "example.com"}}
+}
+
+func TestTenant_SingleTenantIgnoresHeader(t *testing.T) {
+	got, code := runTenant(t, &config.Config{}, "2", -1)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, tenant.DefaultTenantId, got)
+}
+
+func TestTenant_Header(t *testing.T) {
+	got, code := runTenant(t, multiTenantConfig(), "2", -1)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(2), got)
+
+	got, code = runTenant(t, multiTenantConfig(), "", -1)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, tenant.DefaultTenantId, got)
+}
+
+func TestTenant_InvalidHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	called := false
+	r.Use(Tenant(multiTenantConfig(), testTenants))
+	r.GET("/test", func(c *gin.Context) { called = true })
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Tenant-Id", "abc")
+	r.ServeHTTP(w, req)
+	assert.False(t, called)
+}
+
+func TestTenant_ClaimOverridesDefault(t *testing.T) {
+	// 未指定租户时以凭证租户为准
+	got, code := runTenant(t, multiTenantConfig(), "", 3)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(3), got)
+
+	// 指定的租户与凭证一致
+	got, code = runTenant(t, multiTenantConfig(), "3", 3)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(3), got)
+}
+
+func TestTenant_ClaimMismatchRejected(t *testing.T) {
+	_, code := runTenant(t, multiTenantConfig(), "2", 3)
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
+
+func TestTenant_SuspendedRejected(t *testing.T) {
+	_, code := runTenant(t, multiTenantConfig(), "4", -1)
+	assert.Equal(t, response.CodeForbidden, code)
+}
+
+func TestTenant_SubdomainLookup(t *testing.T) {
+	serve := func(host string) (int64, int) {
+		gin.SetMode(gin.TestMode)
+		w := httptest.NewRecorder()
+		_, r := gin.CreateTestContext(w)
+		var got int64
+		r.Use(Tenant(multiTenantConfig(), testTenants))
+		r.GET("/test", func(c *gin.Context) {
+			got = tenant.FromContext(c.Request.Context())
+			assert.True(t, c.GetBool(tenantExplicitKey))
+		})
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Host = host
+		r.ServeHTTP(w, req)
+		return got, resultCode(w)
+	}
+
+	got, code := serve("acme.example.com")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, int64(2), got)
+
+	_, code = serve("frozen.example.com")
+	assert.Equal(t, response.CodeForbidden, code)
+
+	_, code = serve("unknown.example.com")
+	assert.Equal(t, response.CodeBadRequest, code)
+}
+
+func TestPlatformTenant(t *testing.T) {
+	serve := func(header string) int {
+		gin.SetMode(gin.TestMode)
+		w := httptest.NewRecorder()
+		_, r := gin.CreateTestContext(w)
+		r.Use(Tenant(multiTenantConfig(), testTenants), PlatformTenant())
+		r.GET("/test", func(c *gin.Context) { c.Status(http.StatusOK) })
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		if header != "" {
+			req.Header.Set("Tenant-Id", header)
+		}
+		r.ServeHTTP(w, req)
+		return resultCode(w)
+	}
+	assert.Equal(t, http.StatusOK, serve(""))
+	assert.Equal(t, response.CodeForbidden, serve("2"))
+}
+
+func TestTenantResolver_Subdomain(t *testing.T) {
+	r := &tenantResolver{baseDomain: "example.com"}
+	assert.Equal(t, "acme", r.subdomain("acme.example.com"))
+	assert.Equal(t, "acme", r.subdomain("ACME.example.com:8080"))
+	assert.Equal(t, "", r.subdomain("example.com"))
+	assert.Equal(t, "", r.subdomain("www.example.com"))
+	assert.Equal(t, "", r.subdomain("a.b.example.com"))
+	assert.Equal(t, "", r.subdomain("acme.other.com"))
+	assert.Equal(t, "", (&tenantResolver{}).subdomain("acme.example.com"))
+}
@@ -0,0 +1,31 @@
+package router
+
+import (
+	"github.com/force-c/nai-tizi/internal/constants"
+	"github.com/force-c/nai-tizi/internal/controller"
+	"github.com/force-c/nai-tizi/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// registerApiKeyRoutes 注册 API Key 路由
+func registerApiKeyRoutes(r *gin.Engine, ctx *RouterContext) {
+	apiKeyController := controller.NewApiKeyController(ctx.Container)
+
+	// 当前用户的 API Key 管理（登录即可访问，不允许使用 API Key 自身访问）
+	myKeys := r.Group("/api/v1/auth/api-keys")
+	myKeys.Use(ctx.AuthMiddleware, middleware.DenyApiKey(), middleware.DenyImpersonation())
+	{
+		myKeys.GET("", apiKeyController.ListMyKeys)         // 查询我的 API Key
+		myKeys.POST("", apiKeyController.CreateMyKey)       // 创建 API Key
+		myKeys.DELETE("/:id", apiKeyController.DeleteMyKey) // 删除 API Key
+	}
+
+	// 管理员管理用户（服务账号）的 API Key（需要 api_key.* 权限）
+	users := r.Group("/api/v1/user")
+	users.Use(ctx.AuthMiddleware, middleware.DenyApiKey())
+	{
+		users.GET("/:id/api-keys", middleware.Permission(ctx.CasbinService, constants.ResourceApiKeyRead), apiKeyController.ListUserKeys)
+		users.POST("/:id/api-keys", middleware.Permission(ctx.CasbinService, constants.ResourceApiKeyCreate), apiKeyController.CreateUserKey)
+		users.DELETE("/:id/api-keys/:keyId", middleware.Permission(ctx.CasbinService, constants.ResourceApiKeyDelete), apiKeyController.DeleteUserKey)
+	}
+}
@@ -14,7 +14,7 @@ func registerAttachmentRoutes(r *gin.Engine, ctx *RouterContext) {
 
 	// 附件管理路由组（需要认证和权限）
 	attachments := r.Group("/api/v1/attachment")
-	attachments.Use(ctx.AuthMiddleware)
+	attachments.Use(ctx.AuthMiddleware, ctx.DataScopeMiddleware)
 	{
 		// 分两步上传（符合参数传递规范）
 		// 步骤1：上传文件 - 需要 attachment.upload 权限
@@ -14,6 +14,7 @@ func registerAuthRoutes(r *gin.Engine, ctx *RouterContext) {
 	r.POST("/login", authController.Login)               // 统一登录接口
 	r.POST("/logout", authController.Logout)             // 登出
 	r.POST("/auth/refresh", authController.RefreshToken) // 刷新Token
+	r.POST("/auth/sms/code", authController.SendSmsCode) // 发送短信登录验证码
 
 	// 需要认证的路由
 	r.GET("/me", ctx.AuthMiddleware, authController.Me)
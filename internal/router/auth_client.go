@@ -0,0 +1,31 @@
+package router
+
+import (
+	"github.com/force-c/nai-tizi/internal/constants"
+	"github.com/force-c/nai-tizi/internal/controller"
+	"github.com/force-c/nai-tizi/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// registerClientRoutes 注册客户端应用管理路由
+func registerClientRoutes(r *gin.Engine, ctx *RouterContext) {
+	clientController := controller.NewClientController(ctx.Container)
+
+	// 客户端管理路由组（需要认证和权限）
+	clients := r.Group("/api/v1/client")
+	clients.Use(ctx.AuthMiddleware)
+	{
+		clients.POST("/page", middleware.Permission(ctx.CasbinService, constants.ResourceClientRead), clientController.PageClient)
+		clients.GET("/grant-types", middleware.Permission(ctx.CasbinService, constants.ResourceClientRead), clientController.GrantTypes)
+		clients.POST("", middleware.Permission(ctx.CasbinService, constants.ResourceClientCreate), clientController.CreateClient)
+
+		// 密钥轮换不允许 API Key 和代登录会话操作
+		clients.POST("/:clientId/secret", middleware.DenyApiKey(), middleware.DenyImpersonation(),
+			middleware.Permission(ctx.CasbinService, constants.ResourceClientRotateSecret), clientController.RotateSecret)
+
+		// 带参数的路由放在最后
+		clients.GET("/:clientId", middleware.Permission(ctx.CasbinService, constants.ResourceClientRead), clientController.GetClient)
+		clients.PUT("/:clientId", middleware.Permission(ctx.CasbinService, constants.ResourceClientUpdate), clientController.UpdateClient)
+		clients.DELETE("/:clientId", middleware.Permission(ctx.CasbinService, constants.ResourceClientDelete), clientController.DeleteClient)
+	}
+}
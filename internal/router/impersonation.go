@@ -0,0 +1,31 @@
+package router
+
+import (
+	"github.com/force-c/nai-tizi/internal/constants"
+	"github.com/force-c/nai-tizi/internal/controller"
+	"github.com/force-c/nai-tizi/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// registerImpersonationRoutes 注册代登录路由
+func registerImpersonationRoutes(r *gin.Engine, ctx *RouterContext) {
+	impersonationController := controller.NewImpersonationController(ctx.Container)
+
+	// 当前用户被代登录的记录（登录即可访问）
+	mine := r.Group("/api/v1/auth/impersonations")
+	mine.Use(ctx.AuthMiddleware)
+	{
+		mine.GET("", impersonationController.ListMyImpersonations)
+	}
+
+	users := r.Group("/api/v1/user")
+	users.Use(ctx.AuthMiddleware, ctx.DataScopeMiddleware) // 只能代登录或查询数据权限范围内的用户
+	{
+		// 代登录 - 需要 user.impersonate 权限，不允许 API Key 和代登录会话发起
+		users.POST("/:id/impersonate", middleware.DenyApiKey(), middleware.DenyImpersonation(),
+			middleware.Permission(ctx.CasbinService, constants.ResourceUserImpersonate), impersonationController.Impersonate)
+
+		// 查询用户被代登录的记录 - 需要 user.read 权限
+		users.GET("/:id/impersonations", middleware.Permission(ctx.CasbinService, constants.ResourceUserRead), impersonationController.ListUserImpersonations)
+	}
+}
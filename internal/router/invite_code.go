@@ -0,0 +1,25 @@
+package router
+
+import (
+	"github.com/force-c/nai-tizi/internal/constants"
+	"github.com/force-c/nai-tizi/internal/controller"
+	"github.com/force-c/nai-tizi/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// registerInviteCodeRoutes 注册邀请码管理路由
+func registerInviteCodeRoutes(r *gin.Engine, ctx *RouterContext) {
+	inviteCodeController := controller.NewInviteCodeController(ctx.Container)
+
+	// 邀请码管理路由组（需要认证和权限）
+	inviteCodes := r.Group("/api/v1/invite-code")
+	inviteCodes.Use(ctx.AuthMiddleware)
+	{
+		inviteCodes.POST("/page", middleware.Permission(ctx.CasbinService, constants.ResourceInviteCodeRead), inviteCodeController.Page)
+		inviteCodes.POST("", middleware.Permission(ctx.CasbinService, constants.ResourceInviteCodeCreate), inviteCodeController.Create)
+
+		// 带参数的路由放在最后
+		inviteCodes.PUT("/:id", middleware.Permission(ctx.CasbinService, constants.ResourceInviteCodeUpdate), inviteCodeController.Update)
+		inviteCodes.DELETE("/:id", middleware.Permission(ctx.CasbinService, constants.ResourceInviteCodeDelete), inviteCodeController.Delete)
+	}
+}
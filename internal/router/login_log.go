@@ -15,7 +15,7 @@ func registerLoginLogRoutes(r *gin.Engine, ctx *RouterContext) {
 	v1 := r.Group("/api/v1")
 	{
 		loginLog := v1.Group("/loginLog")
-		loginLog.Use(ctx.AuthMiddleware) // 添加认证中间件
+		loginLog.Use(ctx.AuthMiddleware, ctx.DataScopeMiddleware) // 添加认证和数据权限中间件
 		{
 			// 创建登录日志 - 需要 login_log.create 权限
 			loginLog.POST("", middleware.Permission(ctx.CasbinService, constants.ResourceLoginLogCreate), loginLogController.CreateLoginLog)
@@ -29,6 +29,10 @@ func registerLoginLogRoutes(r *gin.Engine, ctx *RouterContext) {
 			// 清理登录日志 - 需要 login_log.delete 权限
 			loginLog.DELETE("/clean", middleware.Permission(ctx.CasbinService, constants.ResourceLoginLogDelete), loginLogController.CleanLoginLog)
 
+			// 查询登录锁定和解锁 - 需要 login_log.unlock 权限
+			loginLog.GET("/locks", middleware.Permission(ctx.CasbinService, constants.ResourceLoginLogUnlock), loginLogController.ListLoginLocks)
+			loginLog.POST("/locks/unlock", middleware.Permission(ctx.CasbinService, constants.ResourceLoginLogUnlock), loginLogController.UnlockLogin)
+
 			// 更新、查询和删除登录日志 - 需要 login_log.update/read/delete 权限（带参数的路由放在最后）
 			loginLog.PUT("/:id", middleware.Permission(ctx.CasbinService, constants.ResourceLoginLogUpdate), loginLogController.UpdateLoginLog)
 			loginLog.GET("/:id", middleware.Permission(ctx.CasbinService, constants.ResourceLoginLogRead), loginLogController.GetLoginLogById)
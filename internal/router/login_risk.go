@@ -0,0 +1,13 @@
+package router
+
+import (
+	"github.com/force-c/nai-tizi/internal/controller"
+	"github.com/gin-gonic/gin"
+)
+
+// registerLoginRiskRoutes 注册异常登录处理路由（公开，凭提醒链接中的一次性令牌访问）
+func registerLoginRiskRoutes(r *gin.Engine, ctx *RouterContext) {
+	loginRiskController := controller.NewLoginRiskController(ctx.Container)
+
+	r.POST("/auth/login-risk/revoke", loginRiskController.Revoke) // "不是我本人"：注销异常登录的会话
+}
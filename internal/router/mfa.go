@@ -0,0 +1,34 @@
+package router
+
+import (
+	"github.com/force-c/nai-tizi/internal/constants"
+	"github.com/force-c/nai-tizi/internal/controller"
+	"github.com/force-c/nai-tizi/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// registerMfaRoutes 注册两步验证路由
+func registerMfaRoutes(r *gin.Engine, ctx *RouterContext) {
+	mfaController := controller.NewMfaController(ctx.Container)
+
+	// 公开路由：登录过程中使用票据绑定（票据本身即凭证）
+	r.POST("/auth/mfa/enroll", mfaController.TicketEnroll)
+
+	// 当前用户的两步验证管理（登录即可访问）
+	mfa := r.Group("/api/v1/auth/mfa")
+	mfa.Use(ctx.AuthMiddleware, middleware.DenyApiKey(), middleware.DenyImpersonation())
+	{
+		mfa.GET("", mfaController.GetStatus)                               // 查询状态
+		mfa.POST("/totp/enroll", mfaController.Enroll)                     // 开始绑定
+		mfa.POST("/totp/confirm", mfaController.ConfirmEnroll)             // 确认绑定
+		mfa.POST("/totp/disable", mfaController.Disable)                   // 关闭
+		mfa.POST("/recovery-codes", mfaController.RegenerateRecoveryCodes) // 重新生成恢复码
+	}
+
+	// 管理员重置用户两步验证 - 需要 user.mfa 权限
+	users := r.Group("/api/v1/user")
+	users.Use(ctx.AuthMiddleware)
+	{
+		users.DELETE("/:id/mfa", middleware.Permission(ctx.CasbinService, constants.ResourceUserMfa), mfaController.ResetUserMfa)
+	}
+}
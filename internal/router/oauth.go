@@ -0,0 +1,35 @@
+package router
+
+import (
+	"github.com/force-c/nai-tizi/internal/controller"
+	"github.com/force-c/nai-tizi/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// registerOAuthRoutes 注册 OAuth2 授权服务路由
+func registerOAuthRoutes(r *gin.Engine, ctx *RouterContext) {
+	oauthController := controller.NewOAuthController(ctx.Container)
+
+	// 标准协议端点（客户端凭证或访问令牌自行认证）
+	r.GET("/.well-known/oauth-authorization-server", oauthController.Metadata)
+	r.GET("/.well-known/jwks.json", oauthController.Jwks)
+	oauth := r.Group("/oauth")
+	{
+		oauth.GET("/authorize", oauthController.AuthorizeEndpoint) // 授权端点（跳转前端授权页）
+		oauth.POST("/token", oauthController.Token)                // 令牌端点
+		oauth.POST("/introspect", oauthController.Introspect)      // 令牌内省
+		oauth.POST("/revoke", oauthController.Revoke)              // 令牌撤销
+		oauth.GET("/userinfo", oauthController.UserInfo)           // 用户信息
+		oauth.POST("/userinfo", oauthController.UserInfo)
+	}
+
+	// 授权确认页与授权管理（登录即可访问）
+	consent := r.Group("/api/v1/oauth")
+	consent.Use(ctx.AuthMiddleware)
+	{
+		consent.GET("/authorize", oauthController.GetConsent)                                                          // 获取授权确认页信息
+		consent.POST("/authorize", middleware.DenyApiKey(), middleware.DenyImpersonation(), oauthController.Authorize) // 确认或拒绝授权（API Key 和代登录会话不能代用户授权第三方应用）
+		consent.GET("/consents", oauthController.ListConsents)                                                         // 查询我授权的应用
+		consent.DELETE("/consents/:clientId", oauthController.RevokeConsent)                                           // 撤销应用授权
+	}
+}
@@ -0,0 +1,26 @@
+package router
+
+import (
+	"github.com/force-c/nai-tizi/internal/controller"
+	"github.com/force-c/nai-tizi/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// registerOidcRoutes 注册第三方 OIDC 登录路由
+func registerOidcRoutes(r *gin.Engine, ctx *RouterContext) {
+	oidcController := controller.NewOidcController(ctx.Container)
+
+	// 公开路由：提供方列表和登录授权地址（登录本身走 /login，grantType=oidc）
+	r.GET("/auth/oidc/providers", oidcController.Providers)
+	r.POST("/auth/oidc/authorize", oidcController.Authorize)
+
+	// 当前用户的第三方账号绑定管理（登录即可访问）
+	oidc := r.Group("/api/v1/auth/oidc")
+	oidc.Use(ctx.AuthMiddleware, middleware.DenyApiKey(), middleware.DenyImpersonation())
+	{
+		oidc.GET("/identities", oidcController.ListIdentities)        // 查询绑定列表
+		oidc.POST("/link/authorize", oidcController.LinkAuthorize)    // 获取绑定授权地址
+		oidc.POST("/link", oidcController.Link)                       // 完成绑定
+		oidc.DELETE("/identities/:id", oidcController.UnlinkIdentity) // 解除绑定
+	}
+}
@@ -15,7 +15,7 @@ func registerOperLogRoutes(r *gin.Engine, ctx *RouterContext) {
 	v1 := r.Group("/api/v1")
 	{
 		operLog := v1.Group("/operLog")
-		operLog.Use(ctx.AuthMiddleware) // 添加认证中间件
+		operLog.Use(ctx.AuthMiddleware, ctx.DataScopeMiddleware) // 添加认证和数据权限中间件
 		{
 			// 创建操作日志 - 需要 oper_log.create 权限
 			operLog.POST("", middleware.Permission(ctx.CasbinService, constants.ResourceOperLogCreate), operLogController.CreateOperLog)
@@ -14,7 +14,7 @@ func registerOrgRoutes(r *gin.Engine, ctx *RouterContext) {
 
 	// 组织管理路由组（需要认证和权限）
 	orgs := r.Group("/api/v1/org")
-	orgs.Use(ctx.AuthMiddleware)
+	orgs.Use(ctx.AuthMiddleware, ctx.DataScopeMiddleware)
 	{
 		// 组织创建
 		orgs.POST("",
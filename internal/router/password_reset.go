@@ -0,0 +1,15 @@
+package router
+
+import (
+	"github.com/force-c/nai-tizi/internal/controller"
+	"github.com/gin-gonic/gin"
+)
+
+// registerPasswordResetRoutes 注册找回密码路由（公开）
+func registerPasswordResetRoutes(r *gin.Engine, ctx *RouterContext) {
+	passwordResetController := controller.NewPasswordResetController(ctx.Container)
+
+	r.POST("/auth/password/forgot", passwordResetController.Forgot) // 申请找回密码
+	r.POST("/auth/password/verify", passwordResetController.Verify) // 校验邮件链接或短信验证码
+	r.POST("/auth/password/reset", passwordResetController.Reset)   // 设置新密码
+}
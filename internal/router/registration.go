@@ -0,0 +1,15 @@
+package router
+
+import (
+	"github.com/force-c/nai-tizi/internal/controller"
+	"github.com/gin-gonic/gin"
+)
+
+// registerRegistrationRoutes 注册自助注册路由（公开，是否开放由租户注册设置决定）
+func registerRegistrationRoutes(r *gin.Engine, ctx *RouterContext) {
+	registrationController := controller.NewRegistrationController(ctx.Container)
+
+	r.GET("/auth/register/options", registrationController.Options)           // 注册页面选项
+	r.POST("/auth/register/email-code", registrationController.SendEmailCode) // 发送注册邮箱验证码
+	r.POST("/auth/register", registrationController.Register)                 // 自助注册
+}
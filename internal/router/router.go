@@ -1,6 +1,8 @@
 package router
 
 import (
+	"context"
+
 	"github.com/force-c/nai-tizi/internal/container"
 	"github.com/force-c/nai-tizi/internal/middleware"
 	"github.com/force-c/nai-tizi/internal/service"
@@ -8,14 +10,16 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.uber.org/zap"
 )
 
 // RouterContext 路由上下文，统一管理中间件
 type RouterContext struct {
-	Container      container.Container
-	TokenManager   service.TokenManager
-	CasbinService  service.CasbinServiceV2
-	AuthMiddleware gin.HandlerFunc
+	Container           container.Container
+	TokenManager        service.TokenManager
+	CasbinService       service.CasbinServiceV2
+	AuthMiddleware      gin.HandlerFunc
+	DataScopeMiddleware gin.HandlerFunc // 数据权限中间件（须在 AuthMiddleware 之后使用）
 }
 
 // Setup 配置所有路由
@@ -23,23 +27,30 @@ func Setup(r *gin.Engine, c container.Container) {
 	// 添加 Prometheus 指标收集中间件
 	r.Use(middleware.PrometheusMiddleware())
 
+	// 初始化统一的中间件（除了 auth 模块，其他模块都需要认证）
+	tokenManager := service.NewTokenManager(c.GetJWT(), c.GetRedis(), c.GetLogger())
+	casbinService := service.NewCasbinServiceV2(c.GetCasbin(), c.GetDB(), c.GetLogger(), c.GetConfig())
+	apiKeyService := service.NewApiKeyService(c.GetDB(), c.GetRedis(), c.GetLogger())
+	tenantService := service.NewTenantService(c.GetDB(), c.GetRedis(), casbinService, tokenManager, c.GetStorageManager(), c.GetConfig(), c.GetLogger())
+	authMiddleware := middleware.Auth(tokenManager, apiKeyService, tenantService, c.GetConfig(), c.GetDB())
+	dataScopeMiddleware := middleware.DataScope(service.NewDataScopeService(c.GetDB(), c.GetLogger()))
+
+	// 租户识别中间件（多租户模式下按请求头或子域名识别租户，拒绝已停用的租户）
+	r.Use(middleware.Tenant(c.GetConfig(), tenantService))
+
 	// Prometheus metrics 端点
 	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
 	// Swagger UI 文档
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	// 初始化统一的中间件（除了 auth 模块，其他模块都需要认证）
-	tokenManager := service.NewTokenManager(c.GetJWT(), c.GetRedis(), c.GetLogger())
-	casbinService := service.NewCasbinServiceV2(c.GetCasbin(), c.GetDB(), c.GetLogger(), c.GetConfig())
-	authMiddleware := middleware.Auth(tokenManager, c.GetConfig(), c.GetDB())
-
 	// 创建路由上下文
 	ctx := &RouterContext{
-		Container:      c,
-		TokenManager:   tokenManager,
-		CasbinService:  casbinService,
-		AuthMiddleware: authMiddleware,
+		Container:           c,
+		TokenManager:        tokenManager,
+		CasbinService:       casbinService,
+		AuthMiddleware:      authMiddleware,
+		DataScopeMiddleware: dataScopeMiddleware,
 	}
 
 	// 注册公共路由（无前缀，部分需要认证）
@@ -51,6 +62,36 @@ func Setup(r *gin.Engine, c container.Container) {
 	// 注册验证码路由（公开）
 	registerCaptchaRoutes(r, ctx)
 
+	// 注册找回密码路由（公开）
+	registerPasswordResetRoutes(r, ctx)
+
+	// 注册异常登录处理路由（公开）
+	registerLoginRiskRoutes(r, ctx)
+
+	// 注册自助注册路由（公开）
+	registerRegistrationRoutes(r, ctx)
+
+	// 注册在线会话路由
+	registerSessionRoutes(r, ctx)
+
+	// 注册两步验证路由
+	registerMfaRoutes(r, ctx)
+
+	// 注册通行密钥路由
+	registerWebAuthnRoutes(r, ctx)
+
+	// 注册 OAuth2 授权服务路由
+	registerOAuthRoutes(r, ctx)
+
+	// 注册第三方 OIDC 登录路由
+	registerOidcRoutes(r, ctx)
+
+	// 注册 API Key 路由
+	registerApiKeyRoutes(r, ctx)
+
+	// 注册代登录路由
+	registerImpersonationRoutes(r, ctx)
+
 	// 以下模块都需要认证和权限控制
 	// 注册用户管理路由
 	registerUserRoutes(r, ctx)
@@ -70,6 +111,15 @@ func Setup(r *gin.Engine, c container.Container) {
 	// 注册配置管理路由
 	registerConfigRoutes(r, ctx)
 
+	// 注册客户端应用管理路由
+	registerClientRoutes(r, ctx)
+
+	// 注册邀请码管理路由
+	registerInviteCodeRoutes(r, ctx)
+
+	// 注册租户管理路由（仅平台租户）
+	registerTenantRoutes(r, ctx)
+
 	// 注册登录日志路由
 	registerLoginLogRoutes(r, ctx)
 
@@ -81,4 +131,26 @@ func Setup(r *gin.Engine, c container.Container) {
 
 	// 注册存储环境管理路由
 	registerStorageEnvRoutes(r, ctx)
+
+	// 检查权限路由是否都有菜单按钮声明
+	checkPermissionCoverage(c)
+}
+
+// checkPermissionCoverage 启动时检查通过 Permission 中间件注册的权限资源是否都有菜单按钮声明
+// 未声明的权限无法通过给角色分配菜单授予，只记录告警，不影响启动
+func checkPermissionCoverage(c container.Container) {
+	resources := middleware.RegisteredResources()
+	uncovered, err := service.NewMenuService(c.GetDB()).UncoveredPermissions(context.Background(), resources)
+	if err != nil {
+		c.GetLogger().Warn("检查菜单权限覆盖失败", zap.Error(err))
+		return
+	}
+	if len(uncovered) > 0 {
+		c.GetLogger().Warn("以下路由权限没有菜单按钮声明，无法通过菜单分配授予",
+			zap.Strings("resources", uncovered),
+			zap.Int("uncovered", len(uncovered)),
+			zap.Int("total", len(resources)))
+		return
+	}
+	c.GetLogger().Info("路由权限均已由菜单按钮声明", zap.Int("total", len(resources)))
 }
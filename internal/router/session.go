@@ -0,0 +1,34 @@
+package router
+
+import (
+	"github.com/force-c/nai-tizi/internal/constants"
+	"github.com/force-c/nai-tizi/internal/controller"
+	"github.com/force-c/nai-tizi/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// registerSessionRoutes 注册在线会话路由
+func registerSessionRoutes(r *gin.Engine, ctx *RouterContext) {
+	sessionController := controller.NewSessionController(ctx.Container)
+
+	// 当前用户的会话管理（登录即可访问）
+	mySessions := r.Group("/api/v1/auth/sessions")
+	mySessions.Use(ctx.AuthMiddleware)
+	{
+		mySessions.GET("", sessionController.ListMySessions)                                                                           // 查询我的会话
+		mySessions.DELETE("/others", middleware.DenyApiKey(), middleware.DenyImpersonation(), sessionController.RevokeMyOtherSessions) // 注销其他会话
+		mySessions.DELETE("/:sessionId", middleware.DenyApiKey(), middleware.DenyImpersonation(), sessionController.RevokeMySession)   // 注销指定会话
+	}
+
+	// 管理员会话管理（需要 session.* 权限）
+	sessions := r.Group("/api/v1/session")
+	sessions.Use(ctx.AuthMiddleware, ctx.DataScopeMiddleware) // 只能管理数据权限范围内用户的会话
+	{
+		// 查询用户会话 - 需要 session.read 权限
+		sessions.GET("/user/:userId", middleware.Permission(ctx.CasbinService, constants.ResourceSessionRead), sessionController.ListUserSessions)
+
+		// 强制下线 - 需要 session.delete 权限
+		sessions.DELETE("/user/:userId", middleware.Permission(ctx.CasbinService, constants.ResourceSessionDelete), sessionController.RevokeUserSessions)
+		sessions.DELETE("/user/:userId/:sessionId", middleware.Permission(ctx.CasbinService, constants.ResourceSessionDelete), sessionController.RevokeUserSession)
+	}
+}
@@ -0,0 +1,29 @@
+package router
+
+import (
+	"github.com/force-c/nai-tizi/internal/constants"
+	"github.com/force-c/nai-tizi/internal/controller"
+	"github.com/force-c/nai-tizi/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// registerTenantRoutes 注册租户管理路由（仅平台租户可访问）
+func registerTenantRoutes(r *gin.Engine, ctx *RouterContext) {
+	tenantController := controller.NewTenantController(ctx.Container)
+
+	// 租户管理路由组（需要认证、平台租户和权限）
+	tenants := r.Group("/api/v1/tenant")
+	tenants.Use(ctx.AuthMiddleware, middleware.PlatformTenant())
+	{
+		tenants.POST("/page", middleware.Permission(ctx.CasbinService, constants.ResourceTenantRead), tenantController.Page)
+		tenants.POST("", middleware.Permission(ctx.CasbinService, constants.ResourceTenantCreate), tenantController.Create)
+
+		// 带参数的路由放在最后
+		tenants.GET("/:id", middleware.Permission(ctx.CasbinService, constants.ResourceTenantRead), tenantController.Get)
+		tenants.PUT("/:id", middleware.Permission(ctx.CasbinService, constants.ResourceTenantUpdate), tenantController.Update)
+		tenants.POST("/:id/suspend", middleware.Permission(ctx.CasbinService, constants.ResourceTenantUpdate), tenantController.Suspend)
+		tenants.POST("/:id/resume", middleware.Permission(ctx.CasbinService, constants.ResourceTenantUpdate), tenantController.Resume)
+		tenants.DELETE("/:id", middleware.DenyApiKey(), middleware.DenyImpersonation(),
+			middleware.Permission(ctx.CasbinService, constants.ResourceTenantDelete), tenantController.Delete)
+	}
+}
@@ -11,10 +11,11 @@ import (
 func registerUserRoutes(r *gin.Engine, ctx *RouterContext) {
 	// 初始化 controller
 	userController := controller.NewUserController(ctx.Container)
+	registrationController := controller.NewRegistrationController(ctx.Container)
 
 	// 用户管理路由组（需要认证和权限）
 	users := r.Group("/api/v1/user")
-	users.Use(ctx.AuthMiddleware)
+	users.Use(ctx.AuthMiddleware, ctx.DataScopeMiddleware)
 	{
 		// 用户创建 - 需要 user.create 权限
 		users.POST("", middleware.Permission(ctx.CasbinService, constants.ResourceUserCreate), userController.Create)
@@ -27,11 +28,14 @@ func registerUserRoutes(r *gin.Engine, ctx *RouterContext) {
 		users.DELETE("/batch", middleware.Permission(ctx.CasbinService, constants.ResourceUserDelete), userController.BatchDelete)
 
 		// 用户修改密码 - 不需要特殊权限（用户修改自己的密码）
-		users.POST("/password/change", userController.ChangePassword)
+		users.POST("/password/change", middleware.DenyApiKey(), middleware.DenyImpersonation(), userController.ChangePassword)
 
 		// 用户更新 - 需要 user.update 权限（带参数的路由放在后面）
 		users.PUT("/:id", middleware.Permission(ctx.CasbinService, constants.ResourceUserUpdate), userController.Update)
-		users.PUT("/:id/password", middleware.Permission(ctx.CasbinService, constants.ResourceUserUpdate), userController.ResetPassword)
+		users.PUT("/:id/password", middleware.DenyImpersonation(), middleware.Permission(ctx.CasbinService, constants.ResourceUserUpdate), userController.ResetPassword)
+
+		// 审批自助注册用户 - 需要 user.approve 权限
+		users.POST("/:id/approve", middleware.Permission(ctx.CasbinService, constants.ResourceUserApprove), registrationController.Approve)
 
 		// 用户查询 - 需要 user.read 权限（带参数的路由放在最后）
 		users.GET("/:id", middleware.Permission(ctx.CasbinService, constants.ResourceUserRead), userController.GetById)
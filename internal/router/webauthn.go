@@ -0,0 +1,26 @@
+package router
+
+import (
+	"github.com/force-c/nai-tizi/internal/controller"
+	"github.com/force-c/nai-tizi/internal/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// registerWebAuthnRoutes 注册通行密钥路由
+func registerWebAuthnRoutes(r *gin.Engine, ctx *RouterContext) {
+	webAuthnController := controller.NewWebAuthnController(ctx.Container)
+
+	// 公开路由：获取登录挑战（登录本身走 /login，grantType=webauthn）
+	r.POST("/auth/webauthn/login/options", webAuthnController.LoginOptions)
+
+	// 当前用户的通行密钥管理（登录即可访问）
+	webAuthn := r.Group("/api/v1/auth/webauthn")
+	webAuthn.Use(ctx.AuthMiddleware, middleware.DenyApiKey(), middleware.DenyImpersonation())
+	{
+		webAuthn.POST("/register/options", webAuthnController.RegisterOptions)   // 获取注册参数
+		webAuthn.POST("/register", webAuthnController.Register)                  // 完成注册
+		webAuthn.GET("/credentials", webAuthnController.ListCredentials)         // 查询凭证列表
+		webAuthn.PUT("/credentials/:id", webAuthnController.RenameCredential)    // 重命名凭证
+		webAuthn.DELETE("/credentials/:id", webAuthnController.DeleteCredential) // 删除凭证
+	}
+}
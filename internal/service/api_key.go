@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/force-c/nai-tizi/internal/constants"
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/infrastructure/tenant"
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// ApiKeyPrefix API Key 明文前缀，Authorization 头中以此开头的值按 API Key 认证
+	ApiKeyPrefix = "ntz_"
+
+	// ApiKeyTouchKeyPrefix 最后使用时间写入节流 Redis Key 前缀
+	// api_key:touch:{id} -> 1
+	ApiKeyTouchKeyPrefix = "api_key:touch:"
+
+	apiKeyTouchInterval = time.Minute
+	apiKeyMaxPerUser    = 20
+)
+
+// ApiKeyService API Key 服务接口
+type ApiKeyService interface {
+	// Create 为用户创建 API Key，返回记录和明文（明文仅此一次返回）
+	Create(ctx context.Context, userId, createBy int64, name string, scopes []string, expireAt int64) (*model.ApiKey, string, error)
+
+	// List 查询用户的 API Key
+	List(ctx context.Context, userId int64) ([]model.ApiKey, error)
+
+	// Delete 删除（吊销）用户的 API Key
+	Delete(ctx context.Context, userId, id int64) error
+
+	// Authenticate 校验 API Key 明文，返回 Key 记录和所属用户
+	Authenticate(ctx context.Context, plaintext, ip string) (*model.ApiKey, *model.User, error)
+}
+
+type apiKeyService struct {
+	db     *gorm.DB
+	redis  *redis.Client
+	logger logging.Logger
+}
+
+// NewApiKeyService 创建 API Key 服务实例
+func NewApiKeyService(db *gorm.DB, redis *redis.Client, logger logging.Logger) ApiKeyService {
+	return &apiKeyService{db: db, redis: redis, logger: logger}
+}
+
+// Create 创建 API Key
+func (s *apiKeyService) Create(ctx context.Context, userId, createBy int64, name string, scopes []string, expireAt int64) (*model.ApiKey, string, error) {
+	scopes, err := NormalizeApiKeyScopes(scopes)
+	if err != nil {
+		return nil, "", err
+	}
+	if expireAt != 0 && expireAt <= time.Now().Unix() {
+		return nil, "", fmt.Errorf("过期时间必须晚于当前时间")
+	}
+
+	db := s.db.WithContext(ctx)
+	user, err := (&model.User{}).FindByID(db, userId)
+	if err != nil {
+		return nil, "", fmt.Errorf("用户不存在")
+	}
+	if user.Status != constants.StatusNormal {
+		return nil, "", fmt.Errorf("用户已停用")
+	}
+
+	var km model.ApiKey
+	count, err := km.CountByUserId(db, userId)
+	if err != nil {
+		return nil, "", fmt.Errorf("创建失败")
+	}
+	if count >= apiKeyMaxPerUser {
+		return nil, "", fmt.Errorf("最多只能创建 %d 个 API Key", apiKeyMaxPerUser)
+	}
+
+	random, err := generateRandomToken(30)
+	if err != nil {
+		return nil, "", fmt.Errorf("生成 API Key 失败")
+	}
+	plaintext := ApiKeyPrefix + random
+	key := &model.ApiKey{
+		UserId:    userId,
+		Name:      name,
+		KeyPrefix: plaintext[:len(ApiKeyPrefix)+8],
+		KeyHash:   generateTokenHash(plaintext),
+		Scopes:    strings.Join(scopes, ","),
+		ExpireAt:  expireAt,
+		CreateBy:  createBy,
+	}
+	if err := key.Create(db); err != nil {
+		s.logger.Error("创建 API Key 失败", zap.Int64("userId", userId), zap.Error(err))
+		return nil, "", fmt.Errorf("创建失败")
+	}
+
+	s.logger.Info("创建 API Key",
+		zap.Int64("userId", userId),
+		zap.Int64("keyId", key.ID),
+		zap.Int64("createBy", createBy),
+		zap.String("scopes", key.Scopes))
+	return key, plaintext, nil
+}
+
+// List 查询用户的 API Key
+func (s *apiKeyService) List(ctx context.Context, userId int64) ([]model.ApiKey, error) {
+	keys, err := (&model.ApiKey{}).FindByUserId(s.db.WithContext(ctx), userId)
+	if err != nil {
+		return nil, fmt.Errorf("查询失败")
+	}
+	return keys, nil
+}
+
+// Delete 删除 API Key，删除后立即失效
+func (s *apiKeyService) Delete(ctx context.Context, userId, id int64) error {
+	affected, err := (&model.ApiKey{}).Delete(s.db.WithContext(ctx), userId, id)
+	if err != nil {
+		return fmt.Errorf("删除失败")
+	}
+	if affected == 0 {
+		return fmt.Errorf("API Key 不存在")
+	}
+	s.logger.Info("删除 API Key", zap.Int64("userId", userId), zap.Int64("keyId", id))
+	return nil
+}
+
+// Authenticate 校验 API Key
+func (s *apiKeyService) Authenticate(ctx context.Context, plaintext, ip string) (*model.ApiKey, *model.User, error) {
+	if !strings.HasPrefix(plaintext, ApiKeyPrefix) {
+		return nil, nil, fmt.Errorf("API Key 无效")
+	}
+
+	// API Key 全局唯一，按 Key 跨租户查询后以 Key 所属租户继续处理
+	key, err := (&model.ApiKey{}).FindByHash(s.db.WithContext(tenant.WithoutTenant(ctx)), generateTokenHash(plaintext))
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Error("查询 API Key 失败", zap.Error(err))
+		}
+		return nil, nil, fmt.Errorf("API Key 无效")
+	}
+	db := s.db.WithContext(tenant.WithTenantId(ctx, key.TenantId))
+	now := time.Now().Unix()
+	if key.ExpireAt != 0 && key.ExpireAt <= now {
+		return nil, nil, fmt.Errorf("API Key 已过期")
+	}
+
+	user, err := (&model.User{}).FindByID(db, key.UserId)
+	if err != nil || user.Status != constants.StatusNormal {
+		return nil, nil, fmt.Errorf("API Key 所属用户不存在或已停用")
+	}
+
+	// 每个 Key 每分钟最多写一次最后使用时间
+	if ok, err := s.redis.SetNX(ctx, ApiKeyTouchKeyPrefix+strconv.FormatInt(key.ID, 10), 1, apiKeyTouchInterval).Result(); err == nil && ok {
+		if err := key.TouchUsed(db, key.ID, now, ip); err != nil {
+			s.logger.Warn("更新 API Key 使用时间失败", zap.Int64("keyId", key.ID), zap.Error(err))
+		}
+	}
+	return key, user, nil
+}
+
+// NormalizeApiKeyScopes 校验并去重授权范围，每项必须是已知权限资源或匹配已知资源的通配符（如 user.*、*.read）
+func NormalizeApiKeyScopes(scopes []string) ([]string, error) {
+	seen := make(map[string]bool, len(scopes))
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if scope == "" || seen[scope] {
+			continue
+		}
+		known := false
+		for _, resource := range constants.Resources {
+			if MatchApiKeyScope(scope, resource) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("未知的授权范围: %s", scope)
+		}
+		seen[scope] = true
+		result = append(result, scope)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("授权范围不能为空")
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// MatchApiKeyScope 判断授权范围是否覆盖权限资源，支持 *、module.*、*.action 三种通配
+func MatchApiKeyScope(scope, resource string) bool {
+	switch {
+	case scope == "*" || scope == resource:
+		return true
+	case strings.HasSuffix(scope, ".*"):
+		return strings.HasPrefix(resource, strings.TrimSuffix(scope, "*"))
+	case strings.HasPrefix(scope, "*."):
+		return strings.HasSuffix(resource, strings.TrimPrefix(scope, "*"))
+	}
+	return false
+}
+
+// ApiKeyScopesAllow 判断 API Key 的授权范围是否包含权限资源
+func ApiKeyScopesAllow(scopes []string, resource string) bool {
+	for _, scope := range scopes {
+		if MatchApiKeyScope(scope, resource) {
+			return true
+		}
+	}
+	return false
+}
@@ -123,7 +123,7 @@ func (s *attachmentService) UploadFile(ctx context.Context, req *request.UploadF
 		Status:   0, // 0 = 正常
 	}
 
-	if err := s.db.Create(attachment).Error; err != nil {
+	if err := s.db.WithContext(ctx).Create(attachment).Error; err != nil {
 		// 回滚：删除已上传的文件
 		if delErr := stor.Delete(ctx, fileKey); delErr != nil {
 			s.logger.Error("删除文件失败", zap.Error(delErr))
@@ -185,7 +185,7 @@ func (s *attachmentService) BindToBusiness(ctx context.Context, attachmentId int
 		updates["expire_time"] = *req.ExpireTime
 	}
 
-	if err := s.db.Model(&model.Attachment{}).
+	if err := s.db.WithContext(ctx).Model(&model.Attachment{}).
 		Where("attachment_id = ?", attachmentId).
 		Updates(updates).Error; err != nil {
 		return fmt.Errorf("绑定附件到业务失败: %w", err)
@@ -224,10 +224,10 @@ func (s *attachmentService) Download(ctx context.Context, attachmentId int64) (i
 
 // Delete 删除附件
 func (s *attachmentService) Delete(ctx context.Context, attachmentId int64) error {
-	return s.db.Transaction(func(tx *gorm.DB) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 1. 查询附件记录
 		var attachment model.Attachment
-		if err := tx.Where("attachment_id = ?", attachmentId).First(&attachment).Error; err != nil {
+		if err := tx.Scopes(AttachmentDataScope(ctx)).Where("attachment_id = ?", attachmentId).First(&attachment).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return fmt.Errorf("附件不存在")
 			}
@@ -289,7 +289,7 @@ func (s *attachmentService) GetURL(ctx context.Context, attachmentId int64, expi
 // GetById 根据 ID 查询附件
 func (s *attachmentService) GetById(ctx context.Context, attachmentId int64) (*model.Attachment, error) {
 	var attachment model.Attachment
-	if err := s.db.Where("attachment_id = ? AND status = ?", attachmentId, "0").First(&attachment).Error; err != nil {
+	if err := s.scoped(ctx).Where("attachment_id = ? AND status = ?", attachmentId, "0").First(&attachment).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("附件不存在")
 		}
@@ -302,7 +302,7 @@ func (s *attachmentService) GetById(ctx context.Context, attachmentId int64) (*m
 func (s *attachmentService) ListByBusiness(ctx context.Context, businessType, businessId string) ([]*model.Attachment, error) {
 	var attachments []*model.Attachment
 
-	query := s.db.Where("business_type = ? AND business_id = ? AND status = ?", businessType, businessId, "0")
+	query := s.scoped(ctx).Where("business_type = ? AND business_id = ? AND status = ?", businessType, businessId, "0")
 
 	if err := query.Order("create_time DESC").Find(&attachments).Error; err != nil {
 		return nil, fmt.Errorf("查询附件列表失败: %w", err)
@@ -313,7 +313,7 @@ func (s *attachmentService) ListByBusiness(ctx context.Context, businessType, bu
 
 // Page 分页查询附件列表
 func (s *attachmentService) Page(ctx context.Context, pageNum, pageSize int, fileName, fileType, businessType string) (*pagination.Page[model.Attachment], error) {
-	query := s.db.Model(&model.Attachment{}).Where("status = ?", "0")
+	query := s.scoped(ctx).Model(&model.Attachment{}).Where("status = ?", "0")
 
 	// 添加过滤条件
 	if fileName != "" {
@@ -346,7 +346,7 @@ func (s *attachmentService) Page(ctx context.Context, pageNum, pageSize int, fil
 func (s *attachmentService) CleanExpired(ctx context.Context) error {
 	// 查询过期的附件
 	var attachments []*model.Attachment
-	if err := s.db.Where("expire_time IS NOT NULL AND expire_time < ? AND status = ?",
+	if err := s.db.WithContext(ctx).Where("expire_time IS NOT NULL AND expire_time < ? AND status = ?",
 		time.Now(), "0").Find(&attachments).Error; err != nil {
 		return fmt.Errorf("查询过期附件失败: %w", err)
 	}
@@ -379,3 +379,8 @@ func (s *attachmentService) generateFileKey(filename, businessType string) strin
 
 	return fmt.Sprintf("%s/%s/%d_%s", businessType, date, timestamp, cleanFilename)
 }
+
+// scoped 返回按当前请求数据权限过滤附件的查询（每次查询需重新获取）
+func (s *attachmentService) scoped(ctx context.Context) *gorm.DB {
+	return s.db.WithContext(ctx).Scopes(AttachmentDataScope(ctx))
+}
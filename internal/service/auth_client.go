@@ -5,22 +5,58 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/force-c/nai-tizi/internal/domain/model"
 	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/force-c/nai-tizi/internal/utils/pagination"
 	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
 
 const (
 	ClientCacheKeyPrefix = "s_auth_client:"
 	ClientCacheTTL       = 30 * 24 * time.Hour
+
+	// ClientSecretMaxGracePeriod 轮换密钥时旧密钥最长保留时间（秒），30天
+	ClientSecretMaxGracePeriod = 30 * 24 * 3600
 )
 
+// clientKeyPattern 客户端Key格式（用于缓存 Key 和 OAuth2 client_id）
+var clientKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// ClientGrantTypes 客户端可开通的授权类型
+// 登录方式（password/sms/email/xcx/mfa/webauthn/oidc/ldap）、刷新令牌（refresh）以及 OAuth2 授权类型
+var ClientGrantTypes = []string{
+	"password", "sms", "email", "xcx", "mfa", "webauthn", "oidc", "ldap", "refresh",
+	OAuthGrantAuthorizationCode, OAuthGrantClientCredentials, OAuthGrantRefreshToken,
+}
+
 type ClientService interface {
 	// AuthenticateClient 认证客户端（通过 clientKey + clientSecret）
 	AuthenticateClient(ctx context.Context, clientKey, clientSecret, grantType string) (*model.AuthClient, error)
+
+	// Create 创建客户端，返回密钥明文（仅此一次返回）
+	Create(ctx context.Context, client *model.AuthClient) (string, error)
+
+	// Update 更新客户端配置（clientKey 不可修改）
+	Update(ctx context.Context, client *model.AuthClient) error
+
+	// Delete 删除客户端
+	Delete(ctx context.Context, clientId string) error
+
+	// GetById 根据 clientId 查询客户端
+	GetById(ctx context.Context, clientId string) (*model.AuthClient, error)
+
+	// Page 分页查询客户端列表
+	Page(ctx context.Context, query *pagination.PageQuery, clientKey, clientName string, status *int) (*pagination.Page[model.AuthClient], error)
+
+	// RotateSecret 轮换客户端密钥，旧密钥在 gracePeriod 秒内仍然有效，返回更新后的客户端和新密钥明文
+	RotateSecret(ctx context.Context, clientId string, gracePeriod int64, updateBy int64) (*model.AuthClient, string, error)
 }
 
 type clientService struct {
@@ -35,7 +71,7 @@ func NewClientService(db *gorm.DB, redis *redis.Client, logger logging.Logger) C
 
 // AuthenticateClient 通过 clientKey 和 clientSecret 认证客户端
 // 1. 根据 clientKey 查询客户端配置（优先从 Redis 缓存读取）
-// 2. 验证 clientSecret 是否匹配
+// 2. 验证 clientSecret 是否匹配（轮换后的旧密钥在宽限期内仍可用）
 // 3. 检查客户端状态是否启用
 // 4. 检查客户端是否支持请求的授权类型
 func (s *clientService) AuthenticateClient(ctx context.Context, clientKey, clientSecret, grantType string) (*model.AuthClient, error) {
@@ -47,7 +83,7 @@ func (s *clientService) AuthenticateClient(ctx context.Context, clientKey, clien
 	}
 
 	// 尝试从 Redis 缓存读取（使用 clientKey 作为缓存键）
-	cacheKey := ClientCacheKeyPrefix + "key:" + clientKey
+	cacheKey := clientCacheKey(clientKey)
 	val, err := s.redis.Get(ctx, cacheKey).Result()
 	var client *model.AuthClient
 	if err == nil {
@@ -60,7 +96,7 @@ func (s *clientService) AuthenticateClient(ctx context.Context, clientKey, clien
 	// 缓存未命中，从数据库查询
 	if client == nil {
 		var m model.AuthClient
-		c, err := m.FindByClientKey(s.db, clientKey)
+		c, err := m.FindByClientKey(s.db.WithContext(ctx), clientKey)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return nil, fmt.Errorf("客户端不存在")
@@ -77,6 +113,7 @@ func (s *clientService) AuthenticateClient(ctx context.Context, clientKey, clien
 	if !client.VerifySecret(clientSecret) {
 		return nil, fmt.Errorf("客户端认证失败")
 	}
+	upgradeLegacyClientSecret(ctx, s.db.WithContext(ctx), s.redis, s.logger, client, clientSecret)
 
 	// 检查客户端状态
 	if !client.IsActive() {
@@ -90,3 +127,273 @@ func (s *clientService) AuthenticateClient(ctx context.Context, clientKey, clien
 
 	return client, nil
 }
+
+// Create 创建客户端
+// 密钥由系统生成，库中只保存哈希，明文仅在创建时返回一次
+func (s *clientService) Create(ctx context.Context, client *model.AuthClient) (string, error) {
+	client.ClientKey = strings.TrimSpace(client.ClientKey)
+	if !clientKeyPattern.MatchString(client.ClientKey) {
+		return "", fmt.Errorf("客户端Key只能包含字母、数字、下划线或中划线")
+	}
+	if err := normalizeClient(client); err != nil {
+		return "", err
+	}
+
+	// 包含已删除的客户端，clientKey 上有唯一索引
+	var count int64
+	if err := s.db.WithContext(ctx).Unscoped().Model(&model.AuthClient{}).Where("client_key = ?", client.ClientKey).Count(&count).Error; err != nil {
+		s.logger.Error("检查客户端Key失败", zap.Error(err))
+		return "", fmt.Errorf("创建客户端失败")
+	}
+	if count > 0 {
+		return "", fmt.Errorf("客户端Key已存在: %s", client.ClientKey)
+	}
+
+	secret, err := generateRandomToken(32)
+	if err != nil {
+		return "", fmt.Errorf("生成客户端密钥失败")
+	}
+	client.ClientId = model.GenerateClientId(client.ClientKey, secret)
+	client.ClientSecret = model.HashClientSecret(secret)
+	client.PrevSecret = ""
+	client.PrevExpireAt = 0
+
+	if err := client.Create(s.db.WithContext(ctx)); err != nil {
+		s.logger.Error("创建客户端失败", zap.String("clientKey", client.ClientKey), zap.Error(err))
+		return "", fmt.Errorf("创建客户端失败")
+	}
+
+	s.invalidateCache(ctx, client.ClientKey)
+	s.logger.Info("创建客户端成功",
+		zap.String("clientId", client.ClientId),
+		zap.String("clientKey", client.ClientKey),
+		zap.String("grantType", client.GrantType),
+		zap.Int64("createBy", client.CreateBy))
+	return secret, nil
+}
+
+// Update 更新客户端配置，更新后立即清除缓存
+func (s *clientService) Update(ctx context.Context, client *model.AuthClient) error {
+	existing, err := s.GetById(ctx, client.ClientId)
+	if err != nil {
+		return err
+	}
+	if err := normalizeClient(client); err != nil {
+		return err
+	}
+
+	updates := map[string]any{
+		"client_name":    client.ClientName,
+		"grant_type":     client.GrantType,
+		"device_type":    client.DeviceType,
+		"status":         client.Status,
+		"timeout":        client.Timeout,
+		"active_timeout": client.ActiveTimeout,
+		"idle_check":     client.IdleCheck,
+		"redirect_uris":  client.RedirectUris,
+		"scopes":         client.Scopes,
+		"auto_approve":   client.AutoApprove,
+		"remark":         client.Remark,
+		"update_by":      client.UpdateBy,
+	}
+	if err := s.db.WithContext(ctx).Model(&model.AuthClient{}).Where("client_id = ?", client.ClientId).Updates(updates).Error; err != nil {
+		s.logger.Error("更新客户端失败", zap.String("clientId", client.ClientId), zap.Error(err))
+		return fmt.Errorf("更新客户端失败")
+	}
+
+	s.invalidateCache(ctx, existing.ClientKey)
+	s.logger.Info("更新客户端成功",
+		zap.String("clientId", client.ClientId),
+		zap.String("clientKey", existing.ClientKey),
+		zap.String("grantType", client.GrantType),
+		zap.Int("status", client.Status),
+		zap.Int64("updateBy", client.UpdateBy))
+	return nil
+}
+
+// Delete 删除客户端，删除后使用该客户端的登录和刷新请求立即失败
+func (s *clientService) Delete(ctx context.Context, clientId string) error {
+	existing, err := s.GetById(ctx, clientId)
+	if err != nil {
+		return err
+	}
+	if err := existing.Delete(s.db.WithContext(ctx), clientId); err != nil {
+		s.logger.Error("删除客户端失败", zap.String("clientId", clientId), zap.Error(err))
+		return fmt.Errorf("删除客户端失败")
+	}
+
+	s.invalidateCache(ctx, existing.ClientKey)
+	s.logger.Info("删除客户端成功", zap.String("clientId", clientId), zap.String("clientKey", existing.ClientKey))
+	return nil
+}
+
+// GetById 根据 clientId 查询客户端
+func (s *clientService) GetById(ctx context.Context, clientId string) (*model.AuthClient, error) {
+	client, err := (&model.AuthClient{}).FindByClientId(s.db.WithContext(ctx), clientId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("客户端不存在")
+		}
+		s.logger.Error("查询客户端失败", zap.String("clientId", clientId), zap.Error(err))
+		return nil, fmt.Errorf("查询客户端失败")
+	}
+	return client, nil
+}
+
+// Page 分页查询客户端列表
+func (s *clientService) Page(ctx context.Context, query *pagination.PageQuery, clientKey, clientName string, status *int) (*pagination.Page[model.AuthClient], error) {
+	q := s.db.WithContext(ctx).Model(&model.AuthClient{})
+	if clientKey != "" {
+		q = q.Where("client_key LIKE ?", "%"+clientKey+"%")
+	}
+	if clientName != "" {
+		q = q.Where("client_name LIKE ?", "%"+clientName+"%")
+	}
+	if status != nil {
+		q = q.Where("status = ?", *status)
+	}
+	if query.OrderByColumn == "" {
+		q = q.Order("created_time DESC")
+	}
+	page, err := pagination.New[model.AuthClient](q, query).Find()
+	if err != nil {
+		s.logger.Error("分页查询客户端失败", zap.Error(err))
+		return nil, fmt.Errorf("查询失败")
+	}
+	return page, nil
+}
+
+// RotateSecret 轮换客户端密钥
+// 旧密钥保存为 PrevSecret，在宽限期内与新密钥同时有效；gracePeriod 为 0 时旧密钥立即失效
+// 再次轮换会覆盖上一次保留的旧密钥
+func (s *clientService) RotateSecret(ctx context.Context, clientId string, gracePeriod int64, updateBy int64) (*model.AuthClient, string, error) {
+	if gracePeriod < 0 || gracePeriod > ClientSecretMaxGracePeriod {
+		return nil, "", fmt.Errorf("旧密钥保留时间必须在 0 到 %d 秒之间", ClientSecretMaxGracePeriod)
+	}
+	existing, err := s.GetById(ctx, clientId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	secret, err := generateRandomToken(32)
+	if err != nil {
+		return nil, "", fmt.Errorf("生成客户端密钥失败")
+	}
+
+	prevSecret, prevExpireAt := "", int64(0)
+	if gracePeriod > 0 {
+		prevSecret = existing.ClientSecret
+		if !existing.IsSecretHashed() {
+			prevSecret = model.HashClientSecret(existing.ClientSecret)
+		}
+		prevExpireAt = time.Now().Unix() + gracePeriod
+	}
+
+	updates := map[string]any{
+		"client_secret":  model.HashClientSecret(secret),
+		"prev_secret":    prevSecret,
+		"prev_expire_at": prevExpireAt,
+		"update_by":      updateBy,
+	}
+	if err := s.db.WithContext(ctx).Model(&model.AuthClient{}).Where("client_id = ?", clientId).Updates(updates).Error; err != nil {
+		s.logger.Error("轮换客户端密钥失败", zap.String("clientId", clientId), zap.Error(err))
+		return nil, "", fmt.Errorf("轮换客户端密钥失败")
+	}
+	existing.ClientSecret, existing.PrevSecret, existing.PrevExpireAt = updates["client_secret"].(string), prevSecret, prevExpireAt
+
+	s.invalidateCache(ctx, existing.ClientKey)
+	s.logger.Warn("轮换客户端密钥",
+		zap.String("clientId", clientId),
+		zap.String("clientKey", existing.ClientKey),
+		zap.Int64("gracePeriod", gracePeriod),
+		zap.Int64("updateBy", updateBy))
+	return existing, secret, nil
+}
+
+// invalidateCache 清除客户端配置缓存
+func (s *clientService) invalidateCache(ctx context.Context, clientKey string) {
+	if err := s.redis.Del(ctx, clientCacheKey(clientKey)).Err(); err != nil {
+		s.logger.Warn("清除客户端缓存失败", zap.String("clientKey", clientKey), zap.Error(err))
+	}
+}
+
+// clientCacheKey 客户端配置缓存 Key
+// s_auth_client:key:{clientKey} -> AuthClient JSON
+func clientCacheKey(clientKey string) string {
+	return ClientCacheKeyPrefix + "key:" + clientKey
+}
+
+// upgradeLegacyClientSecret 历史明文密钥认证通过后替换为哈希并清除缓存
+func upgradeLegacyClientSecret(ctx context.Context, db *gorm.DB, rdb *redis.Client, logger logging.Logger, client *model.AuthClient, secret string) {
+	if client.IsSecretHashed() {
+		return
+	}
+	upgraded, err := client.UpgradeLegacySecret(db.WithContext(ctx), client.ClientId, secret)
+	if err != nil {
+		logger.Warn("升级客户端密钥存储失败", zap.String("clientKey", client.ClientKey), zap.Error(err))
+		return
+	}
+	if upgraded {
+		client.ClientSecret = model.HashClientSecret(secret)
+		_ = rdb.Del(ctx, clientCacheKey(client.ClientKey)).Err()
+		logger.Info("客户端密钥已升级为哈希存储", zap.String("clientKey", client.ClientKey))
+	}
+}
+
+// normalizeClient 校验并规范化客户端配置
+func normalizeClient(client *model.AuthClient) error {
+	grantTypes, err := NormalizeClientGrantTypes(client.GrantTypes())
+	if err != nil {
+		return err
+	}
+	client.GrantType = strings.Join(grantTypes, ",")
+
+	if client.Timeout <= 0 || client.ActiveTimeout <= 0 {
+		return fmt.Errorf("Token 有效期必须大于 0")
+	}
+	if client.ActiveTimeout > client.Timeout {
+		return fmt.Errorf("AccessToken 有效期不能超过 RefreshToken 有效期")
+	}
+
+	uris := client.RegisteredRedirectUris()
+	for _, uri := range uris {
+		u, err := url.Parse(uri)
+		if err != nil || u.Scheme == "" || u.Host == "" || u.Fragment != "" {
+			return fmt.Errorf("回调地址无效: %s", uri)
+		}
+	}
+	if client.IsGrantTypeSupported(OAuthGrantAuthorizationCode) && len(uris) == 0 {
+		return fmt.Errorf("开通授权码模式时必须登记回调地址")
+	}
+	client.RedirectUris = strings.Join(uris, "\n")
+	client.Scopes = strings.Join(client.AllowedScopes(), " ")
+	return nil
+}
+
+// NormalizeClientGrantTypes 校验授权类型并去重
+func NormalizeClientGrantTypes(grantTypes []string) ([]string, error) {
+	result := make([]string, 0, len(grantTypes))
+	seen := make(map[string]bool, len(grantTypes))
+	for _, t := range grantTypes {
+		t = strings.TrimSpace(t)
+		if t == "" || seen[t] {
+			continue
+		}
+		known := false
+		for _, g := range ClientGrantTypes {
+			if g == t {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("不支持的授权类型: %s", t)
+		}
+		seen[t] = true
+		result = append(result, t)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("至少需要开通一种授权类型")
+	}
+	return result, nil
+}
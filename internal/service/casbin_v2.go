@@ -6,6 +6,8 @@ import (
 
 	"github.com/casbin/casbin/v2"
 	"github.com/force-c/nai-tizi/internal/config"
+	"github.com/force-c/nai-tizi/internal/infrastructure/casbinsync"
+	"github.com/force-c/nai-tizi/internal/infrastructure/tenant"
 	"github.com/force-c/nai-tizi/internal/logger"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -41,7 +43,10 @@ type CasbinServiceV2 interface {
 	// GetPermissionsForRole 获取角色的所有权限（自动适配）
 	GetPermissionsForRole(ctx context.Context, roleKey string) ([][]string, error)
 
-	// ReloadPolicy 重新加载策略（从数据库）
+	// DeleteTenantPolicies 删除租户域下的所有权限和角色分配（仅多租户模式，删除租户时调用）
+	DeleteTenantPolicies(ctx context.Context, tenantId int64) error
+
+	// ReloadPolicy 重新加载策略（从数据库），并通知其他副本重新加载
 	ReloadPolicy(ctx context.Context) error
 }
 
@@ -68,14 +73,14 @@ func (s *casbinServiceV2) getTenantId(ctx context.Context) int64 {
 		return 1 // 单一企业模式，默认租户ID为1
 	}
 
-	// 从 context 获取租户ID
-	if tenantId, ok := ctx.Value("tenantId").(int64); ok {
+	// 从 context 获取租户ID（由租户中间件写入）
+	if tenantId, ok := tenant.IdFromContext(ctx); ok {
 		return tenantId
 	}
 
 	// 如果没有租户ID，返回默认值1
 	s.logger.Warn("租户ID未设置，使用默认值1")
-	return 1
+	return tenant.DefaultTenantId
 }
 
 // CheckPermission 检查用户权限（自动适配单租户/多租户）
@@ -312,12 +317,37 @@ func (s *casbinServiceV2) GetPermissionsForRole(ctx context.Context, roleKey str
 	return permissions, nil
 }
 
+// DeleteTenantPolicies 删除租户域下的所有权限和角色分配
+// 单一企业模式下策略不区分租户，不做处理
+func (s *casbinServiceV2) DeleteTenantPolicies(ctx context.Context, tenantId int64) error {
+	if !s.multiTenantEnabled {
+		return nil
+	}
+
+	dom := fmt.Sprintf("tenant::%d", tenantId)
+	// p = sub, dom, obj, act；g = user, role, dom
+	if _, err := s.enforcer.RemoveFilteredPolicy(1, dom); err != nil {
+		return fmt.Errorf("删除租户权限失败: %w", err)
+	}
+	if _, err := s.enforcer.RemoveFilteredGroupingPolicy(2, dom); err != nil {
+		return fmt.Errorf("删除租户角色分配失败: %w", err)
+	}
+
+	s.logger.Info("删除租户权限策略", zap.Int64("tenantId", tenantId))
+	return nil
+}
+
 // ReloadPolicy 重新加载策略（从数据库）
+// LoadPolicy 不会通知 Watcher，直接修改数据库后调用时须广播，否则其他副本仍使用旧策略
 func (s *casbinServiceV2) ReloadPolicy(ctx context.Context) error {
 	if err := s.enforcer.LoadPolicy(); err != nil {
 		s.logger.Error("重新加载策略失败", zap.Error(err))
 		return fmt.Errorf("重新加载策略失败: %w", err)
 	}
+	if err := casbinsync.NotifyReload(s.enforcer); err != nil {
+		s.logger.Error("通知其他副本重新加载策略失败", zap.Error(err))
+		return fmt.Errorf("通知其他副本重新加载策略失败: %w", err)
+	}
 
 	s.logger.Info("重新加载策略成功")
 	return nil
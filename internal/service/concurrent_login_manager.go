@@ -107,20 +107,38 @@ func (m *concurrentLoginManager) RecordLogin(ctx context.Context, userId int64,
 }
 
 // InvalidateUserTokens 使用户的所有 Token 失效
-// 1. 删除 Redis 中的用户 Token 记录
-// 2. 使 RefreshToken 失效
+// 1. 使 RefreshToken 失效，吊销该客户端下的所有会话
+// 2. 吊销 Redis 中记录的 AccessToken（加入黑名单，立即生效）
+// 3. 删除 Redis 中的用户 Token 记录
 func (m *concurrentLoginManager) InvalidateUserTokens(ctx context.Context, userId int64, clientId string) error {
 	// 使 RefreshToken 失效
 	_ = m.tokenManager.InvalidateToken(ctx, userId, clientId)
 
+	// 吊销该客户端下的所有会话
+	if sessions, err := m.tokenManager.ListSessions(ctx, userId); err == nil {
+		for _, session := range sessions {
+			if session.ClientId == clientId {
+				_ = m.tokenManager.RevokeSession(ctx, userId, session.SessionId)
+			}
+		}
+	}
+
 	if m.config.Auth.ShareToken {
-		// 共享 Token 模式：删除单个 Token 记录
+		// 共享 Token 模式：吊销并删除单个 Token 记录
 		key := m.getUserTokenKey(userId, clientId)
+		if token, err := m.redis.Get(ctx, key).Result(); err == nil && token != "" {
+			_ = m.tokenManager.RevokeAccessToken(ctx, token)
+		}
 		return m.redis.Del(ctx, key).Err()
 	}
 
-	// 非共享 Token 模式：删除 Token 集合
+	// 非共享 Token 模式：吊销并删除 Token 集合
 	key := m.getUserTokensKey(userId, clientId)
+	if tokens, err := m.redis.SMembers(ctx, key).Result(); err == nil {
+		for _, token := range tokens {
+			_ = m.tokenManager.RevokeAccessToken(ctx, token)
+		}
+	}
 	return m.redis.Del(ctx, key).Err()
 }
 
@@ -56,8 +56,12 @@ func NewConfigService(db *gorm.DB, logger logging.Logger) ConfigService {
 
 // Create 创建配置
 func (s *configService) Create(ctx context.Context, req *request.CreateConfigRequest) error {
+	if err := checkConfigData(req.Code, req.Data); err != nil {
+		return err
+	}
+
 	// 检查配置名称是否已存在
-	exists, err := (&model.Config{}).CheckNameExists(s.db, req.Name)
+	exists, err := (&model.Config{}).CheckNameExists(s.db.WithContext(ctx), req.Name)
 	if err != nil {
 		s.logger.Error("检查配置名称失败", zap.Error(err))
 		return fmt.Errorf("检查配置名称失败: %w", err)
@@ -76,7 +80,7 @@ func (s *configService) Create(ctx context.Context, req *request.CreateConfigReq
 		UpdateBy: req.UpdateBy,
 	}
 
-	if err := config.Create(s.db); err != nil {
+	if err := config.Create(s.db.WithContext(ctx)); err != nil {
 		s.logger.Error("创建配置失败", zap.Error(err))
 		return fmt.Errorf("创建配置失败: %w", err)
 	}
@@ -91,8 +95,12 @@ func (s *configService) Create(ctx context.Context, req *request.CreateConfigReq
 
 // Update 更新配置
 func (s *configService) Update(ctx context.Context, req *request.UpdateConfigRequest) error {
+	if err := checkConfigData(req.Code, req.Data); err != nil {
+		return err
+	}
+
 	// 检查配置是否存在
-	existingConfig, err := (&model.Config{}).FindByID(s.db, req.ID)
+	existingConfig, err := (&model.Config{}).FindByID(s.db.WithContext(ctx), req.ID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("配置不存在")
@@ -103,7 +111,7 @@ func (s *configService) Update(ctx context.Context, req *request.UpdateConfigReq
 
 	// 检查配置名称是否被其他配置占用
 	if req.Name != existingConfig.Name {
-		exists, err := (&model.Config{}).CheckNameExistsExcludingSelf(s.db, req.ID, req.Name)
+		exists, err := (&model.Config{}).CheckNameExistsExcludingSelf(s.db.WithContext(ctx), req.ID, req.Name)
 		if err != nil {
 			s.logger.Error("检查配置名称失败", zap.Error(err))
 			return fmt.Errorf("检查配置名称失败: %w", err)
@@ -122,7 +130,7 @@ func (s *configService) Update(ctx context.Context, req *request.UpdateConfigReq
 		"update_by": req.UpdateBy,
 	}
 
-	if err := existingConfig.Update(s.db, req.ID, updates); err != nil {
+	if err := existingConfig.Update(s.db.WithContext(ctx), req.ID, updates); err != nil {
 		s.logger.Error("更新配置失败", zap.Error(err))
 		return fmt.Errorf("更新配置失败: %w", err)
 	}
@@ -134,7 +142,7 @@ func (s *configService) Update(ctx context.Context, req *request.UpdateConfigReq
 // Delete 删除配置
 func (s *configService) Delete(ctx context.Context, id int64) error {
 	// 检查配置是否存在
-	_, err := (&model.Config{}).FindByID(s.db, id)
+	_, err := (&model.Config{}).FindByID(s.db.WithContext(ctx), id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("配置不存在")
@@ -144,7 +152,7 @@ func (s *configService) Delete(ctx context.Context, id int64) error {
 	}
 
 	// 删除配置
-	if err := (&model.Config{}).Delete(s.db, id); err != nil {
+	if err := (&model.Config{}).Delete(s.db.WithContext(ctx), id); err != nil {
 		s.logger.Error("删除配置失败", zap.Error(err))
 		return fmt.Errorf("删除配置失败: %w", err)
 	}
@@ -160,7 +168,7 @@ func (s *configService) BatchDelete(ctx context.Context, ids []int64) error {
 	}
 
 	// 批量删除
-	rowsAffected, err := (&model.Config{}).BatchDelete(s.db, ids)
+	rowsAffected, err := (&model.Config{}).BatchDelete(s.db.WithContext(ctx), ids)
 	if err != nil {
 		s.logger.Error("批量删除配置失败", zap.Error(err))
 		return fmt.Errorf("批量删除配置失败: %w", err)
@@ -172,7 +180,7 @@ func (s *configService) BatchDelete(ctx context.Context, ids []int64) error {
 
 // GetById 根据ID查询配置
 func (s *configService) GetById(ctx context.Context, id int64) (*model.Config, error) {
-	config, err := (&model.Config{}).FindByID(s.db, id)
+	config, err := (&model.Config{}).FindByID(s.db.WithContext(ctx), id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("配置不存在")
@@ -185,7 +193,7 @@ func (s *configService) GetById(ctx context.Context, id int64) (*model.Config, e
 
 // Page 分页查询配置列表
 func (s *configService) Page(ctx context.Context, pageNum, pageSize int, configCode, name string) (*pagination.Page[model.Config], error) {
-	query := s.db.Model(&model.Config{})
+	query := s.db.WithContext(ctx).Model(&model.Config{})
 
 	// 条件查询
 	if configCode != "" {
@@ -213,7 +221,7 @@ func (s *configService) Page(ctx context.Context, pageNum, pageSize int, configC
 
 // GetByCode 根据配置编码获取配置列表
 func (s *configService) GetByCode(ctx context.Context, configCode string) ([]model.Config, error) {
-	configs, err := (&model.Config{}).FindByCode(s.db, configCode)
+	configs, err := (&model.Config{}).FindByCode(s.db.WithContext(ctx), configCode)
 	if err != nil {
 		s.logger.Error("查询配置列表失败", zap.Error(err))
 		return nil, fmt.Errorf("查询配置列表失败: %w", err)
@@ -223,7 +231,7 @@ func (s *configService) GetByCode(ctx context.Context, configCode string) ([]mod
 
 // GetDataByCode 根据配置编码获取配置数据
 func (s *configService) GetDataByCode(ctx context.Context, configCode string) (json.RawMessage, error) {
-	data, err := (&model.Config{}).GetDataByCode(s.db, configCode)
+	data, err := (&model.Config{}).GetDataByCode(s.db.WithContext(ctx), configCode)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("配置不存在")
@@ -233,3 +241,15 @@ func (s *configService) GetDataByCode(ctx context.Context, configCode string) (j
 	}
 	return data, nil
 }
+
+// checkConfigData 校验有固定结构的系统配置（密码策略、注册设置）
+func checkConfigData(code string, data []byte) error {
+	var err error
+	switch code {
+	case PasswordPolicyConfigCode:
+		_, err = ParsePasswordPolicy(data)
+	case RegistrationConfigCode:
+		_, err = ParseRegistrationSettings(data)
+	}
+	return err
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/force-c/nai-tizi/internal/constants"
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// DataScope 当前请求用户的数据权限范围（多个角色取并集，范围最大者生效）
+type DataScope struct {
+	All      bool    // 全部数据，不做过滤
+	UserId   int64   // 当前用户ID
+	UserName string  // 当前用户名
+	OrgId    int64   // 当前用户所属组织ID
+	OrgIds   []int64 // 可见的组织ID（本组织、本组织及以下、自定义组织的并集）
+	Self     bool    // 是否包含本人数据（仅本人数据范围）
+}
+
+type dataScopeKey struct{}
+
+// WithDataScope 将数据权限范围写入 context，供 Service 层查询时自动过滤
+func WithDataScope(ctx context.Context, scope *DataScope) context.Context {
+	return context.WithValue(ctx, dataScopeKey{}, scope)
+}
+
+// DataScopeFromContext 从 context 读取数据权限范围，未设置时返回 nil（不做过滤，如内部任务调用）
+func DataScopeFromContext(ctx context.Context) *DataScope {
+	scope, _ := ctx.Value(dataScopeKey{}).(*DataScope)
+	return scope
+}
+
+// AllowsOrg 判断组织是否在数据权限范围内
+func (d *DataScope) AllowsOrg(orgId int64) bool {
+	if d == nil || d.All {
+		return true
+	}
+	return slices.Contains(d.OrgIds, orgId)
+}
+
+// where 按 "组织条件 OR 本人条件" 拼接过滤条件，两者都为空时不返回任何数据
+func (d *DataScope) where(db *gorm.DB, orgCond string, orgArg any, selfCond string, selfArg any) *gorm.DB {
+	hasOrg := len(d.OrgIds) > 0
+	switch {
+	case hasOrg && d.Self:
+		return db.Where("("+orgCond+" OR "+selfCond+")", orgArg, selfArg)
+	case hasOrg:
+		return db.Where(orgCond, orgArg)
+	case d.Self:
+		return db.Where(selfCond, selfArg)
+	default:
+		return db.Where("1 = 0")
+	}
+}
+
+// orgUsers 可见组织下的用户子查询
+func (d *DataScope) orgUsers(db *gorm.DB, column string) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true}).Model(&model.User{}).Select(column).Where("org_id IN ?", d.OrgIds)
+}
+
+// UserDataScope 用户数据过滤：可见组织下的用户，或本人
+func UserDataScope(ctx context.Context) func(db *gorm.DB) *gorm.DB {
+	scope := DataScopeFromContext(ctx)
+	return func(db *gorm.DB) *gorm.DB {
+		if scope == nil || scope.All {
+			return db
+		}
+		return scope.where(db, "org_id IN ?", scope.OrgIds, "id = ?", scope.UserId)
+	}
+}
+
+// OrgDataScope 组织数据过滤：可见组织，仅本人时可见自己所属的组织
+func OrgDataScope(ctx context.Context) func(db *gorm.DB) *gorm.DB {
+	scope := DataScopeFromContext(ctx)
+	return func(db *gorm.DB) *gorm.DB {
+		if scope == nil || scope.All {
+			return db
+		}
+		return scope.where(db, "id IN ?", scope.OrgIds, "id = ?", scope.OrgId)
+	}
+}
+
+// AttachmentDataScope 附件数据过滤：可见组织下用户上传的附件，或本人上传的附件
+func AttachmentDataScope(ctx context.Context) func(db *gorm.DB) *gorm.DB {
+	scope := DataScopeFromContext(ctx)
+	return func(db *gorm.DB) *gorm.DB {
+		if scope == nil || scope.All {
+			return db
+		}
+		return scope.where(db, "create_by IN (?)", scope.orgUsers(db, "id"), "create_by = ?", scope.UserId)
+	}
+}
+
+// LoginLogDataScope 登录日志数据过滤：可见组织下用户的登录日志，或本人的登录日志
+func LoginLogDataScope(ctx context.Context) func(db *gorm.DB) *gorm.DB {
+	scope := DataScopeFromContext(ctx)
+	return func(db *gorm.DB) *gorm.DB {
+		if scope == nil || scope.All {
+			return db
+		}
+		return scope.where(db, "user_name IN (?)", scope.orgUsers(db, "user_name"), "user_name = ?", scope.UserName)
+	}
+}
+
+// OperLogDataScope 操作日志数据过滤：可见组织下用户的操作日志，或本人的操作日志
+func OperLogDataScope(ctx context.Context) func(db *gorm.DB) *gorm.DB {
+	scope := DataScopeFromContext(ctx)
+	return func(db *gorm.DB) *gorm.DB {
+		if scope == nil || scope.All {
+			return db
+		}
+		return scope.where(db, "oper_user_id IN (?)", scope.orgUsers(db, "id"), "oper_user_id = ?", scope.UserId)
+	}
+}
+
+// DataScopeService 数据权限服务接口
+type DataScopeService interface {
+	// Resolve 解析用户所有角色的数据范围并取并集
+	Resolve(ctx context.Context, userId int64, userName string, orgId int64) (*DataScope, error)
+}
+
+type dataScopeService struct {
+	db     *gorm.DB
+	logger logging.Logger
+}
+
+// NewDataScopeService 创建数据权限服务实例
+func NewDataScopeService(db *gorm.DB, logger logging.Logger) DataScopeService {
+	return &dataScopeService{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// Resolve 解析用户所有角色的数据范围并取并集
+// 超级管理员或任一角色为全部数据时不做过滤；未分配角色的用户仅可见本人数据
+func (s *dataScopeService) Resolve(ctx context.Context, userId int64, userName string, orgId int64) (*DataScope, error) {
+	db := s.db.WithContext(ctx)
+	scope := &DataScope{UserId: userId, UserName: userName, OrgId: orgId}
+
+	roles, err := (&model.Role{}).FindByUserId(db, userId)
+	if err != nil {
+		s.logger.Error("查询用户角色失败", zap.Int64("userId", userId), zap.Error(err))
+		return nil, fmt.Errorf("查询用户角色失败: %w", err)
+	}
+	if len(roles) == 0 {
+		scope.Self = true
+		return scope, nil
+	}
+
+	var customRoleIds []int64
+	orgSub := false
+	for _, role := range roles {
+		switch {
+		case role.RoleKey == "super_admin" || role.DataScope == constants.DataScopeAll:
+			scope.All = true
+			return scope, nil
+		case role.DataScope == constants.DataScopeCustom:
+			customRoleIds = append(customRoleIds, role.ID)
+		case role.DataScope == constants.DataScopeOrgAndSub:
+			orgSub = true
+		case role.DataScope == constants.DataScopeOrg:
+			scope.OrgIds = append(scope.OrgIds, orgId)
+		default:
+			scope.Self = true
+		}
+	}
+
+	if orgSub && orgId != 0 {
+		orgIds, err := (&model.Org{}).FindSelfAndDescendantIds(db, orgId)
+		if err != nil {
+			s.logger.Error("查询下级组织失败", zap.Int64("orgId", orgId), zap.Error(err))
+			return nil, fmt.Errorf("查询下级组织失败: %w", err)
+		}
+		scope.OrgIds = append(scope.OrgIds, orgIds...)
+	}
+	if len(customRoleIds) > 0 {
+		orgIds, err := (&model.MRoleOrg{}).FindOrgIdsByRoleIds(db, customRoleIds)
+		if err != nil {
+			s.logger.Error("查询角色自定义数据权限失败", zap.Int64s("roleIds", customRoleIds), zap.Error(err))
+			return nil, fmt.Errorf("查询角色自定义数据权限失败: %w", err)
+		}
+		scope.OrgIds = append(scope.OrgIds, orgIds...)
+	}
+
+	// 去重并剔除无效组织（用户未归属组织时本组织范围为空）
+	slices.Sort(scope.OrgIds)
+	scope.OrgIds = slices.Compact(scope.OrgIds)
+	scope.OrgIds = slices.DeleteFunc(scope.OrgIds, func(id int64) bool { return id == 0 })
+	return scope, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/force-c/nai-tizi/internal/domain/model"
 	"github.com/force-c/nai-tizi/internal/domain/request"
+	"github.com/force-c/nai-tizi/internal/infrastructure/tenant"
 	logging "github.com/force-c/nai-tizi/internal/logger"
 	"github.com/force-c/nai-tizi/internal/utils/pagination"
 	"go.uber.org/zap"
@@ -62,7 +63,7 @@ func NewDictService(db *gorm.DB, logger logging.Logger) DictService {
 // Create 创建字典
 func (s *dictService) Create(ctx context.Context, req *request.CreateDictRequest) error {
 	// 检查字典值是否已存在（同类型下）
-	exists, err := (&model.DictData{}).CheckDictValueExists(s.db, req.DictType, req.DictValue)
+	exists, err := (&model.DictData{}).CheckDictValueExists(s.db.WithContext(ctx), req.DictType, req.DictValue)
 	if err != nil {
 		s.logger.Error("检查字典值失败", zap.Error(err))
 		return fmt.Errorf("检查字典值失败: %w", err)
@@ -73,7 +74,7 @@ func (s *dictService) Create(ctx context.Context, req *request.CreateDictRequest
 
 	// 如果指定了父字典，检查父字典是否存在
 	if req.ParentId > 0 {
-		parent, err := (&model.DictData{}).FindByID(s.db, req.ParentId)
+		parent, err := (&model.DictData{}).FindByID(s.db.WithContext(ctx), req.ParentId)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return fmt.Errorf("父字典不存在")
@@ -102,7 +103,7 @@ func (s *dictService) Create(ctx context.Context, req *request.CreateDictRequest
 		UpdateBy:  req.UpdateBy,
 	}
 
-	if err := dict.Create(s.db); err != nil {
+	if err := dict.Create(s.db.WithContext(ctx)); err != nil {
 		s.logger.Error("创建字典失败", zap.Error(err))
 		return fmt.Errorf("创建字典失败: %w", err)
 	}
@@ -118,7 +119,7 @@ func (s *dictService) Create(ctx context.Context, req *request.CreateDictRequest
 // Update 更新字典
 func (s *dictService) Update(ctx context.Context, req *request.UpdateDictRequest) error {
 	// 检查字典是否存在
-	existingDict, err := (&model.DictData{}).FindByID(s.db, req.ID)
+	existingDict, err := (&model.DictData{}).FindByID(s.db.WithContext(ctx), req.ID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("字典不存在")
@@ -130,7 +131,7 @@ func (s *dictService) Update(ctx context.Context, req *request.UpdateDictRequest
 	// 检查字典值是否被其他字典占用
 	if req.DictValue != existingDict.DictValue {
 		exists, err := (&model.DictData{}).CheckDictValueExistsExcludingSelf(
-			s.db, req.ID, req.DictType, req.DictValue,
+			s.db.WithContext(ctx), req.ID, req.DictType, req.DictValue,
 		)
 		if err != nil {
 			s.logger.Error("检查字典值失败", zap.Error(err))
@@ -148,7 +149,7 @@ func (s *dictService) Update(ctx context.Context, req *request.UpdateDictRequest
 
 	// 如果修改了父字典，检查父字典是否存在
 	if req.ParentId > 0 && req.ParentId != existingDict.ParentId {
-		parent, err := (&model.DictData{}).FindByID(s.db, req.ParentId)
+		parent, err := (&model.DictData{}).FindByID(s.db.WithContext(ctx), req.ParentId)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return fmt.Errorf("父字典不存在")
@@ -176,7 +177,7 @@ func (s *dictService) Update(ctx context.Context, req *request.UpdateDictRequest
 		"update_by":  req.UpdateBy,
 	}
 
-	if err := existingDict.Update(s.db, req.ID, updates); err != nil {
+	if err := existingDict.Update(s.db.WithContext(ctx), req.ID, updates); err != nil {
 		s.logger.Error("更新字典失败", zap.Error(err))
 		return fmt.Errorf("更新字典失败: %w", err)
 	}
@@ -188,7 +189,7 @@ func (s *dictService) Update(ctx context.Context, req *request.UpdateDictRequest
 // Delete 删除字典（级联删除子字典）
 func (s *dictService) Delete(ctx context.Context, id int64) error {
 	// 检查字典是否存在
-	_, err := (&model.DictData{}).FindByID(s.db, id)
+	_, err := (&model.DictData{}).FindByID(s.db.WithContext(ctx), id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("字典不存在")
@@ -200,15 +201,18 @@ func (s *dictService) Delete(ctx context.Context, id int64) error {
 	// 使用递归CTE查询所有需要删除的ID并批量删除
 	sql := `
 		WITH RECURSIVE dict_tree AS (
-			SELECT id FROM s_dict_data WHERE id = ?
+			SELECT id FROM s_dict_data WHERE id = ? AND tenant_id = ?
 			UNION ALL
 			SELECT d.id FROM s_dict_data d
 			INNER JOIN dict_tree dt ON d.parent_id = dt.id
+			WHERE d.tenant_id = ?
 		)
-		DELETE FROM s_dict_data WHERE id IN (SELECT id FROM dict_tree)
+		DELETE FROM s_dict_data WHERE id IN (SELECT id FROM dict_tree) AND tenant_id = ?
 	`
 
-	if err := s.db.Exec(sql, id).Error; err != nil {
+	// 原生 SQL 不经过租户插件，需显式限定租户
+	tenantId := tenant.FromContext(ctx)
+	if err := s.db.WithContext(ctx).Exec(sql, id, tenantId, tenantId, tenantId).Error; err != nil {
 		s.logger.Error("删除字典失败", zap.Error(err))
 		return fmt.Errorf("删除字典失败: %w", err)
 	}
@@ -226,15 +230,18 @@ func (s *dictService) BatchDelete(ctx context.Context, ids []int64) error {
 	// 使用递归CTE查询所有需要删除的ID并批量删除
 	sql := `
 		WITH RECURSIVE dict_tree AS (
-			SELECT id FROM s_dict_data WHERE id = ANY(?)
+			SELECT id FROM s_dict_data WHERE id = ANY(?) AND tenant_id = ?
 			UNION ALL
 			SELECT d.id FROM s_dict_data d
 			INNER JOIN dict_tree dt ON d.parent_id = dt.id
+			WHERE d.tenant_id = ?
 		)
-		DELETE FROM s_dict_data WHERE id IN (SELECT id FROM dict_tree)
+		DELETE FROM s_dict_data WHERE id IN (SELECT id FROM dict_tree) AND tenant_id = ?
 	`
 
-	if err := s.db.Exec(sql, ids).Error; err != nil {
+	// 原生 SQL 不经过租户插件，需显式限定租户
+	tenantId := tenant.FromContext(ctx)
+	if err := s.db.WithContext(ctx).Exec(sql, ids, tenantId, tenantId, tenantId).Error; err != nil {
 		s.logger.Error("批量删除字典失败", zap.Error(err))
 		return fmt.Errorf("批量删除字典失败: %w", err)
 	}
@@ -245,7 +252,7 @@ func (s *dictService) BatchDelete(ctx context.Context, ids []int64) error {
 
 // GetById 根据ID查询字典
 func (s *dictService) GetById(ctx context.Context, id int64) (*model.DictData, error) {
-	dict, err := (&model.DictData{}).FindByID(s.db, id)
+	dict, err := (&model.DictData{}).FindByID(s.db.WithContext(ctx), id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("字典不存在")
@@ -259,7 +266,7 @@ func (s *dictService) GetById(ctx context.Context, id int64) (*model.DictData, e
 // Page 分页查询字典列表
 func (s *dictService) Page(ctx context.Context, req *request.PageDictRequest) (*pagination.Page[model.DictData], error) {
 	// 构建查询条件
-	query := s.db.Model(&model.DictData{})
+	query := s.db.WithContext(ctx).Model(&model.DictData{})
 
 	// 仅查询顶级字典（parent_id = 0 或 NULL）
 	query = query.Where("parent_id = 0 OR parent_id IS NULL")
@@ -296,7 +303,7 @@ func (s *dictService) Page(ctx context.Context, req *request.PageDictRequest) (*
 
 // GetByType 根据字典类型获取字典列表
 func (s *dictService) GetByType(ctx context.Context, dictType string) ([]model.DictData, error) {
-	dicts, err := (&model.DictData{}).FindByType(s.db, dictType)
+	dicts, err := (&model.DictData{}).FindByType(s.db.WithContext(ctx), dictType)
 	if err != nil {
 		s.logger.Error("查询字典列表失败", zap.Error(err))
 		return nil, fmt.Errorf("查询字典列表失败: %w", err)
@@ -306,7 +313,7 @@ func (s *dictService) GetByType(ctx context.Context, dictType string) ([]model.D
 
 // GetByTypeAndParent 根据字典类型和父ID获取子字典列表
 func (s *dictService) GetByTypeAndParent(ctx context.Context, dictType string, parentId int64) ([]model.DictData, error) {
-	dicts, err := (&model.DictData{}).FindByTypeAndParent(s.db, dictType, parentId)
+	dicts, err := (&model.DictData{}).FindByTypeAndParent(s.db.WithContext(ctx), dictType, parentId)
 	if err != nil {
 		s.logger.Error("查询子字典列表失败", zap.Error(err))
 		return nil, fmt.Errorf("查询子字典列表失败: %w", err)
@@ -316,7 +323,7 @@ func (s *dictService) GetByTypeAndParent(ctx context.Context, dictType string, p
 
 // GetDictLabel 根据字典类型和键值获取标签
 func (s *dictService) GetDictLabel(ctx context.Context, dictType, dictValue string) (string, error) {
-	label, err := (&model.DictData{}).GetDictLabel(s.db, dictType, dictValue)
+	label, err := (&model.DictData{}).GetDictLabel(s.db.WithContext(ctx), dictType, dictValue)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return "", fmt.Errorf("字典不存在")
@@ -328,7 +335,7 @@ func (s *dictService) GetDictLabel(ctx context.Context, dictType, dictValue stri
 
 // GetDictValue 根据字典类型和标签获取键值
 func (s *dictService) GetDictValue(ctx context.Context, dictType, dictLabel string) (string, error) {
-	value, err := (&model.DictData{}).GetDictValue(s.db, dictType, dictLabel)
+	value, err := (&model.DictData{}).GetDictValue(s.db.WithContext(ctx), dictType, dictLabel)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return "", fmt.Errorf("字典不存在")
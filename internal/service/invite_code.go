@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/domain/request"
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/force-c/nai-tizi/internal/utils/pagination"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// inviteCodeAlphabet 邀请码字符集，去掉了易混淆的 0/O/1/I
+	inviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	inviteCodeLength   = 8
+)
+
+// InviteCodeService 注册邀请码管理服务，邀请码按租户隔离
+type InviteCodeService interface {
+	// Page 分页查询邀请码
+	Page(ctx context.Context, req *request.PageInviteCodeRequest) (*pagination.Page[model.InviteCode], error)
+
+	// Create 批量生成邀请码
+	Create(ctx context.Context, req *request.CreateInviteCodeRequest, operator int64) ([]model.InviteCode, error)
+
+	// Update 更新邀请码的默认角色、使用次数、有效期和状态（组织不可修改）
+	Update(ctx context.Context, id int64, req *request.UpdateInviteCodeRequest, operator int64) error
+
+	// Delete 删除邀请码，已注册的用户不受影响
+	Delete(ctx context.Context, id int64) error
+}
+
+type inviteCodeService struct {
+	db     *gorm.DB
+	logger logging.Logger
+}
+
+func NewInviteCodeService(db *gorm.DB, logger logging.Logger) InviteCodeService {
+	return &inviteCodeService{db: db, logger: logger}
+}
+
+// Page 分页查询邀请码
+func (s *inviteCodeService) Page(ctx context.Context, req *request.PageInviteCodeRequest) (*pagination.Page[model.InviteCode], error) {
+	q := s.db.WithContext(ctx).Model(&model.InviteCode{}).Where("tenant_id = ?", tenantIdFromContext(ctx))
+	if code := strings.TrimSpace(req.Code); code != "" {
+		q = q.Where("code LIKE ?", "%"+strings.ToUpper(code)+"%")
+	}
+	if req.OrgId > 0 {
+		q = q.Where("org_id = ?", req.OrgId)
+	}
+	if req.Status != nil {
+		q = q.Where("status = ?", *req.Status)
+	}
+	if req.OrderByColumn == "" {
+		q = q.Order("created_time DESC")
+	}
+	page, err := pagination.New[model.InviteCode](q, &req.PageQuery).Find()
+	if err != nil {
+		s.logger.Error("分页查询邀请码失败", zap.Error(err))
+		return nil, fmt.Errorf("查询失败")
+	}
+	return page, nil
+}
+
+// Create 批量生成邀请码
+func (s *inviteCodeService) Create(ctx context.Context, req *request.CreateInviteCodeRequest, operator int64) ([]model.InviteCode, error) {
+	db := s.db.WithContext(ctx)
+	org, err := (&model.Org{}).FindByID(db, req.OrgId)
+	if err != nil {
+		return nil, fmt.Errorf("组织不存在")
+	}
+	if !org.IsActive() {
+		return nil, fmt.Errorf("组织已停用")
+	}
+	if err := s.checkRoles(ctx, req.RoleIds); err != nil {
+		return nil, err
+	}
+	if req.ExpireAt != 0 && req.ExpireAt <= time.Now().Unix() {
+		return nil, fmt.Errorf("过期时间必须晚于当前时间")
+	}
+
+	count := req.Count
+	if count <= 0 {
+		count = 1
+	}
+	codes := make([]model.InviteCode, 0, count)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for i := 0; i < count; i++ {
+			code, err := generateInviteCode()
+			if err != nil {
+				return err
+			}
+			invite := model.InviteCode{
+				TenantID: tenantIdFromContext(ctx),
+				Code:     code,
+				OrgId:    req.OrgId,
+				MaxUses:  req.MaxUses,
+				ExpireAt: req.ExpireAt,
+				Remark:   req.Remark,
+				CreateBy: operator,
+				UpdateBy: operator,
+			}
+			invite.SetRoleIds(req.RoleIds)
+			if err := invite.Create(tx); err != nil {
+				return err
+			}
+			codes = append(codes, invite)
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("生成邀请码失败", zap.Error(err))
+		return nil, fmt.Errorf("生成邀请码失败")
+	}
+	s.logger.Info("生成注册邀请码", zap.Int64("orgId", req.OrgId), zap.Int("count", count), zap.Int64("operator", operator))
+	return codes, nil
+}
+
+// Update 更新邀请码
+func (s *inviteCodeService) Update(ctx context.Context, id int64, req *request.UpdateInviteCodeRequest, operator int64) error {
+	if _, err := s.find(ctx, id); err != nil {
+		return err
+	}
+	if err := s.checkRoles(ctx, req.RoleIds); err != nil {
+		return err
+	}
+
+	invite := &model.InviteCode{}
+	invite.SetRoleIds(req.RoleIds)
+	err := invite.Update(s.db.WithContext(ctx), id, map[string]any{
+		"role_ids":  invite.RoleIds,
+		"max_uses":  req.MaxUses,
+		"expire_at": req.ExpireAt,
+		"status":    req.Status,
+		"remark":    req.Remark,
+		"update_by": operator,
+	})
+	if err != nil {
+		s.logger.Error("更新邀请码失败", zap.Int64("id", id), zap.Error(err))
+		return fmt.Errorf("更新邀请码失败")
+	}
+	return nil
+}
+
+// Delete 删除邀请码
+func (s *inviteCodeService) Delete(ctx context.Context, id int64) error {
+	if _, err := s.find(ctx, id); err != nil {
+		return err
+	}
+	if _, err := (&model.InviteCode{}).Delete(s.db.WithContext(ctx), id); err != nil {
+		s.logger.Error("删除邀请码失败", zap.Int64("id", id), zap.Error(err))
+		return fmt.Errorf("删除邀请码失败")
+	}
+	return nil
+}
+
+// find 查询当前租户的邀请码
+func (s *inviteCodeService) find(ctx context.Context, id int64) (*model.InviteCode, error) {
+	invite, err := (&model.InviteCode{}).FindByID(s.db.WithContext(ctx), id)
+	if err != nil || invite.TenantID != tenantIdFromContext(ctx) {
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Error("查询邀请码失败", zap.Int64("id", id), zap.Error(err))
+		}
+		return nil, fmt.Errorf("邀请码不存在")
+	}
+	return invite, nil
+}
+
+// checkRoles 校验默认角色存在且属于当前租户
+func (s *inviteCodeService) checkRoles(ctx context.Context, roleIds []int64) error {
+	if len(roleIds) == 0 {
+		return nil
+	}
+	var count int64
+	err := s.db.WithContext(ctx).Model(&model.Role{}).
+		Where("id IN ? AND tenant_id = ?", roleIds, tenantIdFromContext(ctx)).
+		Count(&count).Error
+	if err != nil {
+		s.logger.Error("查询角色失败", zap.Error(err))
+		return fmt.Errorf("查询角色失败")
+	}
+	if int(count) != len(roleIds) {
+		return fmt.Errorf("角色不存在")
+	}
+	return nil
+}
+
+// generateInviteCode 生成随机邀请码
+func generateInviteCode() (string, error) {
+	buf := make([]byte, inviteCodeLength)
+	max := big.NewInt(int64(len(inviteCodeAlphabet)))
+	for i := range buf {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		buf[i] = inviteCodeAlphabet[n.Int64()]
+	}
+	return string(buf), nil
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/force-c/nai-tizi/internal/config"
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/infrastructure/jwt"
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	jwtKeyLockKey = "jwt_key:rotate_lock"
+	jwtKeyLockTTL = 30 * time.Second
+
+	// jwtKeyPropagationDelay 新密钥生成后延迟启用签名，确保所有实例（每分钟重新加载）先获得其公钥
+	jwtKeyPropagationDelay = 5 * time.Minute
+)
+
+// JwtKeyService 数据库存储的 JWT 签名密钥管理（jwt.keySource=database）
+type JwtKeyService interface {
+	// Load 从数据库加载未过期的密钥到密钥环，没有当前算法的密钥时生成一个
+	Load(ctx context.Context) error
+
+	// RotateIfDue 最新密钥超过轮换周期（或算法已变更）时生成新密钥，并为旧密钥设置过期时间
+	RotateIfDue(ctx context.Context) (bool, error)
+}
+
+type jwtKeyService struct {
+	db     *gorm.DB
+	redis  *redis.Client
+	jwt    *jwt.Jwt
+	config *config.Config
+	logger logging.Logger
+}
+
+// NewJwtKeyService 创建 JWT 密钥管理服务实例
+func NewJwtKeyService(db *gorm.DB, rdb *redis.Client, j *jwt.Jwt, cfg *config.Config, logger logging.Logger) JwtKeyService {
+	return &jwtKeyService{db: db, redis: rdb, jwt: j, config: cfg, logger: logger}
+}
+
+// Load 加载密钥：启用时间不晚于当前时间的最新密钥用于签名，所有未过期密钥用于验证
+func (s *jwtKeyService) Load(ctx context.Context) error {
+	var km model.JwtKey
+	rows, err := km.FindValid(s.db.WithContext(ctx), time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("查询 JWT 密钥失败: %w", err)
+	}
+	if !hasJwtKeyAlgorithm(rows, s.config.JWT.Algorithm) {
+		if err := s.bootstrap(ctx); err != nil {
+			return err
+		}
+		if rows, err = km.FindValid(s.db.WithContext(ctx), time.Now().Unix()); err != nil {
+			return fmt.Errorf("查询 JWT 密钥失败: %w", err)
+		}
+	}
+
+	now := time.Now().Unix()
+	keys := make([]*jwt.Key, 0, len(rows))
+	activeKid := ""
+	for _, row := range rows {
+		key, err := jwt.ParsePrivateKeyPEM(row.Kid, row.Algorithm, []byte(row.PrivateKey))
+		if err != nil {
+			s.logger.Error("解析 JWT 密钥失败，已跳过", zap.String("kid", row.Kid), zap.Error(err))
+			continue
+		}
+		if row.ExpireAt > 0 {
+			key.ExpireAt = time.Unix(row.ExpireAt, 0)
+		}
+		keys = append(keys, key)
+		if row.ActivateAt <= now {
+			activeKid = row.Kid
+		}
+	}
+	if activeKid == "" {
+		return fmt.Errorf("没有可用于签名的 JWT 密钥")
+	}
+
+	previous := s.jwt.Keyring().ActiveKid()
+	if err := s.jwt.Keyring().Replace(keys, activeKid); err != nil {
+		return fmt.Errorf("加载 JWT 密钥失败: %w", err)
+	}
+	if previous != activeKid {
+		s.logger.Info("JWT 签名密钥已切换", zap.String("kid", activeKid), zap.Int("keys", len(keys)))
+	}
+	return nil
+}
+
+// RotateIfDue 按轮换周期生成新密钥
+func (s *jwtKeyService) RotateIfDue(ctx context.Context) (bool, error) {
+	if !s.config.JWT.Rotation.Enabled {
+		return false, nil
+	}
+
+	ok, err := s.redis.SetNX(ctx, jwtKeyLockKey, 1, jwtKeyLockTTL).Result()
+	if err != nil {
+		return false, fmt.Errorf("获取轮换锁失败: %w", err)
+	}
+	if !ok {
+		return false, nil
+	}
+	defer s.redis.Del(context.Background(), jwtKeyLockKey)
+
+	db := s.db.WithContext(ctx)
+	now := time.Now()
+	var km model.JwtKey
+	rows, err := km.FindValid(db, now.Unix())
+	if err != nil {
+		return false, fmt.Errorf("查询 JWT 密钥失败: %w", err)
+	}
+	interval := time.Duration(s.config.JWT.Rotation.IntervalHours) * time.Hour
+	if n := len(rows); n > 0 {
+		latest := rows[n-1]
+		if latest.Algorithm == s.config.JWT.Algorithm && now.Sub(time.Unix(latest.ActivateAt, 0)) < interval {
+			return false, nil
+		}
+	}
+
+	activateAt := now.Add(jwtKeyPropagationDelay)
+	overlap := time.Duration(s.config.JWT.Rotation.OverlapHours) * time.Hour
+	row, err := s.generate(activateAt)
+	if err != nil {
+		return false, err
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := row.Create(tx); err != nil {
+			return err
+		}
+		return km.ExpireBefore(tx, row.ActivateAt, activateAt.Add(overlap).Unix())
+	})
+	if err != nil {
+		return false, fmt.Errorf("保存 JWT 密钥失败: %w", err)
+	}
+	if purged, err := km.DeleteExpired(db, now.Unix()); err != nil {
+		s.logger.Warn("清理过期 JWT 密钥失败", zap.Error(err))
+	} else if purged > 0 {
+		s.logger.Info("已清理过期 JWT 密钥", zap.Int64("count", purged))
+	}
+
+	s.logger.Info("已生成新的 JWT 签名密钥",
+		zap.String("kid", row.Kid),
+		zap.String("algorithm", row.Algorithm),
+		zap.Time("activateAt", activateAt))
+	return true, nil
+}
+
+// bootstrap 首次启动或算法变更时生成密钥并立即启用，多实例同时启动时只有持有锁的实例生成
+func (s *jwtKeyService) bootstrap(ctx context.Context) error {
+	for attempt := 0; attempt < 10; attempt++ {
+		ok, err := s.redis.SetNX(ctx, jwtKeyLockKey, 1, jwtKeyLockTTL).Result()
+		if err != nil {
+			return fmt.Errorf("获取轮换锁失败: %w", err)
+		}
+		if !ok {
+			time.Sleep(time.Second)
+			var km model.JwtKey
+			rows, err := km.FindValid(s.db.WithContext(ctx), time.Now().Unix())
+			if err == nil && hasJwtKeyAlgorithm(rows, s.config.JWT.Algorithm) {
+				return nil
+			}
+			continue
+		}
+
+		defer s.redis.Del(context.Background(), jwtKeyLockKey)
+		now := time.Now()
+		row, err := s.generate(now)
+		if err != nil {
+			return err
+		}
+		// 其他算法的旧密钥在重叠期内继续用于验证
+		overlap := time.Duration(s.config.JWT.Rotation.OverlapHours) * time.Hour
+		err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := row.Create(tx); err != nil {
+				return err
+			}
+			return row.ExpireBefore(tx, row.ActivateAt, now.Add(overlap).Unix())
+		})
+		if err != nil {
+			return fmt.Errorf("保存 JWT 密钥失败: %w", err)
+		}
+		s.logger.Info("已生成初始 JWT 签名密钥", zap.String("kid", row.Kid), zap.String("algorithm", row.Algorithm))
+		return nil
+	}
+	return fmt.Errorf("等待其他实例生成 JWT 密钥超时")
+}
+
+// generate 按配置的算法生成密钥记录
+func (s *jwtKeyService) generate(activateAt time.Time) (*model.JwtKey, error) {
+	pem, err := jwt.GenerateKey(s.config.JWT.Algorithm)
+	if err != nil {
+		return nil, fmt.Errorf("生成 JWT 密钥失败: %w", err)
+	}
+	return &model.JwtKey{
+		Kid:        activateAt.Format("20060102") + "-" + uuid.NewString()[:8],
+		Algorithm:  s.config.JWT.Algorithm,
+		PrivateKey: string(pem),
+		ActivateAt: activateAt.Unix(),
+	}, nil
+}
+
+func hasJwtKeyAlgorithm(rows []model.JwtKey, alg string) bool {
+	for _, row := range rows {
+		if row.Algorithm == alg {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/force-c/nai-tizi/internal/config"
+	"github.com/force-c/nai-tizi/internal/constants"
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/infrastructure/thirdparty/ldap"
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// LdapIdentityProvider LDAP 目录账号在绑定表中的提供方标识
+const LdapIdentityProvider = "ldap"
+
+// LdapService LDAP / Active Directory 登录服务接口
+type LdapService interface {
+	// Authenticate 校验目录账号密码，按目录属性创建或更新系统用户并同步组角色
+	Authenticate(ctx context.Context, username, password string) (*model.User, error)
+
+	// SyncUsers 同步所有目录账号：更新属性和组角色，停用目录中已删除的用户
+	SyncUsers(ctx context.Context) (*LdapSyncResult, error)
+}
+
+// LdapSyncResult 同步结果
+type LdapSyncResult struct {
+	Total    int // 目录账号总数
+	Updated  int // 已更新
+	Disabled int // 已停用
+}
+
+type ldapService struct {
+	db            *gorm.DB
+	manager       *ldap.Manager
+	casbinService CasbinServiceV2
+	tokenManager  TokenManager
+	config        *config.Config
+	logger        logging.Logger
+}
+
+// NewLdapService 创建 LDAP 登录服务实例，manager 为空表示未启用
+func NewLdapService(db *gorm.DB, manager *ldap.Manager, casbinService CasbinServiceV2, tokenManager TokenManager, cfg *config.Config, logger logging.Logger) LdapService {
+	return &ldapService{
+		db:            db,
+		manager:       manager,
+		casbinService: casbinService,
+		tokenManager:  tokenManager,
+		config:        cfg,
+		logger:        logger,
+	}
+}
+
+// Authenticate 校验目录账号密码
+func (s *ldapService) Authenticate(ctx context.Context, username, password string) (*model.User, error) {
+	if s.manager == nil {
+		return nil, fmt.Errorf("未启用 LDAP 登录")
+	}
+
+	entry, err := s.manager.Authenticate(ctx, username, password)
+	if err != nil {
+		if errors.Is(err, ldap.ErrInvalidCredentials) || errors.Is(err, ldap.ErrUserNotFound) {
+			return nil, fmt.Errorf("用户名或密码错误")
+		}
+		s.logger.Error("LDAP 认证失败", zap.String("username", username), zap.Error(err))
+		return nil, fmt.Errorf("目录服务暂不可用，请稍后再试")
+	}
+
+	user, err := s.provision(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	s.syncRoles(ctx, user.ID, entry.Groups)
+	return user, nil
+}
+
+// SyncUsers 同步所有目录账号
+// 目录查询出现非"用户不存在"的错误时立即中止，避免目录服务故障导致批量停用
+func (s *ldapService) SyncUsers(ctx context.Context) (*LdapSyncResult, error) {
+	if s.manager == nil {
+		return nil, fmt.Errorf("未启用 LDAP 登录")
+	}
+
+	var im model.UserIdentity
+	identities, err := im.FindByProvider(s.db.WithContext(ctx), LdapIdentityProvider)
+	if err != nil {
+		return nil, fmt.Errorf("查询目录账号失败: %w", err)
+	}
+
+	result := &LdapSyncResult{Total: len(identities)}
+	for _, identity := range identities {
+		entry, err := s.manager.Lookup(ctx, identity.Subject)
+		if errors.Is(err, ldap.ErrUserNotFound) {
+			if s.disable(ctx, identity.UserId) {
+				result.Disabled++
+			}
+			continue
+		}
+		if err != nil {
+			return result, fmt.Errorf("查询目录用户 %s 失败: %w", identity.Subject, err)
+		}
+
+		user, err := (&model.User{}).FindByID(s.db.WithContext(ctx), identity.UserId)
+		if err != nil {
+			continue
+		}
+		if err := s.updateUser(ctx, user, entry); err != nil {
+			s.logger.Warn("同步目录用户属性失败", zap.Int64("userId", user.ID), zap.Error(err))
+			continue
+		}
+		s.syncRoles(ctx, user.ID, entry.Groups)
+		result.Updated++
+	}
+
+	s.logger.Info("LDAP 用户同步完成",
+		zap.Int("total", result.Total),
+		zap.Int("updated", result.Updated),
+		zap.Int("disabled", result.Disabled))
+	return result, nil
+}
+
+// provision 按目录条目匹配系统用户，不存在时创建（JIT），存在时更新属性
+func (s *ldapService) provision(ctx context.Context, entry *ldap.Entry) (*model.User, error) {
+	db := s.db.WithContext(ctx)
+	subject := strings.ToLower(entry.Username)
+
+	var (
+		im model.UserIdentity
+		um model.User
+	)
+	linked, err := im.FindBySubject(db, LdapIdentityProvider, subject)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		s.logger.Error("查询目录账号绑定失败", zap.String("username", entry.Username), zap.Error(err))
+		return nil, fmt.Errorf("登录失败")
+	}
+	if linked != nil {
+		user, err := um.FindByID(db, linked.UserId)
+		if err == nil {
+			if err := s.updateUser(ctx, user, entry); err != nil {
+				s.logger.Warn("更新目录用户属性失败", zap.Int64("userId", user.ID), zap.Error(err))
+			}
+			_ = im.TouchLogin(db, linked.ID, time.Now().Unix())
+			return user, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("登录失败")
+		}
+		// 绑定的用户已被删除，清理失效的绑定后重新创建
+		if err := im.DeleteById(db, linked.ID); err != nil {
+			return nil, fmt.Errorf("登录失败")
+		}
+	}
+
+	record := &model.UserIdentity{
+		Provider:    LdapIdentityProvider,
+		Subject:     subject,
+		Email:       entry.Email,
+		Nickname:    truncateRunes(entry.Nickname, 64),
+		LastLoginAt: time.Now().Unix(),
+	}
+
+	existing, err := um.FindByUsername(db, entry.Username)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("登录失败")
+	}
+	if existing != nil {
+		if !s.config.LDAP.BindExisting {
+			s.logger.Warn("目录账号与本地账号同名，已拒绝登录", zap.String("username", entry.Username))
+			return nil, fmt.Errorf("用户名已被本地账号占用，请联系管理员")
+		}
+		record.UserId = existing.ID
+		if err := record.Create(db); err != nil {
+			s.logger.Error("关联目录账号失败", zap.Int64("userId", existing.ID), zap.Error(err))
+			return nil, fmt.Errorf("登录失败")
+		}
+		if err := s.updateUser(ctx, existing, entry); err != nil {
+			s.logger.Warn("更新目录用户属性失败", zap.Int64("userId", existing.ID), zap.Error(err))
+		}
+		s.logger.Info("本地账号已关联目录账号", zap.Int64("userId", existing.ID), zap.String("dn", entry.DN))
+		return existing, nil
+	}
+
+	user := &model.User{
+		OrgId:    s.resolveOrgId(ctx, entry.Org),
+		UserName: entry.Username,
+		NickName: truncateRunes(entry.Nickname, 64),
+		UserType: constants.UserTypeSystem,
+		Status:   constants.StatusNormal,
+		Remark:   "LDAP 目录账号",
+	}
+	if user.NickName == "" {
+		user.NickName = entry.Username
+	}
+	user.Email, user.Phonenumber = s.availableContacts(ctx, 0, entry.Email, entry.Phone)
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := um.Create(tx, user); err != nil {
+			return err
+		}
+		record.UserId = user.ID
+		return record.Create(tx)
+	})
+	if err != nil {
+		s.logger.Error("创建目录用户失败", zap.String("username", entry.Username), zap.Error(err))
+		return nil, fmt.Errorf("创建用户失败")
+	}
+
+	s.logger.Info("创建目录用户", zap.Int64("userId", user.ID), zap.String("dn", entry.DN))
+	return user, nil
+}
+
+// updateUser 按目录属性更新昵称、联系方式和组织，仅写入有变化的字段
+func (s *ldapService) updateUser(ctx context.Context, user *model.User, entry *ldap.Entry) error {
+	updates := make(map[string]any)
+	if nickname := truncateRunes(entry.Nickname, 64); nickname != "" && nickname != user.NickName {
+		updates["nick_name"] = nickname
+		user.NickName = nickname
+	}
+	email, phone := s.availableContacts(ctx, user.ID, entry.Email, entry.Phone)
+	if email != "" && email != user.Email {
+		updates["email"] = email
+		user.Email = email
+	}
+	if phone != "" && phone != user.Phonenumber {
+		updates["phonenumber"] = phone
+		user.Phonenumber = phone
+	}
+	if s.config.LDAP.Attributes.Org != "" {
+		if orgId := s.resolveOrgId(ctx, entry.Org); orgId != user.OrgId {
+			updates["org_id"] = orgId
+			user.OrgId = orgId
+		}
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+	return user.Update(s.db.WithContext(ctx), user.ID, updates)
+}
+
+// availableContacts 过滤已被其他用户占用的邮箱和手机号
+func (s *ldapService) availableContacts(ctx context.Context, userId int64, email, phone string) (string, string) {
+	var um model.User
+	if email != "" {
+		if u, err := um.FindByEmail(s.db.WithContext(ctx), email); err == nil && u.ID != userId {
+			email = ""
+		}
+	}
+	if phone != "" {
+		if u, err := um.FindByPhonenumber(s.db.WithContext(ctx), phone); err == nil && u.ID != userId {
+			phone = ""
+		}
+	}
+	return email, phone
+}
+
+// resolveOrgId 按组织属性值匹配组织编码，未匹配时使用默认组织
+func (s *ldapService) resolveOrgId(ctx context.Context, orgCode string) int64 {
+	if orgCode != "" {
+		if org, err := (&model.Org{}).FindByOrgCode(s.db.WithContext(ctx), orgCode); err == nil {
+			return org.ID
+		}
+	}
+	return s.config.LDAP.DefaultOrgId
+}
+
+// syncRoles 按组映射授予或收回角色，未出现在映射中的角色不受影响
+func (s *ldapService) syncRoles(ctx context.Context, userId int64, groups []string) {
+	desired := make(map[string]bool)
+	for _, mapping := range s.config.LDAP.GroupMappings {
+		if _, ok := desired[mapping.RoleKey]; !ok {
+			desired[mapping.RoleKey] = false
+		}
+		for _, group := range groups {
+			if ldap.MatchGroup(group, mapping.Group) {
+				desired[mapping.RoleKey] = true
+				break
+			}
+		}
+	}
+
+	for roleKey, granted := range desired {
+		var err error
+		if granted {
+			err = s.casbinService.AddRoleForUser(ctx, userId, roleKey)
+		} else {
+			err = s.casbinService.DeleteRoleForUser(ctx, userId, roleKey)
+		}
+		if err != nil {
+			s.logger.Warn("同步目录组角色失败",
+				zap.Int64("userId", userId),
+				zap.String("roleKey", roleKey),
+				zap.Bool("granted", granted),
+				zap.Error(err))
+		}
+	}
+}
+
+// disable 停用目录中已删除的用户并吊销其 Token，返回是否执行了停用
+func (s *ldapService) disable(ctx context.Context, userId int64) bool {
+	user, err := (&model.User{}).FindByID(s.db.WithContext(ctx), userId)
+	if err != nil || user.Status != constants.StatusNormal {
+		return false
+	}
+	if err := user.Update(s.db.WithContext(ctx), userId, map[string]any{"status": constants.StatusDisabled}); err != nil {
+		s.logger.Error("停用目录用户失败", zap.Int64("userId", userId), zap.Error(err))
+		return false
+	}
+	if err := s.tokenManager.RevokeUserTokens(ctx, userId); err != nil {
+		s.logger.Warn("吊销已停用目录用户的 Token 失败", zap.Int64("userId", userId), zap.Error(err))
+	}
+	s.logger.Info("目录中已删除，停用用户", zap.Int64("userId", userId), zap.String("username", user.UserName))
+	return true
+}
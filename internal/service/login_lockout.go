@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/force-c/nai-tizi/internal/config"
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/infrastructure/tenant"
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/force-c/nai-tizi/internal/utils"
+	"github.com/force-c/nai-tizi/internal/utils/idgen"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// 登录锁定维度
+const (
+	LockScopeAccount   = "account"    // 账号（不区分来源 IP）
+	LockScopeIpAccount = "ip_account" // IP + 账号
+	LockScopeIp        = "ip"         // IP（不区分账号，防撞库）
+)
+
+// 各租户的账号相互独立，计数、锁定和索引都按租户隔离
+const (
+	// LoginFailKeyPrefix 登录失败计数 Redis Key 前缀
+	// login_fail:{tenantId}:{scope}:{subject} -> 窗口内失败次数
+	LoginFailKeyPrefix = "login_fail:"
+
+	// LoginLockKeyPrefix 登录锁定 Redis Key 前缀，过期即自动解锁
+	// login_lock:{tenantId}:{scope}:{subject} -> Hash{account, ip, level, lockedAt, until}
+	LoginLockKeyPrefix = "login_lock:"
+
+	// LoginLockLevelKeyPrefix 锁定级别 Redis Key 前缀，用于逐级延长锁定时长
+	// login_lock_level:{tenantId}:{scope}:{subject} -> 已锁定次数
+	LoginLockLevelKeyPrefix = "login_lock_level:"
+
+	// LoginLockIndexKeyPrefix 锁定记录索引 Redis Key 前缀，供管理端查询
+	// login_lock_index:{tenantId} -> ZSET{member={scope}:{subject}, score=解锁时间}
+	LoginLockIndexKeyPrefix = "login_lock_index:"
+)
+
+// LoginLock 登录锁定记录
+type LoginLock struct {
+	Scope    string `json:"scope"`    // 锁定维度：account / ip_account / ip
+	Subject  string `json:"subject"`  // 锁定对象：账号、IP|账号 或 IP
+	Account  string `json:"account"`  // 触发锁定的账号
+	Ip       string `json:"ip"`       // 触发锁定的 IP
+	Level    int    `json:"level"`    // 锁定级别（第几次锁定）
+	LockedAt int64  `json:"lockedAt"` // 锁定时间（时间戳）
+	Until    int64  `json:"until"`    // 解锁时间（时间戳）
+}
+
+// LoginLockoutService 登录失败锁定服务
+// 所有登录方式共用：登录前调用 Check，凭证错误时调用 RecordFailure，成功后调用 RecordSuccess
+type LoginLockoutService interface {
+	// Check 检查账号、IP + 账号、IP 是否处于锁定状态
+	Check(ctx context.Context, account, ip string) error
+
+	// RecordFailure 记录一次失败，达到阈值时锁定并写入登录日志
+	RecordFailure(ctx context.Context, account, ip string)
+
+	// RecordSuccess 登录成功后清除账号相关计数；IP 计数自然过期，避免用自己的账号重置撞库限制
+	RecordSuccess(ctx context.Context, account, ip string)
+
+	// List 查询当前租户的所有锁定记录
+	List(ctx context.Context) ([]*LoginLock, error)
+
+	// Unlock 解除当前租户的锁定，同时清除失败计数和锁定级别
+	Unlock(ctx context.Context, scope, subject string) error
+}
+
+type loginLockoutService struct {
+	db     *gorm.DB
+	redis  *redis.Client
+	config config.LoginLockout
+	logger logging.Logger
+}
+
+// NewLoginLockoutService 创建登录锁定服务实例
+func NewLoginLockoutService(db *gorm.DB, rdb *redis.Client, cfg *config.Config, logger logging.Logger) LoginLockoutService {
+	return &loginLockoutService{db: db, redis: rdb, config: cfg.Auth.Lockout, logger: logger}
+}
+
+// lockTarget 一个统计维度
+type lockTarget struct {
+	scope     string
+	subject   string
+	threshold int
+}
+
+func (s *loginLockoutService) targets(account, ip string) []lockTarget {
+	account = strings.TrimSpace(account)
+	targets := make([]lockTarget, 0, 3)
+	if account != "" {
+		targets = append(targets, lockTarget{LockScopeAccount, account, s.config.AccountThreshold})
+	}
+	if account != "" && ip != "" {
+		targets = append(targets, lockTarget{LockScopeIpAccount, ip + "|" + account, s.config.IpAccountThreshold})
+	}
+	if ip != "" {
+		targets = append(targets, lockTarget{LockScopeIp, ip, s.config.IpThreshold})
+	}
+	return targets
+}
+
+// tenantKey 在 Redis Key 前缀后拼接当前租户ID
+func lockoutKey(ctx context.Context, prefix string) string {
+	return prefix + strconv.FormatInt(tenant.FromContext(ctx), 10) + ":"
+}
+
+// Check 检查锁定状态
+func (s *loginLockoutService) Check(ctx context.Context, account, ip string) error {
+	for _, t := range s.targets(account, ip) {
+		ttl, err := s.redis.TTL(ctx, lockoutKey(ctx, LoginLockKeyPrefix)+t.scope+":"+t.subject).Result()
+		if err != nil {
+			s.logger.Warn("failed to check login lock", zap.Error(err))
+			continue
+		}
+		if ttl > 0 {
+			return fmt.Errorf("登录失败次数过多，请%d分钟后再试", int(ttl.Minutes())+1)
+		}
+	}
+	return nil
+}
+
+// RecordFailure 记录失败
+func (s *loginLockoutService) RecordFailure(ctx context.Context, account, ip string) {
+	window := time.Duration(s.config.Window) * time.Second
+	for _, t := range s.targets(account, ip) {
+		key := lockoutKey(ctx, LoginFailKeyPrefix) + t.scope + ":" + t.subject
+		count, err := s.redis.Incr(ctx, key).Result()
+		if err != nil {
+			s.logger.Warn("failed to record login failure", zap.Error(err))
+			continue
+		}
+		if count == 1 {
+			_ = s.redis.Expire(ctx, key, window).Err()
+		}
+		if count >= int64(t.threshold) {
+			s.lock(ctx, t, account, ip, count)
+		}
+	}
+}
+
+// lock 锁定并按级别计算时长
+func (s *loginLockoutService) lock(ctx context.Context, t lockTarget, account, ip string, failures int64) {
+	member := t.scope + ":" + t.subject
+	levelKey := lockoutKey(ctx, LoginLockLevelKeyPrefix) + member
+	level, err := s.redis.Incr(ctx, levelKey).Result()
+	if err != nil {
+		s.logger.Warn("failed to lock login", zap.Error(err))
+		return
+	}
+	_ = s.redis.Expire(ctx, levelKey, time.Duration(s.config.LevelReset)*time.Second).Err()
+
+	idx := int(level) - 1
+	if idx >= len(s.config.Durations) {
+		idx = len(s.config.Durations) - 1
+	}
+	duration := time.Duration(s.config.Durations[idx]) * time.Second
+	now := time.Now()
+	until := now.Add(duration)
+
+	lockKey := lockoutKey(ctx, LoginLockKeyPrefix) + member
+	pipe := s.redis.TxPipeline()
+	pipe.Del(ctx, lockKey, lockoutKey(ctx, LoginFailKeyPrefix)+member)
+	pipe.HSet(ctx, lockKey,
+		"account", account,
+		"ip", ip,
+		"level", level,
+		"lockedAt", now.Unix(),
+		"until", until.Unix())
+	pipe.Expire(ctx, lockKey, duration)
+	pipe.ZAdd(ctx, lockoutKey(ctx, LoginLockIndexKeyPrefix), redis.Z{Score: float64(until.Unix()), Member: member})
+	if _, err := pipe.Exec(ctx); err != nil {
+		s.logger.Warn("failed to lock login", zap.Error(err))
+		return
+	}
+
+	s.logger.Warn("登录失败次数过多，已锁定",
+		zap.String("scope", t.scope),
+		zap.String("account", account),
+		zap.String("ip", ip),
+		zap.Int64("level", level),
+		zap.Duration("duration", duration))
+
+	scopeNames := map[string]string{
+		LockScopeAccount:   "账号",
+		LockScopeIpAccount: "IP+账号",
+		LockScopeIp:        "IP",
+	}
+	logEntry := &model.LoginLog{
+		ID:        idgen.MustNextID(),
+		UserName:  account,
+		Ipaddr:    ip,
+		Status:    1,
+		Msg:       fmt.Sprintf("%s已锁定%d分钟（连续失败%d次，第%d次锁定）", scopeNames[t.scope], int(duration.Minutes()), failures, level),
+		LoginTime: utils.Now(),
+	}
+	if err := logEntry.Create(s.db.WithContext(ctx)); err != nil {
+		s.logger.Error("failed to record login lock log", zap.Error(err))
+	}
+}
+
+// RecordSuccess 清除账号相关计数
+func (s *loginLockoutService) RecordSuccess(ctx context.Context, account, ip string) {
+	keys := make([]string, 0, 2)
+	for _, t := range s.targets(account, ip) {
+		if t.scope != LockScopeIp {
+			keys = append(keys, lockoutKey(ctx, LoginFailKeyPrefix)+t.scope+":"+t.subject)
+		}
+	}
+	if len(keys) > 0 {
+		_ = s.redis.Del(ctx, keys...).Err()
+	}
+}
+
+// List 查询当前租户的锁定记录，按解锁时间倒序
+func (s *loginLockoutService) List(ctx context.Context) ([]*LoginLock, error) {
+	indexKey := lockoutKey(ctx, LoginLockIndexKeyPrefix)
+	lockPrefix := lockoutKey(ctx, LoginLockKeyPrefix)
+	now := time.Now().Unix()
+	_ = s.redis.ZRemRangeByScore(ctx, indexKey, "-inf", strconv.FormatInt(now, 10)).Err()
+	members, err := s.redis.ZRange(ctx, indexKey, 0, -1).Result()
+	if err != nil {
+		s.logger.Error("查询登录锁定失败", zap.Error(err))
+		return nil, fmt.Errorf("查询失败")
+	}
+
+	locks := make([]*LoginLock, 0, len(members))
+	for _, member := range members {
+		data, err := s.redis.HGetAll(ctx, lockPrefix+member).Result()
+		if err != nil || len(data) == 0 {
+			// 已被解锁或过期
+			_ = s.redis.ZRem(ctx, indexKey, member).Err()
+			continue
+		}
+		scope, subject, _ := strings.Cut(member, ":")
+		level, _ := strconv.Atoi(data["level"])
+		locks = append(locks, &LoginLock{
+			Scope:    scope,
+			Subject:  subject,
+			Account:  data["account"],
+			Ip:       data["ip"],
+			Level:    level,
+			LockedAt: parseInt64(data["lockedAt"]),
+			Until:    parseInt64(data["until"]),
+		})
+	}
+	sort.Slice(locks, func(i, j int) bool { return locks[i].Until > locks[j].Until })
+	return locks, nil
+}
+
+// Unlock 解除当前租户的锁定
+func (s *loginLockoutService) Unlock(ctx context.Context, scope, subject string) error {
+	switch scope {
+	case LockScopeAccount, LockScopeIpAccount, LockScopeIp:
+	default:
+		return fmt.Errorf("不支持的锁定维度: %s", scope)
+	}
+	member := scope + ":" + subject
+	n, err := s.redis.Del(ctx,
+		lockoutKey(ctx, LoginLockKeyPrefix)+member,
+		lockoutKey(ctx, LoginFailKeyPrefix)+member,
+		lockoutKey(ctx, LoginLockLevelKeyPrefix)+member).Result()
+	if err != nil {
+		s.logger.Error("解除登录锁定失败", zap.Error(err))
+		return fmt.Errorf("解锁失败")
+	}
+	_ = s.redis.ZRem(ctx, lockoutKey(ctx, LoginLockIndexKeyPrefix), member).Err()
+	if n == 0 {
+		return fmt.Errorf("锁定记录不存在")
+	}
+	s.logger.Info("解除登录锁定",
+		zap.Int64("tenantId", tenant.FromContext(ctx)),
+		zap.String("scope", scope),
+		zap.String("subject", subject))
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/force-c/nai-tizi/internal/domain/model"
 	"github.com/force-c/nai-tizi/internal/domain/request"
+	"github.com/force-c/nai-tizi/internal/infrastructure/tenant"
 	logging "github.com/force-c/nai-tizi/internal/logger"
 	"github.com/force-c/nai-tizi/internal/utils"
 	"github.com/force-c/nai-tizi/internal/utils/pagination"
@@ -62,11 +63,11 @@ func (s *loginLogService) Create(ctx context.Context, req *request.CreateLoginLo
 		Status:        req.Status,
 		Msg:           req.Msg,
 		LoginTime:     utils.Now(),
-		TenantId:      req.TenantId,
+		TenantId:      tenant.FromContext(ctx),
 		ClientId:      req.ClientId,
 	}
 
-	if err := log.Create(s.db); err != nil {
+	if err := log.Create(s.db.WithContext(ctx)); err != nil {
 		s.logger.Error("创建登录日志失败", zap.Error(err))
 		return fmt.Errorf("创建登录日志失败: %w", err)
 	}
@@ -82,7 +83,7 @@ func (s *loginLogService) Create(ctx context.Context, req *request.CreateLoginLo
 // Update 更新登录日志
 func (s *loginLogService) Update(ctx context.Context, req *request.UpdateLoginLogRequest) error {
 	// 检查日志是否存在
-	_, err := (&model.LoginLog{}).FindByID(s.db, req.ID)
+	_, err := (&model.LoginLog{}).FindByID(s.scoped(ctx), req.ID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("登录日志不存在")
@@ -100,12 +101,11 @@ func (s *loginLogService) Update(ctx context.Context, req *request.UpdateLoginLo
 		"os":             req.Os,
 		"status":         req.Status,
 		"msg":            req.Msg,
-		"tenant_id":      req.TenantId,
 		"client_id":      req.ClientId,
 	}
 
 	log := &model.LoginLog{}
-	if err := log.Update(s.db, req.ID, updates); err != nil {
+	if err := log.Update(s.db.WithContext(ctx), req.ID, updates); err != nil {
 		s.logger.Error("更新登录日志失败", zap.Error(err))
 		return fmt.Errorf("更新登录日志失败: %w", err)
 	}
@@ -117,7 +117,7 @@ func (s *loginLogService) Update(ctx context.Context, req *request.UpdateLoginLo
 // Delete 删除登录日志
 func (s *loginLogService) Delete(ctx context.Context, id int64) error {
 	// 检查日志是否存在
-	_, err := (&model.LoginLog{}).FindByID(s.db, id)
+	_, err := (&model.LoginLog{}).FindByID(s.scoped(ctx), id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("登录日志不存在")
@@ -127,7 +127,7 @@ func (s *loginLogService) Delete(ctx context.Context, id int64) error {
 	}
 
 	// 删除日志
-	if err := (&model.LoginLog{}).Delete(s.db, id); err != nil {
+	if err := (&model.LoginLog{}).Delete(s.db.WithContext(ctx), id); err != nil {
 		s.logger.Error("删除登录日志失败", zap.Error(err))
 		return fmt.Errorf("删除登录日志失败: %w", err)
 	}
@@ -143,7 +143,7 @@ func (s *loginLogService) BatchDelete(ctx context.Context, ids []int64) error {
 	}
 
 	// 批量删除
-	rowsAffected, err := (&model.LoginLog{}).BatchDelete(s.db, ids)
+	rowsAffected, err := (&model.LoginLog{}).BatchDelete(s.scoped(ctx), ids)
 	if err != nil {
 		s.logger.Error("批量删除登录日志失败", zap.Error(err))
 		return fmt.Errorf("批量删除登录日志失败: %w", err)
@@ -155,7 +155,7 @@ func (s *loginLogService) BatchDelete(ctx context.Context, ids []int64) error {
 
 // GetById 根据ID查询登录日志
 func (s *loginLogService) GetById(ctx context.Context, id int64) (*model.LoginLog, error) {
-	log, err := (&model.LoginLog{}).FindByID(s.db, id)
+	log, err := (&model.LoginLog{}).FindByID(s.scoped(ctx), id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("登录日志不存在")
@@ -169,7 +169,7 @@ func (s *loginLogService) GetById(ctx context.Context, id int64) (*model.LoginLo
 // Page 分页查询登录日志列表
 func (s *loginLogService) Page(ctx context.Context, req *request.PageLoginLogRequest) (*pagination.Page[model.LoginLog], error) {
 	// 1. 构建查询条件
-	query := s.db.Model(&model.LoginLog{})
+	query := s.scoped(ctx).Model(&model.LoginLog{})
 
 	// 2. 添加条件过滤
 	if req.UserName != "" {
@@ -187,6 +187,9 @@ func (s *loginLogService) Page(ctx context.Context, req *request.PageLoginLogReq
 	if req.EndTime != "" {
 		query = query.Where("login_time <= ?", req.EndTime)
 	}
+	if req.MinRiskScore > 0 {
+		query = query.Where("risk_score >= ?", req.MinRiskScore)
+	}
 
 	// 3. 添加默认排序（如果 PageQuery 没有指定排序）
 	if req.PageQuery.OrderByColumn == "" {
@@ -217,7 +220,7 @@ func (s *loginLogService) CleanOldLogs(ctx context.Context, days int) (int64, er
 		return 0, fmt.Errorf("天数必须大于0")
 	}
 
-	rowsAffected, err := (&model.LoginLog{}).CleanOldLogs(s.db, days)
+	rowsAffected, err := (&model.LoginLog{}).CleanOldLogs(s.scoped(ctx), days)
 	if err != nil {
 		s.logger.Error("清理登录日志失败", zap.Error(err), zap.Int("days", days))
 		return 0, fmt.Errorf("清理登录日志失败: %w", err)
@@ -226,3 +229,8 @@ func (s *loginLogService) CleanOldLogs(ctx context.Context, days int) (int64, er
 	s.logger.Info("清理登录日志成功", zap.Int64("count", rowsAffected), zap.Int("days", days))
 	return rowsAffected, nil
 }
+
+// scoped 返回按当前请求数据权限过滤登录日志的查询（每次查询需重新获取）
+func (s *loginLogService) scoped(ctx context.Context) *gorm.DB {
+	return s.db.WithContext(ctx).Scopes(LoginLogDataScope(ctx))
+}
@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/force-c/nai-tizi/internal/config"
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/infrastructure/geoip"
+	"github.com/force-c/nai-tizi/internal/infrastructure/thirdparty/email"
+	"github.com/force-c/nai-tizi/internal/infrastructure/websocket"
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// 风险因素及分值，总分上限 100
+	LoginRiskNewDevice        = "new_device"        // 新设备
+	LoginRiskNewIpRange       = "new_ip_range"      // 新 IP 段
+	LoginRiskNewCountry       = "new_country"       // 新国家
+	LoginRiskImpossibleTravel = "impossible_travel" // 不可能的移动速度
+	LoginRiskBlockedIp        = "blocked_ip"        // 黑名单 IP
+
+	// LoginRiskRevokeKeyPrefix "不是我本人"令牌 Redis Key 前缀
+	// login_risk:revoke:{sha256(token)} -> JSON{userId, sessionId, fingerprint}
+	LoginRiskRevokeKeyPrefix = "login_risk:revoke:"
+
+	// LoginRiskMessageType 异常登录提醒的 WebSocket 消息类型
+	LoginRiskMessageType = "login_risk"
+
+	loginRiskMaxScore = 100
+	// 两地距离小于该值时不判断移动速度，避免归属地库精度误差造成误报
+	loginRiskMinTravelKm = 300
+)
+
+// loginRiskScores 各风险因素的分值
+var loginRiskScores = map[string]int{
+	LoginRiskNewDevice:        30,
+	LoginRiskNewIpRange:       10,
+	LoginRiskNewCountry:       30,
+	LoginRiskImpossibleTravel: 40,
+	LoginRiskBlockedIp:        50,
+}
+
+// loginRiskReasonText 风险因素描述（用于提醒内容）
+var loginRiskReasonText = map[string]string{
+	LoginRiskNewDevice:        "新设备",
+	LoginRiskNewIpRange:       "新的网络",
+	LoginRiskNewCountry:       "新的国家/地区",
+	LoginRiskImpossibleTravel: "与上次登录地点距离过远",
+	LoginRiskBlockedIp:        "高风险 IP（如 TOR 出口节点）",
+}
+
+// LoginRiskAssessment 登录风险评估结果
+type LoginRiskAssessment struct {
+	UserId      int64
+	Score       int                // 风险分（0-100）
+	Reasons     []string           // 命中的风险因素
+	Fingerprint string             // 设备指纹
+	Ipaddr      string             // 登录IP
+	Browser     string             // 浏览器
+	Os          string             // 操作系统
+	Location    *geoip.Location    // IP 归属地，未加载归属地库或未收录时为 nil
+	device      *model.LoginDevice // 命中的已知设备，新设备为 nil
+}
+
+// ReasonCodes 风险因素编码（逗号分隔，写入登录日志）
+func (a *LoginRiskAssessment) ReasonCodes() string {
+	if a == nil {
+		return ""
+	}
+	return strings.Join(a.Reasons, ",")
+}
+
+// LocationText 登录地点文本
+func (a *LoginRiskAssessment) LocationText() string {
+	if a == nil || a.Location == nil {
+		return ""
+	}
+	return a.Location.String()
+}
+
+// loginRiskRevokeTicket "不是我本人"令牌对应的会话
+type loginRiskRevokeTicket struct {
+	UserId      int64  `json:"userId"`
+	SessionId   string `json:"sessionId"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// LoginRiskService 登录风险评估服务
+// 流程：Assess 在签发 Token 前评估风险 -> Complete 在会话建立后记录设备并按风险分提醒用户 -> Revoke 用户通过"不是我本人"链接注销该会话
+type LoginRiskService interface {
+	// Assess 评估本次登录的风险，IP 和 User-Agent 取自 WithSessionMeta；未启用时返回 nil
+	Assess(ctx context.Context, userId int64, deviceId, browser, os string) (*LoginRiskAssessment, error)
+
+	// Complete 记录已知设备，风险分达到阈值时通过邮件和 WebSocket 提醒用户
+	Complete(ctx context.Context, assessment *LoginRiskAssessment, user *model.User, sessionId string)
+
+	// Revoke 通过"不是我本人"令牌注销对应会话，并将该设备移出已知设备；令牌只能使用一次
+	Revoke(ctx context.Context, token string) error
+}
+
+type loginRiskService struct {
+	db           *gorm.DB
+	redis        *redis.Client
+	tokenManager TokenManager
+	geo          *geoip.Manager
+	emailManager *email.Manager
+	wsHub        *websocket.Hub
+	config       *config.Config
+	logger       logging.Logger
+}
+
+// NewLoginRiskService 创建登录风险评估服务实例，geo / emailManager / wsHub 为 nil 表示对应能力未启用
+func NewLoginRiskService(db *gorm.DB, rdb *redis.Client, tokenManager TokenManager, geo *geoip.Manager, emailManager *email.Manager, wsHub *websocket.Hub, cfg *config.Config, logger logging.Logger) LoginRiskService {
+	return &loginRiskService{
+		db:           db,
+		redis:        rdb,
+		tokenManager: tokenManager,
+		geo:          geo,
+		emailManager: emailManager,
+		wsHub:        wsHub,
+		config:       cfg,
+		logger:       logger,
+	}
+}
+
+// Assess 评估登录风险
+// 用户没有近期登录记录时（首次登录）没有比较基准，只检查黑名单
+func (s *loginRiskService) Assess(ctx context.Context, userId int64, deviceId, browser, os string) (*LoginRiskAssessment, error) {
+	cfg := s.config.Auth.LoginRisk
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	meta := sessionMetaFromContext(ctx)
+	a := &LoginRiskAssessment{
+		UserId:      userId,
+		Fingerprint: deviceFingerprint(deviceId, meta.UserAgent),
+		Ipaddr:      meta.Ipaddr,
+		Browser:     browser,
+		Os:          os,
+		Location:    s.geo.Lookup(meta.Ipaddr),
+	}
+	if s.geo.IsBlocked(a.Ipaddr) {
+		a.addReason(LoginRiskBlockedIp)
+	}
+
+	now := time.Now().Unix()
+	since := now - int64(cfg.DeviceRetention)*86400
+	devices, err := (&model.LoginDevice{}).FindRecent(s.db.WithContext(ctx), userId, since)
+	if err != nil {
+		return a, fmt.Errorf("查询已知设备失败: %w", err)
+	}
+	if len(devices) == 0 {
+		return a, nil
+	}
+
+	ipRange := ipRangeOf(a.Ipaddr)
+	knownRange, knownCountry, hasCountry := false, false, false
+	for i := range devices {
+		d := &devices[i]
+		if d.Fingerprint == a.Fingerprint {
+			a.device = d
+		}
+		if ipRange != "" && d.IpRange == ipRange {
+			knownRange = true
+		}
+		if d.Country != "" {
+			hasCountry = true
+			if a.Location != nil && d.Country == a.Location.Country {
+				knownCountry = true
+			}
+		}
+	}
+	if a.device == nil {
+		a.addReason(LoginRiskNewDevice)
+	}
+	if ipRange != "" && !knownRange {
+		a.addReason(LoginRiskNewIpRange)
+	}
+	if a.Location != nil && a.Location.Country != "" && hasCountry && !knownCountry {
+		a.addReason(LoginRiskNewCountry)
+	}
+
+	// 与最近一次登录比较移动速度
+	last := &devices[0]
+	if a.Location != nil && a.Location.HasCoord && last.HasCoord {
+		distance := geoip.Distance(last.Latitude, last.Longitude, a.Location.Latitude, a.Location.Longitude)
+		hours := float64(max(now-last.LastLoginAt, 60)) / 3600
+		if distance >= loginRiskMinTravelKm && distance/hours > float64(cfg.MaxSpeed) {
+			a.addReason(LoginRiskImpossibleTravel)
+		}
+	}
+	return a, nil
+}
+
+// Complete 记录设备并按风险分提醒用户
+func (s *loginRiskService) Complete(ctx context.Context, a *LoginRiskAssessment, user *model.User, sessionId string) {
+	if a == nil {
+		return
+	}
+
+	device := &model.LoginDevice{
+		UserId:      a.UserId,
+		Fingerprint: a.Fingerprint,
+		Browser:     a.Browser,
+		Os:          a.Os,
+		LastIp:      a.Ipaddr,
+		IpRange:     ipRangeOf(a.Ipaddr),
+		LastLoginAt: time.Now().Unix(),
+	}
+	if a.Location != nil {
+		device.Country = a.Location.Country
+		device.City = a.Location.City
+		device.Latitude = a.Location.Latitude
+		device.Longitude = a.Location.Longitude
+		device.HasCoord = a.Location.HasCoord
+	}
+	if err := s.saveDevice(ctx, a, device); err != nil {
+		s.logger.Error("记录登录设备失败", zap.Int64("userId", a.UserId), zap.Error(err))
+	}
+
+	if a.Score < s.config.Auth.LoginRisk.NotifyScore {
+		return
+	}
+	if err := s.notify(ctx, a, user, sessionId); err != nil {
+		s.logger.Error("发送异常登录提醒失败", zap.Int64("userId", a.UserId), zap.Error(err))
+	}
+}
+
+// Revoke 通过"不是我本人"令牌注销会话
+func (s *loginRiskService) Revoke(ctx context.Context, token string) error {
+	if token == "" {
+		return fmt.Errorf("链接无效或已过期")
+	}
+	raw, err := s.redis.GetDel(ctx, LoginRiskRevokeKeyPrefix+generateTokenHash(token)).Result()
+	if errors.Is(err, redis.Nil) {
+		return fmt.Errorf("链接无效或已过期")
+	}
+	if err != nil {
+		return fmt.Errorf("校验链接失败: %w", err)
+	}
+	var ticket loginRiskRevokeTicket
+	if err := json.Unmarshal([]byte(raw), &ticket); err != nil {
+		return fmt.Errorf("链接无效或已过期")
+	}
+
+	// 会话可能已自然过期或被用户主动退出，仍然移出已知设备
+	if err := s.tokenManager.RevokeSession(ctx, ticket.UserId, ticket.SessionId); err != nil {
+		s.logger.Warn("异常登录会话注销失败",
+			zap.Int64("userId", ticket.UserId),
+			zap.String("sessionId", ticket.SessionId),
+			zap.Error(err))
+	}
+	if err := (&model.LoginDevice{}).DeleteByFingerprint(s.db.WithContext(ctx), ticket.UserId, ticket.Fingerprint); err != nil {
+		s.logger.Error("移除已知设备失败", zap.Int64("userId", ticket.UserId), zap.Error(err))
+	}
+
+	s.logger.Info("用户确认异常登录并注销会话",
+		zap.Int64("userId", ticket.UserId),
+		zap.String("sessionId", ticket.SessionId))
+	return nil
+}
+
+// saveDevice 新设备写入记录，已知设备更新最近登录信息
+func (s *loginRiskService) saveDevice(ctx context.Context, a *LoginRiskAssessment, device *model.LoginDevice) error {
+	db := s.db.WithContext(ctx)
+	if a.device == nil {
+		// 设备超过保留期未登录时记录仍在，按指纹复用
+		existing, err := device.FindByFingerprint(db, a.UserId, a.Fingerprint)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if existing == nil {
+			device.LoginCount = 1
+			return device.Create(db)
+		}
+		device.ID = existing.ID
+		return device.Touch(db)
+	}
+	device.ID = a.device.ID
+	return device.Touch(db)
+}
+
+// notify 签发"不是我本人"令牌并通过邮件和 WebSocket 提醒用户
+func (s *loginRiskService) notify(ctx context.Context, a *LoginRiskAssessment, user *model.User, sessionId string) error {
+	cfg := s.config.Auth.LoginRisk
+	token, err := generateRandomToken(32)
+	if err != nil {
+		return fmt.Errorf("生成令牌失败: %w", err)
+	}
+	ticket, _ := json.Marshal(&loginRiskRevokeTicket{UserId: a.UserId, SessionId: sessionId, Fingerprint: a.Fingerprint})
+	ttl := time.Duration(cfg.RevokeTokenExpire) * time.Second
+	if err := s.redis.Set(ctx, LoginRiskRevokeKeyPrefix+generateTokenHash(token), ticket, ttl).Err(); err != nil {
+		return fmt.Errorf("保存令牌失败: %w", err)
+	}
+	link := cfg.RevokeUrl + "?token=" + url.QueryEscape(token)
+
+	reasons := make([]string, 0, len(a.Reasons))
+	for _, code := range a.Reasons {
+		reasons = append(reasons, loginRiskReasonText[code])
+	}
+	loginTime := time.Now().Format("2006-01-02 15:04:05")
+	location := a.LocationText()
+	if location == "" {
+		location = "未知"
+	}
+
+	if s.wsHub != nil {
+		_ = s.wsHub.SendToUser(a.UserId, LoginRiskMessageType, map[string]any{
+			"sessionId": sessionId,
+			"score":     a.Score,
+			"reasons":   a.Reasons,
+			"ipaddr":    a.Ipaddr,
+			"location":  a.LocationText(),
+			"browser":   a.Browser,
+			"os":        a.Os,
+			"loginTime": loginTime,
+			"revokeUrl": link,
+		})
+	}
+
+	if s.emailManager != nil && user.Email != "" {
+		body := fmt.Sprintf(`<p>%s，您好：</p><p>您的账号 %s 刚刚有一次异常登录（%s）：</p>`+
+			`<ul><li>时间：%s</li><li>IP：%s</li><li>地点：%s</li><li>设备：%s / %s</li></ul>`+
+			`<p>如果是您本人操作，请忽略本邮件。如果不是您本人，请立即点击下面的链接注销该登录，并尽快修改密码：</p>`+
+			`<p><a href="%s">%s</a></p>`,
+			html.EscapeString(user.NickName), html.EscapeString(user.UserName), strings.Join(reasons, "、"),
+			loginTime, a.Ipaddr, html.EscapeString(location), html.EscapeString(a.Browser), html.EscapeString(a.Os),
+			link, link)
+		if err := s.emailManager.Send(user.Email, "异常登录提醒", body); err != nil {
+			return fmt.Errorf("发送邮件失败: %w", err)
+		}
+	}
+	return nil
+}
+
+// addReason 记录风险因素并累加分值
+func (a *LoginRiskAssessment) addReason(code string) {
+	a.Reasons = append(a.Reasons, code)
+	a.Score = min(a.Score+loginRiskScores[code], loginRiskMaxScore)
+}
+
+// deviceFingerprint 计算设备指纹：优先使用客户端上报的设备ID，缺省时退化为 User-Agent
+func deviceFingerprint(deviceId, userAgent string) string {
+	if deviceId = strings.TrimSpace(deviceId); deviceId != "" {
+		return generateTokenHash("id:" + deviceId)
+	}
+	return generateTokenHash("ua:" + userAgent)
+}
+
+// ipRangeOf 计算 IP 所在网段（IPv4 /24，IPv6 /48），IP 无效时返回空
+func ipRangeOf(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ""
+	}
+	addr = addr.Unmap()
+	bits := 48
+	if addr.Is4() {
+		bits = 24
+	}
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return ""
+	}
+	return prefix.String()
+}
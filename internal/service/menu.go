@@ -1,9 +1,11 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
+	"github.com/force-c/nai-tizi/internal/constants"
 	"github.com/force-c/nai-tizi/internal/domain/model"
 	"gorm.io/gorm"
 )
@@ -25,10 +27,10 @@ type MenuTree struct {
 }
 
 // GetUserMenuTree 获取用户的菜单树（用于前端路由生成）
-func (s *MenuService) GetUserMenuTree(userId int64) ([]*MenuTree, error) {
+func (s *MenuService) GetUserMenuTree(ctx context.Context, userId int64) ([]*MenuTree, error) {
 	// 1. 获取用户的角色列表
 	roleModel := &model.Role{}
-	roles, err := roleModel.FindByUserId(s.db, userId)
+	roles, err := roleModel.FindByUserId(s.db.WithContext(ctx), userId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user roles: %w", err)
 	}
@@ -239,6 +241,29 @@ func (s *MenuService) DeleteMenu(menuId int64) error {
 	return nil
 }
 
+// UncoveredPermissions 返回没有任何菜单按钮声明的权限资源
+// 这些权限无法通过给角色分配菜单授予，只能单独添加角色权限；按钮的权限标识支持 user.*、*.read 等通配符
+func (s *MenuService) UncoveredPermissions(ctx context.Context, resources []string) ([]string, error) {
+	menus, err := (&model.Menu{}).FindAll(s.db.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("查询菜单失败: %w", err)
+	}
+	var declared []string
+	for _, menu := range menus {
+		if menu.MenuType == constants.MenuTypeButton {
+			declared = append(declared, splitMenuPerms(menu.Perms)...)
+		}
+	}
+
+	var uncovered []string
+	for _, resource := range resources {
+		if !ApiKeyScopesAllow(declared, resource) {
+			uncovered = append(uncovered, resource)
+		}
+	}
+	return uncovered, nil
+}
+
 // buildMenuTree 构建菜单树
 func (s *MenuService) buildMenuTree(menus []model.Menu, parentId int64) []*MenuTree {
 	var tree []*MenuTree
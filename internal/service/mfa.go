@@ -0,0 +1,599 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/force-c/nai-tizi/internal/config"
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/force-c/nai-tizi/internal/utils"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// MfaTicketKeyPrefix 两步验证待验证票据 Redis Key 前缀
+	// mfa:ticket:{ticket} -> Hash{userId, clientKey, grantType, setupRequired, enrollSecret, attempts}
+	MfaTicketKeyPrefix = "mfa:ticket:"
+
+	// MfaEnrollKeyPrefix 两步验证绑定中的临时密钥 Redis Key 前缀
+	// mfa:enroll:{userId} -> secret
+	MfaEnrollKeyPrefix = "mfa:enroll:"
+
+	// MfaUsedStepKeyPrefix 用户最近一次使用的 TOTP 时间步，防止动态码重放
+	// mfa:used_step:{userId} -> step
+	MfaUsedStepKeyPrefix = "mfa:used_step:"
+
+	// MfaFailKeyPrefix 用户两步验证失败计数 Redis Key 前缀，登录、绑定、关闭和重新生成恢复码共用
+	// mfa:fail:{userId} -> 窗口内验证次数（成功后清零）
+	MfaFailKeyPrefix = "mfa:fail:"
+
+	// MfaTicketTTL 待验证票据有效期
+	MfaTicketTTL = 5 * time.Minute
+
+	// MfaTicketMaxAttempts 单个票据允许的最大验证次数，同时是每个用户在 MfaFailWindow 内允许的最大失败次数
+	MfaTicketMaxAttempts = 5
+
+	// MfaFailWindow 用户失败计数窗口，失败次数达到上限后在窗口内拒绝验证，防止换票据或登录后穷举动态码
+	MfaFailWindow = 15 * time.Minute
+
+	// MfaEnrollTTL 绑定流程有效期
+	MfaEnrollTTL = 10 * time.Minute
+
+	// MfaRecoveryCodeCount 每次生成的恢复码数量
+	MfaRecoveryCodeCount = 10
+
+	// mfaTotpSkew 允许的 TOTP 时间步偏差
+	mfaTotpSkew = 1
+)
+
+// MfaStatus 两步验证状态
+type MfaStatus struct {
+	Enabled                bool // 是否已启用
+	Required               bool // 所属角色是否强制启用
+	RecoveryCodesRemaining int  // 剩余可用恢复码数量
+}
+
+// MfaEnrollment 两步验证绑定信息
+type MfaEnrollment struct {
+	Secret     string // TOTP 密钥（Base32）
+	OtpauthUri string // otpauth:// 绑定地址，前端据此生成二维码
+}
+
+// MfaService 两步验证服务接口
+type MfaService interface {
+	// Status 查询用户两步验证状态
+	Status(ctx context.Context, userId int64) (*MfaStatus, error)
+
+	// IsRequired 用户所属角色是否强制启用两步验证
+	IsRequired(ctx context.Context, userId int64) (bool, error)
+
+	// BeginEnroll 开始绑定，生成临时密钥
+	BeginEnroll(ctx context.Context, userId int64) (*MfaEnrollment, error)
+
+	// ConfirmEnroll 校验动态码完成绑定，返回一次性恢复码（明文仅返回这一次）
+	ConfirmEnroll(ctx context.Context, userId int64, code string) ([]string, error)
+
+	// Disable 关闭两步验证（需校验动态码或恢复码）
+	Disable(ctx context.Context, userId int64, code string) error
+
+	// RegenerateRecoveryCodes 重新生成恢复码（需校验动态码或恢复码），旧恢复码全部作废
+	RegenerateRecoveryCodes(ctx context.Context, userId int64, code string) ([]string, error)
+
+	// Reset 管理员重置用户的两步验证
+	Reset(ctx context.Context, userId int64) error
+
+	// Verify 校验动态码或恢复码（恢复码使用后即失效），用户失败次数超过上限时在窗口内拒绝
+	Verify(ctx context.Context, user *model.User, code string) error
+
+	// IssueTicket 首因素认证通过后签发待验证票据
+	IssueTicket(ctx context.Context, user *model.User, clientKey, grantType string, setupRequired bool) (string, error)
+
+	// TicketGrantType 查询票据对应的原始授权类型
+	TicketGrantType(ctx context.Context, ticket string) (string, error)
+
+	// BeginTicketEnroll 使用票据开始绑定（角色强制启用但用户尚未绑定时）
+	BeginTicketEnroll(ctx context.Context, ticket string) (*MfaEnrollment, error)
+
+	// RedeemTicket 使用票据和动态码完成登录
+	// 若票据要求先绑定，校验通过后同时完成绑定并返回恢复码
+	RedeemTicket(ctx context.Context, ticket, clientKey, code string) (*model.User, []string, error)
+}
+
+type mfaService struct {
+	db     *gorm.DB
+	redis  *redis.Client
+	config *config.Config
+	logger logging.Logger
+}
+
+// NewMfaService 创建两步验证服务实例
+func NewMfaService(db *gorm.DB, redis *redis.Client, cfg *config.Config, logger logging.Logger) MfaService {
+	return &mfaService{
+		db:     db,
+		redis:  redis,
+		config: cfg,
+		logger: logger,
+	}
+}
+
+// Status 查询用户两步验证状态
+func (s *mfaService) Status(ctx context.Context, userId int64) (*MfaStatus, error) {
+	user, err := s.findUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	required, err := s.IsRequired(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	return &MfaStatus{
+		Enabled:                user.MfaEnabled,
+		Required:               required,
+		RecoveryCodesRemaining: len(decodeRecoveryHashes(user.MfaRecovery)),
+	}, nil
+}
+
+// IsRequired 用户所属角色是否强制启用两步验证
+func (s *mfaService) IsRequired(ctx context.Context, userId int64) (bool, error) {
+	var count int64
+	err := s.db.WithContext(ctx).Model(&model.Role{}).
+		Joins("INNER JOIN m_user_role ur ON ur.role_id = s_role.id AND ur.deleted_at IS NULL").
+		Where("ur.user_id = ? AND s_role.status = 0 AND s_role.require_mfa = ?", userId, true).
+		Count(&count).Error
+	if err != nil {
+		s.logger.Error("查询角色两步验证要求失败", zap.Int64("userId", userId), zap.Error(err))
+		return false, fmt.Errorf("查询角色两步验证要求失败: %w", err)
+	}
+	return count > 0, nil
+}
+
+// BeginEnroll 开始绑定
+func (s *mfaService) BeginEnroll(ctx context.Context, userId int64) (*MfaEnrollment, error) {
+	user, err := s.findUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if user.MfaEnabled {
+		return nil, fmt.Errorf("已启用两步验证，如需更换请先关闭")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		s.logger.Error("生成 TOTP 密钥失败", zap.Error(err))
+		return nil, fmt.Errorf("生成密钥失败")
+	}
+	if err := s.redis.Set(ctx, s.getEnrollKey(userId), secret, MfaEnrollTTL).Err(); err != nil {
+		s.logger.Error("保存绑定密钥失败", zap.Error(err))
+		return nil, fmt.Errorf("生成密钥失败")
+	}
+
+	return s.buildEnrollment(user, secret), nil
+}
+
+// ConfirmEnroll 校验动态码完成绑定
+func (s *mfaService) ConfirmEnroll(ctx context.Context, userId int64, code string) ([]string, error) {
+	secret, err := s.redis.Get(ctx, s.getEnrollKey(userId)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("绑定已过期，请重新开始")
+		}
+		return nil, fmt.Errorf("查询绑定信息失败")
+	}
+
+	codes, err := s.enable(ctx, userId, secret, code)
+	if err != nil {
+		return nil, err
+	}
+	_ = s.redis.Del(ctx, s.getEnrollKey(userId)).Err()
+	return codes, nil
+}
+
+// Disable 关闭两步验证
+func (s *mfaService) Disable(ctx context.Context, userId int64, code string) error {
+	user, err := s.findUser(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if !user.MfaEnabled {
+		return fmt.Errorf("未启用两步验证")
+	}
+	required, err := s.IsRequired(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if required {
+		return fmt.Errorf("所属角色要求启用两步验证，无法关闭")
+	}
+	if err := s.Verify(ctx, user, code); err != nil {
+		return err
+	}
+	return s.clear(ctx, userId)
+}
+
+// RegenerateRecoveryCodes 重新生成恢复码
+func (s *mfaService) RegenerateRecoveryCodes(ctx context.Context, userId int64, code string) ([]string, error) {
+	user, err := s.findUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if !user.MfaEnabled {
+		return nil, fmt.Errorf("未启用两步验证")
+	}
+	if err := s.Verify(ctx, user, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		s.logger.Error("生成恢复码失败", zap.Error(err))
+		return nil, fmt.Errorf("生成恢复码失败")
+	}
+	if err := s.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", userId).
+		Update("mfa_recovery", hashes).Error; err != nil {
+		s.logger.Error("保存恢复码失败", zap.Error(err))
+		return nil, fmt.Errorf("保存恢复码失败: %w", err)
+	}
+
+	s.logger.Info("重新生成恢复码", zap.Int64("userId", userId))
+	return codes, nil
+}
+
+// Reset 管理员重置用户的两步验证
+func (s *mfaService) Reset(ctx context.Context, userId int64) error {
+	if _, err := s.findUser(ctx, userId); err != nil {
+		return err
+	}
+	_ = s.redis.Del(ctx, s.getEnrollKey(userId), s.getFailKey(userId)).Err()
+	return s.clear(ctx, userId)
+}
+
+// Verify 校验动态码或恢复码
+// 6 位数字按 TOTP 校验，其余按恢复码校验
+func (s *mfaService) Verify(ctx context.Context, user *model.User, code string) error {
+	if !user.MfaEnabled || user.MfaSecret == "" {
+		return fmt.Errorf("未启用两步验证")
+	}
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return fmt.Errorf("验证码不能为空")
+	}
+
+	return s.attempt(ctx, user.ID, func() error {
+		if len(code) == utils.TOTPDigits && isDigits(code) {
+			return s.verifyTotp(ctx, user.ID, user.MfaSecret, code)
+		}
+		return s.consumeRecoveryCode(ctx, user, code)
+	})
+}
+
+// IssueTicket 签发待验证票据
+func (s *mfaService) IssueTicket(ctx context.Context, user *model.User, clientKey, grantType string, setupRequired bool) (string, error) {
+	ticket, err := generateRandomToken(32)
+	if err != nil {
+		s.logger.Error("生成两步验证票据失败", zap.Error(err))
+		return "", fmt.Errorf("生成两步验证票据失败")
+	}
+
+	key := MfaTicketKeyPrefix + ticket
+	pipe := s.redis.TxPipeline()
+	pipe.HSet(ctx, key, map[string]interface{}{
+		"userId":        user.ID,
+		"clientKey":     clientKey,
+		"grantType":     grantType,
+		"setupRequired": strconv.FormatBool(setupRequired),
+	})
+	pipe.Expire(ctx, key, MfaTicketTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		s.logger.Error("保存两步验证票据失败", zap.Error(err))
+		return "", fmt.Errorf("生成两步验证票据失败")
+	}
+	return ticket, nil
+}
+
+// TicketGrantType 查询票据对应的原始授权类型
+func (s *mfaService) TicketGrantType(ctx context.Context, ticket string) (string, error) {
+	grantType, err := s.redis.HGet(ctx, MfaTicketKeyPrefix+ticket, "grantType").Result()
+	if err != nil || grantType == "" {
+		return "", fmt.Errorf("两步验证票据无效或已过期")
+	}
+	return grantType, nil
+}
+
+// BeginTicketEnroll 使用票据开始绑定
+func (s *mfaService) BeginTicketEnroll(ctx context.Context, ticket string) (*MfaEnrollment, error) {
+	key := MfaTicketKeyPrefix + ticket
+	data, err := s.redis.HGetAll(ctx, key).Result()
+	if err != nil || len(data) == 0 {
+		return nil, fmt.Errorf("两步验证票据无效或已过期")
+	}
+	if data["setupRequired"] != "true" {
+		return nil, fmt.Errorf("当前账号已绑定两步验证")
+	}
+
+	user, err := s.findUser(ctx, parseInt64(data["userId"]))
+	if err != nil {
+		return nil, err
+	}
+
+	secret := data["enrollSecret"]
+	if secret == "" {
+		if secret, err = utils.GenerateTOTPSecret(); err != nil {
+			s.logger.Error("生成 TOTP 密钥失败", zap.Error(err))
+			return nil, fmt.Errorf("生成密钥失败")
+		}
+		if err := s.redis.HSet(ctx, key, "enrollSecret", secret).Err(); err != nil {
+			return nil, fmt.Errorf("生成密钥失败")
+		}
+	}
+	return s.buildEnrollment(user, secret), nil
+}
+
+// RedeemTicket 使用票据和动态码完成登录
+func (s *mfaService) RedeemTicket(ctx context.Context, ticket, clientKey, code string) (*model.User, []string, error) {
+	key := MfaTicketKeyPrefix + ticket
+	data, err := s.redis.HGetAll(ctx, key).Result()
+	if err != nil || len(data) == 0 {
+		return nil, nil, fmt.Errorf("两步验证票据无效或已过期")
+	}
+	if data["clientKey"] != clientKey {
+		return nil, nil, fmt.Errorf("两步验证票据与客户端不匹配")
+	}
+
+	attempts, err := s.redis.HIncrBy(ctx, key, "attempts", 1).Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("两步验证失败")
+	}
+	if attempts > MfaTicketMaxAttempts {
+		_ = s.redis.Del(ctx, key).Err()
+		return nil, nil, fmt.Errorf("验证失败次数过多，请重新登录")
+	}
+
+	userId := parseInt64(data["userId"])
+	var recoveryCodes []string
+	if data["setupRequired"] == "true" {
+		if data["enrollSecret"] == "" {
+			return nil, nil, fmt.Errorf("请先绑定两步验证")
+		}
+		if recoveryCodes, err = s.enable(ctx, userId, data["enrollSecret"], code); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	user, err := s.findUser(ctx, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := CheckUserStatus(user.Status); err != nil {
+		_ = s.redis.Del(ctx, key).Err()
+		return nil, nil, err
+	}
+	if recoveryCodes == nil {
+		if err := s.Verify(ctx, user, code); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// 票据一次性使用
+	_ = s.redis.Del(ctx, key).Err()
+	return user, recoveryCodes, nil
+}
+
+// enable 校验动态码并为用户启用两步验证，返回恢复码明文
+func (s *mfaService) enable(ctx context.Context, userId int64, secret, code string) ([]string, error) {
+	var step int64
+	if err := s.attempt(ctx, userId, func() error {
+		var ok bool
+		if step, ok = utils.ValidateTOTPCode(secret, code, time.Now(), mfaTotpSkew); !ok {
+			return fmt.Errorf("动态验证码错误")
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		s.logger.Error("生成恢复码失败", zap.Error(err))
+		return nil, fmt.Errorf("生成恢复码失败")
+	}
+
+	if err := s.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", userId).Updates(map[string]any{
+		"mfa_enabled":  true,
+		"mfa_secret":   secret,
+		"mfa_recovery": hashes,
+	}).Error; err != nil {
+		s.logger.Error("启用两步验证失败", zap.Int64("userId", userId), zap.Error(err))
+		return nil, fmt.Errorf("启用两步验证失败: %w", err)
+	}
+	_ = s.redis.Set(ctx, s.getUsedStepKey(userId), step, MfaEnrollTTL).Err()
+
+	s.logger.Info("启用两步验证", zap.Int64("userId", userId))
+	return codes, nil
+}
+
+// clear 清除用户的两步验证配置
+func (s *mfaService) clear(ctx context.Context, userId int64) error {
+	if err := s.db.WithContext(ctx).Model(&model.User{}).Where("id = ?", userId).Updates(map[string]any{
+		"mfa_enabled":  false,
+		"mfa_secret":   "",
+		"mfa_recovery": "",
+	}).Error; err != nil {
+		s.logger.Error("关闭两步验证失败", zap.Int64("userId", userId), zap.Error(err))
+		return fmt.Errorf("关闭两步验证失败: %w", err)
+	}
+	_ = s.redis.Del(ctx, s.getUsedStepKey(userId)).Err()
+
+	s.logger.Info("关闭两步验证", zap.Int64("userId", userId))
+	return nil
+}
+
+// attempt 累计用户的验证次数后执行校验，校验通过时清零
+// 先计数再校验，并发请求也不能超过次数上限；Redis 异常时拒绝验证
+func (s *mfaService) attempt(ctx context.Context, userId int64, verify func() error) error {
+	key := s.getFailKey(userId)
+	pipe := s.redis.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.ExpireNX(ctx, key, MfaFailWindow)
+	ttl := pipe.TTL(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		s.logger.Error("记录两步验证次数失败", zap.Int64("userId", userId), zap.Error(err))
+		return fmt.Errorf("两步验证失败")
+	}
+	if incr.Val() > MfaTicketMaxAttempts {
+		s.logger.Warn("两步验证失败次数过多", zap.Int64("userId", userId), zap.Int64("attempts", incr.Val()))
+		return fmt.Errorf("两步验证失败次数过多，请%d分钟后再试", int(ttl.Val().Minutes())+1)
+	}
+
+	if err := verify(); err != nil {
+		return err
+	}
+	_ = s.redis.Del(ctx, key).Err()
+	return nil
+}
+
+// verifyTotp 校验动态码，同一时间步内的动态码只能使用一次
+func (s *mfaService) verifyTotp(ctx context.Context, userId int64, secret, code string) error {
+	step, ok := utils.ValidateTOTPCode(secret, code, time.Now(), mfaTotpSkew)
+	if !ok {
+		return fmt.Errorf("动态验证码错误")
+	}
+
+	usedKey := s.getUsedStepKey(userId)
+	if lastStep, err := s.redis.Get(ctx, usedKey).Int64(); err == nil && step <= lastStep {
+		return fmt.Errorf("动态验证码已使用，请等待下一个验证码")
+	}
+	_ = s.redis.Set(ctx, usedKey, step, time.Duration(utils.TOTPPeriod*(2*mfaTotpSkew+1))*time.Second).Err()
+	return nil
+}
+
+// consumeRecoveryCode 校验并消耗恢复码
+func (s *mfaService) consumeRecoveryCode(ctx context.Context, user *model.User, code string) error {
+	target := hashRecoveryCode(code)
+	hashes := decodeRecoveryHashes(user.MfaRecovery)
+	for i, h := range hashes {
+		if h != target {
+			continue
+		}
+		remaining := append(hashes[:i:i], hashes[i+1:]...)
+		encoded, _ := json.Marshal(remaining)
+		// 以原值作为条件更新，避免同一恢复码被并发重复使用
+		result := s.db.WithContext(ctx).Model(&model.User{}).
+			Where("id = ? AND mfa_recovery = ?", user.ID, user.MfaRecovery).
+			Update("mfa_recovery", string(encoded))
+		if result.Error != nil {
+			s.logger.Error("更新恢复码失败", zap.Error(result.Error))
+			return fmt.Errorf("恢复码校验失败")
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("恢复码已使用")
+		}
+		user.MfaRecovery = string(encoded)
+		s.logger.Info("使用恢复码登录", zap.Int64("userId", user.ID), zap.Int("remaining", len(remaining)))
+		return nil
+	}
+	return fmt.Errorf("动态验证码或恢复码错误")
+}
+
+// buildEnrollment 组装绑定信息
+func (s *mfaService) buildEnrollment(user *model.User, secret string) *MfaEnrollment {
+	return &MfaEnrollment{
+		Secret:     secret,
+		OtpauthUri: utils.BuildTOTPURI(s.config.Auth.MfaIssuer, user.UserName, secret),
+	}
+}
+
+// findUser 查询用户
+func (s *mfaService) findUser(ctx context.Context, userId int64) (*model.User, error) {
+	user, err := (&model.User{}).FindByID(s.db.WithContext(ctx), userId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("用户不存在")
+		}
+		s.logger.Error("查询用户失败", zap.Error(err))
+		return nil, fmt.Errorf("查询用户失败: %w", err)
+	}
+	return user, nil
+}
+
+// getEnrollKey 获取绑定临时密钥 Redis Key
+func (s *mfaService) getEnrollKey(userId int64) string {
+	return fmt.Sprintf("%s%d", MfaEnrollKeyPrefix, userId)
+}
+
+// getFailKey 获取验证失败计数 Redis Key
+func (s *mfaService) getFailKey(userId int64) string {
+	return fmt.Sprintf("%s%d", MfaFailKeyPrefix, userId)
+}
+
+// getUsedStepKey 获取已使用时间步 Redis Key
+func (s *mfaService) getUsedStepKey(userId int64) string {
+	return fmt.Sprintf("%s%d", MfaUsedStepKeyPrefix, userId)
+}
+
+// recoveryCodeAlphabet 恢复码字符集（去除易混淆的 0/O/1/I）
+const recoveryCodeAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// generateRecoveryCodes 生成恢复码，返回明文列表和哈希列表（JSON）
+func generateRecoveryCodes() ([]string, string, error) {
+	codes := make([]string, 0, MfaRecoveryCodeCount)
+	hashes := make([]string, 0, MfaRecoveryCodeCount)
+	buf := make([]byte, 10)
+	for i := 0; i < MfaRecoveryCodeCount; i++ {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, "", err
+		}
+		chars := make([]byte, len(buf))
+		for j, b := range buf {
+			chars[j] = recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)]
+		}
+		code := string(chars[:5]) + "-" + string(chars[5:])
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	encoded, err := json.Marshal(hashes)
+	if err != nil {
+		return nil, "", err
+	}
+	return codes, string(encoded), nil
+}
+
+// hashRecoveryCode 计算恢复码哈希（忽略大小写、空格和连字符）
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToUpper(code)
+	normalized = strings.NewReplacer("-", "", " ", "").Replace(normalized)
+	h := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(h[:])
+}
+
+// decodeRecoveryHashes 解析恢复码哈希列表
+func decodeRecoveryHashes(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+	var hashes []string
+	if err := json.Unmarshal([]byte(raw), &hashes); err != nil {
+		return nil
+	}
+	return hashes
+}
+
+// isDigits 判断字符串是否全为数字
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
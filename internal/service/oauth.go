@@ -0,0 +1,894 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/force-c/nai-tizi/internal/config"
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/domain/request"
+	"github.com/force-c/nai-tizi/internal/infrastructure/jwt"
+	"github.com/force-c/nai-tizi/internal/infrastructure/tenant"
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/force-c/nai-tizi/internal/utils"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// OAuthCodeKeyPrefix 授权码 Redis Key 前缀
+	// oauth:code:{sha256(code)} -> oauthCode(JSON)，一次性使用
+	OAuthCodeKeyPrefix = "oauth:code:"
+
+	// OAuthRefreshTokenKeyPrefix OAuth2 刷新令牌 Redis Key 前缀
+	// oauth:refresh:{sha256(refreshToken)} -> oauthGrant(JSON)
+	OAuthRefreshTokenKeyPrefix = "oauth:refresh:"
+
+	// OAuthGrantIndexKeyPrefix 用户对客户端已签发刷新令牌的索引 Redis Key 前缀
+	// oauth:grant:{userId}:{clientId} -> Set{sha256(refreshToken)}，撤销授权时批量作废
+	OAuthGrantIndexKeyPrefix = "oauth:grant:"
+)
+
+// OAuth2 授权类型
+const (
+	OAuthGrantAuthorizationCode = "authorization_code"
+	OAuthGrantClientCredentials = "client_credentials"
+	OAuthGrantRefreshToken      = "refresh_token"
+)
+
+// OAuth2 内置授权范围，客户端可额外登记自定义范围（供接入方资源服务使用）
+const (
+	OAuthScopeProfile = "profile" // 基本资料
+	OAuthScopeEmail   = "email"   // 邮箱
+	OAuthScopePhone   = "phone"   // 手机号
+	OAuthScopeApi     = "api"     // 以用户身份访问系统接口（受用户角色权限限制）
+)
+
+// oauthScopeDescriptions 内置授权范围说明（授权确认页展示）
+var oauthScopeDescriptions = map[string]string{
+	OAuthScopeProfile: "读取你的基本资料（用户名、昵称、头像）",
+	OAuthScopeEmail:   "读取你的邮箱地址",
+	OAuthScopePhone:   "读取你的手机号",
+	OAuthScopeApi:     "以你的身份访问系统接口（受你的角色权限限制）",
+}
+
+// OAuth2 错误码（RFC 6749 4.1.2.1 / 5.2）
+const (
+	OAuthErrInvalidRequest          = "invalid_request"
+	OAuthErrInvalidClient           = "invalid_client"
+	OAuthErrInvalidGrant            = "invalid_grant"
+	OAuthErrUnauthorizedClient      = "unauthorized_client"
+	OAuthErrUnsupportedGrantType    = "unsupported_grant_type"
+	OAuthErrUnsupportedResponseType = "unsupported_response_type"
+	OAuthErrInvalidScope            = "invalid_scope"
+	OAuthErrAccessDenied            = "access_denied"
+	OAuthErrServerError             = "server_error"
+)
+
+// OAuthError OAuth2 协议错误
+type OAuthError struct {
+	Code        string
+	Description string
+}
+
+func (e *OAuthError) Error() string {
+	return e.Description
+}
+
+// StatusCode 错误对应的 HTTP 状态码
+func (e *OAuthError) StatusCode() int {
+	switch e.Code {
+	case OAuthErrInvalidClient:
+		return 401
+	case OAuthErrServerError:
+		return 500
+	default:
+		return 400
+	}
+}
+
+func newOAuthError(code, description string) *OAuthError {
+	return &OAuthError{Code: code, Description: description}
+}
+
+// HasOAuthScope 判断空格分隔的授权范围中是否包含指定范围
+func HasOAuthScope(scope, want string) bool {
+	return slices.Contains(strings.Fields(scope), want)
+}
+
+// OAuthScopeDescription 授权范围说明，自定义范围原样返回
+func OAuthScopeDescription(scope string) string {
+	if desc, ok := oauthScopeDescriptions[scope]; ok {
+		return desc
+	}
+	return scope
+}
+
+// OAuthConsentInfo 授权确认页信息
+type OAuthConsentInfo struct {
+	Client      *model.AuthClient
+	RedirectUri string
+	Scopes      []string
+	Approved    bool // 客户端免确认或用户此前已同意全部申请范围
+}
+
+// OAuthToken 签发的令牌
+type OAuthToken struct {
+	AccessToken  string
+	ExpiresIn    int64
+	RefreshToken string
+	Scope        string
+}
+
+// OAuthIntrospection 令牌内省结果
+type OAuthIntrospection struct {
+	Active    bool
+	Scope     string
+	ClientKey string
+	Username  string
+	TokenType string
+	ExpiresAt int64
+	IssuedAt  int64
+	Subject   string
+	Issuer    string
+	Jti       string
+}
+
+// OAuthConsentGrant 用户已授权的客户端
+type OAuthConsentGrant struct {
+	ClientKey   string
+	ClientName  string
+	Scopes      []string
+	CreatedTime utils.LocalTime
+	UpdatedTime utils.LocalTime
+}
+
+// OAuthService OAuth2 授权服务接口
+// 客户端复用 s_auth_client，对外的 client_id 即 clientKey
+type OAuthService interface {
+	// PrepareConsent 校验授权请求并返回授权确认页信息
+	PrepareConsent(ctx context.Context, userId int64, req *request.OAuthAuthorizeRequest) (*OAuthConsentInfo, error)
+
+	// Authorize 用户确认（或拒绝）授权，返回携带授权码（或错误）的回调地址
+	Authorize(ctx context.Context, userId int64, req *request.OAuthConsentRequest) (string, error)
+
+	// Token 令牌端点，支持 authorization_code（PKCE）、client_credentials、refresh_token
+	Token(ctx context.Context, clientKey, clientSecret string, req *request.OAuthTokenRequest) (*OAuthToken, error)
+
+	// Introspect 令牌内省（RFC 7662）
+	Introspect(ctx context.Context, clientKey, clientSecret, token, tokenTypeHint string) (*OAuthIntrospection, error)
+
+	// Revoke 令牌撤销（RFC 7009），令牌无效或不属于该客户端时静默成功
+	Revoke(ctx context.Context, clientKey, clientSecret, token, tokenTypeHint string) error
+
+	// UserInfo 根据访问令牌查询用户及其授权范围
+	UserInfo(ctx context.Context, accessToken string) (*model.User, string, error)
+
+	// ListConsents 查询用户已授权的客户端
+	ListConsents(ctx context.Context, userId int64) ([]OAuthConsentGrant, error)
+
+	// RevokeConsent 撤销用户对客户端的授权，并作废已签发的刷新令牌
+	RevokeConsent(ctx context.Context, userId int64, clientKey string) error
+}
+
+type oauthService struct {
+	db           *gorm.DB
+	redis        *redis.Client
+	jwt          *jwt.Jwt
+	config       *config.Config
+	tokenManager TokenManager
+	logger       logging.Logger
+}
+
+// oauthCode 授权码绑定的授权上下文
+type oauthCode struct {
+	ClientId            string `json:"clientId"`
+	UserId              int64  `json:"userId"`
+	RedirectUri         string `json:"redirectUri"`
+	RedirectUriProvided bool   `json:"redirectUriProvided"`
+	Scope               string `json:"scope"`
+	CodeChallenge       string `json:"codeChallenge"`
+}
+
+// oauthGrant 刷新令牌绑定的授权信息
+type oauthGrant struct {
+	ClientId string `json:"clientId"`
+	UserId   int64  `json:"userId"`
+	Scope    string `json:"scope"`
+	IssuedAt int64  `json:"issuedAt"`
+}
+
+// NewOAuthService 创建 OAuth2 授权服务实例
+func NewOAuthService(db *gorm.DB, redis *redis.Client, jwtService *jwt.Jwt, cfg *config.Config, logger logging.Logger) OAuthService {
+	return &oauthService{
+		db:           db,
+		redis:        redis,
+		jwt:          jwtService,
+		config:       cfg,
+		tokenManager: NewTokenManager(jwtService, redis, logger),
+		logger:       logger,
+	}
+}
+
+// PrepareConsent 校验授权请求并返回授权确认页信息
+func (s *oauthService) PrepareConsent(ctx context.Context, userId int64, req *request.OAuthAuthorizeRequest) (*OAuthConsentInfo, error) {
+	client, redirectUri, scopes, err := s.validateAuthorize(req)
+	if err != nil {
+		return nil, err
+	}
+
+	approved := client.AutoApprove
+	if !approved {
+		var m model.OAuthConsent
+		if consent, err := m.Find(s.db.WithContext(ctx), userId, client.ClientId); err == nil {
+			approved = containsAllScopes(strings.Fields(consent.Scope), scopes)
+		}
+	}
+
+	return &OAuthConsentInfo{
+		Client:      client,
+		RedirectUri: redirectUri,
+		Scopes:      scopes,
+		Approved:    approved,
+	}, nil
+}
+
+// Authorize 用户确认（或拒绝）授权
+// 1. 重新校验授权请求（客户端、回调地址、PKCE、范围）
+// 2. 拒绝时回调 error=access_denied
+// 3. 同意时记录授权范围，生成一次性授权码并回调
+func (s *oauthService) Authorize(ctx context.Context, userId int64, req *request.OAuthConsentRequest) (string, error) {
+	client, redirectUri, scopes, err := s.validateAuthorize(&req.OAuthAuthorizeRequest)
+	if err != nil {
+		return "", err
+	}
+
+	if !req.Approve {
+		s.logger.Info("用户拒绝 OAuth2 授权", zap.Int64("userId", userId), zap.String("clientKey", client.ClientKey))
+		return buildRedirectUri(redirectUri, map[string]string{
+			"error":             OAuthErrAccessDenied,
+			"error_description": "用户拒绝授权",
+			"state":             req.State,
+		}), nil
+	}
+
+	if err := s.saveConsent(ctx, userId, client.ClientId, scopes); err != nil {
+		return "", err
+	}
+
+	code, err := generateRandomToken(32)
+	if err != nil {
+		return "", fmt.Errorf("生成授权码失败: %w", err)
+	}
+	data, _ := json.Marshal(&oauthCode{
+		ClientId:            client.ClientId,
+		UserId:              userId,
+		RedirectUri:         redirectUri,
+		RedirectUriProvided: req.RedirectUri != "",
+		Scope:               strings.Join(scopes, " "),
+		CodeChallenge:       req.CodeChallenge,
+	})
+	ttl := time.Duration(s.config.OAuth.CodeTimeout) * time.Second
+	if err := s.redis.Set(ctx, OAuthCodeKeyPrefix+generateTokenHash(code), data, ttl).Err(); err != nil {
+		s.logger.Error("保存授权码失败", zap.Error(err))
+		return "", fmt.Errorf("生成授权码失败")
+	}
+
+	s.logger.Info("用户同意 OAuth2 授权",
+		zap.Int64("userId", userId),
+		zap.String("clientKey", client.ClientKey),
+		zap.Strings("scopes", scopes))
+
+	return buildRedirectUri(redirectUri, map[string]string{
+		"code":  code,
+		"state": req.State,
+	}), nil
+}
+
+// Token 令牌端点
+func (s *oauthService) Token(ctx context.Context, clientKey, clientSecret string, req *request.OAuthTokenRequest) (*OAuthToken, error) {
+	switch req.GrantType {
+	case OAuthGrantAuthorizationCode, OAuthGrantClientCredentials, OAuthGrantRefreshToken:
+	default:
+		return nil, newOAuthError(OAuthErrUnsupportedGrantType, "不支持的授权类型")
+	}
+
+	client, err := s.authenticateClient(ctx, clientKey, clientSecret)
+	if err != nil {
+		return nil, err
+	}
+	if !client.IsGrantTypeSupported(req.GrantType) {
+		return nil, newOAuthError(OAuthErrUnauthorizedClient, "客户端未开通该授权类型")
+	}
+
+	switch req.GrantType {
+	case OAuthGrantAuthorizationCode:
+		return s.exchangeCode(ctx, client, req)
+	case OAuthGrantRefreshToken:
+		return s.exchangeRefreshToken(ctx, client, req)
+	default:
+		return s.issueClientCredentials(ctx, client, req)
+	}
+}
+
+// Introspect 令牌内省
+// 访问令牌对所有已认证客户端开放；刷新令牌仅对其所属客户端可见
+func (s *oauthService) Introspect(ctx context.Context, clientKey, clientSecret, token, tokenTypeHint string) (*OAuthIntrospection, error) {
+	client, err := s.authenticateClient(ctx, clientKey, clientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	lookups := []func() *OAuthIntrospection{
+		func() *OAuthIntrospection { return s.introspectAccessToken(ctx, token) },
+		func() *OAuthIntrospection { return s.introspectRefreshToken(ctx, client, token) },
+	}
+	if tokenTypeHint == OAuthGrantRefreshToken {
+		lookups[0], lookups[1] = lookups[1], lookups[0]
+	}
+	for _, lookup := range lookups {
+		if result := lookup(); result != nil {
+			return result, nil
+		}
+	}
+	return &OAuthIntrospection{Active: false}, nil
+}
+
+// Revoke 令牌撤销
+func (s *oauthService) Revoke(ctx context.Context, clientKey, clientSecret, token, tokenTypeHint string) error {
+	client, err := s.authenticateClient(ctx, clientKey, clientSecret)
+	if err != nil {
+		return err
+	}
+
+	// 刷新令牌
+	refreshKey := OAuthRefreshTokenKeyPrefix + generateTokenHash(token)
+	if grant, err := s.getGrant(ctx, refreshKey); err == nil {
+		if grant.ClientId == client.ClientId {
+			s.deleteGrant(ctx, refreshKey, grant)
+			s.logger.Info("撤销 OAuth2 刷新令牌", zap.String("clientKey", client.ClientKey), zap.Int64("userId", grant.UserId))
+		}
+		return nil
+	}
+
+	// 访问令牌
+	claims, err := s.tokenManager.ValidateAccessToken(ctx, token)
+	if err != nil || claims.ClientId != client.ClientId {
+		return nil
+	}
+	if err := s.tokenManager.RevokeAccessToken(ctx, token); err != nil {
+		s.logger.Error("撤销 OAuth2 访问令牌失败", zap.Error(err))
+		return newOAuthError(OAuthErrServerError, "撤销令牌失败")
+	}
+	s.logger.Info("撤销 OAuth2 访问令牌", zap.String("clientKey", client.ClientKey), zap.Int64("userId", claims.UserId))
+	return nil
+}
+
+// UserInfo 根据访问令牌查询用户
+func (s *oauthService) UserInfo(ctx context.Context, accessToken string) (*model.User, string, error) {
+	claims, err := s.tokenManager.ValidateAccessToken(ctx, accessToken)
+	if err != nil {
+		return nil, "", err
+	}
+	if claims.UserId == 0 {
+		return nil, "", fmt.Errorf("客户端凭证令牌不代表任何用户")
+	}
+
+	var user model.User
+	if err := s.db.WithContext(tenant.WithTenantId(ctx, claims.TenantId)).Where("id = ?", claims.UserId).First(&user).Error; err != nil {
+		return nil, "", fmt.Errorf("用户不存在")
+	}
+	if user.Status != 0 {
+		return nil, "", fmt.Errorf("用户已停用")
+	}
+	return &user, claims.Scope, nil
+}
+
+// ListConsents 查询用户已授权的客户端
+func (s *oauthService) ListConsents(ctx context.Context, userId int64) ([]OAuthConsentGrant, error) {
+	var m model.OAuthConsent
+	consents, err := m.FindByUserId(s.db.WithContext(ctx), userId)
+	if err != nil {
+		return nil, fmt.Errorf("查询授权记录失败: %w", err)
+	}
+	if len(consents) == 0 {
+		return []OAuthConsentGrant{}, nil
+	}
+
+	clientIds := make([]string, 0, len(consents))
+	for _, consent := range consents {
+		clientIds = append(clientIds, consent.ClientId)
+	}
+	var clients []model.AuthClient
+	if err := s.db.WithContext(ctx).Where("client_id IN ?", clientIds).Find(&clients).Error; err != nil {
+		return nil, fmt.Errorf("查询客户端失败: %w", err)
+	}
+	clientMap := make(map[string]*model.AuthClient, len(clients))
+	for i := range clients {
+		clientMap[clients[i].ClientId] = &clients[i]
+	}
+
+	result := make([]OAuthConsentGrant, 0, len(consents))
+	for _, consent := range consents {
+		client, ok := clientMap[consent.ClientId]
+		if !ok {
+			continue // 客户端已删除
+		}
+		result = append(result, OAuthConsentGrant{
+			ClientKey:   client.ClientKey,
+			ClientName:  client.ClientName,
+			Scopes:      strings.Fields(consent.Scope),
+			CreatedTime: consent.CreatedTime,
+			UpdatedTime: consent.UpdatedTime,
+		})
+	}
+	return result, nil
+}
+
+// RevokeConsent 撤销用户对客户端的授权
+// 已签发的访问令牌在短期过期后自然失效，刷新令牌立即作废
+func (s *oauthService) RevokeConsent(ctx context.Context, userId int64, clientKey string) error {
+	var cm model.AuthClient
+	client, err := cm.FindByClientKey(s.db.WithContext(ctx), clientKey)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("客户端不存在")
+		}
+		return fmt.Errorf("查询客户端失败: %w", err)
+	}
+
+	var m model.OAuthConsent
+	rows, err := m.Delete(s.db.WithContext(ctx), userId, client.ClientId)
+	if err != nil {
+		return fmt.Errorf("撤销授权失败: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("未授权该应用")
+	}
+
+	indexKey := s.getGrantIndexKey(userId, client.ClientId)
+	hashes, err := s.redis.SMembers(ctx, indexKey).Result()
+	if err != nil && err != redis.Nil {
+		s.logger.Warn("查询 OAuth2 刷新令牌索引失败", zap.Error(err))
+	}
+	keys := make([]string, 0, len(hashes)+1)
+	for _, hash := range hashes {
+		keys = append(keys, OAuthRefreshTokenKeyPrefix+hash)
+	}
+	keys = append(keys, indexKey)
+	if err := s.redis.Del(ctx, keys...).Err(); err != nil {
+		s.logger.Warn("作废 OAuth2 刷新令牌失败", zap.Error(err))
+	}
+
+	s.logger.Info("用户撤销 OAuth2 授权", zap.Int64("userId", userId), zap.String("clientKey", clientKey))
+	return nil
+}
+
+// validateAuthorize 校验授权请求，返回客户端、实际回调地址和授权范围
+func (s *oauthService) validateAuthorize(req *request.OAuthAuthorizeRequest) (*model.AuthClient, string, []string, error) {
+	if req.ResponseType != "code" {
+		return nil, "", nil, newOAuthError(OAuthErrUnsupportedResponseType, "仅支持 response_type=code")
+	}
+
+	var cm model.AuthClient
+	client, err := cm.FindByClientKey(s.db, req.ClientId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", nil, newOAuthError(OAuthErrInvalidClient, "客户端不存在")
+		}
+		return nil, "", nil, newOAuthError(OAuthErrServerError, "查询客户端失败")
+	}
+	if !client.IsActive() {
+		return nil, "", nil, newOAuthError(OAuthErrInvalidClient, "客户端已停用")
+	}
+	if !client.IsGrantTypeSupported(OAuthGrantAuthorizationCode) {
+		return nil, "", nil, newOAuthError(OAuthErrUnauthorizedClient, "客户端未开通授权码模式")
+	}
+
+	// 回调地址必须精确匹配已登记地址；仅登记一个时可省略
+	redirectUri := req.RedirectUri
+	if redirectUri == "" {
+		registered := client.RegisteredRedirectUris()
+		if len(registered) != 1 {
+			return nil, "", nil, newOAuthError(OAuthErrInvalidRequest, "redirect_uri 不能为空")
+		}
+		redirectUri = registered[0]
+	} else if !client.IsRedirectUriAllowed(redirectUri) {
+		return nil, "", nil, newOAuthError(OAuthErrInvalidRequest, "redirect_uri 未登记")
+	}
+
+	// PKCE：仅接受 S256，挑战值为 32 字节 SHA256 的 Base64URL 编码
+	if req.CodeChallengeMethod != "S256" {
+		return nil, "", nil, newOAuthError(OAuthErrInvalidRequest, "code_challenge_method 仅支持 S256")
+	}
+	if len(req.CodeChallenge) != 43 {
+		return nil, "", nil, newOAuthError(OAuthErrInvalidRequest, "code_challenge 格式错误")
+	}
+
+	scopes, err := resolveScopes(req.Scope, client.AllowedScopes())
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if len(scopes) == 0 {
+		return nil, "", nil, newOAuthError(OAuthErrInvalidScope, "客户端未登记可申请的授权范围")
+	}
+	return client, redirectUri, scopes, nil
+}
+
+// exchangeCode 授权码换取令牌
+func (s *oauthService) exchangeCode(ctx context.Context, client *model.AuthClient, req *request.OAuthTokenRequest) (*OAuthToken, error) {
+	if req.Code == "" || req.CodeVerifier == "" {
+		return nil, newOAuthError(OAuthErrInvalidRequest, "code 和 code_verifier 不能为空")
+	}
+
+	// 授权码一次性使用：取出即删除
+	val, err := s.redis.GetDel(ctx, OAuthCodeKeyPrefix+generateTokenHash(req.Code)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, newOAuthError(OAuthErrInvalidGrant, "授权码无效或已过期")
+		}
+		return nil, newOAuthError(OAuthErrServerError, "读取授权码失败")
+	}
+	var code oauthCode
+	if err := json.Unmarshal([]byte(val), &code); err != nil {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "授权码无效或已过期")
+	}
+
+	if code.ClientId != client.ClientId {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "授权码不属于该客户端")
+	}
+	if (code.RedirectUriProvided || req.RedirectUri != "") && req.RedirectUri != code.RedirectUri {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "redirect_uri 与授权请求不一致")
+	}
+	if !verifyCodeChallenge(req.CodeVerifier, code.CodeChallenge) {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "code_verifier 校验失败")
+	}
+
+	user, err := s.loadActiveUser(ctx, code.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return s.issueToken(ctx, client, user, code.Scope)
+}
+
+// exchangeRefreshToken 刷新令牌换取新令牌（轮换刷新令牌）
+func (s *oauthService) exchangeRefreshToken(ctx context.Context, client *model.AuthClient, req *request.OAuthTokenRequest) (*OAuthToken, error) {
+	if req.RefreshToken == "" {
+		return nil, newOAuthError(OAuthErrInvalidRequest, "refresh_token 不能为空")
+	}
+
+	refreshKey := OAuthRefreshTokenKeyPrefix + generateTokenHash(req.RefreshToken)
+	grant, err := s.getGrant(ctx, refreshKey)
+	if err != nil {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "刷新令牌无效或已过期")
+	}
+	if grant.ClientId != client.ClientId {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "刷新令牌不属于该客户端")
+	}
+
+	// 并发刷新时只有一个请求能成功占用旧令牌
+	deleted, err := s.redis.Del(ctx, refreshKey).Result()
+	if err != nil {
+		return nil, newOAuthError(OAuthErrServerError, "刷新令牌失败")
+	}
+	if deleted == 0 {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "刷新令牌无效或已过期")
+	}
+	_ = s.redis.SRem(ctx, s.getGrantIndexKey(grant.UserId, grant.ClientId), generateTokenHash(req.RefreshToken)).Err()
+
+	// 用户批量吊销（停用、改密、强制下线）之前签发的授权一并失效
+	if s.isGrantRevoked(ctx, grant) {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "授权已失效，请重新授权")
+	}
+
+	scope := grant.Scope
+	if req.Scope != "" {
+		scopes, err := resolveScopes(req.Scope, strings.Fields(grant.Scope))
+		if err != nil {
+			return nil, err
+		}
+		scope = strings.Join(scopes, " ")
+	}
+
+	user, err := s.loadActiveUser(ctx, grant.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return s.issueToken(ctx, client, user, scope)
+}
+
+// issueClientCredentials 客户端模式签发令牌（不签发刷新令牌）
+func (s *oauthService) issueClientCredentials(ctx context.Context, client *model.AuthClient, req *request.OAuthTokenRequest) (*OAuthToken, error) {
+	scopes, err := resolveScopes(req.Scope, client.AllowedScopes())
+	if err != nil {
+		return nil, err
+	}
+	scope := strings.Join(scopes, " ")
+
+	accessToken, expiresIn, err := s.jwt.GenerateTokenWithClaims(jwt.Claims{
+		UserName:   client.ClientKey,
+		ClientId:   client.ClientId,
+		DeviceType: client.DeviceType,
+		Scope:      scope,
+	}, client.ActiveTimeout)
+	if err != nil {
+		s.logger.Error("签发客户端令牌失败", zap.Error(err))
+		return nil, newOAuthError(OAuthErrServerError, "签发令牌失败")
+	}
+
+	s.logger.Info("签发 OAuth2 客户端令牌", zap.String("clientKey", client.ClientKey), zap.String("scope", scope))
+	return &OAuthToken{AccessToken: accessToken, ExpiresIn: expiresIn, Scope: scope}, nil
+}
+
+// issueToken 为用户签发访问令牌，客户端开通 refresh_token 时同时签发刷新令牌
+func (s *oauthService) issueToken(ctx context.Context, client *model.AuthClient, user *model.User, scope string) (*OAuthToken, error) {
+	accessToken, expiresIn, err := s.jwt.GenerateTokenWithClaims(jwt.Claims{
+		UserId:     user.ID,
+		UserName:   user.UserName,
+		ClientId:   client.ClientId,
+		DeviceType: client.DeviceType,
+		TenantId:   user.TenantId,
+		Scope:      scope,
+	}, client.ActiveTimeout)
+	if err != nil {
+		s.logger.Error("签发 OAuth2 访问令牌失败", zap.Error(err))
+		return nil, newOAuthError(OAuthErrServerError, "签发令牌失败")
+	}
+	token := &OAuthToken{AccessToken: accessToken, ExpiresIn: expiresIn, Scope: scope}
+
+	if client.IsGrantTypeSupported(OAuthGrantRefreshToken) {
+		refreshToken, err := generateRandomToken(32)
+		if err != nil {
+			return nil, newOAuthError(OAuthErrServerError, "签发令牌失败")
+		}
+		hash := generateTokenHash(refreshToken)
+		data, _ := json.Marshal(&oauthGrant{
+			ClientId: client.ClientId,
+			UserId:   user.ID,
+			Scope:    scope,
+			IssuedAt: time.Now().Unix(),
+		})
+		ttl := time.Duration(client.Timeout) * time.Second
+		indexKey := s.getGrantIndexKey(user.ID, client.ClientId)
+
+		pipe := s.redis.TxPipeline()
+		pipe.Set(ctx, OAuthRefreshTokenKeyPrefix+hash, data, ttl)
+		pipe.SAdd(ctx, indexKey, hash)
+		pipe.Expire(ctx, indexKey, ttl)
+		if _, err := pipe.Exec(ctx); err != nil {
+			s.logger.Error("保存 OAuth2 刷新令牌失败", zap.Error(err))
+			return nil, newOAuthError(OAuthErrServerError, "签发令牌失败")
+		}
+		token.RefreshToken = refreshToken
+	}
+
+	s.logger.Info("签发 OAuth2 令牌",
+		zap.Int64("userId", user.ID),
+		zap.String("clientKey", client.ClientKey),
+		zap.String("scope", scope))
+	return token, nil
+}
+
+// introspectAccessToken 内省访问令牌，无效时返回 nil
+func (s *oauthService) introspectAccessToken(ctx context.Context, token string) *OAuthIntrospection {
+	claims, err := s.tokenManager.ValidateAccessToken(ctx, token)
+	if err != nil {
+		return nil
+	}
+
+	result := &OAuthIntrospection{
+		Active:    true,
+		Scope:     claims.Scope,
+		Username:  claims.UserName,
+		TokenType: "Bearer",
+		Issuer:    claims.Issuer,
+		Jti:       claims.ID,
+		Subject:   strconv.FormatInt(claims.UserId, 10),
+	}
+	if claims.ExpiresAt != nil {
+		result.ExpiresAt = claims.ExpiresAt.Unix()
+	}
+	if claims.IssuedAt != nil {
+		result.IssuedAt = claims.IssuedAt.Unix()
+	}
+	var cm model.AuthClient
+	if client, err := cm.FindByClientId(s.db.WithContext(ctx), claims.ClientId); err == nil {
+		result.ClientKey = client.ClientKey
+	}
+	if claims.UserId == 0 {
+		// 客户端模式令牌的主体为客户端本身
+		result.Subject = result.ClientKey
+		result.Username = ""
+	}
+	return result
+}
+
+// introspectRefreshToken 内省刷新令牌，无效或不属于请求方客户端时返回 nil
+func (s *oauthService) introspectRefreshToken(ctx context.Context, client *model.AuthClient, token string) *OAuthIntrospection {
+	refreshKey := OAuthRefreshTokenKeyPrefix + generateTokenHash(token)
+	grant, err := s.getGrant(ctx, refreshKey)
+	if err != nil || grant.ClientId != client.ClientId || s.isGrantRevoked(ctx, grant) {
+		return nil
+	}
+
+	result := &OAuthIntrospection{
+		Active:    true,
+		Scope:     grant.Scope,
+		ClientKey: client.ClientKey,
+		TokenType: OAuthGrantRefreshToken,
+		IssuedAt:  grant.IssuedAt,
+		Subject:   strconv.FormatInt(grant.UserId, 10),
+	}
+	if ttl, err := s.redis.TTL(ctx, refreshKey).Result(); err == nil && ttl > 0 {
+		result.ExpiresAt = time.Now().Add(ttl).Unix()
+	}
+	var user model.User
+	if err := s.db.WithContext(tenant.WithoutTenant(ctx)).Select("user_name").Where("id = ?", grant.UserId).First(&user).Error; err == nil {
+		result.Username = user.UserName
+	}
+	return result
+}
+
+// authenticateClient 认证客户端（令牌端点、内省、撤销共用）
+func (s *oauthService) authenticateClient(ctx context.Context, clientKey, clientSecret string) (*model.AuthClient, error) {
+	if clientKey == "" || clientSecret == "" {
+		return nil, newOAuthError(OAuthErrInvalidClient, "缺少客户端认证信息")
+	}
+	var cm model.AuthClient
+	client, err := cm.FindByClientKey(s.db.WithContext(ctx), clientKey)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, newOAuthError(OAuthErrInvalidClient, "客户端认证失败")
+		}
+		return nil, newOAuthError(OAuthErrServerError, "查询客户端失败")
+	}
+	if !client.VerifySecret(clientSecret) || !client.IsActive() {
+		return nil, newOAuthError(OAuthErrInvalidClient, "客户端认证失败")
+	}
+	upgradeLegacyClientSecret(ctx, s.db.WithContext(ctx), s.redis, s.logger, client, clientSecret)
+	return client, nil
+}
+
+// loadActiveUser 查询正常状态的用户
+// 令牌端点由第三方应用调用，不携带租户信息，按授权码/刷新令牌中的用户ID跨租户查询
+func (s *oauthService) loadActiveUser(ctx context.Context, userId int64) (*model.User, error) {
+	var user model.User
+	if err := s.db.WithContext(tenant.WithoutTenant(ctx)).Where("id = ?", userId).First(&user).Error; err != nil {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "用户不存在")
+	}
+	if user.Status != 0 {
+		return nil, newOAuthError(OAuthErrInvalidGrant, "用户已停用")
+	}
+	return &user, nil
+}
+
+// saveConsent 记录用户同意的授权范围（与已有范围合并）
+func (s *oauthService) saveConsent(ctx context.Context, userId int64, clientId string, scopes []string) error {
+	var m model.OAuthConsent
+	consent, err := m.Find(s.db.WithContext(ctx), userId, clientId)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("查询授权记录失败: %w", err)
+		}
+		consent = &model.OAuthConsent{UserId: userId, ClientId: clientId, Scope: strings.Join(scopes, " ")}
+		if err := consent.Create(s.db.WithContext(ctx)); err != nil {
+			return fmt.Errorf("保存授权记录失败: %w", err)
+		}
+		return nil
+	}
+
+	merged := strings.Fields(consent.Scope)
+	for _, scope := range scopes {
+		if !slices.Contains(merged, scope) {
+			merged = append(merged, scope)
+		}
+	}
+	if err := m.UpdateScope(s.db.WithContext(ctx), consent.ID, strings.Join(merged, " ")); err != nil {
+		return fmt.Errorf("保存授权记录失败: %w", err)
+	}
+	return nil
+}
+
+// getGrant 读取刷新令牌绑定的授权信息
+func (s *oauthService) getGrant(ctx context.Context, refreshKey string) (*oauthGrant, error) {
+	val, err := s.redis.Get(ctx, refreshKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	var grant oauthGrant
+	if err := json.Unmarshal([]byte(val), &grant); err != nil {
+		return nil, err
+	}
+	return &grant, nil
+}
+
+// deleteGrant 删除刷新令牌及其索引
+func (s *oauthService) deleteGrant(ctx context.Context, refreshKey string, grant *oauthGrant) {
+	hash := strings.TrimPrefix(refreshKey, OAuthRefreshTokenKeyPrefix)
+	pipe := s.redis.TxPipeline()
+	pipe.Del(ctx, refreshKey)
+	pipe.SRem(ctx, s.getGrantIndexKey(grant.UserId, grant.ClientId), hash)
+	if _, err := pipe.Exec(ctx); err != nil {
+		s.logger.Warn("删除 OAuth2 刷新令牌失败", zap.Error(err))
+	}
+}
+
+// isGrantRevoked 判断授权是否早于用户的批量吊销时间点
+func (s *oauthService) isGrantRevoked(ctx context.Context, grant *oauthGrant) bool {
+	val, err := s.redis.Get(ctx, fmt.Sprintf("%s%d", TokenRevokeBeforeKeyPrefix, grant.UserId)).Result()
+	if err != nil {
+		return false
+	}
+	before := parseRevokeBefore(val)
+	// 授权签发时间只有秒级精度，与吊销时间点同一秒的授权按已吊销处理
+	return before > 0 && grant.IssuedAt*1000 <= before
+}
+
+// getGrantIndexKey 获取刷新令牌索引 Redis Key
+func (s *oauthService) getGrantIndexKey(userId int64, clientId string) string {
+	return fmt.Sprintf("%s%d:%s", OAuthGrantIndexKeyPrefix, userId, clientId)
+}
+
+// resolveScopes 解析申请的授权范围，必须是允许范围的子集；为空时授予全部允许范围
+func resolveScopes(requested string, allowed []string) ([]string, error) {
+	if strings.TrimSpace(requested) == "" {
+		return allowed, nil
+	}
+	scopes := make([]string, 0)
+	for _, scope := range strings.Fields(requested) {
+		if !slices.Contains(allowed, scope) {
+			return nil, newOAuthError(OAuthErrInvalidScope, "不允许申请的授权范围: "+scope)
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
+
+// containsAllScopes 判断 granted 是否包含 wanted 中的全部范围
+func containsAllScopes(granted, wanted []string) bool {
+	for _, w := range wanted {
+		if !slices.Contains(granted, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// verifyCodeChallenge 校验 PKCE（S256）：BASE64URL(SHA256(code_verifier)) == code_challenge
+func verifyCodeChallenge(verifier, challenge string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
+}
+
+// buildRedirectUri 在回调地址上追加查询参数，空值参数忽略
+func buildRedirectUri(redirectUri string, params map[string]string) string {
+	u, err := url.Parse(redirectUri)
+	if err != nil {
+		return redirectUri
+	}
+	query := u.Query()
+	for k, v := range params {
+		if v != "" {
+			query.Set(k, v)
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
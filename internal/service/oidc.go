@@ -0,0 +1,421 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/force-c/nai-tizi/internal/config"
+	"github.com/force-c/nai-tizi/internal/constants"
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/infrastructure/thirdparty/oidc"
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+const (
+	// OidcStateKeyPrefix 第三方登录授权请求 Redis Key 前缀
+	// oidc:state:{state} -> oidcState(JSON)
+	OidcStateKeyPrefix = "oidc:state:"
+
+	// OidcStateTTL 授权请求有效期
+	OidcStateTTL = 10 * time.Minute
+
+	oidcPurposeLogin = "login"
+	oidcPurposeLink  = "link"
+)
+
+// usernameSanitizer 自动创建用户时用户名允许的字符
+var usernameSanitizer = regexp.MustCompile(`[^a-zA-Z0-9_.@-]`)
+
+// OidcService 第三方 OIDC 登录服务接口
+type OidcService interface {
+	// Providers 返回已配置的提供方
+	Providers() []oidc.ProviderConfig
+
+	// BeginLogin 开始登录，返回跳转到提供方的授权地址和 state
+	BeginLogin(ctx context.Context, provider, clientKey string) (string, string, error)
+
+	// FinishLogin 完成登录：校验 state 和 ID Token，按绑定关系匹配用户，未绑定时按配置自动创建
+	FinishLogin(ctx context.Context, clientKey, state, code string) (*model.User, error)
+
+	// BeginLink 开始绑定，返回跳转到提供方的授权地址和 state
+	BeginLink(ctx context.Context, userId int64, provider string) (string, string, error)
+
+	// FinishLink 完成绑定
+	FinishLink(ctx context.Context, userId int64, state, code string) (*model.UserIdentity, error)
+
+	// ListIdentities 查询用户绑定的第三方身份
+	ListIdentities(ctx context.Context, userId int64) ([]model.UserIdentity, error)
+
+	// Unlink 解除绑定
+	Unlink(ctx context.Context, userId, identityId int64) error
+}
+
+// oidcState 授权请求上下文，回调时校验并一次性取出
+type oidcState struct {
+	Provider  string `json:"provider"`
+	Nonce     string `json:"nonce"`
+	Verifier  string `json:"verifier"`
+	Purpose   string `json:"purpose"`
+	ClientKey string `json:"clientKey,omitempty"`
+	UserId    int64  `json:"userId,omitempty"`
+}
+
+type oidcService struct {
+	db          *gorm.DB
+	redis       *redis.Client
+	manager     *oidc.Manager
+	roleService RoleService
+	config      *config.Config
+	logger      logging.Logger
+}
+
+// NewOidcService 创建第三方 OIDC 登录服务实例，manager 为空表示未配置提供方
+func NewOidcService(db *gorm.DB, redis *redis.Client, manager *oidc.Manager, roleService RoleService, cfg *config.Config, logger logging.Logger) OidcService {
+	return &oidcService{
+		db:          db,
+		redis:       redis,
+		manager:     manager,
+		roleService: roleService,
+		config:      cfg,
+		logger:      logger,
+	}
+}
+
+// Providers 返回已配置的提供方
+func (s *oidcService) Providers() []oidc.ProviderConfig {
+	if s.manager == nil {
+		return []oidc.ProviderConfig{}
+	}
+	return s.manager.Providers()
+}
+
+// BeginLogin 开始登录
+func (s *oidcService) BeginLogin(ctx context.Context, provider, clientKey string) (string, string, error) {
+	return s.begin(ctx, &oidcState{Provider: provider, Purpose: oidcPurposeLogin, ClientKey: clientKey})
+}
+
+// FinishLogin 完成登录
+func (s *oidcService) FinishLogin(ctx context.Context, clientKey, state, code string) (*model.User, error) {
+	st, err := s.takeState(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	if st.Purpose != oidcPurposeLogin || st.ClientKey != clientKey {
+		return nil, fmt.Errorf("授权请求无效，请重新登录")
+	}
+
+	identity, err := s.manager.Exchange(ctx, st.Provider, code, st.Verifier, st.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	var im model.UserIdentity
+	linked, err := im.FindBySubject(s.db.WithContext(ctx), identity.Provider, identity.Subject)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		s.logger.Error("查询第三方身份失败", zap.String("provider", identity.Provider), zap.Error(err))
+		return nil, fmt.Errorf("登录失败")
+	}
+
+	if linked != nil {
+		user, err := (&model.User{}).FindByID(s.db.WithContext(ctx), linked.UserId)
+		if err == nil {
+			if err := im.TouchLogin(s.db.WithContext(ctx), linked.ID, time.Now().Unix()); err != nil {
+				s.logger.Warn("更新第三方身份登录时间失败", zap.Int64("identityId", linked.ID), zap.Error(err))
+			}
+			return user, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Error("查询用户失败", zap.Int64("userId", linked.UserId), zap.Error(err))
+			return nil, fmt.Errorf("登录失败")
+		}
+		// 绑定的用户已被删除，清理失效的绑定后按新身份处理
+		if err := im.DeleteById(s.db.WithContext(ctx), linked.ID); err != nil {
+			s.logger.Error("清理失效的第三方身份失败", zap.Int64("identityId", linked.ID), zap.Error(err))
+			return nil, fmt.Errorf("登录失败")
+		}
+	}
+
+	providerCfg := s.providerConfig(identity.Provider)
+	if providerCfg == nil || !providerCfg.AutoProvision {
+		return nil, fmt.Errorf("该第三方账号未绑定系统用户，请使用其他方式登录后在个人资料中绑定")
+	}
+	return s.provision(ctx, identity, providerCfg)
+}
+
+// BeginLink 开始绑定
+func (s *oidcService) BeginLink(ctx context.Context, userId int64, provider string) (string, string, error) {
+	return s.begin(ctx, &oidcState{Provider: provider, Purpose: oidcPurposeLink, UserId: userId})
+}
+
+// FinishLink 完成绑定
+func (s *oidcService) FinishLink(ctx context.Context, userId int64, state, code string) (*model.UserIdentity, error) {
+	st, err := s.takeState(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	if st.Purpose != oidcPurposeLink || st.UserId != userId {
+		return nil, fmt.Errorf("授权请求无效，请重新绑定")
+	}
+
+	identity, err := s.manager.Exchange(ctx, st.Provider, code, st.Verifier, st.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	var im model.UserIdentity
+	existing, err := im.FindBySubject(s.db.WithContext(ctx), identity.Provider, identity.Subject)
+	if err == nil {
+		if existing.UserId == userId {
+			return existing, nil
+		}
+		return nil, fmt.Errorf("该第三方账号已绑定其他用户")
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		s.logger.Error("查询第三方身份失败", zap.String("provider", identity.Provider), zap.Error(err))
+		return nil, fmt.Errorf("绑定失败")
+	}
+
+	identities, err := im.FindByUserId(s.db.WithContext(ctx), userId)
+	if err != nil {
+		return nil, fmt.Errorf("查询绑定记录失败: %w", err)
+	}
+	for _, i := range identities {
+		if i.Provider == identity.Provider {
+			return nil, fmt.Errorf("已绑定该提供方的其他账号，请先解除绑定")
+		}
+	}
+
+	record := &model.UserIdentity{
+		UserId:   userId,
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+		Nickname: truncateRunes(identity.Nickname, 64),
+	}
+	if err := record.Create(s.db.WithContext(ctx)); err != nil {
+		s.logger.Error("保存第三方身份失败", zap.Int64("userId", userId), zap.Error(err))
+		return nil, fmt.Errorf("绑定失败: %w", err)
+	}
+
+	s.logger.Info("绑定第三方身份", zap.Int64("userId", userId), zap.String("provider", identity.Provider))
+	return record, nil
+}
+
+// ListIdentities 查询用户绑定的第三方身份
+func (s *oidcService) ListIdentities(ctx context.Context, userId int64) ([]model.UserIdentity, error) {
+	identities, err := (&model.UserIdentity{}).FindByUserId(s.db.WithContext(ctx), userId)
+	if err != nil {
+		s.logger.Error("查询第三方身份失败", zap.Int64("userId", userId), zap.Error(err))
+		return nil, fmt.Errorf("查询第三方身份失败: %w", err)
+	}
+	return identities, nil
+}
+
+// Unlink 解除绑定
+// 未设置密码的用户（自动创建）只能通过第三方登录，不允许解除最后一个绑定
+func (s *oidcService) Unlink(ctx context.Context, userId, identityId int64) error {
+	user, err := (&model.User{}).FindByID(s.db.WithContext(ctx), userId)
+	if err != nil {
+		return fmt.Errorf("用户不存在")
+	}
+
+	var im model.UserIdentity
+	if user.Password == "" {
+		count, err := im.CountByUserId(s.db.WithContext(ctx), userId)
+		if err != nil {
+			return fmt.Errorf("查询绑定记录失败: %w", err)
+		}
+		if count <= 1 {
+			return fmt.Errorf("账号未设置密码，不能解除唯一的第三方登录方式")
+		}
+	}
+
+	rows, err := im.Delete(s.db.WithContext(ctx), userId, identityId)
+	if err != nil {
+		s.logger.Error("解除第三方身份绑定失败", zap.Int64("identityId", identityId), zap.Error(err))
+		return fmt.Errorf("解除绑定失败: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("绑定记录不存在")
+	}
+
+	s.logger.Info("解除第三方身份绑定", zap.Int64("userId", userId), zap.Int64("identityId", identityId))
+	return nil
+}
+
+// begin 生成 state、nonce 和 PKCE 校验码，保存授权请求后返回授权地址
+func (s *oidcService) begin(ctx context.Context, st *oidcState) (string, string, error) {
+	if s.manager == nil {
+		return "", "", fmt.Errorf("未配置第三方登录")
+	}
+
+	state, err := generateRandomToken(32)
+	if err != nil {
+		return "", "", fmt.Errorf("生成授权请求失败")
+	}
+	if st.Nonce, err = generateRandomToken(32); err != nil {
+		return "", "", fmt.Errorf("生成授权请求失败")
+	}
+	st.Verifier = oauth2.GenerateVerifier()
+
+	authURL, err := s.manager.AuthCodeURL(ctx, st.Provider, state, st.Nonce, st.Verifier)
+	if err != nil {
+		return "", "", err
+	}
+
+	data, err := json.Marshal(st)
+	if err != nil {
+		return "", "", fmt.Errorf("生成授权请求失败")
+	}
+	if err := s.redis.Set(ctx, OidcStateKeyPrefix+state, data, OidcStateTTL).Err(); err != nil {
+		s.logger.Error("保存 OIDC 授权请求失败", zap.Error(err))
+		return "", "", fmt.Errorf("生成授权请求失败")
+	}
+	return authURL, state, nil
+}
+
+// takeState 取出并删除授权请求，state 只能使用一次
+func (s *oidcService) takeState(ctx context.Context, state string) (*oidcState, error) {
+	if s.manager == nil {
+		return nil, fmt.Errorf("未配置第三方登录")
+	}
+	if state == "" {
+		return nil, fmt.Errorf("授权请求已过期，请重试")
+	}
+	data, err := s.redis.GetDel(ctx, OidcStateKeyPrefix+state).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("授权请求已过期，请重试")
+		}
+		return nil, fmt.Errorf("查询授权请求失败")
+	}
+	var st oidcState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("授权请求数据异常")
+	}
+	return &st, nil
+}
+
+// provision 自动创建用户并绑定身份，分配提供方配置的默认角色
+func (s *oidcService) provision(ctx context.Context, identity *oidc.Identity, providerCfg *config.OIDCProvider) (*model.User, error) {
+	var um model.User
+	username, err := s.uniqueUsername(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &model.User{
+		OrgId:    providerCfg.DefaultOrgId,
+		UserName: username,
+		NickName: truncateRunes(identity.Nickname, 64),
+		UserType: constants.UserTypeSystem,
+		Avatar:   identity.Avatar,
+		Status:   constants.StatusNormal,
+		Remark:   "通过 " + providerCfg.Name + " 自动创建",
+	}
+	if user.NickName == "" {
+		user.NickName = username
+	}
+	// 仅使用提供方已验证且未被占用的邮箱
+	if identity.Email != "" && identity.EmailVerified {
+		if _, err := um.FindByEmail(s.db.WithContext(ctx), identity.Email); errors.Is(err, gorm.ErrRecordNotFound) {
+			user.Email = identity.Email
+		}
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := um.Create(tx, user); err != nil {
+			return err
+		}
+		record := &model.UserIdentity{
+			UserId:      user.ID,
+			Provider:    identity.Provider,
+			Subject:     identity.Subject,
+			Email:       identity.Email,
+			Nickname:    truncateRunes(identity.Nickname, 64),
+			LastLoginAt: time.Now().Unix(),
+		}
+		return record.Create(tx)
+	})
+	if err != nil {
+		s.logger.Error("自动创建第三方登录用户失败", zap.String("provider", identity.Provider), zap.Error(err))
+		return nil, fmt.Errorf("创建用户失败")
+	}
+
+	if providerCfg.DefaultRoleKey != "" {
+		role, err := s.roleService.GetByRoleKey(ctx, providerCfg.DefaultRoleKey)
+		if err == nil {
+			err = s.roleService.AssignRoleToUser(ctx, user.ID, role.ID)
+		}
+		if err != nil {
+			s.logger.Warn("为自动创建的用户分配默认角色失败",
+				zap.Int64("userId", user.ID),
+				zap.String("roleKey", providerCfg.DefaultRoleKey),
+				zap.Error(err))
+		}
+	}
+
+	s.logger.Info("自动创建第三方登录用户",
+		zap.Int64("userId", user.ID),
+		zap.String("provider", identity.Provider),
+		zap.String("username", username))
+	return user, nil
+}
+
+// uniqueUsername 按提供方返回的用户名或邮箱生成未被占用的用户名
+func (s *oidcService) uniqueUsername(ctx context.Context, identity *oidc.Identity) (string, error) {
+	base := identity.Username
+	if base == "" && identity.Email != "" {
+		base = strings.SplitN(identity.Email, "@", 2)[0]
+	}
+	base = truncateRunes(usernameSanitizer.ReplaceAllString(base, ""), 48)
+	if base == "" {
+		base = identity.Provider
+	}
+
+	var um model.User
+	candidate := base
+	for i := 0; i < 5; i++ {
+		_, err := um.FindByUsername(s.db.WithContext(ctx), candidate)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("查询用户失败")
+		}
+		suffix, err := generateRandomToken(3)
+		if err != nil {
+			return "", fmt.Errorf("创建用户失败")
+		}
+		candidate = base + "_" + suffix
+	}
+	return "", fmt.Errorf("无法生成可用的用户名")
+}
+
+// providerConfig 查询提供方配置
+func (s *oidcService) providerConfig(name string) *config.OIDCProvider {
+	for i := range s.config.OIDC.Providers {
+		if s.config.OIDC.Providers[i].Name == name {
+			return &s.config.OIDC.Providers[i]
+		}
+	}
+	return nil
+}
+
+// truncateRunes 按字符数截断字符串
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
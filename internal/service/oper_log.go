@@ -72,7 +72,7 @@ func (s *operLogService) Create(ctx context.Context, req *request.CreateOperLogR
 		UserAgent:     req.UserAgent,
 	}
 
-	if err := log.Create(s.db); err != nil {
+	if err := log.Create(s.db.WithContext(ctx)); err != nil {
 		s.logger.Error("创建操作日志失败", zap.Error(err))
 		return fmt.Errorf("创建操作日志失败: %w", err)
 	}
@@ -88,7 +88,7 @@ func (s *operLogService) Create(ctx context.Context, req *request.CreateOperLogR
 // Update 更新操作日志
 func (s *operLogService) Update(ctx context.Context, req *request.UpdateOperLogRequest) error {
 	// 检查日志是否存在
-	_, err := (&model.OperLog{}).FindByID(s.db, req.ID)
+	_, err := (&model.OperLog{}).FindByID(s.scoped(ctx), req.ID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("操作日志不存在")
@@ -117,7 +117,7 @@ func (s *operLogService) Update(ctx context.Context, req *request.UpdateOperLogR
 	}
 
 	log := &model.OperLog{}
-	if err := log.Update(s.db, req.ID, updates); err != nil {
+	if err := log.Update(s.db.WithContext(ctx), req.ID, updates); err != nil {
 		s.logger.Error("更新操作日志失败", zap.Error(err))
 		return fmt.Errorf("更新操作日志失败: %w", err)
 	}
@@ -129,7 +129,7 @@ func (s *operLogService) Update(ctx context.Context, req *request.UpdateOperLogR
 // Delete 删除操作日志
 func (s *operLogService) Delete(ctx context.Context, id int64) error {
 	// 检查日志是否存在
-	_, err := (&model.OperLog{}).FindByID(s.db, id)
+	_, err := (&model.OperLog{}).FindByID(s.scoped(ctx), id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("操作日志不存在")
@@ -139,7 +139,7 @@ func (s *operLogService) Delete(ctx context.Context, id int64) error {
 	}
 
 	// 删除日志
-	if err := (&model.OperLog{}).Delete(s.db, id); err != nil {
+	if err := (&model.OperLog{}).Delete(s.db.WithContext(ctx), id); err != nil {
 		s.logger.Error("删除操作日志失败", zap.Error(err))
 		return fmt.Errorf("删除操作日志失败: %w", err)
 	}
@@ -155,7 +155,7 @@ func (s *operLogService) BatchDelete(ctx context.Context, ids []int64) error {
 	}
 
 	// 批量删除
-	rowsAffected, err := (&model.OperLog{}).BatchDelete(s.db, ids)
+	rowsAffected, err := (&model.OperLog{}).BatchDelete(s.scoped(ctx), ids)
 	if err != nil {
 		s.logger.Error("批量删除操作日志失败", zap.Error(err))
 		return fmt.Errorf("批量删除操作日志失败: %w", err)
@@ -167,7 +167,7 @@ func (s *operLogService) BatchDelete(ctx context.Context, ids []int64) error {
 
 // GetById 根据ID查询操作日志
 func (s *operLogService) GetById(ctx context.Context, id int64) (*model.OperLog, error) {
-	log, err := (&model.OperLog{}).FindByID(s.db, id)
+	log, err := (&model.OperLog{}).FindByID(s.scoped(ctx), id)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("操作日志不存在")
@@ -181,7 +181,7 @@ func (s *operLogService) GetById(ctx context.Context, id int64) (*model.OperLog,
 // Page 分页查询操作日志列表
 func (s *operLogService) Page(ctx context.Context, req *request.PageOperLogRequest) (*pagination.Page[model.OperLog], error) {
 	// 1. 构建查询条件
-	query := s.db.Model(&model.OperLog{})
+	query := s.scoped(ctx).Model(&model.OperLog{})
 
 	// 2. 添加条件过滤
 	if req.Title != "" {
@@ -190,6 +190,9 @@ func (s *operLogService) Page(ctx context.Context, req *request.PageOperLogReque
 	if req.OperName != "" {
 		query = query.Where("oper_name LIKE ?", "%"+req.OperName+"%")
 	}
+	if req.ActorName != "" {
+		query = query.Where("actor_name LIKE ?", "%"+req.ActorName+"%")
+	}
 	if req.BusinessType != "" {
 		query = query.Where("business_type = ?", req.BusinessType)
 	}
@@ -233,7 +236,7 @@ func (s *operLogService) CleanOldLogs(ctx context.Context, days int) (int64, err
 		return 0, fmt.Errorf("天数必须大于0")
 	}
 
-	rowsAffected, err := (&model.OperLog{}).CleanOldLogs(s.db, days)
+	rowsAffected, err := (&model.OperLog{}).CleanOldLogs(s.scoped(ctx), days)
 	if err != nil {
 		s.logger.Error("清理操作日志失败", zap.Error(err), zap.Int("days", days))
 		return 0, fmt.Errorf("清理操作日志失败: %w", err)
@@ -242,3 +245,8 @@ func (s *operLogService) CleanOldLogs(ctx context.Context, days int) (int64, err
 	s.logger.Info("清理操作日志成功", zap.Int64("count", rowsAffected), zap.Int("days", days))
 	return rowsAffected, nil
 }
+
+// scoped 返回按当前请求数据权限过滤操作日志的查询（每次查询需重新获取）
+func (s *operLogService) scoped(ctx context.Context) *gorm.DB {
+	return s.db.WithContext(ctx).Scopes(OperLogDataScope(ctx))
+}
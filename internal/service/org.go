@@ -54,8 +54,13 @@ func NewOrgService(db *gorm.DB, logger logging.Logger) OrgService {
 
 // Create 创建组织，返回组织ID
 func (s *orgService) Create(ctx context.Context, req *request.CreateOrgRequest) (int64, error) {
+	// 只能在数据权限范围内的组织下创建子组织
+	if !DataScopeFromContext(ctx).AllowsOrg(req.ParentId) {
+		return 0, errors.New("无权在该组织下创建子组织")
+	}
+
 	// 检查组织编码唯一性
-	exists, err := (&model.Org{}).CheckOrgCodeExists(s.db, req.OrgCode)
+	exists, err := (&model.Org{}).CheckOrgCodeExists(s.db.WithContext(ctx), req.OrgCode)
 	if err != nil {
 		s.logger.Error("检查组织编码失败", zap.Error(err))
 		return 0, fmt.Errorf("检查组织编码失败: %w", err)
@@ -65,7 +70,7 @@ func (s *orgService) Create(ctx context.Context, req *request.CreateOrgRequest)
 	}
 
 	// 构建祖级列表
-	ancestors, err := (&model.Org{}).BuildAncestors(s.db, req.ParentId)
+	ancestors, err := (&model.Org{}).BuildAncestors(s.db.WithContext(ctx), req.ParentId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return 0, errors.New("父组织不存在")
@@ -76,19 +81,20 @@ func (s *orgService) Create(ctx context.Context, req *request.CreateOrgRequest)
 
 	// 创建组织对象
 	org := &model.Org{
-		ParentId:  req.ParentId,
-		Ancestors: ancestors,
-		OrgName:   req.OrgName,
-		OrgCode:   req.OrgCode,
-		OrgType:   req.OrgType,
-		Leader:    req.Leader,
-		Phone:     req.Phone,
-		Email:     req.Email,
-		Status:    req.Status,
-		Sort:      req.Sort,
-		Remark:    req.Remark,
-		CreateBy:  req.CreateBy,
-		UpdateBy:  req.UpdateBy,
+		ParentId:    req.ParentId,
+		Ancestors:   ancestors,
+		OrgName:     req.OrgName,
+		OrgCode:     req.OrgCode,
+		OrgType:     req.OrgType,
+		Leader:      req.Leader,
+		Phone:       req.Phone,
+		Email:       req.Email,
+		Status:      req.Status,
+		RegApproval: req.RegApproval,
+		Sort:        req.Sort,
+		Remark:      req.Remark,
+		CreateBy:    req.CreateBy,
+		UpdateBy:    req.UpdateBy,
 	}
 
 	// 设置默认值
@@ -100,7 +106,7 @@ func (s *orgService) Create(ctx context.Context, req *request.CreateOrgRequest)
 	}
 
 	// 调用模型层的创建方法
-	if err := org.Create(s.db, org); err != nil {
+	if err := org.Create(s.db.WithContext(ctx), org); err != nil {
 		s.logger.Error("创建组织失败", zap.Error(err))
 		return 0, fmt.Errorf("创建组织失败: %w", err)
 	}
@@ -115,8 +121,8 @@ func (s *orgService) Update(ctx context.Context, req *request.UpdateOrgRequest)
 		return errors.New("组织ID不能为空")
 	}
 
-	// 检查组织是否存在
-	existingOrg, err := (&model.Org{}).FindByID(s.db, req.OrgId)
+	// 检查组织是否存在（且在数据权限范围内）
+	existingOrg, err := (&model.Org{}).FindByID(s.scoped(ctx), req.OrgId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("组织不存在")
@@ -127,7 +133,7 @@ func (s *orgService) Update(ctx context.Context, req *request.UpdateOrgRequest)
 
 	// 检查组织编码是否被其他组织占用
 	if req.OrgCode != "" && req.OrgCode != existingOrg.OrgCode {
-		exists, err := (&model.Org{}).CheckOrgCodeExistsExcludingSelf(s.db, req.OrgId, req.OrgCode)
+		exists, err := (&model.Org{}).CheckOrgCodeExistsExcludingSelf(s.db.WithContext(ctx), req.OrgId, req.OrgCode)
 		if err != nil {
 			s.logger.Error("检查组织编码失败", zap.Error(err))
 			return fmt.Errorf("检查组织编码失败: %w", err)
@@ -138,14 +144,18 @@ func (s *orgService) Update(ctx context.Context, req *request.UpdateOrgRequest)
 	}
 
 	// 检查是否修改了父组织
+	oldAncestors := ""
 	if req.ParentId != existingOrg.ParentId {
 		// 不能将组织设置为自己的子组织
 		if req.ParentId == req.OrgId {
 			return errors.New("不能将组织设置为自己的子组织")
 		}
+		if !DataScopeFromContext(ctx).AllowsOrg(req.ParentId) {
+			return errors.New("无权将组织移动到该组织下")
+		}
 
 		// 重新构建祖级列表
-		ancestors, err := (&model.Org{}).BuildAncestors(s.db, req.ParentId)
+		ancestors, err := (&model.Org{}).BuildAncestors(s.db.WithContext(ctx), req.ParentId)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return errors.New("父组织不存在")
@@ -159,6 +169,7 @@ func (s *orgService) Update(ctx context.Context, req *request.UpdateOrgRequest)
 			return errors.New("不能将组织移动到其子组织下")
 		}
 
+		oldAncestors = existingOrg.Ancestors
 		existingOrg.Ancestors = ancestors
 	}
 
@@ -181,12 +192,22 @@ func (s *orgService) Update(ctx context.Context, req *request.UpdateOrgRequest)
 	if req.Status != 0 {
 		existingOrg.Status = req.Status
 	}
+	existingOrg.RegApproval = req.RegApproval
 	existingOrg.Sort = req.Sort
 	existingOrg.Remark = req.Remark
 	existingOrg.UpdateBy = req.UpdateBy
 
-	// 调用模型层的更新方法
-	if err := existingOrg.Update(s.db, existingOrg); err != nil {
+	// 调用模型层的更新方法，移动组织时同步更新下级组织的祖级列表（数据权限按祖级列表匹配下级组织）
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := existingOrg.Update(tx, existingOrg); err != nil {
+			return err
+		}
+		if oldAncestors != "" {
+			return existingOrg.UpdateDescendantAncestors(tx, existingOrg.ID, oldAncestors, existingOrg.Ancestors)
+		}
+		return nil
+	})
+	if err != nil {
 		s.logger.Error("更新组织失败", zap.Error(err))
 		return fmt.Errorf("更新组织失败: %w", err)
 	}
@@ -201,8 +222,8 @@ func (s *orgService) Delete(ctx context.Context, orgId int64) error {
 		return errors.New("组织ID不能为空")
 	}
 
-	// 检查组织是否存在
-	org, err := (&model.Org{}).FindByID(s.db, orgId)
+	// 检查组织是否存在（且在数据权限范围内）
+	org, err := (&model.Org{}).FindByID(s.scoped(ctx), orgId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("组织不存在")
@@ -212,7 +233,7 @@ func (s *orgService) Delete(ctx context.Context, orgId int64) error {
 	}
 
 	// 检查是否有子组织
-	hasChildren, err := org.HasChildren(s.db)
+	hasChildren, err := org.HasChildren(s.db.WithContext(ctx))
 	if err != nil {
 		s.logger.Error("检查子组织失败", zap.Error(err))
 		return fmt.Errorf("检查子组织失败: %w", err)
@@ -222,7 +243,7 @@ func (s *orgService) Delete(ctx context.Context, orgId int64) error {
 	}
 
 	// 检查是否有关联用户
-	hasUsers, err := org.HasUsers(s.db)
+	hasUsers, err := org.HasUsers(s.db.WithContext(ctx))
 	if err != nil {
 		s.logger.Error("检查关联用户失败", zap.Error(err))
 		return fmt.Errorf("检查关联用户失败: %w", err)
@@ -232,7 +253,7 @@ func (s *orgService) Delete(ctx context.Context, orgId int64) error {
 	}
 
 	// 调用模型层的删除方法
-	if err := org.Delete(s.db, orgId); err != nil {
+	if err := org.Delete(s.db.WithContext(ctx), orgId); err != nil {
 		s.logger.Error("删除组织失败", zap.Error(err))
 		return fmt.Errorf("删除组织失败: %w", err)
 	}
@@ -272,7 +293,7 @@ func (s *orgService) GetById(ctx context.Context, orgId int64) (*model.Org, erro
 		return nil, errors.New("组织ID不能为空")
 	}
 
-	org, err := (&model.Org{}).FindByID(s.db, orgId)
+	org, err := (&model.Org{}).FindByID(s.scoped(ctx), orgId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("组织不存在")
@@ -286,7 +307,7 @@ func (s *orgService) GetById(ctx context.Context, orgId int64) (*model.Org, erro
 
 // Page 分页查询组织列表
 func (s *orgService) Page(ctx context.Context, pageNum, pageSize int, orgName, orgCode string, status int32, parentId *int64) (*pagination.Page[model.Org], error) {
-	query := s.db.Model(&model.Org{})
+	query := s.scoped(ctx).Model(&model.Org{})
 
 	// 条件查询
 	if orgName != "" {
@@ -324,14 +345,29 @@ type OrgTree struct {
 	Children []*OrgTree `json:"children,omitempty"`
 }
 
-// GetTree 获取组织树（所有组织）
+// GetTree 获取组织树（数据权限范围内的组织）
 func (s *orgService) GetTree(ctx context.Context) ([]*OrgTree, error) {
-	orgs, err := (&model.Org{}).FindAll(s.db)
+	orgs, err := (&model.Org{}).FindAll(s.scoped(ctx))
 	if err != nil {
 		s.logger.Error("查询组织树失败", zap.Error(err))
 		return nil, fmt.Errorf("查询组织树失败: %w", err)
 	}
-	return s.buildOrgTree(orgs, 0), nil
+
+	// 父组织不可见的组织作为树根（数据权限只覆盖部分子树时）
+	visible := make(map[int64]bool, len(orgs))
+	for _, org := range orgs {
+		visible[org.ID] = true
+	}
+	var tree []*OrgTree
+	for _, org := range orgs {
+		if !visible[org.ParentId] {
+			tree = append(tree, &OrgTree{
+				Org:      org,
+				Children: s.buildOrgTree(orgs, org.ID),
+			})
+		}
+	}
+	return tree, nil
 }
 
 // buildOrgTree 构建组织树
@@ -350,3 +386,8 @@ func (s *orgService) buildOrgTree(orgs []model.Org, parentId int64) []*OrgTree {
 
 	return tree
 }
+
+// scoped 返回按当前请求数据权限过滤组织的查询（每次查询需重新获取）
+func (s *orgService) scoped(ctx context.Context) *gorm.DB {
+	return s.db.WithContext(ctx).Scopes(OrgDataScope(ctx))
+}
@@ -0,0 +1,350 @@
+package service
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/infrastructure/tenant"
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/force-c/nai-tizi/internal/utils"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// PasswordPolicyConfigCode 密码策略在 s_config 中的配置编码
+	PasswordPolicyConfigCode = "password_policy"
+
+	// 强制修改密码的原因
+	PasswordChangeFirstLogin = "first_login" // 首次登录
+	PasswordChangeReset      = "reset"       // 管理员重置
+	PasswordChangeExpired    = "expired"     // 超过最长有效期
+
+	// passwordHistoryKeep 每个用户最多保留的历史密码数量
+	passwordHistoryKeep = 24
+
+	// passwordMaxBytes bcrypt 只使用前 72 字节
+	passwordMaxBytes = 72
+)
+
+//go:embed password_common.txt
+var commonPasswordList string
+
+var commonPasswords = func() map[string]bool {
+	m := make(map[string]bool)
+	for _, line := range strings.Split(commonPasswordList, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			m[strings.ToLower(line)] = true
+		}
+	}
+	return m
+}()
+
+// PasswordPolicy 密码策略
+// 存储在 s_config（code=password_policy，data 为本结构的 JSON），orgId 为 0 表示租户默认策略，
+// 否则作用于该组织及其下级组织
+type PasswordPolicy struct {
+	OrgId                   int64    `json:"orgId"`                   // 适用组织ID，0 表示租户默认
+	MinLength               int      `json:"minLength"`               // 最小长度
+	RequireUpper            bool     `json:"requireUpper"`            // 必须包含大写字母
+	RequireLower            bool     `json:"requireLower"`            // 必须包含小写字母
+	RequireDigit            bool     `json:"requireDigit"`            // 必须包含数字
+	RequireSymbol           bool     `json:"requireSymbol"`           // 必须包含特殊字符
+	BanCommon               bool     `json:"banCommon"`               // 禁止使用常见弱密码
+	BannedPasswords         []string `json:"bannedPasswords"`         // 额外禁止的密码（不区分大小写）
+	DisallowUsername        bool     `json:"disallowUsername"`        // 禁止包含用户名
+	HistoryCount            int      `json:"historyCount"`            // 不能与最近 N 次使用过的密码相同，0 表示仅不能与当前密码相同
+	MaxAgeDays              int      `json:"maxAgeDays"`              // 密码最长有效期（天），0 表示不过期
+	ForceChangeOnFirstLogin bool     `json:"forceChangeOnFirstLogin"` // 管理员创建的用户首次登录须修改密码
+	ForceChangeOnReset      bool     `json:"forceChangeOnReset"`      // 管理员重置密码后须修改密码
+}
+
+// DefaultPasswordPolicy 未配置策略时使用的内置策略
+func DefaultPasswordPolicy() *PasswordPolicy {
+	return &PasswordPolicy{MinLength: 6}
+}
+
+// Check 校验策略配置本身是否合理
+func (p *PasswordPolicy) Check() error {
+	if p.MinLength < 6 || p.MinLength > passwordMaxBytes {
+		return fmt.Errorf("最小长度必须在 6 到 %d 之间", passwordMaxBytes)
+	}
+	if p.HistoryCount < 0 || p.HistoryCount > passwordHistoryKeep {
+		return fmt.Errorf("历史密码数量必须在 0 到 %d 之间", passwordHistoryKeep)
+	}
+	if p.MaxAgeDays < 0 {
+		return fmt.Errorf("密码有效期不能为负数")
+	}
+	return nil
+}
+
+// ParsePasswordPolicy 解析并校验 s_config 中的密码策略配置数据
+func ParsePasswordPolicy(data []byte) (*PasswordPolicy, error) {
+	var p PasswordPolicy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("密码策略配置格式错误: %w", err)
+	}
+	if err := p.Check(); err != nil {
+		return nil, fmt.Errorf("密码策略配置无效: %w", err)
+	}
+	return &p, nil
+}
+
+// Validate 按策略校验密码强度（不含历史密码校验）
+func (p *PasswordPolicy) Validate(password, username string) error {
+	if len([]rune(password)) < p.MinLength {
+		return fmt.Errorf("密码长度不能少于 %d 位", p.MinLength)
+	}
+	if len(password) > passwordMaxBytes {
+		return fmt.Errorf("密码长度不能超过 %d 字节", passwordMaxBytes)
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsSpace(r):
+		default:
+			symbol = true
+		}
+	}
+	var missing []string
+	if p.RequireUpper && !upper {
+		missing = append(missing, "大写字母")
+	}
+	if p.RequireLower && !lower {
+		missing = append(missing, "小写字母")
+	}
+	if p.RequireDigit && !digit {
+		missing = append(missing, "数字")
+	}
+	if p.RequireSymbol && !symbol {
+		missing = append(missing, "特殊字符")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("密码必须包含%s", strings.Join(missing, "、"))
+	}
+
+	lowered := strings.ToLower(password)
+	if p.BanCommon && commonPasswords[lowered] {
+		return fmt.Errorf("密码过于简单，请勿使用常见密码")
+	}
+	for _, banned := range p.BannedPasswords {
+		if strings.EqualFold(password, banned) {
+			return fmt.Errorf("该密码已被禁止使用")
+		}
+	}
+	if p.DisallowUsername && username != "" && strings.Contains(lowered, strings.ToLower(username)) {
+		return fmt.Errorf("密码不能包含用户名")
+	}
+	return nil
+}
+
+// PasswordPolicyService 密码策略服务接口
+type PasswordPolicyService interface {
+	// Resolve 获取组织生效的策略：组织及上级组织中最近的策略 > 租户默认策略 > 内置策略
+	Resolve(ctx context.Context, orgId int64) (*PasswordPolicy, error)
+
+	// PrepareNew 为新建用户校验并加密密码，返回哈希和待强制修改原因
+	PrepareNew(ctx context.Context, orgId int64, username, password string) (hash, pwdChange string, err error)
+
+	// RecordHistory 记录新建用户的初始密码
+	RecordHistory(ctx context.Context, userId int64, hash string)
+
+	// SetPassword 为已有用户设置新密码（校验策略和历史密码），byAdmin 表示管理员重置
+	SetPassword(ctx context.Context, user *model.User, password string, byAdmin bool) error
+
+	// ChangeRequired 判断用户是否须修改密码，返回原因，空表示无需修改
+	ChangeRequired(ctx context.Context, userId int64) string
+}
+
+type passwordPolicyService struct {
+	db     *gorm.DB
+	logger logging.Logger
+}
+
+// NewPasswordPolicyService 创建密码策略服务实例
+func NewPasswordPolicyService(db *gorm.DB, logger logging.Logger) PasswordPolicyService {
+	return &passwordPolicyService{db: db, logger: logger}
+}
+
+// Resolve 获取组织生效的策略
+func (s *passwordPolicyService) Resolve(ctx context.Context, orgId int64) (*PasswordPolicy, error) {
+	db := s.db.WithContext(ctx)
+	var configs []model.Config
+	err := db.Where("code = ? AND tenant_id = ?", PasswordPolicyConfigCode, tenantIdFromContext(ctx)).
+		Order("id ASC").Find(&configs).Error
+	if err != nil {
+		s.logger.Error("查询密码策略失败", zap.Error(err))
+		return nil, fmt.Errorf("查询密码策略失败")
+	}
+	if len(configs) == 0 {
+		return DefaultPasswordPolicy(), nil
+	}
+
+	policies := make(map[int64]*PasswordPolicy, len(configs))
+	for _, cfg := range configs {
+		p, err := ParsePasswordPolicy(cfg.Data)
+		if err != nil {
+			s.logger.Warn("密码策略配置无效，已忽略", zap.Int64("configId", cfg.ID), zap.Error(err))
+			continue
+		}
+		if _, ok := policies[p.OrgId]; !ok {
+			policies[p.OrgId] = p
+		}
+	}
+
+	// 从本组织开始逐级向上查找
+	if orgId != 0 {
+		if p, ok := policies[orgId]; ok {
+			return p, nil
+		}
+		if org, err := (&model.Org{}).FindByID(db, orgId); err == nil {
+			ancestors := strings.Split(org.Ancestors, ",")
+			for i := len(ancestors) - 1; i >= 0; i-- {
+				id, err := strconv.ParseInt(strings.TrimSpace(ancestors[i]), 10, 64)
+				if err != nil || id == 0 {
+					continue
+				}
+				if p, ok := policies[id]; ok {
+					return p, nil
+				}
+			}
+		}
+	}
+	if p, ok := policies[0]; ok {
+		return p, nil
+	}
+	return DefaultPasswordPolicy(), nil
+}
+
+// PrepareNew 为新建用户校验并加密密码
+func (s *passwordPolicyService) PrepareNew(ctx context.Context, orgId int64, username, password string) (string, string, error) {
+	policy, err := s.Resolve(ctx, orgId)
+	if err != nil {
+		return "", "", err
+	}
+	if err := policy.Validate(password, username); err != nil {
+		return "", "", err
+	}
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		s.logger.Error("密码加密失败", zap.Error(err))
+		return "", "", fmt.Errorf("密码加密失败")
+	}
+	pwdChange := ""
+	if policy.ForceChangeOnFirstLogin {
+		pwdChange = PasswordChangeFirstLogin
+	}
+	return hash, pwdChange, nil
+}
+
+// RecordHistory 记录初始密码
+func (s *passwordPolicyService) RecordHistory(ctx context.Context, userId int64, hash string) {
+	record := &model.PasswordHistory{UserId: userId, Password: hash}
+	if err := record.Create(s.db.WithContext(ctx)); err != nil {
+		s.logger.Warn("记录历史密码失败", zap.Int64("userId", userId), zap.Error(err))
+	}
+}
+
+// SetPassword 为已有用户设置新密码
+func (s *passwordPolicyService) SetPassword(ctx context.Context, user *model.User, password string, byAdmin bool) error {
+	policy, err := s.Resolve(ctx, user.OrgId)
+	if err != nil {
+		return err
+	}
+	if err := policy.Validate(password, user.UserName); err != nil {
+		return err
+	}
+
+	db := s.db.WithContext(ctx)
+	var hm model.PasswordHistory
+	if user.Password != "" && utils.VerifyPassword(user.Password, password) == nil {
+		return fmt.Errorf("新密码不能与当前密码相同")
+	}
+	if policy.HistoryCount > 0 {
+		history, err := hm.FindRecent(db, user.ID, policy.HistoryCount)
+		if err != nil {
+			s.logger.Error("查询历史密码失败", zap.Error(err))
+			return fmt.Errorf("设置密码失败")
+		}
+		for _, h := range history {
+			if utils.VerifyPassword(h.Password, password) == nil {
+				return fmt.Errorf("新密码不能与最近 %d 次使用过的密码相同", policy.HistoryCount)
+			}
+		}
+	}
+
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		s.logger.Error("密码加密失败", zap.Error(err))
+		return fmt.Errorf("密码加密失败")
+	}
+	pwdChange := ""
+	if byAdmin && policy.ForceChangeOnReset {
+		pwdChange = PasswordChangeReset
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := user.UpdatePasswordWithState(tx, user.ID, hash, time.Now().Unix(), pwdChange); err != nil {
+			return err
+		}
+		if err := (&model.PasswordHistory{UserId: user.ID, Password: hash}).Create(tx); err != nil {
+			return err
+		}
+		return hm.Prune(tx, user.ID, passwordHistoryKeep)
+	})
+	if err != nil {
+		s.logger.Error("更新密码失败", zap.Int64("userId", user.ID), zap.Error(err))
+		return fmt.Errorf("更新密码失败")
+	}
+	return nil
+}
+
+// ChangeRequired 判断用户是否须修改密码，查询失败时不阻断登录
+func (s *passwordPolicyService) ChangeRequired(ctx context.Context, userId int64) string {
+	user, err := (&model.User{}).FindByID(s.db.WithContext(ctx), userId)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Warn("查询用户失败", zap.Int64("userId", userId), zap.Error(err))
+		}
+		return ""
+	}
+	// 未设置密码的账号（短信、第三方登录创建）不受密码策略约束
+	if user.Password == "" {
+		return ""
+	}
+	if user.PwdChange != "" {
+		return user.PwdChange
+	}
+	if user.PwdUpdateAt == 0 {
+		return ""
+	}
+
+	policy, err := s.Resolve(ctx, user.OrgId)
+	if err != nil || policy.MaxAgeDays <= 0 {
+		return ""
+	}
+	if time.Since(time.Unix(user.PwdUpdateAt, 0)) > time.Duration(policy.MaxAgeDays)*24*time.Hour {
+		return PasswordChangeExpired
+	}
+	return ""
+}
+
+// tenantIdFromContext 从 context 获取租户ID，未设置时为默认租户 1
+func tenantIdFromContext(ctx context.Context) int64 {
+	return tenant.FromContext(ctx)
+}
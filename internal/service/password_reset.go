@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/force-c/nai-tizi/internal/config"
+	"github.com/force-c/nai-tizi/internal/constants"
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/infrastructure/thirdparty/email"
+	"github.com/force-c/nai-tizi/internal/infrastructure/thirdparty/sms"
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// 找回密码渠道
+	PasswordResetChannelEmail = "email"
+	PasswordResetChannelSms   = "sms"
+
+	// PasswordResetTokenKeyPrefix 重置令牌 Redis Key 前缀
+	// pwd_reset:token:{sha256(token)} -> userId
+	PasswordResetTokenKeyPrefix = "pwd_reset:token:"
+
+	// PasswordResetUserKeyPrefix 用户当前有效的重置令牌，新的申请会使旧令牌失效
+	// pwd_reset:user:{userId} -> sha256(token)
+	PasswordResetUserKeyPrefix = "pwd_reset:user:"
+
+	// PasswordResetSmsKeyPrefix 短信验证码 Redis Key 前缀
+	// pwd_reset:sms:{phonenumber} -> Hash{userId, code, attempts}
+	PasswordResetSmsKeyPrefix = "pwd_reset:sms:"
+
+	passwordResetSmsExpire      = 5 * time.Minute
+	passwordResetSmsMaxAttempts = 5
+	passwordResetSendInterval   = 60 * time.Second
+	passwordResetIpHourlyLimit  = 10
+	passwordResetLockTTL        = 30 * time.Second
+)
+
+// PasswordResetService 自助找回密码服务
+// 流程：Request 发送邮件链接或短信验证码 -> Verify 校验并换取重置令牌 -> Reset 设置新密码
+type PasswordResetService interface {
+	// Request 申请找回密码，账号不存在时同样返回成功，避免账号枚举
+	Request(ctx context.Context, channel, account, ip string) error
+
+	// Verify 校验邮件链接令牌或短信验证码，返回用于 Reset 的重置令牌
+	// 邮件渠道仅检查令牌有效性并原样返回，短信渠道校验通过后签发新令牌
+	Verify(ctx context.Context, channel, account, code string) (string, error)
+
+	// Reset 使用重置令牌设置新密码（执行密码策略），成功后令牌失效并吊销用户全部会话
+	Reset(ctx context.Context, token, newPassword string) error
+}
+
+type passwordResetService struct {
+	db           *gorm.DB
+	redis        *redis.Client
+	tokenManager TokenManager
+	policy       PasswordPolicyService
+	emailManager *email.Manager
+	smsManager   *sms.Manager
+	config       *config.Config
+	logger       logging.Logger
+}
+
+// NewPasswordResetService 创建找回密码服务实例，emailManager / smsManager 为 nil 表示对应渠道未启用
+func NewPasswordResetService(db *gorm.DB, rdb *redis.Client, tokenManager TokenManager, emailManager *email.Manager, smsManager *sms.Manager, cfg *config.Config, logger logging.Logger) PasswordResetService {
+	return &passwordResetService{
+		db:           db,
+		redis:        rdb,
+		tokenManager: tokenManager,
+		policy:       NewPasswordPolicyService(db, logger),
+		emailManager: emailManager,
+		smsManager:   smsManager,
+		config:       cfg,
+		logger:       logger,
+	}
+}
+
+// Request 申请找回密码
+func (s *passwordResetService) Request(ctx context.Context, channel, account, ip string) error {
+	switch channel {
+	case PasswordResetChannelEmail:
+		if s.emailManager == nil {
+			return fmt.Errorf("邮件服务未启用")
+		}
+	case PasswordResetChannelSms:
+		if s.smsManager == nil {
+			return fmt.Errorf("短信服务未启用")
+		}
+	default:
+		return fmt.Errorf("不支持的找回方式: %s", channel)
+	}
+
+	// 频率限制在查询用户之前执行，账号存在与否的响应保持一致
+	if err := s.checkSendLimit(ctx, channel, account, ip); err != nil {
+		return err
+	}
+
+	user, err := s.findUser(ctx, channel, account)
+	if err != nil {
+		s.logger.Info("找回密码账号不存在或不可用", zap.String("channel", channel), zap.String("ip", ip))
+		return nil
+	}
+
+	// 异步发送，账号存在与否的响应时间和结果保持一致
+	go func() {
+		sendCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		var err error
+		if channel == PasswordResetChannelEmail {
+			err = s.sendEmail(sendCtx, user)
+		} else {
+			err = s.sendSms(sendCtx, user)
+		}
+		if err != nil {
+			s.logger.Error("发送找回密码消息失败", zap.Int64("userId", user.ID), zap.String("channel", channel), zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// Verify 校验并换取重置令牌
+func (s *passwordResetService) Verify(ctx context.Context, channel, account, code string) (string, error) {
+	switch channel {
+	case PasswordResetChannelEmail:
+		if _, err := s.redis.Get(ctx, PasswordResetTokenKeyPrefix+generateTokenHash(code)).Result(); err != nil {
+			return "", fmt.Errorf("重置链接无效或已过期")
+		}
+		return code, nil
+	case PasswordResetChannelSms:
+		return s.verifySms(ctx, account, code)
+	}
+	return "", fmt.Errorf("不支持的找回方式: %s", channel)
+}
+
+// Reset 设置新密码
+func (s *passwordResetService) Reset(ctx context.Context, token, newPassword string) error {
+	tokenHash := generateTokenHash(token)
+	key := PasswordResetTokenKeyPrefix + tokenHash
+	userId, err := s.redis.Get(ctx, key).Int64()
+	if err != nil {
+		return fmt.Errorf("重置令牌无效或已过期")
+	}
+
+	// 同一令牌的并发请求只处理一个；密码不符合策略时令牌保留，允许重新提交
+	lockKey := key + ":lock"
+	ok, err := s.redis.SetNX(ctx, lockKey, 1, passwordResetLockTTL).Result()
+	if err != nil {
+		return fmt.Errorf("重置密码失败，请稍后再试")
+	}
+	if !ok {
+		return fmt.Errorf("请求处理中，请稍后再试")
+	}
+	defer s.redis.Del(context.Background(), lockKey)
+
+	user, err := (&model.User{}).FindByID(s.db.WithContext(ctx), userId)
+	if err != nil || user.Status != constants.StatusNormal {
+		_ = s.redis.Del(ctx, key).Err()
+		return fmt.Errorf("重置令牌无效或已过期")
+	}
+	if err := s.policy.SetPassword(ctx, user, newPassword, false); err != nil {
+		return err
+	}
+
+	// 令牌一次性使用
+	userKey := PasswordResetUserKeyPrefix + strconv.FormatInt(userId, 10)
+	_ = s.redis.Del(ctx, key, userKey).Err()
+
+	if err := s.tokenManager.RevokeUserTokens(ctx, userId); err != nil {
+		s.logger.Error("找回密码后吊销会话失败", zap.Int64("userId", userId), zap.Error(err))
+	}
+	s.logger.Info("用户通过找回密码重置了密码", zap.Int64("userId", userId))
+	return nil
+}
+
+// checkSendLimit 发送频率限制：同一账号 60 秒一次，同一 IP 每小时 10 次
+func (s *passwordResetService) checkSendLimit(ctx context.Context, channel, account, ip string) error {
+	ipKey := "pwd_reset_cnt:ip:" + ip
+	count, err := s.redis.Incr(ctx, ipKey).Result()
+	if err != nil {
+		s.logger.Warn("failed to check password reset limit", zap.Error(err))
+		return fmt.Errorf("发送失败，请稍后再试")
+	}
+	if count == 1 {
+		_ = s.redis.Expire(ctx, ipKey, time.Hour).Err()
+	}
+	if count > passwordResetIpHourlyLimit {
+		return fmt.Errorf("发送次数过多，请稍后再试")
+	}
+
+	ok, err := s.redis.SetNX(ctx, "pwd_reset_lock:"+channel+":"+account, 1, passwordResetSendInterval).Result()
+	if err != nil {
+		s.logger.Warn("failed to check password reset limit", zap.Error(err))
+		return fmt.Errorf("发送失败，请稍后再试")
+	}
+	if !ok {
+		return fmt.Errorf("发送过于频繁，请稍后再试")
+	}
+	return nil
+}
+
+// findUser 按渠道查找可找回密码的用户，服务账号不支持
+func (s *passwordResetService) findUser(ctx context.Context, channel, account string) (*model.User, error) {
+	db := s.db.WithContext(ctx)
+	var (
+		user *model.User
+		err  error
+	)
+	if channel == PasswordResetChannelEmail {
+		user, err = (&model.User{}).FindByEmail(db, account)
+	} else {
+		user, err = (&model.User{}).FindByPhonenumber(db, account)
+	}
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			s.logger.Error("查询用户失败", zap.Error(err))
+		}
+		return nil, err
+	}
+	if user.Status != constants.StatusNormal || user.UserType == constants.UserTypeService {
+		return nil, fmt.Errorf("user unavailable")
+	}
+	return user, nil
+}
+
+// issueToken 签发重置令牌，同一用户之前签发的令牌失效
+func (s *passwordResetService) issueToken(ctx context.Context, userId int64) (string, error) {
+	token, err := generateRandomToken(32)
+	if err != nil {
+		return "", err
+	}
+	tokenHash := generateTokenHash(token)
+	ttl := time.Duration(s.config.Auth.PasswordReset.TokenExpire) * time.Second
+	userKey := PasswordResetUserKeyPrefix + strconv.FormatInt(userId, 10)
+
+	if previous, err := s.redis.Get(ctx, userKey).Result(); err == nil && previous != "" {
+		_ = s.redis.Del(ctx, PasswordResetTokenKeyPrefix+previous).Err()
+	}
+	pipe := s.redis.TxPipeline()
+	pipe.Set(ctx, PasswordResetTokenKeyPrefix+tokenHash, userId, ttl)
+	pipe.Set(ctx, userKey, tokenHash, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// sendEmail 发送重置链接邮件
+func (s *passwordResetService) sendEmail(ctx context.Context, user *model.User) error {
+	token, err := s.issueToken(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf("签发重置令牌失败: %w", err)
+	}
+
+	link := s.config.Auth.PasswordReset.ResetUrl + "?token=" + url.QueryEscape(token)
+	minutes := s.config.Auth.PasswordReset.TokenExpire / 60
+	body := fmt.Sprintf(`<p>%s，您好：</p><p>您正在找回账号 %s 的密码，请在 %d 分钟内点击下面的链接设置新密码：</p>`+
+		`<p><a href="%s">%s</a></p><p>如果这不是您本人的操作，请忽略本邮件，您的密码不会被修改。</p>`,
+		user.NickName, user.UserName, minutes, link, link)
+	return s.emailManager.Send(user.Email, "找回密码", body)
+}
+
+// sendSms 发送短信验证码
+func (s *passwordResetService) sendSms(ctx context.Context, user *model.User) error {
+	code, err := generateDigitCode(6)
+	if err != nil {
+		return err
+	}
+	key := PasswordResetSmsKeyPrefix + user.Phonenumber
+	pipe := s.redis.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.HSet(ctx, key, "userId", user.ID, "code", generateTokenHash(code), "attempts", 0)
+	pipe.Expire(ctx, key, passwordResetSmsExpire)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("保存验证码失败: %w", err)
+	}
+
+	templateCode := s.config.Auth.PasswordReset.SmsTemplateCode
+	if templateCode == "" {
+		templateCode = s.config.SMS.TemplateCode
+	}
+	if err := s.smsManager.Send(user.Phonenumber, templateCode, map[string]string{"code": code}); err != nil {
+		_ = s.redis.Del(ctx, key).Err()
+		return err
+	}
+	return nil
+}
+
+// verifySms 校验短信验证码，通过后签发重置令牌
+func (s *passwordResetService) verifySms(ctx context.Context, phonenumber, code string) (string, error) {
+	key := PasswordResetSmsKeyPrefix + phonenumber
+	data, err := s.redis.HGetAll(ctx, key).Result()
+	if err != nil || len(data) == 0 {
+		return "", fmt.Errorf("验证码无效或已过期")
+	}
+
+	attempts, err := s.redis.HIncrBy(ctx, key, "attempts", 1).Result()
+	if err != nil {
+		return "", fmt.Errorf("验证失败，请稍后再试")
+	}
+	if attempts > passwordResetSmsMaxAttempts {
+		_ = s.redis.Del(ctx, key).Err()
+		return "", fmt.Errorf("验证失败次数过多，请重新获取验证码")
+	}
+	if subtle.ConstantTimeCompare([]byte(data["code"]), []byte(generateTokenHash(code))) != 1 {
+		return "", fmt.Errorf("验证码错误")
+	}
+
+	// 验证码一次性使用，并发校验时只有删除成功的请求可以换取令牌
+	if n, err := s.redis.Del(ctx, key).Result(); err != nil || n == 0 {
+		return "", fmt.Errorf("验证码无效或已过期")
+	}
+	token, err := s.issueToken(ctx, parseInt64(data["userId"]))
+	if err != nil {
+		s.logger.Error("签发重置令牌失败", zap.Error(err))
+		return "", fmt.Errorf("验证失败，请稍后再试")
+	}
+	return token, nil
+}
+
+// generateDigitCode 生成数字验证码
+func generateDigitCode(length int) (string, error) {
+	buf := make([]byte, length)
+	for i := range buf {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		buf[i] = byte('0' + n.Int64())
+	}
+	return string(buf), nil
+}
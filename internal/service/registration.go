@@ -0,0 +1,506 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/force-c/nai-tizi/internal/constants"
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/domain/request"
+	"github.com/force-c/nai-tizi/internal/infrastructure/captcha"
+	"github.com/force-c/nai-tizi/internal/infrastructure/thirdparty/email"
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// RegistrationConfigCode 注册设置在 s_config 中的配置编码（按租户生效）
+	RegistrationConfigCode = "registration"
+
+	// RegisterEmailKeyPrefix 注册邮箱验证码 Redis Key 前缀
+	// register:email:{tenantId}:{email} -> Hash{code, attempts}
+	RegisterEmailKeyPrefix = "register:email:"
+
+	registerEmailExpire      = 10 * time.Minute
+	registerEmailMaxAttempts = 5
+	registerSendInterval     = 60 * time.Second
+	registerIpHourlyLimit    = 20
+)
+
+// errInviteCodeUnusable 注册事务中邀请码已被用完或停用
+var errInviteCodeUnusable = errors.New("invite code unusable")
+
+// RegistrationSettings 租户注册设置，保存在 s_config（code = registration）中，未配置时不开放注册
+type RegistrationSettings struct {
+	Enabled         bool     `json:"enabled"`         // 是否开放注册
+	OpenSignup      bool     `json:"openSignup"`      // 是否允许无邀请码注册（必须验证邮箱）
+	InviteSignup    bool     `json:"inviteSignup"`    // 是否允许邀请码注册
+	AllowedDomains  []string `json:"allowedDomains"`  // 开放注册允许的邮箱域名，空表示不限制
+	DefaultOrgId    int64    `json:"defaultOrgId"`    // 开放注册用户所属组织
+	DefaultRoleKeys []string `json:"defaultRoleKeys"` // 开放注册用户默认角色
+	Captcha         bool     `json:"captcha"`         // 是否要求图形验证码（需启用图形验证码）
+}
+
+// Check 校验注册设置
+func (s *RegistrationSettings) Check() error {
+	if !s.Enabled {
+		return nil
+	}
+	if !s.OpenSignup && !s.InviteSignup {
+		return fmt.Errorf("开放注册时至少需要启用一种注册方式")
+	}
+	if s.OpenSignup && s.DefaultOrgId <= 0 {
+		return fmt.Errorf("开放注册必须指定默认组织")
+	}
+	return nil
+}
+
+// DomainAllowed 邮箱域名是否在允许列表中，列表为空时不限制
+func (s *RegistrationSettings) DomainAllowed(emailAddr string) bool {
+	if len(s.AllowedDomains) == 0 {
+		return true
+	}
+	at := strings.LastIndex(emailAddr, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(emailAddr[at+1:])
+	for _, d := range s.AllowedDomains {
+		if domain == d {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseRegistrationSettings 解析并校验注册设置，域名统一转为小写并去掉前导 @
+func ParseRegistrationSettings(data []byte) (*RegistrationSettings, error) {
+	var s RegistrationSettings
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("注册设置格式错误: %w", err)
+	}
+	domains := make([]string, 0, len(s.AllowedDomains))
+	for _, d := range s.AllowedDomains {
+		d = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(d)), "@")
+		if d != "" {
+			domains = append(domains, d)
+		}
+	}
+	s.AllowedDomains = domains
+	if err := s.Check(); err != nil {
+		return nil, fmt.Errorf("注册设置无效: %w", err)
+	}
+	return &s, nil
+}
+
+// CheckUserStatus 校验用户状态是否允许登录
+func CheckUserStatus(status int32) error {
+	switch status {
+	case constants.StatusNormal:
+		return nil
+	case constants.StatusPending:
+		return fmt.Errorf("账号正在等待管理员审批")
+	default:
+		return fmt.Errorf("用户已被停用")
+	}
+}
+
+// RegistrationService 自助注册服务
+// 支持两种方式：开放注册（验证邮箱，可限制邮箱域名）和邀请码注册（加入邀请码绑定的组织和角色）
+// 组织开启注册审批时，注册用户处于待审批状态，审批通过前不能登录
+type RegistrationService interface {
+	// Settings 获取当前租户的注册设置
+	Settings(ctx context.Context) (*RegistrationSettings, error)
+
+	// SendEmailCode 发送注册邮箱验证码，同一邮箱 60 秒一次，同一 IP 每小时 20 次
+	SendEmailCode(ctx context.Context, req *request.RegisterEmailCodeRequest, ip string) error
+
+	// Register 自助注册
+	Register(ctx context.Context, req *request.RegisterRequest, ip string) (*model.User, error)
+
+	// Approve 审批待审批用户，拒绝时删除该注册（用户名、邮箱可重新注册）
+	Approve(ctx context.Context, userId int64, approved bool, reason string, operator int64) error
+}
+
+type registrationService struct {
+	db             *gorm.DB
+	redis          *redis.Client
+	captchaManager *captcha.CaptchaManager
+	emailManager   *email.Manager
+	policy         PasswordPolicyService
+	roleService    RoleService
+	logger         logging.Logger
+}
+
+// NewRegistrationService 创建自助注册服务实例，emailManager 为 nil 时不支持邮箱验证
+func NewRegistrationService(db *gorm.DB, rdb *redis.Client, captchaManager *captcha.CaptchaManager, emailManager *email.Manager, roleService RoleService, logger logging.Logger) RegistrationService {
+	return &registrationService{
+		db:             db,
+		redis:          rdb,
+		captchaManager: captchaManager,
+		emailManager:   emailManager,
+		policy:         NewPasswordPolicyService(db, logger),
+		roleService:    roleService,
+		logger:         logger,
+	}
+}
+
+// Settings 获取当前租户的注册设置
+func (s *registrationService) Settings(ctx context.Context) (*RegistrationSettings, error) {
+	var cfg model.Config
+	err := s.db.WithContext(ctx).
+		Where("code = ? AND tenant_id = ?", RegistrationConfigCode, tenantIdFromContext(ctx)).
+		Order("id ASC").Limit(1).Find(&cfg).Error
+	if err != nil {
+		s.logger.Error("查询注册设置失败", zap.Error(err))
+		return nil, fmt.Errorf("查询注册设置失败")
+	}
+	if cfg.ID == 0 {
+		return &RegistrationSettings{}, nil
+	}
+	settings, err := ParseRegistrationSettings(cfg.Data)
+	if err != nil {
+		s.logger.Warn("注册设置无效，按未开放处理", zap.Error(err))
+		return &RegistrationSettings{}, nil
+	}
+	return settings, nil
+}
+
+// SendEmailCode 发送注册邮箱验证码
+func (s *registrationService) SendEmailCode(ctx context.Context, req *request.RegisterEmailCodeRequest, ip string) error {
+	settings, err := s.Settings(ctx)
+	if err != nil {
+		return err
+	}
+	if !settings.Enabled {
+		return fmt.Errorf("当前未开放注册")
+	}
+	if s.emailManager == nil {
+		return fmt.Errorf("邮件服务未启用")
+	}
+	if err := s.verifyCaptcha(ctx, settings, req.CaptchaId, req.CaptchaCode); err != nil {
+		return err
+	}
+
+	emailAddr := strings.ToLower(strings.TrimSpace(req.Email))
+	if err := s.checkIpLimit(ctx, ip); err != nil {
+		return err
+	}
+	key := s.emailKey(ctx, emailAddr)
+	ok, err := s.redis.SetNX(ctx, "register_lock:"+key, 1, registerSendInterval).Result()
+	if err != nil {
+		s.logger.Warn("failed to check register send limit", zap.Error(err))
+		return fmt.Errorf("发送失败，请稍后再试")
+	}
+	if !ok {
+		return fmt.Errorf("发送过于频繁，请稍后再试")
+	}
+
+	code, err := generateDigitCode(6)
+	if err != nil {
+		return fmt.Errorf("发送失败，请稍后再试")
+	}
+	pipe := s.redis.TxPipeline()
+	pipe.Del(ctx, key)
+	pipe.HSet(ctx, key, "code", generateTokenHash(code), "attempts", 0)
+	pipe.Expire(ctx, key, registerEmailExpire)
+	if _, err := pipe.Exec(ctx); err != nil {
+		s.logger.Error("保存注册验证码失败", zap.Error(err))
+		return fmt.Errorf("发送失败，请稍后再试")
+	}
+
+	body := fmt.Sprintf(`<p>您好：</p><p>您正在注册账号，验证码为 <b>%s</b>，%d 分钟内有效。</p>`+
+		`<p>如果这不是您本人的操作，请忽略本邮件。</p>`, code, int(registerEmailExpire.Minutes()))
+	if err := s.emailManager.Send(emailAddr, "注册验证码", body); err != nil {
+		_ = s.redis.Del(ctx, key).Err()
+		s.logger.Error("发送注册验证码失败", zap.Error(err))
+		return fmt.Errorf("发送失败，请稍后再试")
+	}
+	return nil
+}
+
+// Register 自助注册
+func (s *registrationService) Register(ctx context.Context, req *request.RegisterRequest, ip string) (*model.User, error) {
+	settings, err := s.Settings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.Enabled {
+		return nil, fmt.Errorf("当前未开放注册")
+	}
+	if err := s.verifyCaptcha(ctx, settings, req.CaptchaId, req.CaptchaCode); err != nil {
+		return nil, err
+	}
+	if err := s.checkIpLimit(ctx, ip); err != nil {
+		return nil, err
+	}
+
+	db := s.db.WithContext(ctx)
+	emailAddr := strings.ToLower(strings.TrimSpace(req.Email))
+	now := time.Now().Unix()
+
+	// 确定注册方式：填写邀请码时走邀请码注册，否则为开放注册
+	var (
+		invite *model.InviteCode
+		orgId  int64
+	)
+	if req.InviteCode != "" {
+		if !settings.InviteSignup {
+			return nil, fmt.Errorf("当前不支持邀请码注册")
+		}
+		invite, err = (&model.InviteCode{}).FindByCode(db, tenantIdFromContext(ctx), strings.ToUpper(strings.TrimSpace(req.InviteCode)))
+		if err != nil || !invite.Usable(now) {
+			return nil, fmt.Errorf("邀请码无效或已过期")
+		}
+		orgId = invite.OrgId
+	} else {
+		if !settings.OpenSignup {
+			return nil, fmt.Errorf("请填写邀请码")
+		}
+		if emailAddr == "" {
+			return nil, fmt.Errorf("请填写邮箱")
+		}
+		if !settings.DomainAllowed(emailAddr) {
+			return nil, fmt.Errorf("该邮箱域名不允许注册")
+		}
+		orgId = settings.DefaultOrgId
+	}
+
+	org, err := (&model.Org{}).FindByID(db, orgId)
+	if err != nil || !org.IsActive() {
+		return nil, fmt.Errorf("注册组织不存在或已停用")
+	}
+
+	conflicts, err := (&model.User{}).FindConflicts(db, req.UserName, "", emailAddr)
+	if err != nil {
+		s.logger.Error("检查冲突失败", zap.Error(err))
+		return nil, fmt.Errorf("注册失败，请稍后再试")
+	}
+	for _, u := range conflicts {
+		if u.UserName == req.UserName {
+			return nil, fmt.Errorf("用户名已存在")
+		}
+		if emailAddr != "" && u.Email == emailAddr {
+			return nil, fmt.Errorf("邮箱已被注册")
+		}
+	}
+
+	// 自助注册的用户自己设置密码，无需首次登录修改
+	hash, _, err := s.policy.PrepareNew(ctx, orgId, req.UserName, req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	// 邮箱验证码放在其他校验之后，避免因其他参数错误浪费验证次数
+	if emailAddr != "" {
+		if err := s.verifyEmailCode(ctx, emailAddr, req.EmailCode); err != nil {
+			return nil, err
+		}
+	}
+
+	nickName := req.NickName
+	if nickName == "" {
+		nickName = req.UserName
+	}
+	status := constants.StatusNormal
+	if org.RegApproval {
+		status = constants.StatusPending
+	}
+	user := &model.User{
+		OrgId:       orgId,
+		UserName:    req.UserName,
+		NickName:    nickName,
+		UserType:    constants.UserTypeApp,
+		Email:       emailAddr,
+		Password:    hash,
+		PwdUpdateAt: now,
+		Status:      status,
+		Remark:      "自助注册",
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if invite != nil {
+			ok, err := invite.Consume(tx, invite.ID, now)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return errInviteCodeUnusable
+			}
+		}
+		return user.Create(tx, user)
+	})
+	if err != nil {
+		if errors.Is(err, errInviteCodeUnusable) {
+			return nil, fmt.Errorf("邀请码无效或已过期")
+		}
+		s.logger.Error("自助注册创建用户失败", zap.String("userName", req.UserName), zap.Error(err))
+		return nil, fmt.Errorf("注册失败，请稍后再试")
+	}
+	s.policy.RecordHistory(ctx, user.ID, hash)
+
+	// 分配默认角色，失败不影响注册结果，由管理员补充分配
+	var roleIds []int64
+	if invite != nil {
+		roleIds = invite.RoleIdList()
+	} else {
+		for _, key := range settings.DefaultRoleKeys {
+			role, err := s.roleService.GetByRoleKey(ctx, key)
+			if err != nil {
+				s.logger.Warn("注册默认角色不存在", zap.String("roleKey", key), zap.Error(err))
+				continue
+			}
+			roleIds = append(roleIds, role.ID)
+		}
+	}
+	for _, roleId := range roleIds {
+		if err := s.roleService.AssignRoleToUser(ctx, user.ID, roleId); err != nil {
+			s.logger.Warn("为注册用户分配默认角色失败", zap.Int64("userId", user.ID), zap.Int64("roleId", roleId), zap.Error(err))
+		}
+	}
+
+	s.logger.Info("用户自助注册",
+		zap.Int64("userId", user.ID),
+		zap.String("userName", user.UserName),
+		zap.Bool("invite", invite != nil),
+		zap.Bool("pending", status == constants.StatusPending),
+		zap.String("ip", ip))
+	return user, nil
+}
+
+// Approve 审批待审批用户
+func (s *registrationService) Approve(ctx context.Context, userId int64, approved bool, reason string, operator int64) error {
+	db := s.db.WithContext(ctx)
+	user, err := (&model.User{}).FindByID(db, userId)
+	if err != nil {
+		return fmt.Errorf("用户不存在")
+	}
+	if user.Status != constants.StatusPending {
+		return fmt.Errorf("该用户不是待审批状态")
+	}
+
+	if approved {
+		result := db.Model(&model.User{}).
+			Where("id = ? AND status = ?", userId, constants.StatusPending).
+			Updates(map[string]any{"status": constants.StatusNormal, "update_by": operator})
+		if result.Error != nil {
+			s.logger.Error("审批用户失败", zap.Int64("userId", userId), zap.Error(result.Error))
+			return fmt.Errorf("审批失败")
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("该用户不是待审批状态")
+		}
+	} else {
+		userRoles, err := (&model.MUserRole{}).FindByUserId(db, userId)
+		if err != nil {
+			s.logger.Error("查询用户角色失败", zap.Int64("userId", userId), zap.Error(err))
+			return fmt.Errorf("审批失败")
+		}
+		for _, ur := range userRoles {
+			if err := s.roleService.RemoveRoleFromUser(ctx, userId, ur.RoleId); err != nil {
+				s.logger.Warn("移除被拒绝用户的角色失败", zap.Int64("userId", userId), zap.Int64("roleId", ur.RoleId), zap.Error(err))
+			}
+		}
+		// 物理删除，释放用户名和邮箱
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Where("user_id = ?", userId).Delete(&model.PasswordHistory{}).Error; err != nil {
+				return err
+			}
+			return tx.Unscoped().Where("id = ? AND status = ?", userId, constants.StatusPending).Delete(&model.User{}).Error
+		})
+		if err != nil {
+			s.logger.Error("删除被拒绝的注册失败", zap.Int64("userId", userId), zap.Error(err))
+			return fmt.Errorf("审批失败")
+		}
+	}
+	s.logger.Info("审批自助注册用户", zap.Int64("userId", userId), zap.Bool("approved", approved), zap.Int64("operator", operator))
+
+	if user.Email != "" && s.emailManager != nil {
+		go func() {
+			var body string
+			if approved {
+				body = fmt.Sprintf(`<p>%s，您好：</p><p>您注册的账号 %s 已通过审批，现在可以登录了。</p>`, user.NickName, user.UserName)
+			} else {
+				body = fmt.Sprintf(`<p>%s，您好：</p><p>很抱歉，您注册的账号 %s 未通过审批。</p>`, user.NickName, user.UserName)
+				if reason != "" {
+					body += fmt.Sprintf(`<p>原因：%s</p>`, reason)
+				}
+			}
+			if err := s.emailManager.Send(user.Email, "注册审批结果", body); err != nil {
+				s.logger.Warn("发送注册审批结果邮件失败", zap.Int64("userId", userId), zap.Error(err))
+			}
+		}()
+	}
+	return nil
+}
+
+// verifyCaptcha 注册设置要求且已启用图形验证码时校验
+func (s *registrationService) verifyCaptcha(ctx context.Context, settings *RegistrationSettings, captchaId, code string) error {
+	if !settings.Captcha || s.captchaManager == nil || !s.captchaManager.IsEnabled(captcha.CaptchaTypeImage) {
+		return nil
+	}
+	if captchaId == "" || code == "" {
+		return fmt.Errorf("请输入图形验证码")
+	}
+	params := map[string]interface{}{"captchaID": captchaId, "code": code}
+	if err := s.captchaManager.Verify(ctx, captcha.CaptchaTypeImage, params); err != nil {
+		return fmt.Errorf("图形验证码错误: %w", err)
+	}
+	return nil
+}
+
+// checkIpLimit 同一 IP 每小时最多发送验证码和注册 20 次
+func (s *registrationService) checkIpLimit(ctx context.Context, ip string) error {
+	ipKey := "register_cnt:ip:" + ip
+	count, err := s.redis.Incr(ctx, ipKey).Result()
+	if err != nil {
+		s.logger.Warn("failed to check register limit", zap.Error(err))
+		return fmt.Errorf("操作失败，请稍后再试")
+	}
+	if count == 1 {
+		_ = s.redis.Expire(ctx, ipKey, time.Hour).Err()
+	}
+	if count > registerIpHourlyLimit {
+		return fmt.Errorf("操作过于频繁，请稍后再试")
+	}
+	return nil
+}
+
+// verifyEmailCode 校验注册邮箱验证码，最多尝试 5 次，校验通过后失效
+func (s *registrationService) verifyEmailCode(ctx context.Context, emailAddr, code string) error {
+	if code == "" {
+		return fmt.Errorf("请输入邮箱验证码")
+	}
+	key := s.emailKey(ctx, emailAddr)
+	data, err := s.redis.HGetAll(ctx, key).Result()
+	if err != nil || len(data) == 0 {
+		return fmt.Errorf("邮箱验证码无效或已过期")
+	}
+	attempts, err := s.redis.HIncrBy(ctx, key, "attempts", 1).Result()
+	if err != nil {
+		return fmt.Errorf("验证失败，请稍后再试")
+	}
+	if attempts > registerEmailMaxAttempts {
+		_ = s.redis.Del(ctx, key).Err()
+		return fmt.Errorf("验证失败次数过多，请重新获取验证码")
+	}
+	if subtle.ConstantTimeCompare([]byte(data["code"]), []byte(generateTokenHash(code))) != 1 {
+		return fmt.Errorf("邮箱验证码错误")
+	}
+	if n, err := s.redis.Del(ctx, key).Result(); err != nil || n == 0 {
+		return fmt.Errorf("邮箱验证码无效或已过期")
+	}
+	return nil
+}
+
+func (s *registrationService) emailKey(ctx context.Context, emailAddr string) string {
+	return RegisterEmailKeyPrefix + strconv.FormatInt(tenantIdFromContext(ctx), 10) + ":" + emailAddr
+}
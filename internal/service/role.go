@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/force-c/nai-tizi/internal/constants"
 	"github.com/force-c/nai-tizi/internal/domain/model"
 	"github.com/force-c/nai-tizi/internal/logger"
 	"github.com/force-c/nai-tizi/internal/utils/pagination"
@@ -84,8 +86,18 @@ func (s *roleService) Create(ctx context.Context, role *model.Role) error {
 		return fmt.Errorf("角色标识已存在: %s", role.RoleKey)
 	}
 
-	// 创建角色
-	if err := gorm.G[model.Role](s.db).Create(ctx, role); err != nil {
+	if err := normalizeDataScope(role); err != nil {
+		return err
+	}
+
+	// 创建角色及自定义数据权限组织
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := gorm.G[model.Role](tx).Create(ctx, role); err != nil {
+			return err
+		}
+		return s.saveRoleOrgs(ctx, tx, role)
+	})
+	if err != nil {
 		s.logger.Error("创建角色失败", zap.Error(err))
 		return fmt.Errorf("创建角色失败: %w", err)
 	}
@@ -111,17 +123,28 @@ func (s *roleService) Update(ctx context.Context, role *model.Role) error {
 		return fmt.Errorf("系统内置角色不允许修改角色标识")
 	}
 
-	// 更新角色
+	if err := normalizeDataScope(role); err != nil {
+		return err
+	}
+
+	// 更新角色及自定义数据权限组织
 	updates := map[string]any{
-		"role_name":  role.RoleName,
-		"sort":       role.Sort,
-		"status":     role.Status,
-		"data_scope": role.DataScope,
-		"remark":     role.Remark,
-		"update_by":  role.UpdateBy,
+		"role_name":   role.RoleName,
+		"sort":        role.Sort,
+		"status":      role.Status,
+		"data_scope":  role.DataScope,
+		"require_mfa": role.RequireMfa,
+		"remark":      role.Remark,
+		"update_by":   role.UpdateBy,
 	}
 
-	if err := s.db.Model(&model.Role{}).Where("id = ?", role.ID).Updates(updates).Error; err != nil {
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Role{}).Where("id = ?", role.ID).Updates(updates).Error; err != nil {
+			return err
+		}
+		return s.saveRoleOrgs(ctx, tx, role)
+	})
+	if err != nil {
 		s.logger.Error("更新角色失败", zap.Error(err))
 		return fmt.Errorf("更新角色失败: %w", err)
 	}
@@ -157,12 +180,17 @@ func (s *roleService) Delete(ctx context.Context, roleId int64) error {
 	}
 
 	// 开启事务删除角色及相关数据
-	return s.db.Transaction(func(tx *gorm.DB) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 删除角色菜单关联
 		if _, err := gorm.G[model.MRoleMenu](tx).Where("role_id = ?", roleId).Delete(ctx); err != nil {
 			return fmt.Errorf("删除角色菜单关联失败: %w", err)
 		}
 
+		// 删除角色自定义数据权限组织
+		if _, err := gorm.G[model.MRoleOrg](tx).Where("role_id = ?", roleId).Delete(ctx); err != nil {
+			return fmt.Errorf("删除角色数据权限失败: %w", err)
+		}
+
 		// 删除角色
 		if _, err := gorm.G[model.Role](tx).Where("id = ?", roleId).Delete(ctx); err != nil {
 			return fmt.Errorf("删除角色失败: %w", err)
@@ -184,6 +212,14 @@ func (s *roleService) GetById(ctx context.Context, roleId int64) (*model.Role, e
 		return nil, fmt.Errorf("查询角色失败: %w", err)
 	}
 
+	if role.DataScope == constants.DataScopeCustom {
+		role.OrgIds, err = (&model.MRoleOrg{}).FindOrgIdsByRoleIds(s.db.WithContext(ctx), []int64{roleId})
+		if err != nil {
+			s.logger.Error("查询角色数据权限失败", zap.Error(err))
+			return nil, fmt.Errorf("查询角色数据权限失败: %w", err)
+		}
+	}
+
 	return &role, nil
 }
 
@@ -203,7 +239,7 @@ func (s *roleService) GetByRoleKey(ctx context.Context, roleKey string) (*model.
 
 // Page 分页查询角色列表
 func (s *roleService) Page(ctx context.Context, pageNum, pageSize int, roleName string, status int32) (*pagination.Page[model.Role], error) {
-	query := s.db.Model(&model.Role{})
+	query := s.db.WithContext(ctx).Model(&model.Role{})
 
 	// 条件查询
 	if roleName != "" {
@@ -232,9 +268,9 @@ func (s *roleService) Page(ctx context.Context, pageNum, pageSize int, roleName
 // AssignRoleToUser 为用户分配角色（包含 Casbin 同步）
 func (s *roleService) AssignRoleToUser(ctx context.Context, userId, roleId int64) error {
 	// 使用事务确保数据一致性
-	return s.db.Transaction(func(tx *gorm.DB) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 1. 检查用户是否存在
-		if _, err := gorm.G[model.User](tx).Where("user_id = ?", userId).First(ctx); err != nil {
+		if _, err := gorm.G[model.User](tx).Where("id = ?", userId).First(ctx); err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				return fmt.Errorf("用户不存在")
 			}
@@ -293,7 +329,7 @@ func (s *roleService) AssignRoleToUser(ctx context.Context, userId, roleId int64
 // RemoveRoleFromUser 移除用户的角色（包含 Casbin 同步）
 func (s *roleService) RemoveRoleFromUser(ctx context.Context, userId, roleId int64) error {
 	// 使用事务确保数据一致性
-	return s.db.Transaction(func(tx *gorm.DB) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 1. 获取角色信息（用于 Casbin 同步）
 		role, err := gorm.G[model.Role](tx).Where("id = ?", roleId).First(ctx)
 		if err != nil {
@@ -337,7 +373,7 @@ func (s *roleService) RemoveRoleFromUser(ctx context.Context, userId, roleId int
 func (s *roleService) GetUserRoles(ctx context.Context, userId int64) ([]model.Role, error) {
 	var roles []model.Role
 
-	err := s.db.Table("s_role r").
+	err := s.db.WithContext(ctx).Table("s_role r").
 		Joins("INNER JOIN s_user_role ur ON r.role_id = ur.role_id").
 		Where("ur.user_id = ? AND r.status = 0", userId).
 		Order("r.sort ASC").
@@ -351,10 +387,11 @@ func (s *roleService) GetUserRoles(ctx context.Context, userId int64) ([]model.R
 	return roles, nil
 }
 
-// AssignMenusToRole 为角色分配菜单权限
+// AssignMenusToRole 为角色分配菜单权限，并按菜单的权限标识同步角色的 Casbin 权限
 func (s *roleService) AssignMenusToRole(ctx context.Context, roleId int64, menuIds []int64) error {
 	// 检查角色是否存在
-	if _, err := gorm.G[model.Role](s.db).Where("id = ?", roleId).First(ctx); err != nil {
+	role, err := gorm.G[model.Role](s.db).Where("id = ?", roleId).First(ctx)
+	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("角色不存在")
 		}
@@ -362,7 +399,7 @@ func (s *roleService) AssignMenusToRole(ctx context.Context, roleId int64, menuI
 	}
 
 	// 开启事务
-	return s.db.Transaction(func(tx *gorm.DB) error {
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 删除旧的菜单权限
 		if _, err := gorm.G[model.MRoleMenu](tx).Where("role_id = ?", roleId).Delete(ctx); err != nil {
 			return fmt.Errorf("删除旧菜单权限失败: %w", err)
@@ -388,13 +425,112 @@ func (s *roleService) AssignMenusToRole(ctx context.Context, roleId int64, menuI
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	// 策略同步是幂等的，失败后重新分配即可修复
+	if err := s.syncMenuPermissions(ctx, &role, menuIds); err != nil {
+		s.logger.Error("同步角色菜单权限失败", zap.Int64("roleId", roleId), zap.Error(err))
+		return fmt.Errorf("同步角色权限失败: %w", err)
+	}
+	return nil
+}
+
+// syncMenuPermissions 按已分配菜单的权限标识同步角色的 Casbin 权限
+// 菜单声明过的权限由菜单分配维护：添加已分配菜单的权限，删除角色已不再拥有的菜单权限；
+// 没有任何菜单声明的权限（通过 AddRolePermission 单独授予的）保持不变
+func (s *roleService) syncMenuPermissions(ctx context.Context, role *model.Role, menuIds []int64) error {
+	// 超级管理员通过 * 拥有所有权限，不按菜单维护
+	if role.RoleKey == "super_admin" {
+		return nil
+	}
+
+	menus, err := (&model.Menu{}).FindAll(s.db.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("查询菜单失败: %w", err)
+	}
+	assigned := make(map[int64]bool, len(menuIds))
+	for _, id := range menuIds {
+		assigned[id] = true
+	}
+	declared := make(map[string]bool)
+	want := make(map[[2]string]bool)
+	for _, menu := range menus {
+		for _, perm := range splitMenuPerms(menu.Perms) {
+			declared[perm] = true
+			if assigned[menu.ID] {
+				want[[2]string{perm, menuPermAction(perm)}] = true
+			}
+		}
+	}
+
+	current, err := s.casbinService.GetPermissionsForRole(ctx, role.RoleKey)
+	if err != nil {
+		return err
+	}
+	// 单一企业模式为 [sub, obj, act]，多租户模式为 [sub, dom, obj, act]
+	have := make(map[[2]string]bool, len(current))
+	removed := 0
+	for _, p := range current {
+		if len(p) < 3 {
+			continue
+		}
+		key := [2]string{p[len(p)-2], p[len(p)-1]}
+		have[key] = true
+		if declared[key[0]] && !want[key] {
+			if err := s.casbinService.DeletePermissionForRole(ctx, role.RoleKey, key[0], key[1]); err != nil {
+				return err
+			}
+			removed++
+		}
+	}
+	added := 0
+	for key := range want {
+		if have[key] {
+			continue
+		}
+		if err := s.casbinService.AddPermissionForRole(ctx, role.RoleKey, key[0], key[1]); err != nil {
+			return err
+		}
+		added++
+	}
+
+	s.logger.Info("同步角色菜单权限",
+		zap.String("roleKey", role.RoleKey),
+		zap.Int("added", added),
+		zap.Int("removed", removed))
+	return nil
+}
+
+// splitMenuPerms 解析菜单权限标识，多个标识以逗号分隔
+func splitMenuPerms(perms string) []string {
+	var result []string
+	for _, perm := range strings.Split(perms, ",") {
+		if perm = strings.TrimSpace(perm); perm != "" {
+			result = append(result, perm)
+		}
+	}
+	return result
+}
+
+// menuPermAction 菜单权限标识对应的 Casbin 操作，与 Permission 中间件的解析规则一致：
+// *.read 为 read 操作，通配符（如 user.*、*）为所有操作，其他为 write 操作
+func menuPermAction(perm string) string {
+	switch {
+	case strings.HasSuffix(perm, ".read"):
+		return "read"
+	case strings.Contains(perm, "*"):
+		return "*"
+	}
+	return "write"
 }
 
 // GetRoleMenus 获取角色的所有菜单
 func (s *roleService) GetRoleMenus(ctx context.Context, roleId int64) ([]model.Menu, error) {
 	var menus []model.Menu
 
-	err := s.db.Table("s_menu m").
+	err := s.db.WithContext(ctx).Table("s_menu m").
 		Joins("INNER JOIN s_role_menu rm ON m.menu_id = rm.menu_id").
 		Where("rm.role_id = ? AND m.status = 0", roleId).
 		Order("m.sort ASC").
@@ -458,3 +594,38 @@ func (s *roleService) GetRolePermissions(ctx context.Context, roleKey string) ([
 
 	return permissions, nil
 }
+
+// normalizeDataScope 校验数据范围，未指定时默认为全部数据
+func normalizeDataScope(role *model.Role) error {
+	if role.DataScope == 0 {
+		role.DataScope = constants.DataScopeAll
+	}
+	if role.DataScope == constants.DataScopeCustom && len(role.OrgIds) == 0 {
+		return fmt.Errorf("自定义数据权限至少需要选择一个组织")
+	}
+	return nil
+}
+
+// saveRoleOrgs 保存角色的自定义数据权限组织（先删后建），数据范围不是自定义时清空
+func (s *roleService) saveRoleOrgs(ctx context.Context, tx *gorm.DB, role *model.Role) error {
+	if _, err := gorm.G[model.MRoleOrg](tx).Where("role_id = ?", role.ID).Delete(ctx); err != nil {
+		return fmt.Errorf("删除旧数据权限组织失败: %w", err)
+	}
+	if role.DataScope != constants.DataScopeCustom {
+		return nil
+	}
+
+	roleOrgs := make([]model.MRoleOrg, 0, len(role.OrgIds))
+	for _, orgId := range role.OrgIds {
+		roleOrgs = append(roleOrgs, model.MRoleOrg{
+			RoleId:   role.ID,
+			OrgId:    orgId,
+			CreateBy: role.CreateBy,
+			UpdateBy: role.UpdateBy,
+		})
+	}
+	if err := tx.Create(&roleOrgs).Error; err != nil {
+		return fmt.Errorf("添加数据权限组织失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// SessionActiveKeyPrefix 会话活动记录 Redis Key 前缀，过期即视为空闲超时
+	// session:active:{sessionId} -> 最近活动时间（Unix 秒）
+	SessionActiveKeyPrefix = "session:active:"
+
+	// SessionActiveTouchInterval 刷新活动记录的最小间隔，避免每个请求都写 Redis
+	// 空闲超时较短时按超时时间的 1/4 计算，保证误差可控
+	SessionActiveTouchInterval = time.Minute
+)
+
+// ErrSessionIdle 会话空闲超时
+var ErrSessionIdle = errors.New("长时间未操作，会话已失效，请重新登录")
+
+// SessionActivity 会话活动记录（滑动空闲超时）
+type SessionActivity interface {
+	// Start 登录或开启空闲检测时创建活动记录
+	Start(ctx context.Context, sessionId string, idle time.Duration) error
+
+	// Touch 请求到达时刷新活动记录（按间隔节流），记录已过期时返回 ErrSessionIdle
+	Touch(ctx context.Context, sessionId string, idle time.Duration) error
+
+	// Alive 检查活动记录是否仍然存在（不刷新）
+	Alive(ctx context.Context, sessionId string) (bool, error)
+
+	// Remove 删除活动记录
+	Remove(ctx context.Context, sessionId string) error
+}
+
+type sessionActivity struct {
+	redis *redis.Client
+}
+
+// NewSessionActivity 创建基于 Redis 的会话活动记录
+func NewSessionActivity(redis *redis.Client) SessionActivity {
+	return &sessionActivity{redis: redis}
+}
+
+// Start 创建活动记录
+func (a *sessionActivity) Start(ctx context.Context, sessionId string, idle time.Duration) error {
+	return a.redis.Set(ctx, SessionActiveKeyPrefix+sessionId, time.Now().Unix(), idle).Err()
+}
+
+// Touch 刷新活动记录
+// 剩余时间不足 idle - interval 时才续期，续期使用 SET XX，记录恰好过期时不会被重新创建
+func (a *sessionActivity) Touch(ctx context.Context, sessionId string, idle time.Duration) error {
+	key := SessionActiveKeyPrefix + sessionId
+	ttl, err := a.redis.TTL(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	// -2 表示 Key 不存在；-1 表示未设置过期时间（异常数据），按需续期处理
+	if ttl == -2 {
+		return ErrSessionIdle
+	}
+
+	interval := SessionActiveTouchInterval
+	if interval > idle/4 {
+		interval = idle / 4
+	}
+	if ttl > 0 && idle-ttl < interval {
+		return nil
+	}
+
+	err = a.redis.SetArgs(ctx, key, time.Now().Unix(), redis.SetArgs{Mode: "XX", TTL: idle}).Err()
+	if errors.Is(err, redis.Nil) {
+		return ErrSessionIdle
+	}
+	return err
+}
+
+// Alive 检查活动记录是否存在
+func (a *sessionActivity) Alive(ctx context.Context, sessionId string) (bool, error) {
+	n, err := a.redis.Exists(ctx, SessionActiveKeyPrefix+sessionId).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// Remove 删除活动记录
+func (a *sessionActivity) Remove(ctx context.Context, sessionId string) error {
+	return a.redis.Del(ctx, SessionActiveKeyPrefix+sessionId).Err()
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	// SessionKeyPrefix 会话详情 Redis Key 前缀
+	// session:{userId}:{sessionId} -> Hash
+	SessionKeyPrefix = "session:"
+
+	// UserSessionsKeyPrefix 用户会话索引 Redis Key 前缀
+	// user:sessions:{userId} -> Set[sessionId]
+	UserSessionsKeyPrefix = "user:sessions:"
+)
+
+// Session 登录会话
+type Session struct {
+	SessionId       string // 会话ID
+	UserId          int64  // 用户ID
+	UserName        string // 用户名
+	ClientId        string // 客户端ID
+	DeviceType      string // 设备类型
+	Ipaddr          string // 登录IP
+	UserAgent       string // User-Agent
+	CreatedAt       int64  // 创建时间（Unix 秒）
+	LastRefreshAt   int64  // 最近刷新时间（Unix 秒）
+	AccessJti       string // 当前 AccessToken 的 jti
+	AccessExpiresAt int64  // 当前 AccessToken 过期时间（Unix 秒）
+	ActorId         int64  // 代登录的管理员ID（非代登录会话为 0）
+	ActorName       string // 代登录的管理员用户名
+}
+
+// SessionMeta 会话的请求端信息（由 Controller 通过 context 传入）
+type SessionMeta struct {
+	Ipaddr    string
+	UserAgent string
+}
+
+type sessionMetaKey struct{}
+
+// WithSessionMeta 将请求端信息写入 context，供 TokenManager 创建/刷新会话时使用
+func WithSessionMeta(ctx context.Context, ipaddr, userAgent string) context.Context {
+	return context.WithValue(ctx, sessionMetaKey{}, SessionMeta{Ipaddr: ipaddr, UserAgent: userAgent})
+}
+
+// sessionMetaFromContext 从 context 读取请求端信息
+func sessionMetaFromContext(ctx context.Context) SessionMeta {
+	if meta, ok := ctx.Value(sessionMetaKey{}).(SessionMeta); ok {
+		return meta
+	}
+	return SessionMeta{}
+}
+
+// ClientIPFromContext 从 context 读取请求端 IP（供登录策略按 IP 限流）
+func ClientIPFromContext(ctx context.Context) string {
+	return sessionMetaFromContext(ctx).Ipaddr
+}
+
+// SessionRegistry 会话注册表接口
+type SessionRegistry interface {
+	// Save 保存会话（创建或覆盖），ttl 为会话最长存活时间
+	Save(ctx context.Context, session *Session, ttl time.Duration) error
+
+	// Touch 会话刷新后更新 AccessToken 信息和最近刷新时间
+	Touch(ctx context.Context, userId int64, sessionId, accessJti string, accessExpiresAt int64, meta SessionMeta, ttl time.Duration) error
+
+	// Get 获取单个会话，不存在时返回 nil
+	Get(ctx context.Context, userId int64, sessionId string) (*Session, error)
+
+	// List 列出用户的所有会话（按创建时间倒序），并清理已失效的索引
+	List(ctx context.Context, userId int64) ([]*Session, error)
+
+	// Remove 删除会话记录
+	Remove(ctx context.Context, userId int64, sessionId string) error
+}
+
+type sessionRegistry struct {
+	redis  *redis.Client
+	logger logging.Logger
+}
+
+// NewSessionRegistry 创建基于 Redis 的会话注册表
+func NewSessionRegistry(redis *redis.Client, logger logging.Logger) SessionRegistry {
+	return &sessionRegistry{redis: redis, logger: logger}
+}
+
+// Save 保存会话
+func (r *sessionRegistry) Save(ctx context.Context, session *Session, ttl time.Duration) error {
+	key := r.getSessionKey(session.UserId, session.SessionId)
+	indexKey := r.getUserSessionsKey(session.UserId)
+
+	pipe := r.redis.TxPipeline()
+	pipe.HSet(ctx, key, map[string]interface{}{
+		"sessionId":       session.SessionId,
+		"userId":          session.UserId,
+		"userName":        session.UserName,
+		"clientId":        session.ClientId,
+		"deviceType":      session.DeviceType,
+		"ipaddr":          session.Ipaddr,
+		"userAgent":       session.UserAgent,
+		"createdAt":       session.CreatedAt,
+		"lastRefreshAt":   session.LastRefreshAt,
+		"accessJti":       session.AccessJti,
+		"accessExpiresAt": session.AccessExpiresAt,
+		"actorId":         session.ActorId,
+		"actorName":       session.ActorName,
+	})
+	pipe.Expire(ctx, key, ttl)
+	pipe.SAdd(ctx, indexKey, session.SessionId)
+	extendIndexTTL(ctx, pipe, indexKey, ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Touch 更新会话的 AccessToken 信息
+func (r *sessionRegistry) Touch(ctx context.Context, userId int64, sessionId, accessJti string, accessExpiresAt int64, meta SessionMeta, ttl time.Duration) error {
+	key := r.getSessionKey(userId, sessionId)
+	exists, err := r.redis.Exists(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	if exists == 0 {
+		return nil
+	}
+
+	fields := map[string]interface{}{
+		"lastRefreshAt":   time.Now().Unix(),
+		"accessJti":       accessJti,
+		"accessExpiresAt": accessExpiresAt,
+	}
+	if meta.Ipaddr != "" {
+		fields["ipaddr"] = meta.Ipaddr
+	}
+	if meta.UserAgent != "" {
+		fields["userAgent"] = meta.UserAgent
+	}
+
+	indexKey := r.getUserSessionsKey(userId)
+	pipe := r.redis.TxPipeline()
+	pipe.HSet(ctx, key, fields)
+	pipe.Expire(ctx, key, ttl)
+	extendIndexTTL(ctx, pipe, indexKey, ttl)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// Get 获取单个会话
+func (r *sessionRegistry) Get(ctx context.Context, userId int64, sessionId string) (*Session, error) {
+	data, err := r.redis.HGetAll(ctx, r.getSessionKey(userId, sessionId)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return parseSession(data), nil
+}
+
+// List 列出用户的所有会话
+func (r *sessionRegistry) List(ctx context.Context, userId int64) ([]*Session, error) {
+	indexKey := r.getUserSessionsKey(userId)
+	sessionIds, err := r.redis.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*Session, 0, len(sessionIds))
+	for _, sessionId := range sessionIds {
+		session, err := r.Get(ctx, userId, sessionId)
+		if err != nil {
+			r.logger.Warn("读取会话失败", zap.String("sessionId", sessionId), zap.Error(err))
+			continue
+		}
+		if session == nil {
+			// 会话已过期，清理索引
+			_ = r.redis.SRem(ctx, indexKey, sessionId).Err()
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt > sessions[j].CreatedAt
+	})
+	return sessions, nil
+}
+
+// Remove 删除会话记录
+func (r *sessionRegistry) Remove(ctx context.Context, userId int64, sessionId string) error {
+	pipe := r.redis.TxPipeline()
+	pipe.Del(ctx, r.getSessionKey(userId, sessionId))
+	pipe.SRem(ctx, r.getUserSessionsKey(userId), sessionId)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// getSessionKey 获取会话详情 Redis Key
+func (r *sessionRegistry) getSessionKey(userId int64, sessionId string) string {
+	return fmt.Sprintf("%s%d:%s", SessionKeyPrefix, userId, sessionId)
+}
+
+// extendIndexTTL 延长用户会话索引的过期时间，只延长不缩短
+// 索引由用户的所有会话共享，短会话（如代登录）不能让长会话提前从索引中消失；
+// 新建的索引没有过期时间，GT 会视为永不过期而不生效，因此先用 NX 设置（需要 Redis 7.0+）
+func extendIndexTTL(ctx context.Context, pipe redis.Pipeliner, indexKey string, ttl time.Duration) {
+	pipe.ExpireNX(ctx, indexKey, ttl)
+	pipe.ExpireGT(ctx, indexKey, ttl)
+}
+
+// getUserSessionsKey 获取用户会话索引 Redis Key
+func (r *sessionRegistry) getUserSessionsKey(userId int64) string {
+	return fmt.Sprintf("%s%d", UserSessionsKeyPrefix, userId)
+}
+
+// parseSession 将 Redis Hash 转换为会话结构
+func parseSession(data map[string]string) *Session {
+	userId, _ := strconv.ParseInt(data["userId"], 10, 64)
+	createdAt, _ := strconv.ParseInt(data["createdAt"], 10, 64)
+	lastRefreshAt, _ := strconv.ParseInt(data["lastRefreshAt"], 10, 64)
+	accessExpiresAt, _ := strconv.ParseInt(data["accessExpiresAt"], 10, 64)
+	actorId, _ := strconv.ParseInt(data["actorId"], 10, 64)
+	return &Session{
+		SessionId:       data["sessionId"],
+		UserId:          userId,
+		UserName:        data["userName"],
+		ClientId:        data["clientId"],
+		DeviceType:      data["deviceType"],
+		Ipaddr:          data["ipaddr"],
+		UserAgent:       data["userAgent"],
+		CreatedAt:       createdAt,
+		LastRefreshAt:   lastRefreshAt,
+		AccessJti:       data["accessJti"],
+		AccessExpiresAt: accessExpiresAt,
+		ActorId:         actorId,
+		ActorName:       data["actorName"],
+	}
+}
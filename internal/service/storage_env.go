@@ -57,7 +57,7 @@ func NewStorageEnvService(db *gorm.DB, logger logger.Logger) StorageEnvService {
 
 // Create 创建存储环境
 func (s *storageEnvService) Create(ctx context.Context, env *model.StorageEnv) error {
-	return s.db.Transaction(func(tx *gorm.DB) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 1. 检查环境编码是否已存在
 		exists, err := (&model.StorageEnv{}).CheckEnvCodeExists(tx, env.EnvCode)
 		if err != nil {
@@ -100,7 +100,7 @@ func (s *storageEnvService) Create(ctx context.Context, env *model.StorageEnv) e
 
 // Update 更新存储环境
 func (s *storageEnvService) Update(ctx context.Context, env *model.StorageEnv) error {
-	return s.db.Transaction(func(tx *gorm.DB) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 1. 检查环境是否存在
 		existingEnv, err := (&model.StorageEnv{}).FindByID(tx, env.ID)
 		if err != nil {
@@ -151,7 +151,7 @@ func (s *storageEnvService) Update(ctx context.Context, env *model.StorageEnv) e
 
 // Delete 删除存储环境
 func (s *storageEnvService) Delete(ctx context.Context, envId int64) error {
-	return s.db.Transaction(func(tx *gorm.DB) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 1. 检查环境是否存在
 		env, err := (&model.StorageEnv{}).FindByID(tx, envId)
 		if err != nil {
@@ -188,7 +188,7 @@ func (s *storageEnvService) Delete(ctx context.Context, envId int64) error {
 
 // GetById 根据 ID 查询存储环境
 func (s *storageEnvService) GetById(ctx context.Context, envId int64) (*model.StorageEnv, error) {
-	env, err := (&model.StorageEnv{}).FindByID(s.db, envId)
+	env, err := (&model.StorageEnv{}).FindByID(s.db.WithContext(ctx), envId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("存储环境不存在")
@@ -200,7 +200,7 @@ func (s *storageEnvService) GetById(ctx context.Context, envId int64) (*model.St
 
 // GetByCode 根据编码查询存储环境
 func (s *storageEnvService) GetByCode(ctx context.Context, envCode string) (*model.StorageEnv, error) {
-	env, err := (&model.StorageEnv{}).FindByCode(s.db, envCode)
+	env, err := (&model.StorageEnv{}).FindByCode(s.db.WithContext(ctx), envCode)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("存储环境不存在: %s", envCode)
@@ -212,7 +212,7 @@ func (s *storageEnvService) GetByCode(ctx context.Context, envCode string) (*mod
 
 // Page 分页查询存储环境列表
 func (s *storageEnvService) Page(ctx context.Context, pageNum, pageSize int, name string, storageType string) (*pagination.Page[model.StorageEnv], error) {
-	query := s.db.Model(&model.StorageEnv{})
+	query := s.db.WithContext(ctx).Model(&model.StorageEnv{})
 
 	// 条件查询
 	if name != "" {
@@ -240,7 +240,7 @@ func (s *storageEnvService) Page(ctx context.Context, pageNum, pageSize int, nam
 
 // SetDefault 设置默认环境
 func (s *storageEnvService) SetDefault(ctx context.Context, envId int64) error {
-	return s.db.Transaction(func(tx *gorm.DB) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 1. 检查环境是否存在
 		env, err := (&model.StorageEnv{}).FindByID(tx, envId)
 		if err != nil {
@@ -270,7 +270,7 @@ func (s *storageEnvService) SetDefault(ctx context.Context, envId int64) error {
 
 // GetDefault 获取默认环境
 func (s *storageEnvService) GetDefault(ctx context.Context) (*model.StorageEnv, error) {
-	env, err := (&model.StorageEnv{}).FindDefault(s.db)
+	env, err := (&model.StorageEnv{}).FindDefault(s.db.WithContext(ctx))
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("未配置默认存储环境")
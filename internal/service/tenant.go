@@ -0,0 +1,674 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/force-c/nai-tizi/internal/config"
+	"github.com/force-c/nai-tizi/internal/constants"
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	"github.com/force-c/nai-tizi/internal/domain/request"
+	"github.com/force-c/nai-tizi/internal/infrastructure/storage"
+	"github.com/force-c/nai-tizi/internal/infrastructure/tenant"
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/force-c/nai-tizi/internal/utils/pagination"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	TenantCacheKeyPrefix = "s_tenant:"
+	TenantCacheTTL       = 10 * time.Minute
+
+	TenantStatusNormal    int32 = 0 // 正常
+	TenantStatusSuspended int32 = 1 // 停用
+)
+
+// tenantCodePattern 租户编码格式（用作子域名）
+var tenantCodePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,30}$`)
+
+var (
+	ErrTenantNotFound  = errors.New("租户不存在")
+	ErrTenantSuspended = errors.New("租户已停用")
+	ErrTenantExpired   = errors.New("租户已到期")
+)
+
+// tenantOwnedModels 删除租户时需要清理的租户数据
+var tenantOwnedModels = []any{
+	&model.MUserRole{}, &model.MRoleMenu{}, &model.MRoleOrg{},
+	&model.User{}, &model.Role{}, &model.Org{},
+	&model.DictData{}, &model.Config{}, &model.InviteCode{},
+	&model.LoginLog{}, &model.OperLog{}, &model.Attachment{},
+	&model.ApiKey{}, &model.ImpersonationLog{}, &model.LoginDevice{},
+	&model.PasswordHistory{}, &model.WebAuthnCredential{}, &model.OAuthConsent{}, &model.UserIdentity{},
+}
+
+// TenantResolver 租户识别与状态检查（供租户中间件和认证中间件使用）
+type TenantResolver interface {
+	// ResolveCode 按租户编码（子域名）查询租户ID
+	ResolveCode(ctx context.Context, code string) (int64, error)
+
+	// CheckActive 检查租户是否可用，停用、到期或已删除的租户返回错误
+	CheckActive(ctx context.Context, tenantId int64) error
+}
+
+// TenantService 租户开通与生命周期管理服务（平台运营使用）
+type TenantService interface {
+	TenantResolver
+
+	// Page 分页查询租户
+	Page(ctx context.Context, req *request.PageTenantRequest) (*pagination.Page[model.Tenant], error)
+
+	// GetById 查询租户
+	GetById(ctx context.Context, id int64) (*model.Tenant, error)
+
+	// Create 开通租户：创建根组织、从模板租户复制角色（含菜单和 Casbin 权限）、字典和参数，并创建租户管理员
+	Create(ctx context.Context, req *request.CreateTenantRequest, operator int64) (*TenantOnboardResult, error)
+
+	// Update 更新租户信息
+	Update(ctx context.Context, id int64, req *request.UpdateTenantRequest, operator int64) error
+
+	// Suspend 停用租户，停用后租户用户无法登录和访问接口
+	Suspend(ctx context.Context, id int64, operator int64) error
+
+	// Resume 恢复租户
+	Resume(ctx context.Context, id int64, operator int64) error
+
+	// Delete 删除租户并清除租户的全部数据（不可恢复）
+	Delete(ctx context.Context, id int64) error
+}
+
+// TenantOnboardResult 开通租户结果
+type TenantOnboardResult struct {
+	Tenant      *model.Tenant
+	RootOrg     *model.Org
+	AdminUser   *model.User
+	RoleCount   int
+	PolicyCount int
+}
+
+type tenantService struct {
+	db             *gorm.DB
+	redis          *redis.Client
+	casbinService  CasbinServiceV2
+	tokenManager   TokenManager
+	storageManager storage.StorageManager
+	passwordPolicy PasswordPolicyService
+	cfg            *config.Config
+	logger         logging.Logger
+}
+
+func NewTenantService(db *gorm.DB, redis *redis.Client, casbinService CasbinServiceV2, tokenManager TokenManager, storageManager storage.StorageManager, cfg *config.Config, logger logging.Logger) TenantService {
+	return &tenantService{
+		db:             db,
+		redis:          redis,
+		casbinService:  casbinService,
+		tokenManager:   tokenManager,
+		storageManager: storageManager,
+		passwordPolicy: NewPasswordPolicyService(db, logger),
+		cfg:            cfg,
+		logger:         logger,
+	}
+}
+
+// Page 分页查询租户
+func (s *tenantService) Page(ctx context.Context, req *request.PageTenantRequest) (*pagination.Page[model.Tenant], error) {
+	q := s.db.WithContext(ctx).Model(&model.Tenant{})
+	if code := strings.TrimSpace(req.TenantCode); code != "" {
+		q = q.Where("tenant_code LIKE ?", "%"+strings.ToLower(code)+"%")
+	}
+	if name := strings.TrimSpace(req.TenantName); name != "" {
+		q = q.Where("tenant_name LIKE ?", "%"+name+"%")
+	}
+	if req.Status != nil {
+		q = q.Where("status = ?", *req.Status)
+	}
+	if req.OrderByColumn == "" {
+		q = q.Order("created_time DESC")
+	}
+	page, err := pagination.New[model.Tenant](q, &req.PageQuery).Find()
+	if err != nil {
+		s.logger.Error("分页查询租户失败", zap.Error(err))
+		return nil, fmt.Errorf("查询失败")
+	}
+	return page, nil
+}
+
+// GetById 查询租户
+func (s *tenantService) GetById(ctx context.Context, id int64) (*model.Tenant, error) {
+	t, err := (&model.Tenant{}).FindByID(s.db.WithContext(ctx), id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTenantNotFound
+		}
+		s.logger.Error("查询租户失败", zap.Int64("tenantId", id), zap.Error(err))
+		return nil, fmt.Errorf("查询租户失败")
+	}
+	return t, nil
+}
+
+// Create 开通租户
+// 1. 创建租户和根组织
+// 2. 复制模板租户的角色（超级管理员除外）及其菜单，并在 tenant::N 域下复制角色的 Casbin 权限
+// 3. 复制模板租户的字典和参数配置
+// 4. 创建租户管理员并授予 AdminRoleKey 角色
+func (s *tenantService) Create(ctx context.Context, req *request.CreateTenantRequest, operator int64) (*TenantOnboardResult, error) {
+	if !s.cfg.MultiTenant.Enabled {
+		return nil, fmt.Errorf("未启用多租户模式")
+	}
+	code := strings.ToLower(strings.TrimSpace(req.TenantCode))
+	if !tenantCodePattern.MatchString(code) || code == "www" {
+		return nil, fmt.Errorf("租户编码只能包含小写字母、数字和中划线，长度 2-31 位")
+	}
+	if req.ExpireAt != 0 && req.ExpireAt <= time.Now().Unix() {
+		return nil, fmt.Errorf("到期时间必须晚于当前时间")
+	}
+	exists, err := (&model.Tenant{}).CheckCodeExists(s.db.WithContext(ctx), code)
+	if err != nil {
+		s.logger.Error("检查租户编码失败", zap.Error(err))
+		return nil, fmt.Errorf("检查租户编码失败")
+	}
+	if exists {
+		return nil, fmt.Errorf("租户编码已存在")
+	}
+
+	templateId := s.cfg.MultiTenant.TemplateTenantId
+	templateCtx := tenant.WithTenantId(ctx, templateId)
+	if _, err := s.GetById(ctx, templateId); err != nil && templateId != tenant.DefaultTenantId {
+		return nil, fmt.Errorf("模板租户不存在")
+	}
+
+	// 新租户复制了模板租户的参数配置，按模板租户的密码策略校验管理员密码
+	hashedPassword, pwdChange, err := s.passwordPolicy.PrepareNew(templateCtx, 0, req.AdminUserName, req.AdminPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &model.Tenant{
+		TenantCode:   code,
+		TenantName:   req.TenantName,
+		Plan:         req.Plan,
+		ExpireAt:     req.ExpireAt,
+		Status:       TenantStatusNormal,
+		ContactName:  req.ContactName,
+		ContactPhone: req.ContactPhone,
+		Remark:       req.Remark,
+		CreateBy:     operator,
+		UpdateBy:     operator,
+	}
+	result := &TenantOnboardResult{Tenant: t}
+	roleKeys := make([]string, 0)
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := t.Create(tx); err != nil {
+			return fmt.Errorf("创建租户失败: %w", err)
+		}
+		// 以下数据均归属新租户
+		tenantCtx := tenant.WithTenantId(ctx, t.ID)
+		tx = tx.WithContext(tenantCtx)
+
+		// 根组织
+		org := &model.Org{
+			TenantId:  t.ID,
+			ParentId:  0,
+			Ancestors: "0",
+			OrgName:   t.TenantName,
+			OrgCode:   code,
+			OrgType:   "company",
+			Leader:    req.ContactName,
+			Phone:     req.ContactPhone,
+			CreateBy:  operator,
+			UpdateBy:  operator,
+		}
+		if err := tx.Create(org).Error; err != nil {
+			return fmt.Errorf("创建根组织失败: %w", err)
+		}
+		result.RootOrg = org
+
+		// 角色及角色菜单
+		adminRoleId, err := s.copyRoles(templateCtx, tx, t.ID, operator, &roleKeys)
+		if err != nil {
+			return err
+		}
+		result.RoleCount = len(roleKeys)
+		if adminRoleId == 0 {
+			return fmt.Errorf("模板租户缺少租户管理员角色: %s", s.cfg.MultiTenant.AdminRoleKey)
+		}
+
+		// 字典和参数配置
+		if err := s.copyDicts(templateCtx, tx, t.ID, operator); err != nil {
+			return err
+		}
+		if err := s.copyConfigs(templateCtx, tx, t.ID, operator); err != nil {
+			return err
+		}
+
+		// 租户管理员
+		admin := &model.User{
+			OrgId:       org.ID,
+			TenantId:    t.ID,
+			UserName:    req.AdminUserName,
+			NickName:    req.AdminNickName,
+			Password:    hashedPassword,
+			PwdUpdateAt: time.Now().Unix(),
+			PwdChange:   pwdChange,
+			CreateBy:    operator,
+			UpdateBy:    operator,
+		}
+		if admin.NickName == "" {
+			admin.NickName = req.AdminUserName
+		}
+		if err := tx.Create(admin).Error; err != nil {
+			return fmt.Errorf("创建租户管理员失败: %w", err)
+		}
+		if err := tx.Create(&model.PasswordHistory{UserId: admin.ID, TenantId: t.ID, Password: hashedPassword}).Error; err != nil {
+			return fmt.Errorf("记录历史密码失败: %w", err)
+		}
+		userRole := &model.MUserRole{UserId: admin.ID, RoleId: adminRoleId, TenantId: t.ID, CreateBy: operator, UpdateBy: operator}
+		if err := tx.Create(userRole).Error; err != nil {
+			return fmt.Errorf("分配租户管理员角色失败: %w", err)
+		}
+		if err := t.Update(tx, t.ID, map[string]any{"admin_user_id": admin.ID}); err != nil {
+			return err
+		}
+		t.AdminUserId = admin.ID
+		result.AdminUser = admin
+
+		// Casbin 策略不在数据库事务内，放在最后写入，失败时统一清理
+		policyCount, err := s.copyPolicies(templateCtx, tenantCtx, roleKeys)
+		if err != nil {
+			return err
+		}
+		result.PolicyCount = policyCount
+		if err := s.casbinService.AddRoleForUser(tenantCtx, admin.ID, s.cfg.MultiTenant.AdminRoleKey); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("开通租户失败", zap.Int64("tenantId", t.ID), zap.String("tenantCode", code), zap.Error(err))
+		if t.ID != 0 {
+			if cerr := s.casbinService.DeleteTenantPolicies(ctx, t.ID); cerr != nil {
+				s.logger.Warn("清理租户权限策略失败", zap.Int64("tenantId", t.ID), zap.Error(cerr))
+			}
+		}
+		return nil, fmt.Errorf("开通租户失败: %w", err)
+	}
+
+	s.logger.Info("开通租户",
+		zap.Int64("tenantId", t.ID),
+		zap.String("tenantCode", code),
+		zap.Int64("adminUserId", t.AdminUserId),
+		zap.Int("roleCount", result.RoleCount),
+		zap.Int("policyCount", result.PolicyCount),
+		zap.Int64("operator", operator))
+	return result, nil
+}
+
+// copyRoles 复制模板租户的角色及角色菜单，返回租户管理员角色的新ID
+// 超级管理员角色不复制；自定义数据权限依赖模板租户的组织，复制后改为仅本人
+func (s *tenantService) copyRoles(templateCtx context.Context, tx *gorm.DB, tenantId, operator int64, roleKeys *[]string) (int64, error) {
+	var roles []model.Role
+	if err := tx.WithContext(templateCtx).Where("role_key <> ?", "super_admin").Order("sort ASC, id ASC").Find(&roles).Error; err != nil {
+		return 0, fmt.Errorf("查询模板角色失败: %w", err)
+	}
+
+	var adminRoleId int64
+	for _, src := range roles {
+		role := model.Role{
+			TenantId:   tenantId,
+			RoleKey:    src.RoleKey,
+			RoleName:   src.RoleName,
+			Sort:       src.Sort,
+			Status:     src.Status,
+			DataScope:  src.DataScope,
+			IsSystem:   src.IsSystem,
+			RequireMfa: src.RequireMfa,
+			Remark:     src.Remark,
+			CreateBy:   operator,
+			UpdateBy:   operator,
+		}
+		if role.DataScope == constants.DataScopeCustom {
+			role.DataScope = constants.DataScopeSelf
+		}
+		if err := tx.Create(&role).Error; err != nil {
+			return 0, fmt.Errorf("复制角色 %s 失败: %w", src.RoleKey, err)
+		}
+		*roleKeys = append(*roleKeys, role.RoleKey)
+		if role.RoleKey == s.cfg.MultiTenant.AdminRoleKey {
+			adminRoleId = role.ID
+		}
+
+		var menuIds []int64
+		if err := tx.WithContext(templateCtx).Model(&model.MRoleMenu{}).Where("role_id = ?", src.ID).Pluck("menu_id", &menuIds).Error; err != nil {
+			return 0, fmt.Errorf("查询模板角色菜单失败: %w", err)
+		}
+		if len(menuIds) == 0 {
+			continue
+		}
+		roleMenus := make([]model.MRoleMenu, 0, len(menuIds))
+		for _, menuId := range menuIds {
+			roleMenus = append(roleMenus, model.MRoleMenu{RoleId: role.ID, MenuId: menuId, TenantId: tenantId, CreateBy: operator, UpdateBy: operator})
+		}
+		if err := tx.Create(&roleMenus).Error; err != nil {
+			return 0, fmt.Errorf("复制角色菜单失败: %w", err)
+		}
+	}
+	return adminRoleId, nil
+}
+
+// copyDicts 复制模板租户的字典数据（保持父子关系）
+func (s *tenantService) copyDicts(templateCtx context.Context, tx *gorm.DB, tenantId, operator int64) error {
+	var dicts []model.DictData
+	if err := tx.WithContext(templateCtx).Order("parent_id ASC, id ASC").Find(&dicts).Error; err != nil {
+		return fmt.Errorf("查询模板字典失败: %w", err)
+	}
+
+	// 先复制父节点再复制子节点，按旧ID映射新的父ID
+	idMap := make(map[int64]int64, len(dicts))
+	pending := dicts
+	for len(pending) > 0 {
+		next := pending[:0:0]
+		for _, src := range pending {
+			parentId := int64(0)
+			if src.ParentId != 0 {
+				mapped, ok := idMap[src.ParentId]
+				if !ok {
+					next = append(next, src)
+					continue
+				}
+				parentId = mapped
+			}
+			dict := model.DictData{
+				TenantId:  tenantId,
+				ParentId:  parentId,
+				Sort:      src.Sort,
+				DictLabel: src.DictLabel,
+				DictValue: src.DictValue,
+				DictType:  src.DictType,
+				IsDefault: src.IsDefault,
+				Status:    src.Status,
+				Remark:    src.Remark,
+				CreateBy:  operator,
+				UpdateBy:  operator,
+			}
+			if err := tx.Create(&dict).Error; err != nil {
+				return fmt.Errorf("复制字典失败: %w", err)
+			}
+			idMap[src.ID] = dict.ID
+		}
+		// 父节点不在模板租户中的字典无法挂载，忽略
+		if len(next) == len(pending) {
+			s.logger.Warn("模板字典的父节点不存在，已忽略", zap.Int("count", len(next)))
+			break
+		}
+		pending = next
+	}
+	return nil
+}
+
+// copyConfigs 复制模板租户的参数配置
+func (s *tenantService) copyConfigs(templateCtx context.Context, tx *gorm.DB, tenantId, operator int64) error {
+	var configs []model.Config
+	if err := tx.WithContext(templateCtx).Order("id ASC").Find(&configs).Error; err != nil {
+		return fmt.Errorf("查询模板参数配置失败: %w", err)
+	}
+	for _, src := range configs {
+		cfg := model.Config{
+			TenantID: tenantId,
+			Name:     src.Name,
+			Code:     src.Code,
+			Data:     src.Data,
+			Remark:   src.Remark,
+			CreateBy: operator,
+			UpdateBy: operator,
+		}
+		if err := tx.Create(&cfg).Error; err != nil {
+			return fmt.Errorf("复制参数配置 %s 失败: %w", src.Code, err)
+		}
+	}
+	return nil
+}
+
+// copyPolicies 在新租户域下复制模板租户角色的 Casbin 权限
+func (s *tenantService) copyPolicies(templateCtx, tenantCtx context.Context, roleKeys []string) (int, error) {
+	count := 0
+	for _, roleKey := range roleKeys {
+		permissions, err := s.casbinService.GetPermissionsForRole(templateCtx, roleKey)
+		if err != nil {
+			return count, err
+		}
+		for _, p := range permissions {
+			// 多租户模式 p = sub, dom, obj, act
+			if len(p) < 2 {
+				continue
+			}
+			if err := s.casbinService.AddPermissionForRole(tenantCtx, roleKey, p[len(p)-2], p[len(p)-1]); err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Update 更新租户信息
+func (s *tenantService) Update(ctx context.Context, id int64, req *request.UpdateTenantRequest, operator int64) error {
+	t, err := s.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if req.ExpireAt != 0 && req.ExpireAt != t.ExpireAt && req.ExpireAt <= time.Now().Unix() {
+		return fmt.Errorf("到期时间必须晚于当前时间")
+	}
+	if id == tenant.DefaultTenantId && req.ExpireAt != 0 {
+		return fmt.Errorf("默认租户不能设置到期时间")
+	}
+
+	err = t.Update(s.db.WithContext(ctx), id, map[string]any{
+		"tenant_name":   req.TenantName,
+		"plan":          req.Plan,
+		"expire_at":     req.ExpireAt,
+		"contact_name":  req.ContactName,
+		"contact_phone": req.ContactPhone,
+		"remark":        req.Remark,
+		"update_by":     operator,
+	})
+	if err != nil {
+		s.logger.Error("更新租户失败", zap.Int64("tenantId", id), zap.Error(err))
+		return fmt.Errorf("更新租户失败")
+	}
+	s.invalidateCache(ctx, t)
+	return nil
+}
+
+// Suspend 停用租户
+func (s *tenantService) Suspend(ctx context.Context, id int64, operator int64) error {
+	if id == tenant.DefaultTenantId {
+		return fmt.Errorf("默认租户不能停用")
+	}
+	return s.setStatus(ctx, id, TenantStatusSuspended, operator)
+}
+
+// Resume 恢复租户
+func (s *tenantService) Resume(ctx context.Context, id int64, operator int64) error {
+	return s.setStatus(ctx, id, TenantStatusNormal, operator)
+}
+
+func (s *tenantService) setStatus(ctx context.Context, id int64, status int32, operator int64) error {
+	t, err := s.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if t.Status == status {
+		return nil
+	}
+	if err := t.Update(s.db.WithContext(ctx), id, map[string]any{"status": status, "update_by": operator}); err != nil {
+		s.logger.Error("更新租户状态失败", zap.Int64("tenantId", id), zap.Error(err))
+		return fmt.Errorf("更新租户状态失败")
+	}
+	s.invalidateCache(ctx, t)
+	s.logger.Warn("更新租户状态", zap.Int64("tenantId", id), zap.Int32("status", status), zap.Int64("operator", operator))
+	return nil
+}
+
+// Delete 删除租户并清除租户的全部数据
+// 租户数据物理删除；租户记录软删除，保留租户编码防止子域名被他人复用
+func (s *tenantService) Delete(ctx context.Context, id int64) error {
+	if id == tenant.DefaultTenantId {
+		return fmt.Errorf("默认租户不能删除")
+	}
+	if id == s.cfg.MultiTenant.TemplateTenantId {
+		return fmt.Errorf("模板租户不能删除")
+	}
+	t, err := s.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// 跨租户清理，显式按 tenant_id 删除
+	platformCtx := tenant.WithoutTenant(ctx)
+
+	// 附件文件和用户 Token 不在数据库事务内，先在事务中记录，提交成功后再清理
+	var (
+		attachments []model.Attachment
+		userIds     []int64
+		purged      int64
+	)
+	err = s.db.WithContext(platformCtx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Select("id", "env_id", "file_key").
+			Where("tenant_id = ?", id).Find(&attachments).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&model.User{}).
+			Where("tenant_id = ?", id).Pluck("id", &userIds).Error; err != nil {
+			return err
+		}
+		for _, m := range tenantOwnedModels {
+			result := tx.Unscoped().Where("tenant_id = ?", id).Delete(m)
+			if result.Error != nil {
+				return result.Error
+			}
+			purged += result.RowsAffected
+		}
+		return t.Delete(tx, id)
+	})
+	if err != nil {
+		s.logger.Error("删除租户失败", zap.Int64("tenantId", id), zap.Error(err))
+		return fmt.Errorf("删除租户失败")
+	}
+	if err := s.casbinService.DeleteTenantPolicies(ctx, id); err != nil {
+		s.logger.Warn("删除租户权限策略失败", zap.Int64("tenantId", id), zap.Error(err))
+	}
+	s.invalidateCache(ctx, t)
+
+	// 吊销租户用户的 Token、RefreshToken 和会话，防止刷新接口继续签发
+	for _, userId := range userIds {
+		if err := s.tokenManager.RevokeUserTokens(ctx, userId); err != nil {
+			s.logger.Warn("吊销租户用户 Token 失败", zap.Int64("tenantId", id), zap.Int64("userId", userId), zap.Error(err))
+		}
+	}
+	files, failedFiles := s.purgeAttachmentFiles(ctx, id, attachments)
+
+	s.logger.Warn("删除租户", zap.Int64("tenantId", id), zap.String("tenantCode", t.TenantCode),
+		zap.Int64("purgedRows", purged), zap.Int("revokedUsers", len(userIds)),
+		zap.Int("purgedFiles", files), zap.Int("failedFiles", failedFiles))
+	return nil
+}
+
+// purgeAttachmentFiles 删除租户附件（含已删除的）在存储中的文件，单个文件删除失败只记录日志
+func (s *tenantService) purgeAttachmentFiles(ctx context.Context, tenantId int64, attachments []model.Attachment) (deleted, failed int) {
+	for _, a := range attachments {
+		stor, err := s.storageManager.GetStorage(a.EnvId)
+		if err == nil {
+			err = stor.Delete(ctx, a.FileKey)
+		}
+		if err != nil {
+			failed++
+			s.logger.Warn("删除租户附件文件失败", zap.Int64("tenantId", tenantId),
+				zap.Int64("attachmentId", a.ID), zap.String("fileKey", a.FileKey), zap.Error(err))
+			continue
+		}
+		deleted++
+	}
+	return deleted, failed
+}
+
+// ResolveCode 按租户编码查询租户ID（带缓存）
+func (s *tenantService) ResolveCode(ctx context.Context, code string) (int64, error) {
+	cacheKey := tenantCodeCacheKey(code)
+	if val, err := s.redis.Get(ctx, cacheKey).Result(); err == nil {
+		if id, err := strconv.ParseInt(val, 10, 64); err == nil {
+			return id, nil
+		}
+	}
+
+	t, err := (&model.Tenant{}).FindByCode(s.db.WithContext(ctx), code)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrTenantNotFound
+		}
+		s.logger.Error("查询租户失败", zap.String("tenantCode", code), zap.Error(err))
+		return 0, fmt.Errorf("查询租户失败")
+	}
+	_ = s.redis.Set(ctx, cacheKey, strconv.FormatInt(t.ID, 10), TenantCacheTTL).Err()
+	return t.ID, nil
+}
+
+// CheckActive 检查租户是否可用（带缓存），默认租户始终可用
+func (s *tenantService) CheckActive(ctx context.Context, tenantId int64) error {
+	if tenantId == tenant.DefaultTenantId {
+		return nil
+	}
+
+	var t *model.Tenant
+	cacheKey := tenantCacheKey(tenantId)
+	if val, err := s.redis.Get(ctx, cacheKey).Result(); err == nil {
+		var cached model.Tenant
+		if json.Unmarshal([]byte(val), &cached) == nil {
+			t = &cached
+		}
+	}
+	if t == nil {
+		found, err := s.GetById(ctx, tenantId)
+		if err != nil {
+			return err
+		}
+		t = found
+		b, _ := json.Marshal(t)
+		_ = s.redis.Set(ctx, cacheKey, string(b), TenantCacheTTL).Err()
+	}
+
+	if t.Status != TenantStatusNormal {
+		return ErrTenantSuspended
+	}
+	if !t.Active(time.Now().Unix()) {
+		return ErrTenantExpired
+	}
+	return nil
+}
+
+// invalidateCache 清除租户缓存
+func (s *tenantService) invalidateCache(ctx context.Context, t *model.Tenant) {
+	if err := s.redis.Del(ctx, tenantCacheKey(t.ID), tenantCodeCacheKey(t.TenantCode)).Err(); err != nil {
+		s.logger.Warn("清除租户缓存失败", zap.Int64("tenantId", t.ID), zap.Error(err))
+	}
+}
+
+// tenantCacheKey 租户缓存 Key
+// s_tenant:id:{tenantId} -> Tenant JSON
+func tenantCacheKey(tenantId int64) string {
+	return TenantCacheKeyPrefix + "id:" + strconv.FormatInt(tenantId, 10)
+}
+
+// tenantCodeCacheKey 租户编码缓存 Key
+// s_tenant:code:{tenantCode} -> tenantId
+func tenantCodeCacheKey(code string) string {
+	return TenantCacheKeyPrefix + "code:" + code
+}
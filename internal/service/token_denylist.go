@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/force-c/nai-tizi/internal/infrastructure/jwt"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// TokenDenylistKeyPrefix 已吊销 AccessToken 的 jti 黑名单 Redis Key 前缀
+	// token:denylist:{jti} -> 吊销时间，TTL 为 Token 剩余有效期
+	TokenDenylistKeyPrefix = "token:denylist:"
+
+	// TokenRevokedSessionKeyPrefix 已吊销会话 Redis Key 前缀，会话内签发的所有 AccessToken 失效
+	// token:revoked_session:{sessionId} -> 吊销时间，TTL 为 TokenRevokeBeforeTTL
+	TokenRevokedSessionKeyPrefix = "token:revoked_session:"
+
+	// TokenRevokeBeforeKeyPrefix 用户 Token 批量吊销时间点 Redis Key 前缀
+	// token:revoke_before:{userId} -> Unix 毫秒，签发时间早于该时间点的 Token 全部失效
+	TokenRevokeBeforeKeyPrefix = "token:revoke_before:"
+
+	// TokenRevokeBeforeTTL 批量吊销时间点的保留时长，需覆盖最长的 Token 有效期
+	TokenRevokeBeforeTTL = 30 * 24 * time.Hour
+)
+
+// TokenDenylist AccessToken 吊销名单接口
+type TokenDenylist interface {
+	// Revoke 吊销单个 Token（按 jti），expiresAt 之后记录自动清除
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+
+	// RevokeSession 吊销会话（按 sid），会话刷新过程中签发的每个 AccessToken 都失效
+	RevokeSession(ctx context.Context, sessionId string) error
+
+	// RevokeUserBefore 吊销用户在指定时间点之前签发的所有 Token（毫秒精度）
+	RevokeUserBefore(ctx context.Context, userId int64, before time.Time) error
+
+	// IsRevoked 判断 Token 是否已被吊销
+	IsRevoked(ctx context.Context, claims *jwt.Claims) (bool, error)
+}
+
+type tokenDenylist struct {
+	redis *redis.Client
+}
+
+// NewTokenDenylist 创建基于 Redis 的 Token 吊销名单
+func NewTokenDenylist(redis *redis.Client) TokenDenylist {
+	return &tokenDenylist{redis: redis}
+}
+
+// Revoke 将 jti 写入黑名单，TTL 与 Token 剩余有效期一致
+func (d *tokenDenylist) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	if jti == "" {
+		return fmt.Errorf("jti 不能为空")
+	}
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		// Token 已过期，无需记录
+		return nil
+	}
+	return d.redis.Set(ctx, TokenDenylistKeyPrefix+jti, time.Now().Unix(), ttl).Err()
+}
+
+// RevokeSession 将会话ID写入黑名单
+// 会话内较早签发的 AccessToken 可能仍未过期，保留时长取最长的 Token 有效期
+func (d *tokenDenylist) RevokeSession(ctx context.Context, sessionId string) error {
+	if sessionId == "" {
+		return fmt.Errorf("会话ID不能为空")
+	}
+	return d.redis.Set(ctx, TokenRevokedSessionKeyPrefix+sessionId, time.Now().Unix(), TokenRevokeBeforeTTL).Err()
+}
+
+// RevokeUserBefore 记录用户的批量吊销时间点，只会向后推进
+func (d *tokenDenylist) RevokeUserBefore(ctx context.Context, userId int64, before time.Time) error {
+	key := fmt.Sprintf("%s%d", TokenRevokeBeforeKeyPrefix, userId)
+	ts := before.UnixMilli()
+
+	val, err := d.redis.Get(ctx, key).Result()
+	if err != nil && err != redis.Nil {
+		return err
+	}
+	if current := parseRevokeBefore(val); current > ts {
+		ts = current
+	}
+	return d.redis.Set(ctx, key, ts, TokenRevokeBeforeTTL).Err()
+}
+
+// IsRevoked 依次检查 jti 黑名单、会话黑名单和用户批量吊销时间点
+func (d *tokenDenylist) IsRevoked(ctx context.Context, claims *jwt.Claims) (bool, error) {
+	pipe := d.redis.Pipeline()
+	var jtiCmd, sessionCmd *redis.IntCmd
+	if claims.ID != "" {
+		jtiCmd = pipe.Exists(ctx, TokenDenylistKeyPrefix+claims.ID)
+	}
+	if claims.SessionId != "" {
+		sessionCmd = pipe.Exists(ctx, TokenRevokedSessionKeyPrefix+claims.SessionId)
+	}
+	beforeCmd := pipe.Get(ctx, fmt.Sprintf("%s%d", TokenRevokeBeforeKeyPrefix, claims.UserId))
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return false, err
+	}
+
+	if jtiCmd != nil && jtiCmd.Val() > 0 {
+		return true, nil
+	}
+	if sessionCmd != nil && sessionCmd.Val() > 0 {
+		return true, nil
+	}
+
+	before := parseRevokeBefore(beforeCmd.Val())
+	if before == 0 {
+		return false, nil
+	}
+	// 按毫秒比较，吊销后立即重新登录（如重置密码后立即登录）签发的 Token 必须有效
+	// 不带毫秒签发时间的旧 Token 按所在秒的起点计算，同一秒内签发的视为已吊销
+	issuedAt := claims.IssuedAtMs
+	if issuedAt == 0 && claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Unix() * 1000
+	}
+	return issuedAt < before, nil
+}
+
+// parseRevokeBefore 解析批量吊销时间点（Unix 毫秒），兼容升级前按秒写入的值
+func parseRevokeBefore(val string) int64 {
+	before, err := strconv.ParseInt(val, 10, 64)
+	if err != nil || before <= 0 {
+		return 0
+	}
+	if before < 1e12 {
+		return before * 1000
+	}
+	return before
+}
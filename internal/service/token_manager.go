@@ -6,25 +6,47 @@ import (
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/force-c/nai-tizi/internal/domain/model"
 	"github.com/force-c/nai-tizi/internal/infrastructure/jwt"
 	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
 
 const (
-	// TokenActiveKeyPrefix Token 活动状态 Redis Key 前缀（已废弃，使用 AccessToken 短期过期替代）
-	TokenActiveKeyPrefix = "token:active:"
-
 	// RefreshTokenKeyPrefix RefreshToken Redis Key 前缀
 	// refresh_token:{userId}:{clientId} -> refreshToken
 	RefreshTokenKeyPrefix = "refresh_token:"
+
+	// RefreshTokenIndexKeyPrefix RefreshToken 反查索引 Redis Key 前缀
+	// refresh_token_index:{sha256(refreshToken)} -> refresh_token:{userId}:{clientId}
+	RefreshTokenIndexKeyPrefix = "refresh_token_index:"
+
+	// RefreshTokenRotatedKeyPrefix 已轮换 RefreshToken 记录 Redis Key 前缀
+	// refresh_token_rotated:{sha256(refreshToken)} -> Hash{familyId, userId, clientId, ...}
+	// 用于识别被再次使用的旧 RefreshToken（重放检测）
+	RefreshTokenRotatedKeyPrefix = "refresh_token_rotated:"
 )
 
+// RefreshTokenReuseError 已轮换的 RefreshToken 被再次使用
+// 出现该错误时，同一轮换族（family）下的所有 Token 均已被吊销
+type RefreshTokenReuseError struct {
+	UserId    int64
+	UserName  string
+	ClientId  string
+	FamilyId  string
+	SessionId string
+}
+
+func (e *RefreshTokenReuseError) Error() string {
+	return "RefreshToken 已失效，检测到重复使用，请重新登录"
+}
+
 // TokenManager Token 管理器接口
 type TokenManager interface {
 	// GenerateTokenPair 生成 AccessToken 和 RefreshToken
@@ -38,35 +60,64 @@ type TokenManager interface {
 
 	// InvalidateToken 使 Token 失效（登出时调用）
 	InvalidateToken(ctx context.Context, userId int64, clientId string) error
+
+	// RevokeAccessToken 立即吊销单个 AccessToken（加入 jti 黑名单）
+	RevokeAccessToken(ctx context.Context, token string) error
+
+	// RevokeUserTokens 立即吊销用户当前已签发的所有 Token（AccessToken 与 RefreshToken）
+	RevokeUserTokens(ctx context.Context, userId int64) error
+
+	// ListSessions 列出用户的所有活跃会话
+	ListSessions(ctx context.Context, userId int64) ([]*Session, error)
+
+	// RevokeSession 吊销用户的指定会话（AccessToken 与 RefreshToken 同时失效）
+	RevokeSession(ctx context.Context, userId int64, sessionId string) error
+
+	// TouchSession 记录会话活动（滑动空闲超时），会话已空闲超时返回 ErrSessionIdle，Redis 异常时返回其他错误
+	TouchSession(ctx context.Context, claims *jwt.Claims) error
 }
 
 type tokenManager struct {
-	jwt    *jwt.Jwt
-	redis  *redis.Client
-	logger logging.Logger
+	jwt      *jwt.Jwt
+	redis    *redis.Client
+	denylist TokenDenylist
+	sessions SessionRegistry
+	activity SessionActivity
+	logger   logging.Logger
 }
 
 // NewTokenManager 创建 TokenManager 实例
 func NewTokenManager(jwtService *jwt.Jwt, redis *redis.Client, logger logging.Logger) TokenManager {
 	return &tokenManager{
-		jwt:    jwtService,
-		redis:  redis,
-		logger: logger,
+		jwt:      jwtService,
+		redis:    redis,
+		denylist: NewTokenDenylist(redis),
+		sessions: NewSessionRegistry(redis, logger),
+		activity: NewSessionActivity(redis),
+		logger:   logger,
 	}
 }
 
 // GenerateTokenPair 生成 AccessToken 和 RefreshToken
 // AccessToken: 使用 JWT，过期时间为 client.ActiveTimeout（短期）
 // RefreshToken: 随机字符串，存储在 Redis，过期时间为 client.Timeout（长期）
+// 每次登录开启一个新的轮换族（family），后续刷新出的 RefreshToken 都属于该族
+// 同时创建一个新会话，请求端信息通过 WithSessionMeta 写入 ctx；客户端启用空闲超时检测时创建活动记录
 func (m *tokenManager) GenerateTokenPair(ctx context.Context, user *model.User, client *model.AuthClient) (string, string, int64, int64, error) {
+	sessionId := uuid.NewString()
+	familyId := uuid.NewString()
+	idle := client.IdleTimeout()
+
 	// 1. 生成 AccessToken（JWT）
-	accessToken, accessExpiresIn, err := m.jwt.GenerateToken(
-		user.ID,
-		user.UserName,
-		client.ClientId,
-		client.DeviceType,
-		client.ActiveTimeout, // 使用 ActiveTimeout 作为 AccessToken 过期时间
-	)
+	accessToken, accessExpiresIn, err := m.jwt.GenerateTokenWithClaims(jwt.Claims{
+		UserId:     user.ID,
+		UserName:   user.UserName,
+		ClientId:   client.ClientId,
+		DeviceType: client.DeviceType,
+		TenantId:   user.TenantId,
+		SessionId:  sessionId,
+		Idle:       idle,
+	}, client.ActiveTimeout) // 使用 ActiveTimeout 作为 AccessToken 过期时间
 	if err != nil {
 		m.logger.Error("生成 AccessToken 失败", zap.Error(err))
 		return "", "", 0, 0, fmt.Errorf("生成 AccessToken 失败: %w", err)
@@ -83,6 +134,11 @@ func (m *tokenManager) GenerateTokenPair(ctx context.Context, user *model.User,
 	refreshKey := m.getRefreshTokenKey(user.ID, client.ClientId)
 	refreshTTL := time.Duration(client.Timeout) * time.Second
 
+	// 同一客户端重新登录会覆盖旧的 RefreshToken，先清理旧索引
+	if oldToken, err := m.redis.HGet(ctx, refreshKey, "token").Result(); err == nil && oldToken != "" {
+		_ = m.redis.Del(ctx, RefreshTokenIndexKeyPrefix+generateTokenHash(oldToken)).Err()
+	}
+
 	// 存储 RefreshToken 的元数据（包含用户信息）
 	refreshData := map[string]interface{}{
 		"token":      refreshToken,
@@ -90,6 +146,11 @@ func (m *tokenManager) GenerateTokenPair(ctx context.Context, user *model.User,
 		"userName":   user.UserName,
 		"clientId":   client.ClientId,
 		"deviceType": client.DeviceType,
+		"tenantId":   user.TenantId,
+		"sessionId":  sessionId,
+		"familyId":   familyId,
+		"parentHash": "",
+		"idle":       idle,
 		"createdAt":  time.Now().Unix(),
 	}
 
@@ -105,6 +166,17 @@ func (m *tokenManager) GenerateTokenPair(ctx context.Context, user *model.User,
 		m.logger.Warn("设置 RefreshToken 过期时间失败", zap.Error(err))
 	}
 
+	// 写入反查索引，刷新时通过 RefreshToken 定位用户
+	_ = m.redis.Set(ctx, RefreshTokenIndexKeyPrefix+generateTokenHash(refreshToken), refreshKey, refreshTTL).Err()
+
+	// 4. 登记会话
+	m.saveSession(ctx, sessionId, user.ID, user.UserName, client, accessToken, refreshTTL)
+	if idle > 0 {
+		if err := m.activity.Start(ctx, sessionId, time.Duration(idle)*time.Second); err != nil {
+			m.logger.Warn("创建会话活动记录失败", zap.String("sessionId", sessionId), zap.Error(err))
+		}
+	}
+
 	m.logger.Info("生成 Token 对成功",
 		zap.Int64("userId", user.ID),
 		zap.String("clientId", client.ClientId),
@@ -115,28 +187,45 @@ func (m *tokenManager) GenerateTokenPair(ctx context.Context, user *model.User,
 }
 
 // ValidateAccessToken 验证 AccessToken
+// 1. 校验签名和过期时间
+// 2. 检查是否已被吊销（jti 黑名单 / 会话黑名单 / 用户批量吊销时间点）
 func (m *tokenManager) ValidateAccessToken(ctx context.Context, token string) (*jwt.Claims, error) {
 	claims, err := m.jwt.ValidateToken(token)
 	if err != nil {
 		return nil, fmt.Errorf("AccessToken 无效或已过期")
 	}
+
+	revoked, err := m.denylist.IsRevoked(ctx, claims)
+	if err != nil {
+		// 无法确认是否已吊销时拒绝请求，避免 Redis 故障期间已注销的 Token 重新生效
+		m.logger.Error("检查 Token 吊销状态失败", zap.Error(err))
+		return nil, fmt.Errorf("认证服务暂不可用，请稍后重试")
+	}
+	if revoked {
+		return nil, fmt.Errorf("AccessToken 已失效，请重新登录")
+	}
 	return claims, nil
 }
 
 // RefreshAccessToken 使用 RefreshToken 刷新 AccessToken
 // 1. 验证 RefreshToken 是否存在且有效
 // 2. 生成新的 AccessToken
-// 3. 轮换 RefreshToken（生成新的，使旧的失效）
+// 3. 轮换 RefreshToken（生成新的，使旧的失效，并记录旧 Token 已轮换）
+// 已轮换的 RefreshToken 再次出现时视为泄露，吊销整个轮换族并返回 *RefreshTokenReuseError
 func (m *tokenManager) RefreshAccessToken(ctx context.Context, refreshToken string, client *model.AuthClient) (string, string, int64, int64, error) {
 	// 1. 查找 RefreshToken（遍历所有用户的 RefreshToken）
 	// 注意：这里为了性能，我们需要优化查找方式
 	// 方案：使用 refreshToken 的哈希作为额外的索引
 	tokenHash := generateTokenHash(refreshToken)
-	indexKey := "refresh_token_index:" + tokenHash
+	indexKey := RefreshTokenIndexKeyPrefix + tokenHash
 
 	// 先从索引中获取用户信息
 	userKey, err := m.redis.Get(ctx, indexKey).Result()
 	if err != nil {
+		// 索引不存在时，检查是否为已轮换的旧 Token
+		if reuseErr := m.detectRefreshTokenReuse(ctx, tokenHash); reuseErr != nil {
+			return "", "", 0, 0, reuseErr
+		}
 		m.logger.Warn("RefreshToken 索引不存在", zap.Error(err))
 		return "", "", 0, 0, fmt.Errorf("RefreshToken 无效或已过期")
 	}
@@ -167,15 +256,60 @@ func (m *tokenManager) RefreshAccessToken(ctx context.Context, refreshToken stri
 	userId := parseInt64(refreshData["userId"])
 	userName := refreshData["userName"]
 	deviceType := refreshData["deviceType"]
+	tenantId := parseInt64(refreshData["tenantId"])
+	sessionId := refreshData["sessionId"]
+	familyId := refreshData["familyId"]
+	oldIdle := parseInt64(refreshData["idle"])
+	idle := client.IdleTimeout()
+	refreshTTL := time.Duration(client.Timeout) * time.Second
 
-	// 6. 生成新的 AccessToken
-	newAccessToken, accessExpiresIn, err := m.jwt.GenerateToken(
-		userId,
-		userName,
-		client.ClientId,
-		deviceType,
-		client.ActiveTimeout,
-	)
+	// 空闲超时的会话不能通过刷新续期（刷新由前端自动触发，不代表用户活动）
+	if oldIdle > 0 && sessionId != "" {
+		alive, err := m.activity.Alive(ctx, sessionId)
+		if err != nil {
+			m.logger.Warn("检查会话活动记录失败", zap.String("sessionId", sessionId), zap.Error(err))
+		} else if !alive {
+			_ = m.redis.Del(ctx, indexKey, userKey).Err()
+			_ = m.sessions.Remove(ctx, userId, sessionId)
+			m.logger.Info("会话空闲超时",
+				zap.Int64("userId", userId),
+				zap.String("clientId", client.ClientId),
+				zap.String("sessionId", sessionId))
+			return "", "", 0, 0, ErrSessionIdle
+		}
+	}
+
+	// 占用旧索引：并发请求中只有一个能成功删除，其余视为重复使用
+	claimed, err := m.redis.Del(ctx, indexKey).Result()
+	if err != nil {
+		m.logger.Error("删除 RefreshToken 索引失败", zap.Error(err))
+		return "", "", 0, 0, fmt.Errorf("刷新 Token 失败: %w", err)
+	}
+	if claimed == 0 {
+		reuseErr := &RefreshTokenReuseError{
+			UserId:    userId,
+			UserName:  userName,
+			ClientId:  client.ClientId,
+			FamilyId:  familyId,
+			SessionId: sessionId,
+		}
+		m.revokeRefreshTokenFamily(ctx, reuseErr)
+		return "", "", 0, 0, reuseErr
+	}
+
+	// 记录旧 Token 已轮换，后续再次出现即可识别为重放
+	m.markRefreshTokenRotated(ctx, tokenHash, refreshData, refreshTTL)
+
+	// 6. 生成新的 AccessToken（沿用原会话ID）
+	newAccessToken, accessExpiresIn, err := m.jwt.GenerateTokenWithClaims(jwt.Claims{
+		UserId:     userId,
+		UserName:   userName,
+		ClientId:   client.ClientId,
+		DeviceType: deviceType,
+		TenantId:   tenantId,
+		SessionId:  sessionId,
+		Idle:       idle,
+	}, client.ActiveTimeout)
 	if err != nil {
 		m.logger.Error("生成新 AccessToken 失败", zap.Error(err))
 		return "", "", 0, 0, fmt.Errorf("生成新 AccessToken 失败: %w", err)
@@ -188,14 +322,18 @@ func (m *tokenManager) RefreshAccessToken(ctx context.Context, refreshToken stri
 		return "", "", 0, 0, fmt.Errorf("生成新 RefreshToken 失败: %w", err)
 	}
 
-	// 8. 更新 Redis 中的 RefreshToken
-	refreshTTL := time.Duration(client.Timeout) * time.Second
+	// 8. 更新 Redis 中的 RefreshToken（同一轮换族，父节点为旧 Token）
 	newRefreshData := map[string]interface{}{
 		"token":      newRefreshToken,
 		"userId":     userId,
 		"userName":   userName,
 		"clientId":   client.ClientId,
 		"deviceType": deviceType,
+		"tenantId":   tenantId,
+		"sessionId":  sessionId,
+		"familyId":   familyId,
+		"parentHash": tokenHash,
+		"idle":       idle,
 		"createdAt":  time.Now().Unix(),
 	}
 
@@ -211,12 +349,29 @@ func (m *tokenManager) RefreshAccessToken(ctx context.Context, refreshToken stri
 		m.logger.Warn("设置 RefreshToken 过期时间失败", zap.Error(err))
 	}
 
-	// 9. 更新索引（删除旧的，创建新的）
-	_ = m.redis.Del(ctx, indexKey).Err()
+	// 9. 创建新索引（旧索引已在占用时删除）
 	newTokenHash := generateTokenHash(newRefreshToken)
-	newIndexKey := "refresh_token_index:" + newTokenHash
+	newIndexKey := RefreshTokenIndexKeyPrefix + newTokenHash
 	_ = m.redis.Set(ctx, newIndexKey, userKey, refreshTTL).Err()
 
+	// 10. 客户端开启或关闭空闲超时检测后，同步会话的活动记录
+	if sessionId != "" && idle != oldIdle {
+		if idle > 0 && oldIdle == 0 {
+			_ = m.activity.Start(ctx, sessionId, time.Duration(idle)*time.Second)
+		} else if idle == 0 {
+			_ = m.activity.Remove(ctx, sessionId)
+		}
+	}
+
+	// 11. 更新会话的最近刷新时间
+	if sessionId != "" {
+		if claims, err := m.jwt.ValidateToken(newAccessToken); err == nil {
+			if err := m.sessions.Touch(ctx, userId, sessionId, claims.ID, claims.ExpiresAt.Unix(), sessionMetaFromContext(ctx), refreshTTL); err != nil {
+				m.logger.Warn("更新会话失败", zap.String("sessionId", sessionId), zap.Error(err))
+			}
+		}
+	}
+
 	m.logger.Info("刷新 Token 成功",
 		zap.Int64("userId", userId),
 		zap.String("clientId", client.ClientId))
@@ -225,7 +380,7 @@ func (m *tokenManager) RefreshAccessToken(ctx context.Context, refreshToken stri
 }
 
 // InvalidateToken 使 Token 失效（登出时调用）
-// 删除 Redis 中的 RefreshToken
+// 删除 Redis 中的 RefreshToken 及其对应的会话记录
 func (m *tokenManager) InvalidateToken(ctx context.Context, userId int64, clientId string) error {
 	refreshKey := m.getRefreshTokenKey(userId, clientId)
 
@@ -235,15 +390,218 @@ func (m *tokenManager) InvalidateToken(ctx context.Context, userId int64, client
 		token := refreshData["token"]
 		if token != "" {
 			tokenHash := generateTokenHash(token)
-			indexKey := "refresh_token_index:" + tokenHash
+			indexKey := RefreshTokenIndexKeyPrefix + tokenHash
 			_ = m.redis.Del(ctx, indexKey).Err()
 		}
+		if sessionId := refreshData["sessionId"]; sessionId != "" {
+			_ = m.sessions.Remove(ctx, userId, sessionId)
+			_ = m.activity.Remove(ctx, sessionId)
+		}
 	}
 
 	// 删除 RefreshToken
 	return m.redis.Del(ctx, refreshKey).Err()
 }
 
+// RevokeAccessToken 吊销单个 AccessToken
+// 无效或已过期的 Token 无需吊销，直接返回
+func (m *tokenManager) RevokeAccessToken(ctx context.Context, token string) error {
+	claims, err := m.jwt.ValidateToken(token)
+	if err != nil {
+		return nil
+	}
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+	if err := m.denylist.Revoke(ctx, claims.ID, claims.ExpiresAt.Time); err != nil {
+		m.logger.Error("吊销 AccessToken 失败", zap.String("jti", claims.ID), zap.Error(err))
+		return fmt.Errorf("吊销 AccessToken 失败: %w", err)
+	}
+	return nil
+}
+
+// RevokeUserTokens 吊销用户当前已签发的所有 Token
+// 1. 记录批量吊销时间点，此前签发的 AccessToken 全部失效
+// 2. 删除该用户所有客户端的 RefreshToken，防止继续刷新
+func (m *tokenManager) RevokeUserTokens(ctx context.Context, userId int64) error {
+	if err := m.denylist.RevokeUserBefore(ctx, userId, time.Now()); err != nil {
+		m.logger.Error("吊销用户 Token 失败", zap.Int64("userId", userId), zap.Error(err))
+		return fmt.Errorf("吊销用户 Token 失败: %w", err)
+	}
+
+	pattern := fmt.Sprintf("%s%d:*", RefreshTokenKeyPrefix, userId)
+	iter := m.redis.Scan(ctx, 0, pattern, 100).Iterator()
+	for iter.Next(ctx) {
+		refreshKey := iter.Val()
+		if token, err := m.redis.HGet(ctx, refreshKey, "token").Result(); err == nil && token != "" {
+			_ = m.redis.Del(ctx, RefreshTokenIndexKeyPrefix+generateTokenHash(token)).Err()
+		}
+		_ = m.redis.Del(ctx, refreshKey).Err()
+	}
+	if err := iter.Err(); err != nil {
+		m.logger.Warn("清理用户 RefreshToken 失败", zap.Int64("userId", userId), zap.Error(err))
+	}
+
+	// 清理会话记录
+	if sessions, err := m.sessions.List(ctx, userId); err == nil {
+		for _, session := range sessions {
+			_ = m.sessions.Remove(ctx, userId, session.SessionId)
+		}
+	}
+
+	m.logger.Info("吊销用户全部 Token", zap.Int64("userId", userId))
+	return nil
+}
+
+// ListSessions 列出用户的所有活跃会话
+func (m *tokenManager) ListSessions(ctx context.Context, userId int64) ([]*Session, error) {
+	sessions, err := m.sessions.List(ctx, userId)
+	if err != nil {
+		m.logger.Error("查询会话列表失败", zap.Int64("userId", userId), zap.Error(err))
+		return nil, fmt.Errorf("查询会话列表失败: %w", err)
+	}
+	return sessions, nil
+}
+
+// RevokeSession 吊销用户的指定会话
+// 1. 将会话ID加入黑名单，会话内签发的所有 AccessToken（包括刷新前的旧 Token）失效
+// 2. 若该客户端的 RefreshToken 属于此会话，一并删除
+// 3. 删除会话记录
+func (m *tokenManager) RevokeSession(ctx context.Context, userId int64, sessionId string) error {
+	session, err := m.sessions.Get(ctx, userId, sessionId)
+	if err != nil {
+		m.logger.Error("查询会话失败", zap.String("sessionId", sessionId), zap.Error(err))
+		return fmt.Errorf("查询会话失败: %w", err)
+	}
+	if session == nil {
+		return fmt.Errorf("会话不存在或已过期")
+	}
+
+	if err := m.denylist.RevokeSession(ctx, sessionId); err != nil {
+		m.logger.Error("吊销会话 AccessToken 失败", zap.String("sessionId", sessionId), zap.Error(err))
+		return fmt.Errorf("吊销会话失败: %w", err)
+	}
+
+	refreshKey := m.getRefreshTokenKey(userId, session.ClientId)
+	if owner, err := m.redis.HGet(ctx, refreshKey, "sessionId").Result(); err == nil && owner == sessionId {
+		_ = m.InvalidateToken(ctx, userId, session.ClientId)
+	}
+
+	if err := m.sessions.Remove(ctx, userId, sessionId); err != nil {
+		m.logger.Warn("删除会话记录失败", zap.String("sessionId", sessionId), zap.Error(err))
+	}
+	_ = m.activity.Remove(ctx, sessionId)
+
+	m.logger.Info("吊销会话成功",
+		zap.Int64("userId", userId),
+		zap.String("sessionId", sessionId),
+		zap.String("clientId", session.ClientId))
+	return nil
+}
+
+// TouchSession 记录会话活动
+// 仅对携带空闲超时的会话 Token 生效；Redis 异常时无法确认会话是否已空闲超时，拒绝请求
+func (m *tokenManager) TouchSession(ctx context.Context, claims *jwt.Claims) error {
+	if claims.Idle <= 0 || claims.SessionId == "" {
+		return nil
+	}
+	err := m.activity.Touch(ctx, claims.SessionId, time.Duration(claims.Idle)*time.Second)
+	if err != nil && !errors.Is(err, ErrSessionIdle) {
+		m.logger.Error("刷新会话活动记录失败", zap.String("sessionId", claims.SessionId), zap.Error(err))
+		return fmt.Errorf("认证服务暂不可用，请稍后重试")
+	}
+	return err
+}
+
+// detectRefreshTokenReuse 检查 RefreshToken 是否为已轮换的旧 Token
+// 命中时吊销对应的轮换族并返回 *RefreshTokenReuseError，否则返回 nil
+func (m *tokenManager) detectRefreshTokenReuse(ctx context.Context, tokenHash string) error {
+	rotated, err := m.redis.HGetAll(ctx, RefreshTokenRotatedKeyPrefix+tokenHash).Result()
+	if err != nil || len(rotated) == 0 {
+		return nil
+	}
+
+	reuseErr := &RefreshTokenReuseError{
+		UserId:    parseInt64(rotated["userId"]),
+		UserName:  rotated["userName"],
+		ClientId:  rotated["clientId"],
+		FamilyId:  rotated["familyId"],
+		SessionId: rotated["sessionId"],
+	}
+	m.revokeRefreshTokenFamily(ctx, reuseErr)
+	return reuseErr
+}
+
+// markRefreshTokenRotated 记录 RefreshToken 已被轮换，保留至整个轮换族过期
+func (m *tokenManager) markRefreshTokenRotated(ctx context.Context, tokenHash string, refreshData map[string]string, ttl time.Duration) {
+	key := RefreshTokenRotatedKeyPrefix + tokenHash
+	pipe := m.redis.TxPipeline()
+	pipe.HSet(ctx, key, map[string]interface{}{
+		"familyId":   refreshData["familyId"],
+		"parentHash": refreshData["parentHash"],
+		"userId":     refreshData["userId"],
+		"userName":   refreshData["userName"],
+		"clientId":   refreshData["clientId"],
+		"sessionId":  refreshData["sessionId"],
+		"rotatedAt":  time.Now().Unix(),
+	})
+	pipe.Expire(ctx, key, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		m.logger.Warn("记录 RefreshToken 轮换失败", zap.Error(err))
+	}
+}
+
+// revokeRefreshTokenFamily 吊销整个轮换族
+// 1. 吊销族所属会话（AccessToken 加入黑名单）
+// 2. 若该客户端当前的 RefreshToken 仍属于此族，一并删除
+func (m *tokenManager) revokeRefreshTokenFamily(ctx context.Context, reuse *RefreshTokenReuseError) {
+	m.logger.Warn("检测到 RefreshToken 重复使用，吊销轮换族",
+		zap.Int64("userId", reuse.UserId),
+		zap.String("clientId", reuse.ClientId),
+		zap.String("familyId", reuse.FamilyId),
+		zap.String("sessionId", reuse.SessionId))
+
+	if reuse.SessionId != "" {
+		if err := m.RevokeSession(ctx, reuse.UserId, reuse.SessionId); err != nil {
+			m.logger.Debug("吊销轮换族会话跳过", zap.String("sessionId", reuse.SessionId), zap.Error(err))
+		}
+	}
+
+	if reuse.FamilyId == "" {
+		return
+	}
+	refreshKey := m.getRefreshTokenKey(reuse.UserId, reuse.ClientId)
+	if familyId, err := m.redis.HGet(ctx, refreshKey, "familyId").Result(); err == nil && familyId == reuse.FamilyId {
+		_ = m.InvalidateToken(ctx, reuse.UserId, reuse.ClientId)
+	}
+}
+
+// saveSession 登记新会话，失败仅记录日志，不影响登录
+func (m *tokenManager) saveSession(ctx context.Context, sessionId string, userId int64, userName string, client *model.AuthClient, accessToken string, ttl time.Duration) {
+	claims, err := m.jwt.ValidateToken(accessToken)
+	if err != nil {
+		return
+	}
+	meta := sessionMetaFromContext(ctx)
+	now := time.Now().Unix()
+	session := &Session{
+		SessionId:       sessionId,
+		UserId:          userId,
+		UserName:        userName,
+		ClientId:        client.ClientId,
+		DeviceType:      client.DeviceType,
+		Ipaddr:          meta.Ipaddr,
+		UserAgent:       meta.UserAgent,
+		CreatedAt:       now,
+		LastRefreshAt:   now,
+		AccessJti:       claims.ID,
+		AccessExpiresAt: claims.ExpiresAt.Unix(),
+	}
+	if err := m.sessions.Save(ctx, session, ttl); err != nil {
+		m.logger.Warn("登记会话失败", zap.Int64("userId", userId), zap.Error(err))
+	}
+}
+
 // getRefreshTokenKey 获取 RefreshToken Redis Key
 func (m *tokenManager) getRefreshTokenKey(userId int64, clientId string) string {
 	return fmt.Sprintf("%s%d:%s", RefreshTokenKeyPrefix, userId, clientId)
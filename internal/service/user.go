@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/force-c/nai-tizi/internal/domain/model"
 	"github.com/force-c/nai-tizi/internal/domain/request"
@@ -25,8 +26,8 @@ type UserService interface {
 	// Delete 删除单个用户
 	Delete(ctx context.Context, userId int64) error
 
-	// BatchDelete 批量删除用户
-	BatchDelete(ctx context.Context, userIds []int64) error
+	// BatchDelete 批量删除用户，返回实际删除的用户ID（数据权限范围外的用户会被跳过）
+	BatchDelete(ctx context.Context, userIds []int64) ([]int64, error)
 
 	// GetById 根据ID查询用户
 	GetById(ctx context.Context, userId int64) (*model.User, error)
@@ -45,22 +46,28 @@ type UserService interface {
 }
 
 type userService struct {
-	db     *gorm.DB
-	logger logging.Logger
+	db             *gorm.DB
+	logger         logging.Logger
+	passwordPolicy PasswordPolicyService
 }
 
 // NewUserService 创建用户服务实例
 func NewUserService(db *gorm.DB, logger logging.Logger) UserService {
 	return &userService{
-		db:     db,
-		logger: logger,
+		db:             db,
+		logger:         logger,
+		passwordPolicy: NewPasswordPolicyService(db, logger),
 	}
 }
 
 // Create 创建用户
 func (s *userService) Create(ctx context.Context, req *request.CreateUserRequest) error {
+	if !DataScopeFromContext(ctx).AllowsOrg(req.OrgId) {
+		return fmt.Errorf("无权在该组织下创建用户")
+	}
+
 	// 一次查询检查所有冲突（用户名、手机号、邮箱）
-	conflicts, err := (&model.User{}).FindConflicts(s.db, req.UserName, req.Phonenumber, req.Email)
+	conflicts, err := (&model.User{}).FindConflicts(s.db.WithContext(ctx), req.UserName, req.Phonenumber, req.Email)
 	if err != nil {
 		s.logger.Error("检查冲突失败", zap.Error(err))
 		return fmt.Errorf("检查冲突失败: %w", err)
@@ -79,15 +86,15 @@ func (s *userService) Create(ctx context.Context, req *request.CreateUserRequest
 		}
 	}
 
-	// 加密密码
-	var hashedPassword string
+	// 按密码策略校验并加密密码
+	var hashedPassword, pwdChange string
+	var pwdUpdateAt int64
 	if req.Password != "" {
-		hashed, err := utils.HashPassword(req.Password)
+		hashedPassword, pwdChange, err = s.passwordPolicy.PrepareNew(ctx, req.OrgId, req.UserName, req.Password)
 		if err != nil {
-			s.logger.Error("密码加密失败", zap.Error(err))
-			return fmt.Errorf("密码加密失败: %w", err)
+			return err
 		}
-		hashedPassword = hashed
+		pwdUpdateAt = time.Now().Unix()
 	}
 
 	// 创建用户实体
@@ -96,6 +103,8 @@ func (s *userService) Create(ctx context.Context, req *request.CreateUserRequest
 		UserName:    req.UserName,
 		NickName:    req.NickName,
 		Password:    hashedPassword,
+		PwdUpdateAt: pwdUpdateAt,
+		PwdChange:   pwdChange,
 		UserType:    req.UserType,
 		Email:       req.Email,
 		Phonenumber: req.Phonenumber,
@@ -108,10 +117,13 @@ func (s *userService) Create(ctx context.Context, req *request.CreateUserRequest
 	}
 
 	// 调用模型层的创建方法
-	if err := user.Create(s.db, user); err != nil {
+	if err := user.Create(s.db.WithContext(ctx), user); err != nil {
 		s.logger.Error("创建用户失败", zap.Error(err))
 		return fmt.Errorf("创建用户失败: %w", err)
 	}
+	if hashedPassword != "" {
+		s.passwordPolicy.RecordHistory(ctx, user.ID, hashedPassword)
+	}
 
 	s.logger.Info("创建用户成功", zap.Int64("userId", user.ID), zap.String("userName", user.UserName))
 	return nil
@@ -119,8 +131,8 @@ func (s *userService) Create(ctx context.Context, req *request.CreateUserRequest
 
 // Update 更新用户
 func (s *userService) Update(ctx context.Context, req *request.UpdateUserRequest) error {
-	// 检查用户是否存在
-	existingUser, err := (&model.User{}).FindByID(s.db, req.UserId)
+	// 检查用户是否存在（且在数据权限范围内）
+	existingUser, err := (&model.User{}).FindByID(s.scoped(ctx), req.UserId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("用户不存在")
@@ -128,10 +140,13 @@ func (s *userService) Update(ctx context.Context, req *request.UpdateUserRequest
 		s.logger.Error("查询用户失败", zap.Error(err))
 		return fmt.Errorf("查询用户失败: %w", err)
 	}
+	if req.OrgId != existingUser.OrgId && !DataScopeFromContext(ctx).AllowsOrg(req.OrgId) {
+		return fmt.Errorf("无权将用户调整到该组织")
+	}
 
 	// 一次查询检查所有冲突（排除自己）
 	conflicts, err := (&model.User{}).FindConflictsExcludingSelf(
-		s.db, req.UserId, req.UserName, req.Phonenumber, req.Email,
+		s.db.WithContext(ctx), req.UserId, req.UserName, req.Phonenumber, req.Email,
 	)
 	if err != nil {
 		s.logger.Error("检查冲突失败", zap.Error(err))
@@ -167,7 +182,7 @@ func (s *userService) Update(ctx context.Context, req *request.UpdateUserRequest
 	}
 
 	// 调用模型层的更新方法
-	if err := existingUser.Update(s.db, req.UserId, updates); err != nil {
+	if err := existingUser.Update(s.db.WithContext(ctx), req.UserId, updates); err != nil {
 		s.logger.Error("更新用户失败", zap.Error(err))
 		return fmt.Errorf("更新用户失败: %w", err)
 	}
@@ -178,8 +193,8 @@ func (s *userService) Update(ctx context.Context, req *request.UpdateUserRequest
 
 // Delete 删除单个用户
 func (s *userService) Delete(ctx context.Context, userId int64) error {
-	// 检查用户是否存在
-	user, err := (&model.User{}).FindByID(s.db, userId)
+	// 检查用户是否存在（且在数据权限范围内）
+	user, err := (&model.User{}).FindByID(s.scoped(ctx), userId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("用户不存在")
@@ -189,7 +204,7 @@ func (s *userService) Delete(ctx context.Context, userId int64) error {
 	}
 
 	// 调用模型层的删除方法
-	if err := user.Delete(s.db, userId); err != nil {
+	if err := user.Delete(s.db.WithContext(ctx), userId); err != nil {
 		s.logger.Error("删除用户失败", zap.Error(err))
 		return fmt.Errorf("删除用户失败: %w", err)
 	}
@@ -198,26 +213,37 @@ func (s *userService) Delete(ctx context.Context, userId int64) error {
 	return nil
 }
 
-// BatchDelete 批量删除用户
-func (s *userService) BatchDelete(ctx context.Context, userIds []int64) error {
+// BatchDelete 批量删除用户，返回实际删除的用户ID（数据权限范围外的用户会被跳过）
+func (s *userService) BatchDelete(ctx context.Context, userIds []int64) ([]int64, error) {
 	if len(userIds) == 0 {
-		return fmt.Errorf("用户ID列表不能为空")
+		return nil, fmt.Errorf("用户ID列表不能为空")
 	}
 
-	// 调用模型层的批量删除方法
-	rowsAffected, err := (&model.User{}).BatchDelete(s.db, userIds)
+	var deleted []int64
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 先在数据权限范围内确定可删除的用户，调用方据此吊销 Token
+		if err := tx.Scopes(UserDataScope(ctx)).Model(&model.User{}).
+			Where("id IN ?", userIds).Pluck("id", &deleted).Error; err != nil {
+			return err
+		}
+		if len(deleted) == 0 {
+			return nil
+		}
+		_, err := (&model.User{}).BatchDelete(tx, deleted)
+		return err
+	})
 	if err != nil {
 		s.logger.Error("批量删除用户失败", zap.Error(err))
-		return fmt.Errorf("批量删除用户失败: %w", err)
+		return nil, fmt.Errorf("批量删除用户失败: %w", err)
 	}
 
-	s.logger.Info("批量删除用户成功", zap.Int64("count", rowsAffected))
-	return nil
+	s.logger.Info("批量删除用户成功", zap.Int("count", len(deleted)))
+	return deleted, nil
 }
 
 // GetById 根据ID查询用户
 func (s *userService) GetById(ctx context.Context, userId int64) (*model.User, error) {
-	user, err := (&model.User{}).FindByID(s.db, userId)
+	user, err := (&model.User{}).FindByID(s.scoped(ctx), userId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("用户不存在")
@@ -233,7 +259,7 @@ func (s *userService) GetById(ctx context.Context, userId int64) (*model.User, e
 
 // Page 分页查询用户列表
 func (s *userService) Page(ctx context.Context, pageNum, pageSize int, username, phonenumber string, status int32) (*pagination.Page[model.User], error) {
-	query := s.db.Model(&model.User{})
+	query := s.scoped(ctx).Model(&model.User{})
 
 	// 条件查询
 	if username != "" {
@@ -278,17 +304,33 @@ func (s *userService) BatchImport(ctx context.Context, req *request.BatchImportU
 	var errors []string
 
 	// 逐个导入用户
+	scope := DataScopeFromContext(ctx)
 	for i, userReq := range req.Users {
-		// 加密密码
-		var hashedPassword string
-		if userReq.Password != "" {
-			hashed, err := utils.HashPassword(userReq.Password)
+		if !scope.AllowsOrg(userReq.OrgId) {
+			failCount++
+			errors = append(errors, fmt.Sprintf("第%d行: 无权在该组织下创建用户", i+1))
+			continue
+		}
+
+		// 按密码策略校验并加密密码
+		var hashedPassword, pwdChange string
+		var pwdUpdateAt int64
+		if userReq.PasswordHash != "" {
+			// 导入其他系统的密码哈希，修改时间未知
+			if !utils.PasswordHashSupported(userReq.PasswordHash) {
+				failCount++
+				errors = append(errors, fmt.Sprintf("第%d行: 不支持的密码哈希格式", i+1))
+				continue
+			}
+			hashedPassword = userReq.PasswordHash
+		} else if userReq.Password != "" {
+			hashed, change, err := s.passwordPolicy.PrepareNew(ctx, userReq.OrgId, userReq.UserName, userReq.Password)
 			if err != nil {
 				failCount++
-				errors = append(errors, fmt.Sprintf("第%d行: 密码加密失败", i+1))
+				errors = append(errors, fmt.Sprintf("第%d行: %s", i+1, err.Error()))
 				continue
 			}
-			hashedPassword = hashed
+			hashedPassword, pwdChange, pwdUpdateAt = hashed, change, time.Now().Unix()
 		}
 
 		// 创建用户
@@ -297,6 +339,8 @@ func (s *userService) BatchImport(ctx context.Context, req *request.BatchImportU
 			UserName:    userReq.UserName,
 			NickName:    userReq.NickName,
 			Password:    hashedPassword,
+			PwdUpdateAt: pwdUpdateAt,
+			PwdChange:   pwdChange,
 			UserType:    userReq.UserType,
 			Email:       userReq.Email,
 			Phonenumber: userReq.Phonenumber,
@@ -308,11 +352,14 @@ func (s *userService) BatchImport(ctx context.Context, req *request.BatchImportU
 			UpdateBy:    userReq.UpdateBy,
 		}
 
-		if err := s.db.Create(user).Error; err != nil {
+		if err := s.db.WithContext(ctx).Create(user).Error; err != nil {
 			failCount++
 			errors = append(errors, fmt.Sprintf("第%d行: %s", i+1, err.Error()))
 			continue
 		}
+		if hashedPassword != "" && userReq.PasswordHash == "" {
+			s.passwordPolicy.RecordHistory(ctx, user.ID, hashedPassword)
+		}
 
 		successCount++
 	}
@@ -326,8 +373,8 @@ func (s *userService) BatchImport(ctx context.Context, req *request.BatchImportU
 
 // ResetPassword 重置用户密码
 func (s *userService) ResetPassword(ctx context.Context, userId int64, newPassword string) error {
-	// 检查用户是否存在
-	user, err := (&model.User{}).FindByID(s.db, userId)
+	// 检查用户是否存在（且在数据权限范围内）
+	user, err := (&model.User{}).FindByID(s.scoped(ctx), userId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("用户不存在")
@@ -336,17 +383,9 @@ func (s *userService) ResetPassword(ctx context.Context, userId int64, newPasswo
 		return fmt.Errorf("查询用户失败: %w", err)
 	}
 
-	// 加密新密码
-	hashedPassword, err := utils.HashPassword(newPassword)
-	if err != nil {
-		s.logger.Error("密码加密失败", zap.Error(err))
-		return fmt.Errorf("密码加密失败: %w", err)
-	}
-
-	// 调用模型层的更新密码方法
-	if err := user.UpdatePassword(s.db, userId, hashedPassword); err != nil {
-		s.logger.Error("重置密码失败", zap.Error(err))
-		return fmt.Errorf("重置密码失败: %w", err)
+	// 按密码策略校验并更新密码（策略开启时用户下次登录须修改密码）
+	if err := s.passwordPolicy.SetPassword(ctx, user, newPassword, true); err != nil {
+		return err
 	}
 
 	s.logger.Info("重置密码成功", zap.Int64("userId", userId))
@@ -356,7 +395,7 @@ func (s *userService) ResetPassword(ctx context.Context, userId int64, newPasswo
 // ChangePassword 用户修改密码
 func (s *userService) ChangePassword(ctx context.Context, userId int64, oldPassword, newPassword string) error {
 	// 1. 检查用户是否存在
-	user, err := (&model.User{}).FindByID(s.db, userId)
+	user, err := (&model.User{}).FindByID(s.db.WithContext(ctx), userId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("用户不存在")
@@ -371,19 +410,16 @@ func (s *userService) ChangePassword(ctx context.Context, userId int64, oldPassw
 		return fmt.Errorf("旧密码不正确")
 	}
 
-	// 3. 加密新密码
-	hashedPassword, err := utils.HashPassword(newPassword)
-	if err != nil {
-		s.logger.Error("密码加密失败", zap.Error(err))
-		return fmt.Errorf("密码加密失败: %w", err)
-	}
-
-	// 4. 更新密码
-	if err := user.UpdatePassword(s.db, userId, hashedPassword); err != nil {
-		s.logger.Error("修改密码失败", zap.Error(err))
-		return fmt.Errorf("修改密码失败: %w", err)
+	// 3. 按密码策略校验并更新密码，同时清除强制修改标记
+	if err := s.passwordPolicy.SetPassword(ctx, user, newPassword, false); err != nil {
+		return err
 	}
 
 	s.logger.Info("修改密码成功", zap.Int64("userId", userId))
 	return nil
 }
+
+// scoped 返回按当前请求数据权限过滤用户的查询（每次查询需重新获取）
+func (s *userService) scoped(ctx context.Context) *gorm.DB {
+	return s.db.WithContext(ctx).Scopes(UserDataScope(ctx))
+}
@@ -0,0 +1,453 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/force-c/nai-tizi/internal/config"
+	"github.com/force-c/nai-tizi/internal/domain/model"
+	logging "github.com/force-c/nai-tizi/internal/logger"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// WebAuthnRegisterKeyPrefix 通行密钥注册仪式会话 Redis Key 前缀
+	// webauthn:register:{userId} -> SessionData(JSON)
+	WebAuthnRegisterKeyPrefix = "webauthn:register:"
+
+	// WebAuthnLoginKeyPrefix 通行密钥登录仪式会话 Redis Key 前缀
+	// webauthn:login:{challengeId} -> SessionData(JSON)
+	WebAuthnLoginKeyPrefix = "webauthn:login:"
+
+	// webAuthnMaxCredentials 单个用户最多可注册的凭证数量
+	webAuthnMaxCredentials = 10
+)
+
+// WebAuthnService 通行密钥服务接口
+type WebAuthnService interface {
+	// BeginRegistration 开始注册仪式，返回浏览器 navigator.credentials.create() 所需参数
+	BeginRegistration(ctx context.Context, userId int64) (*protocol.CredentialCreation, error)
+
+	// FinishRegistration 完成注册仪式，校验并保存凭证
+	FinishRegistration(ctx context.Context, userId int64, name string, body []byte) (*model.WebAuthnCredential, error)
+
+	// BeginLogin 开始登录仪式，返回挑战ID和浏览器 navigator.credentials.get() 所需参数
+	// username 为空时使用可发现凭证（Passkey）登录
+	BeginLogin(ctx context.Context, username string) (string, *protocol.CredentialAssertion, error)
+
+	// FinishLogin 完成登录仪式，校验签名和签名计数器，返回登录用户
+	FinishLogin(ctx context.Context, challengeId string, body []byte) (*model.User, error)
+
+	// ListCredentials 查询用户的凭证列表
+	ListCredentials(ctx context.Context, userId int64) ([]model.WebAuthnCredential, error)
+
+	// RenameCredential 重命名凭证
+	RenameCredential(ctx context.Context, userId, credentialId int64, name string) error
+
+	// DeleteCredential 删除凭证
+	DeleteCredential(ctx context.Context, userId, credentialId int64) error
+}
+
+type webAuthnService struct {
+	db     *gorm.DB
+	redis  *redis.Client
+	config *config.Config
+	logger logging.Logger
+}
+
+// NewWebAuthnService 创建通行密钥服务实例
+func NewWebAuthnService(db *gorm.DB, redis *redis.Client, cfg *config.Config, logger logging.Logger) WebAuthnService {
+	return &webAuthnService{
+		db:     db,
+		redis:  redis,
+		config: cfg,
+		logger: logger,
+	}
+}
+
+// BeginRegistration 开始注册仪式
+func (s *webAuthnService) BeginRegistration(ctx context.Context, userId int64) (*protocol.CredentialCreation, error) {
+	rp, err := s.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.loadUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if len(user.credentials) >= webAuthnMaxCredentials {
+		return nil, fmt.Errorf("最多只能注册 %d 个通行密钥", webAuthnMaxCredentials)
+	}
+
+	creation, session, err := rp.BeginRegistration(user,
+		webauthn.WithExclusions(webauthn.Credentials(user.credentials).CredentialDescriptors()),
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementPreferred),
+	)
+	if err != nil {
+		s.logger.Error("开始通行密钥注册失败", zap.Int64("userId", userId), zap.Error(err))
+		return nil, fmt.Errorf("开始注册失败")
+	}
+
+	if err := s.saveSession(ctx, s.getRegisterKey(userId), session); err != nil {
+		return nil, err
+	}
+	return creation, nil
+}
+
+// FinishRegistration 完成注册仪式
+func (s *webAuthnService) FinishRegistration(ctx context.Context, userId int64, name string, body []byte) (*model.WebAuthnCredential, error) {
+	rp, err := s.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+	session, err := s.takeSession(ctx, s.getRegisterKey(userId))
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.loadUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(body)
+	if err != nil {
+		return nil, fmt.Errorf("凭证数据格式错误: %s", webAuthnErrorDetail(err))
+	}
+	credential, err := rp.CreateCredential(user, *session, parsed)
+	if err != nil {
+		s.logger.Warn("通行密钥注册校验失败", zap.Int64("userId", userId), zap.Error(err))
+		return nil, fmt.Errorf("凭证校验失败: %s", webAuthnErrorDetail(err))
+	}
+
+	if name = strings.TrimSpace(name); name == "" {
+		name = "通行密钥 " + time.Now().Format("2006-01-02")
+	}
+	transports := make([]string, 0, len(credential.Transport))
+	for _, t := range credential.Transport {
+		transports = append(transports, string(t))
+	}
+	record := &model.WebAuthnCredential{
+		UserId:          userId,
+		CredentialId:    base64.RawURLEncoding.EncodeToString(credential.ID),
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		Transports:      strings.Join(transports, ","),
+		Aaguid:          hex.EncodeToString(credential.Authenticator.AAGUID),
+		SignCount:       int64(credential.Authenticator.SignCount),
+		BackupEligible:  credential.Flags.BackupEligible,
+		BackupState:     credential.Flags.BackupState,
+		Name:            name,
+	}
+	if err := record.Create(s.db.WithContext(ctx)); err != nil {
+		s.logger.Error("保存通行密钥失败", zap.Int64("userId", userId), zap.Error(err))
+		return nil, fmt.Errorf("保存通行密钥失败: %w", err)
+	}
+
+	s.logger.Info("注册通行密钥成功", zap.Int64("userId", userId), zap.Int64("credentialId", record.ID))
+	return record, nil
+}
+
+// BeginLogin 开始登录仪式
+// 用户不存在或未注册凭证时同样返回可发现凭证登录参数，避免泄露账号是否存在
+func (s *webAuthnService) BeginLogin(ctx context.Context, username string) (string, *protocol.CredentialAssertion, error) {
+	rp, err := s.relyingParty()
+	if err != nil {
+		return "", nil, err
+	}
+
+	var (
+		assertion *protocol.CredentialAssertion
+		session   *webauthn.SessionData
+	)
+	if user := s.findUserForLogin(ctx, username); user != nil {
+		assertion, session, err = rp.BeginLogin(user)
+	} else {
+		assertion, session, err = rp.BeginDiscoverableLogin()
+	}
+	if err != nil {
+		s.logger.Error("开始通行密钥登录失败", zap.Error(err))
+		return "", nil, fmt.Errorf("开始登录失败")
+	}
+
+	challengeId := uuid.NewString()
+	if err := s.saveSession(ctx, WebAuthnLoginKeyPrefix+challengeId, session); err != nil {
+		return "", nil, err
+	}
+	return challengeId, assertion, nil
+}
+
+// FinishLogin 完成登录仪式
+func (s *webAuthnService) FinishLogin(ctx context.Context, challengeId string, body []byte) (*model.User, error) {
+	rp, err := s.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+	session, err := s.takeSession(ctx, WebAuthnLoginKeyPrefix+challengeId)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(body)
+	if err != nil {
+		return nil, fmt.Errorf("凭证数据格式错误: %s", webAuthnErrorDetail(err))
+	}
+
+	var (
+		user       *webAuthnUser
+		credential *webauthn.Credential
+	)
+	if len(session.UserID) > 0 {
+		if user, err = s.loadUser(ctx, parseWebAuthnUserId(session.UserID)); err != nil {
+			return nil, fmt.Errorf("通行密钥校验失败")
+		}
+		credential, err = rp.ValidateLogin(user, *session, parsed)
+	} else {
+		var found webauthn.User
+		found, credential, err = rp.ValidatePasskeyLogin(func(rawID, userHandle []byte) (webauthn.User, error) {
+			return s.loadUser(ctx, parseWebAuthnUserId(userHandle))
+		}, *session, parsed)
+		if found != nil {
+			user, _ = found.(*webAuthnUser)
+		}
+	}
+	if err != nil || user == nil {
+		s.logger.Warn("通行密钥登录校验失败", zap.Error(err))
+		return nil, fmt.Errorf("通行密钥校验失败")
+	}
+
+	// 签名计数器未递增说明凭证可能被复制，拒绝登录
+	if credential.Authenticator.CloneWarning {
+		s.logger.Warn("通行密钥签名计数器异常，疑似凭证被复制",
+			zap.Int64("userId", user.user.ID),
+			zap.Uint32("signCount", credential.Authenticator.SignCount))
+		return nil, fmt.Errorf("通行密钥签名计数异常，已拒绝登录，请联系管理员")
+	}
+
+	var cm model.WebAuthnCredential
+	record, err := cm.FindByCredentialId(s.db.WithContext(ctx), base64.RawURLEncoding.EncodeToString(credential.ID))
+	if err != nil {
+		return nil, fmt.Errorf("通行密钥不存在")
+	}
+	if err := cm.UpdateSignCount(s.db.WithContext(ctx), record.ID, int64(credential.Authenticator.SignCount), credential.Flags.BackupState, time.Now().Unix()); err != nil {
+		s.logger.Warn("更新通行密钥签名计数失败", zap.Int64("credentialId", record.ID), zap.Error(err))
+	}
+
+	return user.user, nil
+}
+
+// ListCredentials 查询用户的凭证列表
+func (s *webAuthnService) ListCredentials(ctx context.Context, userId int64) ([]model.WebAuthnCredential, error) {
+	credentials, err := (&model.WebAuthnCredential{}).FindByUserId(s.db.WithContext(ctx), userId)
+	if err != nil {
+		s.logger.Error("查询通行密钥失败", zap.Int64("userId", userId), zap.Error(err))
+		return nil, fmt.Errorf("查询通行密钥失败: %w", err)
+	}
+	return credentials, nil
+}
+
+// RenameCredential 重命名凭证
+func (s *webAuthnService) RenameCredential(ctx context.Context, userId, credentialId int64, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("名称不能为空")
+	}
+	rows, err := (&model.WebAuthnCredential{}).Rename(s.db.WithContext(ctx), userId, credentialId, name)
+	if err != nil {
+		s.logger.Error("重命名通行密钥失败", zap.Int64("credentialId", credentialId), zap.Error(err))
+		return fmt.Errorf("重命名通行密钥失败: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("通行密钥不存在")
+	}
+	return nil
+}
+
+// DeleteCredential 删除凭证
+func (s *webAuthnService) DeleteCredential(ctx context.Context, userId, credentialId int64) error {
+	rows, err := (&model.WebAuthnCredential{}).Delete(s.db.WithContext(ctx), userId, credentialId)
+	if err != nil {
+		s.logger.Error("删除通行密钥失败", zap.Int64("credentialId", credentialId), zap.Error(err))
+		return fmt.Errorf("删除通行密钥失败: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("通行密钥不存在")
+	}
+	s.logger.Info("删除通行密钥", zap.Int64("userId", userId), zap.Int64("credentialId", credentialId))
+	return nil
+}
+
+// relyingParty 根据配置创建依赖方实例
+func (s *webAuthnService) relyingParty() (*webauthn.WebAuthn, error) {
+	cfg := s.config.WebAuthn
+	if !cfg.Enabled {
+		return nil, fmt.Errorf("未启用通行密钥登录")
+	}
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	rp, err := webauthn.New(&webauthn.Config{
+		RPID:          cfg.RPID,
+		RPDisplayName: cfg.RPDisplayName,
+		RPOrigins:     cfg.RPOrigins,
+		Timeouts: webauthn.TimeoutsConfig{
+			Login:        webauthn.TimeoutConfig{Enforce: true, Timeout: timeout, TimeoutUVD: timeout},
+			Registration: webauthn.TimeoutConfig{Enforce: true, Timeout: timeout, TimeoutUVD: timeout},
+		},
+	})
+	if err != nil {
+		s.logger.Error("WebAuthn 配置错误", zap.Error(err))
+		return nil, fmt.Errorf("通行密钥配置错误")
+	}
+	return rp, nil
+}
+
+// saveSession 保存仪式会话，过期时间与仪式超时一致
+func (s *webAuthnService) saveSession(ctx context.Context, key string, session *webauthn.SessionData) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("保存挑战失败")
+	}
+	ttl := time.Duration(s.config.WebAuthn.Timeout) * time.Second
+	if err := s.redis.Set(ctx, key, data, ttl).Err(); err != nil {
+		s.logger.Error("保存 WebAuthn 挑战失败", zap.Error(err))
+		return fmt.Errorf("保存挑战失败")
+	}
+	return nil
+}
+
+// takeSession 取出并删除仪式会话，挑战只能使用一次
+func (s *webAuthnService) takeSession(ctx context.Context, key string) (*webauthn.SessionData, error) {
+	data, err := s.redis.GetDel(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("挑战已过期，请重试")
+		}
+		return nil, fmt.Errorf("查询挑战失败")
+	}
+	var session webauthn.SessionData
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("挑战数据异常")
+	}
+	return &session, nil
+}
+
+// findUserForLogin 按用户名查找已注册凭证的正常用户，找不到时返回 nil
+func (s *webAuthnService) findUserForLogin(ctx context.Context, username string) *webAuthnUser {
+	if username == "" {
+		return nil
+	}
+	u, err := (&model.User{}).FindByUsername(s.db.WithContext(ctx), username)
+	if err != nil || u.Status != 0 {
+		return nil
+	}
+	user, err := s.wrapUser(ctx, u)
+	if err != nil || len(user.credentials) == 0 {
+		return nil
+	}
+	return user
+}
+
+// loadUser 加载用户及其凭证
+func (s *webAuthnService) loadUser(ctx context.Context, userId int64) (*webAuthnUser, error) {
+	u, err := (&model.User{}).FindByID(s.db.WithContext(ctx), userId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("用户不存在")
+		}
+		return nil, fmt.Errorf("查询用户失败: %w", err)
+	}
+	return s.wrapUser(ctx, u)
+}
+
+// wrapUser 将系统用户包装为 webauthn.User
+func (s *webAuthnService) wrapUser(ctx context.Context, u *model.User) (*webAuthnUser, error) {
+	records, err := (&model.WebAuthnCredential{}).FindByUserId(s.db.WithContext(ctx), u.ID)
+	if err != nil {
+		return nil, fmt.Errorf("查询通行密钥失败: %w", err)
+	}
+	credentials := make([]webauthn.Credential, 0, len(records))
+	for _, r := range records {
+		id, err := base64.RawURLEncoding.DecodeString(r.CredentialId)
+		if err != nil {
+			continue
+		}
+		aaguid, _ := hex.DecodeString(r.Aaguid)
+		var transports []protocol.AuthenticatorTransport
+		if r.Transports != "" {
+			for _, t := range strings.Split(r.Transports, ",") {
+				transports = append(transports, protocol.AuthenticatorTransport(t))
+			}
+		}
+		credentials = append(credentials, webauthn.Credential{
+			ID:              id,
+			PublicKey:       r.PublicKey,
+			AttestationType: r.AttestationType,
+			Transport:       transports,
+			Flags: webauthn.CredentialFlags{
+				BackupEligible: r.BackupEligible,
+				BackupState:    r.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    aaguid,
+				SignCount: uint32(r.SignCount),
+			},
+		})
+	}
+	return &webAuthnUser{user: u, credentials: credentials}, nil
+}
+
+// getRegisterKey 获取注册仪式 Redis Key
+func (s *webAuthnService) getRegisterKey(userId int64) string {
+	return fmt.Sprintf("%s%d", WebAuthnRegisterKeyPrefix, userId)
+}
+
+// webAuthnUser 实现 webauthn.User 接口
+type webAuthnUser struct {
+	user        *model.User
+	credentials []webauthn.Credential
+}
+
+// WebAuthnID 用户句柄，使用用户ID的十进制字符串
+func (u *webAuthnUser) WebAuthnID() []byte {
+	return []byte(strconv.FormatInt(u.user.ID, 10))
+}
+
+func (u *webAuthnUser) WebAuthnName() string {
+	return u.user.UserName
+}
+
+func (u *webAuthnUser) WebAuthnDisplayName() string {
+	if u.user.NickName != "" {
+		return u.user.NickName
+	}
+	return u.user.UserName
+}
+
+func (u *webAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	return u.credentials
+}
+
+// parseWebAuthnUserId 解析用户句柄
+func parseWebAuthnUserId(handle []byte) int64 {
+	id, _ := strconv.ParseInt(string(handle), 10, 64)
+	return id
+}
+
+// webAuthnErrorDetail 提取 WebAuthn 协议错误的详细信息
+func webAuthnErrorDetail(err error) string {
+	var protocolErr *protocol.Error
+	if errors.As(err, &protocolErr) && protocolErr.Details != "" {
+		return protocolErr.Details
+	}
+	return err.Error()
+}
@@ -3,6 +3,7 @@ package utils
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -100,20 +101,55 @@ func (h *BcryptHasher) MustHashPassword(password string) string {
 	return hash
 }
 
-// 全局默认哈希器实例
-var defaultHasher = NewDefaultBcryptHasher()
+// Name 算法名称
+func (h *BcryptHasher) Name() string { return HashAlgBcrypt }
+
+// Match 判断哈希是否为 bcrypt 格式（$2a$ / $2b$ / $2y$）
+func (h *BcryptHasher) Match(hashedPassword string) bool {
+	return strings.HasPrefix(hashedPassword, "$2a$") ||
+		strings.HasPrefix(hashedPassword, "$2b$") ||
+		strings.HasPrefix(hashedPassword, "$2y$")
+}
+
+// NeedsRehash 哈希的成本因子低于当前配置时需要重新哈希
+func (h *BcryptHasher) NeedsRehash(hashedPassword string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	return err != nil || cost < h.cost
+}
+
+// 全局默认哈希器注册表，当前算法为 bcrypt，可通过 SetDefaultHasherRegistry 按配置替换
+var defaultHasher = NewHasherRegistry(NewDefaultBcryptHasher())
+
+// SetDefaultHasherRegistry 替换全局默认哈希器注册表（启动时按配置调用）
+func SetDefaultHasherRegistry(r *HasherRegistry) {
+	defaultHasher = r
+}
 
 // HashPassword 使用默认哈希器生成密码哈希
 func HashPassword(password string) (string, error) {
 	return defaultHasher.HashPassword(password)
 }
 
-// VerifyPassword 使用默认哈希器验证密码
+// VerifyPassword 使用默认哈希器验证密码，按哈希前缀自动选择算法
 func VerifyPassword(hashedPassword, password string) error {
 	return defaultHasher.VerifyPassword(hashedPassword, password)
 }
 
+// PasswordNeedsRehash 判断哈希是否需要按当前算法和参数重新生成
+func PasswordNeedsRehash(hashedPassword string) bool {
+	return defaultHasher.NeedsRehash(hashedPassword)
+}
+
+// PasswordHashSupported 判断哈希格式是否可被默认注册表识别（用于导入其他系统的密码哈希）
+func PasswordHashSupported(hashedPassword string) bool {
+	return defaultHasher.Supported(hashedPassword)
+}
+
 // MustHashPassword 使用默认哈希器生成密码哈希，失败则 panic
 func MustHashPassword(password string) string {
-	return defaultHasher.MustHashPassword(password)
+	hash, err := defaultHasher.HashPassword(password)
+	if err != nil {
+		panic(fmt.Sprintf("failed to hash password: %v", err))
+	}
+	return hash
 }
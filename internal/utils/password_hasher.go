@@ -0,0 +1,404 @@
+package utils
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// 密码哈希算法名称
+const (
+	HashAlgBcrypt   = "bcrypt"
+	HashAlgArgon2id = "argon2id"
+	HashAlgScrypt   = "scrypt"
+	HashAlgLegacy   = "legacy"
+)
+
+// ErrUnknownHashFormat 无法识别的哈希格式
+var ErrUnknownHashFormat = errors.New("unknown password hash format")
+
+// PasswordAlgorithm 可注册到 HasherRegistry 的密码哈希算法
+type PasswordAlgorithm interface {
+	PasswordHasher
+	// Name 算法名称
+	Name() string
+	// Match 根据编码前缀判断哈希是否属于本算法
+	Match(hashedPassword string) bool
+	// NeedsRehash 哈希参数弱于当前配置时返回 true
+	NeedsRehash(hashedPassword string) bool
+}
+
+// HasherRegistry 密码哈希器注册表
+// 新密码使用当前算法生成，验证时按哈希前缀选择算法，因此可以同时验证多种历史格式
+type HasherRegistry struct {
+	current    PasswordAlgorithm
+	algorithms []PasswordAlgorithm
+}
+
+// NewHasherRegistry 创建注册表，current 为生成新密码使用的算法
+// 默认注册 bcrypt、argon2id、scrypt 和旧系统加盐摘要，同名算法以 current 为准
+func NewHasherRegistry(current PasswordAlgorithm) *HasherRegistry {
+	r := &HasherRegistry{current: current, algorithms: []PasswordAlgorithm{current}}
+	for _, alg := range []PasswordAlgorithm{
+		NewDefaultBcryptHasher(),
+		NewDefaultArgon2idHasher(),
+		NewDefaultScryptHasher(),
+		LegacyDigestHasher{},
+	} {
+		r.Register(alg)
+	}
+	return r
+}
+
+// Register 注册验证用算法，已存在同名算法时忽略
+func (r *HasherRegistry) Register(alg PasswordAlgorithm) {
+	for _, a := range r.algorithms {
+		if a.Name() == alg.Name() {
+			return
+		}
+	}
+	r.algorithms = append(r.algorithms, alg)
+}
+
+// Current 当前算法
+func (r *HasherRegistry) Current() PasswordAlgorithm {
+	return r.current
+}
+
+// HashPassword 使用当前算法生成密码哈希
+func (r *HasherRegistry) HashPassword(password string) (string, error) {
+	return r.current.HashPassword(password)
+}
+
+// VerifyPassword 按哈希前缀选择算法验证密码
+func (r *HasherRegistry) VerifyPassword(hashedPassword, password string) error {
+	if hashedPassword == "" {
+		return ErrHashEmpty
+	}
+	alg := r.find(hashedPassword)
+	if alg == nil {
+		return ErrUnknownHashFormat
+	}
+	return alg.VerifyPassword(hashedPassword, password)
+}
+
+// NeedsRehash 哈希不是当前算法，或参数弱于当前配置时返回 true
+func (r *HasherRegistry) NeedsRehash(hashedPassword string) bool {
+	if hashedPassword == "" {
+		return false
+	}
+	if !r.current.Match(hashedPassword) {
+		return true
+	}
+	return r.current.NeedsRehash(hashedPassword)
+}
+
+// Supported 判断哈希格式是否可被识别
+func (r *HasherRegistry) Supported(hashedPassword string) bool {
+	return r.find(hashedPassword) != nil
+}
+
+func (r *HasherRegistry) find(hashedPassword string) PasswordAlgorithm {
+	for _, alg := range r.algorithms {
+		if alg.Match(hashedPassword) {
+			return alg
+		}
+	}
+	return nil
+}
+
+// Argon2idHasher argon2id 密码哈希器
+// 哈希格式（PHC）：$argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>，salt 和 hash 为无填充 Base64
+type Argon2idHasher struct {
+	memory      uint32 // 内存（KiB）
+	iterations  uint32 // 迭代次数
+	parallelism uint8  // 并行度
+	saltLength  int
+	keyLength   uint32
+}
+
+// NewArgon2idHasher 创建 argon2id 哈希器，参数不合法时使用默认值
+func NewArgon2idHasher(memory, iterations uint32, parallelism uint8) *Argon2idHasher {
+	if memory < 8*1024 {
+		memory = 64 * 1024
+	}
+	if iterations == 0 {
+		iterations = 3
+	}
+	if parallelism == 0 {
+		parallelism = 2
+	}
+	return &Argon2idHasher{memory: memory, iterations: iterations, parallelism: parallelism, saltLength: 16, keyLength: 32}
+}
+
+// NewDefaultArgon2idHasher 创建默认参数（64 MiB，3 次迭代，并行度 2）的 argon2id 哈希器
+func NewDefaultArgon2idHasher() *Argon2idHasher {
+	return NewArgon2idHasher(64*1024, 3, 2)
+}
+
+// Name 算法名称
+func (h *Argon2idHasher) Name() string { return HashAlgArgon2id }
+
+// Match 判断哈希是否为 argon2id 格式
+func (h *Argon2idHasher) Match(hashedPassword string) bool {
+	return strings.HasPrefix(hashedPassword, "$argon2id$")
+}
+
+// HashPassword 生成密码哈希
+func (h *Argon2idHasher) HashPassword(password string) (string, error) {
+	if password == "" {
+		return "", ErrPasswordEmpty
+	}
+	salt := make([]byte, h.saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	key := argon2.IDKey([]byte(password), salt, h.iterations, h.memory, h.parallelism, h.keyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.memory, h.iterations, h.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// VerifyPassword 验证密码
+func (h *Argon2idHasher) VerifyPassword(hashedPassword, password string) error {
+	if hashedPassword == "" {
+		return ErrHashEmpty
+	}
+	if password == "" {
+		return ErrPasswordEmpty
+	}
+	p, salt, key, err := decodeArgon2id(hashedPassword)
+	if err != nil {
+		return err
+	}
+	actual := argon2.IDKey([]byte(password), salt, p.iterations, p.memory, p.parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(actual, key) != 1 {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
+
+// NeedsRehash 任一参数低于当前配置时需要重新哈希
+func (h *Argon2idHasher) NeedsRehash(hashedPassword string) bool {
+	p, _, _, err := decodeArgon2id(hashedPassword)
+	if err != nil {
+		return true
+	}
+	return p.memory < h.memory || p.iterations < h.iterations || p.parallelism < h.parallelism
+}
+
+func decodeArgon2id(encoded string) (*Argon2idHasher, []byte, []byte, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != HashAlgArgon2id {
+		return nil, nil, nil, ErrUnknownHashFormat
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, nil, nil, fmt.Errorf("unsupported argon2 version: %s", parts[2])
+	}
+	p := &Argon2idHasher{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.iterations, &p.parallelism); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid argon2 parameters: %w", err)
+	}
+	// 限制参数上限，避免异常的导入数据导致验证时耗尽内存或 CPU
+	if p.memory == 0 || p.memory > 1<<20 || p.iterations == 0 || p.iterations > 16 || p.parallelism == 0 {
+		return nil, nil, nil, fmt.Errorf("invalid argon2 parameters: %s", parts[3])
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid argon2 salt: %w", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return nil, nil, nil, fmt.Errorf("invalid argon2 hash")
+	}
+	return p, salt, key, nil
+}
+
+// ScryptHasher scrypt 密码哈希器，主要用于验证从其他系统导入的哈希
+// 哈希格式：$scrypt$ln=15,r=8,p=1$<salt>$<hash>，N=2^ln，salt 和 hash 为无填充 Base64
+type ScryptHasher struct {
+	logN       uint8
+	r          int
+	p          int
+	saltLength int
+	keyLength  int
+}
+
+// NewScryptHasher 创建 scrypt 哈希器，参数不合法时使用默认值
+func NewScryptHasher(logN uint8, r, p int) *ScryptHasher {
+	if logN < 10 || logN > 20 {
+		logN = 15
+	}
+	if r <= 0 {
+		r = 8
+	}
+	if p <= 0 {
+		p = 1
+	}
+	return &ScryptHasher{logN: logN, r: r, p: p, saltLength: 16, keyLength: 32}
+}
+
+// NewDefaultScryptHasher 创建默认参数（N=32768，r=8，p=1）的 scrypt 哈希器
+func NewDefaultScryptHasher() *ScryptHasher {
+	return NewScryptHasher(15, 8, 1)
+}
+
+// Name 算法名称
+func (h *ScryptHasher) Name() string { return HashAlgScrypt }
+
+// Match 判断哈希是否为 scrypt 格式
+func (h *ScryptHasher) Match(hashedPassword string) bool {
+	return strings.HasPrefix(hashedPassword, "$scrypt$")
+}
+
+// HashPassword 生成密码哈希
+func (h *ScryptHasher) HashPassword(password string) (string, error) {
+	if password == "" {
+		return "", ErrPasswordEmpty
+	}
+	salt := make([]byte, h.saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	key, err := scrypt.Key([]byte(password), salt, 1<<h.logN, h.r, h.p, h.keyLength)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return fmt.Sprintf("$scrypt$ln=%d,r=%d,p=%d$%s$%s", h.logN, h.r, h.p,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// VerifyPassword 验证密码
+func (h *ScryptHasher) VerifyPassword(hashedPassword, password string) error {
+	if hashedPassword == "" {
+		return ErrHashEmpty
+	}
+	if password == "" {
+		return ErrPasswordEmpty
+	}
+	p, salt, key, err := decodeScrypt(hashedPassword)
+	if err != nil {
+		return err
+	}
+	actual, err := scrypt.Key([]byte(password), salt, 1<<p.logN, p.r, p.p, len(key))
+	if err != nil {
+		return fmt.Errorf("failed to verify password: %w", err)
+	}
+	if subtle.ConstantTimeCompare(actual, key) != 1 {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
+
+// NeedsRehash 任一参数低于当前配置时需要重新哈希
+func (h *ScryptHasher) NeedsRehash(hashedPassword string) bool {
+	p, _, _, err := decodeScrypt(hashedPassword)
+	if err != nil {
+		return true
+	}
+	return p.logN < h.logN || p.r < h.r || p.p < h.p
+}
+
+func decodeScrypt(encoded string) (*ScryptHasher, []byte, []byte, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 5 || parts[1] != HashAlgScrypt {
+		return nil, nil, nil, ErrUnknownHashFormat
+	}
+	p := &ScryptHasher{}
+	if _, err := fmt.Sscanf(parts[2], "ln=%d,r=%d,p=%d", &p.logN, &p.r, &p.p); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid scrypt parameters: %w", err)
+	}
+	if p.logN == 0 || p.logN > 20 || p.r <= 0 || p.r > 32 || p.p <= 0 || p.p > 16 {
+		return nil, nil, nil, fmt.Errorf("invalid scrypt parameters: %s", parts[2])
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid scrypt salt: %w", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(key) == 0 {
+		return nil, nil, nil, fmt.Errorf("invalid scrypt hash")
+	}
+	return p, salt, key, nil
+}
+
+// LegacyDigestHasher 旧系统的（加盐）摘要哈希，仅用于验证导入用户的密码，首次登录后升级为当前算法
+// 哈希格式：$legacy$<md5|sha1|sha256|sha512>$<sp|ps>$<salt>$<十六进制摘要>
+// sp 表示 digest(salt + password)，ps 表示 digest(password + salt)，无盐时 salt 为空
+type LegacyDigestHasher struct{}
+
+// Name 算法名称
+func (LegacyDigestHasher) Name() string { return HashAlgLegacy }
+
+// Match 判断哈希是否为旧系统摘要格式
+func (LegacyDigestHasher) Match(hashedPassword string) bool {
+	return strings.HasPrefix(hashedPassword, "$legacy$")
+}
+
+// HashPassword 旧系统摘要不允许用于生成新密码
+func (LegacyDigestHasher) HashPassword(string) (string, error) {
+	return "", errors.New("legacy digest cannot be used to hash new passwords")
+}
+
+// VerifyPassword 验证密码
+func (LegacyDigestHasher) VerifyPassword(hashedPassword, password string) error {
+	if hashedPassword == "" {
+		return ErrHashEmpty
+	}
+	if password == "" {
+		return ErrPasswordEmpty
+	}
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 || parts[1] != HashAlgLegacy {
+		return ErrUnknownHashFormat
+	}
+
+	var h hash.Hash
+	switch parts[2] {
+	case "md5":
+		h = md5.New()
+	case "sha1":
+		h = sha1.New()
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return fmt.Errorf("unsupported legacy digest: %s", parts[2])
+	}
+	switch parts[3] {
+	case "sp":
+		h.Write([]byte(parts[4] + password))
+	case "ps":
+		h.Write([]byte(password + parts[4]))
+	default:
+		return fmt.Errorf("unsupported legacy salt position: %s", parts[3])
+	}
+
+	expected, err := hex.DecodeString(strings.ToLower(parts[5]))
+	if err != nil {
+		return fmt.Errorf("invalid legacy digest: %w", err)
+	}
+	if subtle.ConstantTimeCompare(h.Sum(nil), expected) != 1 {
+		return ErrPasswordMismatch
+	}
+	return nil
+}
+
+// NeedsRehash 旧系统摘要始终需要升级
+func (LegacyDigestHasher) NeedsRehash(string) bool { return true }
@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// TestArgon2idHasher 测试 argon2id 哈希生成与验证
+func TestArgon2idHasher(t *testing.T) {
+	hasher := NewArgon2idHasher(8*1024, 1, 1)
+
+	hash, err := hasher.HashPassword("admin123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=8192,t=1,p=1$") {
+		t.Errorf("unexpected hash format: %s", hash)
+	}
+	if !hasher.Match(hash) {
+		t.Error("expected hasher to match its own hash")
+	}
+	if err := hasher.VerifyPassword(hash, "admin123"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := hasher.VerifyPassword(hash, "admin124"); err != ErrPasswordMismatch {
+		t.Errorf("expected ErrPasswordMismatch, got %v", err)
+	}
+	if hasher.NeedsRehash(hash) {
+		t.Error("hash with current parameters should not need rehash")
+	}
+	if !NewArgon2idHasher(16*1024, 1, 1).NeedsRehash(hash) {
+		t.Error("hash with less memory should need rehash")
+	}
+}
+
+// TestArgon2idRejectsExcessiveParameters 测试拒绝参数异常的哈希
+func TestArgon2idRejectsExcessiveParameters(t *testing.T) {
+	hasher := NewDefaultArgon2idHasher()
+	hash := "$argon2id$v=19$m=4194304,t=1,p=1$c2FsdHNhbHQ$aGFzaGhhc2g"
+	if err := hasher.VerifyPassword(hash, "admin123"); err == nil || err == ErrPasswordMismatch {
+		t.Errorf("expected parameter error, got %v", err)
+	}
+}
+
+// TestScryptHasher 测试 scrypt 哈希生成与验证
+func TestScryptHasher(t *testing.T) {
+	hasher := NewScryptHasher(10, 8, 1)
+
+	hash, err := hasher.HashPassword("admin123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(hash, "$scrypt$ln=10,r=8,p=1$") {
+		t.Errorf("unexpected hash format: %s", hash)
+	}
+	if err := hasher.VerifyPassword(hash, "admin123"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := hasher.VerifyPassword(hash, "wrong"); err != ErrPasswordMismatch {
+		t.Errorf("expected ErrPasswordMismatch, got %v", err)
+	}
+	if !NewScryptHasher(12, 8, 1).NeedsRehash(hash) {
+		t.Error("hash with smaller N should need rehash")
+	}
+}
+
+// TestLegacyDigestHasher 测试旧系统加盐摘要验证
+func TestLegacyDigestHasher(t *testing.T) {
+	md5Sum := md5.Sum([]byte("s4ltadmin123"))
+	sha256Sum := sha256.Sum256([]byte("admin123s4lt"))
+	unsalted := md5.Sum([]byte("admin123"))
+
+	tests := []struct {
+		name     string
+		hash     string
+		password string
+		wantErr  bool
+	}{
+		{"md5 盐在前", "$legacy$md5$sp$s4lt$" + hex.EncodeToString(md5Sum[:]), "admin123", false},
+		{"sha256 盐在后", "$legacy$sha256$ps$s4lt$" + hex.EncodeToString(sha256Sum[:]), "admin123", false},
+		{"无盐 md5 大写摘要", "$legacy$md5$sp$$" + strings.ToUpper(hex.EncodeToString(unsalted[:])), "admin123", false},
+		{"密码错误", "$legacy$md5$sp$s4lt$" + hex.EncodeToString(md5Sum[:]), "admin124", true},
+		{"盐位置错误", "$legacy$md5$ps$s4lt$" + hex.EncodeToString(md5Sum[:]), "admin123", true},
+		{"不支持的摘要", "$legacy$crc32$sp$s4lt$00", "admin123", true},
+	}
+
+	hasher := LegacyDigestHasher{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := hasher.VerifyPassword(tt.hash, tt.password)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("wantErr %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := hasher.HashPassword("admin123"); err == nil {
+		t.Error("legacy digest should not hash new passwords")
+	}
+}
+
+// TestHasherRegistry 测试注册表按前缀验证和重新哈希判断
+func TestHasherRegistry(t *testing.T) {
+	registry := NewHasherRegistry(NewArgon2idHasher(8*1024, 1, 1))
+
+	bcryptHash := "$2a$10$Q55.ONb4ACprCH5Wl9NqouI9uWyvV.wGT4BSRRnCWQXdfJiWgOHzK"
+	md5Sum := md5.Sum([]byte("admin123"))
+	legacyHash := "$legacy$md5$sp$$" + hex.EncodeToString(md5Sum[:])
+	scryptHash, _ := NewScryptHasher(10, 8, 1).HashPassword("admin123")
+	currentHash, err := registry.HashPassword("admin123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		hash        string
+		needsRehash bool
+	}{
+		{"bcrypt", bcryptHash, true},
+		{"旧系统摘要", legacyHash, true},
+		{"scrypt", scryptHash, true},
+		{"当前算法", currentHash, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := registry.VerifyPassword(tt.hash, "admin123"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if err := registry.VerifyPassword(tt.hash, "wrong"); err != ErrPasswordMismatch {
+				t.Errorf("expected ErrPasswordMismatch, got %v", err)
+			}
+			if got := registry.NeedsRehash(tt.hash); got != tt.needsRehash {
+				t.Errorf("expected NeedsRehash %v, got %v", tt.needsRehash, got)
+			}
+		})
+	}
+
+	if err := registry.VerifyPassword("invalid_hash", "admin123"); err != ErrUnknownHashFormat {
+		t.Errorf("expected ErrUnknownHashFormat, got %v", err)
+	}
+	if registry.Supported("invalid_hash") {
+		t.Error("invalid hash should not be supported")
+	}
+}
+
+// TestBcryptNeedsRehash 测试 bcrypt 成本因子升级判断
+func TestBcryptNeedsRehash(t *testing.T) {
+	hash := NewBcryptHasher(4).MustHashPassword("admin123")
+	if NewBcryptHasher(4).NeedsRehash(hash) {
+		t.Error("hash with current cost should not need rehash")
+	}
+	if !NewBcryptHasher(5).NeedsRehash(hash) {
+		t.Error("hash with lower cost should need rehash")
+	}
+}
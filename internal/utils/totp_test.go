@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret RFC 6238 附录 B 中 SHA1 测试向量使用的密钥 "12345678901234567890"
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// TestGenerateTOTPCode_RFC6238 使用 RFC 6238 测试向量校验（取低 6 位）
+func TestGenerateTOTPCode_RFC6238(t *testing.T) {
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := GenerateTOTPCode(rfc6238Secret, TOTPStep(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("GenerateTOTPCode() error = %v", err)
+		}
+		if code != tt.code {
+			t.Errorf("GenerateTOTPCode(%d) = %s, want %s", tt.unix, code, tt.code)
+		}
+	}
+}
+
+// TestValidateTOTPCode 测试动态码校验及时间偏差
+func TestValidateTOTPCode(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret() error = %v", err)
+	}
+	now := time.Unix(1700000000, 0)
+	prev, _ := GenerateTOTPCode(secret, TOTPStep(now)-1)
+	stale, _ := GenerateTOTPCode(secret, TOTPStep(now)-3)
+
+	if step, ok := ValidateTOTPCode(secret, prev, now, 1); !ok || step != TOTPStep(now)-1 {
+		t.Errorf("上一时间步的动态码应在允许偏差内通过校验")
+	}
+	if _, ok := ValidateTOTPCode(secret, prev, now, 0); ok {
+		t.Errorf("skew=0 时上一时间步的动态码不应通过校验")
+	}
+	if _, ok := ValidateTOTPCode(secret, stale, now, 1); ok {
+		t.Errorf("超出偏差范围的动态码不应通过校验")
+	}
+	if _, ok := ValidateTOTPCode(secret, "12345", now, 1); ok {
+		t.Errorf("位数不正确的动态码不应通过校验")
+	}
+}
+
+// TestBuildTOTPURI 测试 otpauth 地址格式
+func TestBuildTOTPURI(t *testing.T) {
+	uri := BuildTOTPURI("NTZ", "admin", rfc6238Secret)
+	if !strings.HasPrefix(uri, "otpauth://totp/NTZ:admin?") {
+		t.Errorf("BuildTOTPURI() = %s", uri)
+	}
+	if !strings.Contains(uri, "secret="+rfc6238Secret) {
+		t.Errorf("BuildTOTPURI() 缺少 secret 参数: %s", uri)
+	}
+}
@@ -7,6 +7,34 @@ import type {
   UserInfo,
 } from '@/types/api';
 
+// 注册页面选项
+export interface RegistrationOptions {
+  enabled: boolean;
+  openSignup: boolean;
+  inviteSignup: boolean;
+  allowedDomains: string[];
+  captcha: boolean;
+}
+
+// 自助注册请求参数
+export interface RegisterParams {
+  userName: string;
+  nickName?: string;
+  password: string;
+  email?: string;
+  emailCode?: string;
+  inviteCode?: string;
+  captchaId?: string;
+  captchaCode?: string;
+}
+
+// 注册结果
+export interface RegisterResult {
+  userId: string;
+  userName: string;
+  pending: boolean;
+}
+
 export const authApi = {
   // 登录
   login: (params: LoginParams) => request.post<LoginResponse>('/login', params),
@@ -28,4 +56,20 @@ export const authApi = {
   // 发送邮箱验证码
   sendEmailCode: (email: string) =>
     request.post('/auth/email', { email }),
+
+  // "不是我本人"：注销异常登录的会话
+  revokeLoginRisk: (token: string) =>
+    request.post<string>('/auth/login-risk/revoke', { token }),
+
+  // 注册页面选项
+  registerOptions: () =>
+    request.get<RegistrationOptions>('/auth/register/options'),
+
+  // 发送注册邮箱验证码
+  sendRegisterEmailCode: (params: { email: string; captchaId?: string; captchaCode?: string }) =>
+    request.post<string>('/auth/register/email-code', params),
+
+  // 自助注册
+  register: (params: RegisterParams) =>
+    request.post<RegisterResult>('/auth/register', params),
 };
@@ -0,0 +1,80 @@
+import { request } from '@/utils/request';
+import type { PageParams, PageResponse } from '@/types/api';
+
+// 客户端应用（不含密钥）
+export interface AuthClient {
+  clientId: string;
+  clientKey: string;
+  clientName?: string;
+  grantTypes: string[];
+  deviceType?: string;
+  status: number;
+  timeout: number;
+  activeTimeout: number;
+  idleCheck: number;
+  redirectUris: string[];
+  scopes: string[];
+  autoApprove: boolean;
+  prevExpireAt: number;
+  remark?: string;
+  createdTime?: string;
+  updatedTime?: string;
+}
+
+// 客户端可编辑配置
+export interface ClientConfigParams {
+  clientName?: string;
+  grantTypes: string[];
+  deviceType?: string;
+  status: number;
+  timeout: number;
+  activeTimeout: number;
+  idleCheck: number;
+  redirectUris?: string[];
+  scopes?: string[];
+  autoApprove?: boolean;
+  remark?: string;
+}
+
+// 创建客户端请求参数
+export interface CreateClientParams extends ClientConfigParams {
+  clientKey: string;
+}
+
+// 密钥响应（明文仅返回一次）
+export interface ClientSecret {
+  clientId: string;
+  clientKey: string;
+  clientSecret: string;
+  prevExpireAt: number;
+}
+
+export const clientApi = {
+  // 获取客户端列表（分页）
+  page: (params: PageParams & { clientKey?: string; clientName?: string; status?: number }) =>
+    request.post<PageResponse<AuthClient>>('/api/v1/client/page', params),
+
+  // 可开通的授权类型
+  grantTypes: () =>
+    request.get<string[]>('/api/v1/client/grant-types'),
+
+  // 获取客户端详情
+  detail: (clientId: string) =>
+    request.get<AuthClient>(`/api/v1/client/${clientId}`),
+
+  // 创建客户端
+  create: (data: CreateClientParams) =>
+    request.post<ClientSecret>('/api/v1/client', data),
+
+  // 更新客户端
+  update: (clientId: string, data: ClientConfigParams) =>
+    request.put(`/api/v1/client/${clientId}`, data),
+
+  // 删除客户端
+  delete: (clientId: string) =>
+    request.delete(`/api/v1/client/${clientId}`),
+
+  // 轮换密钥
+  rotateSecret: (clientId: string, gracePeriod: number) =>
+    request.post<ClientSecret>(`/api/v1/client/${clientId}/secret`, { gracePeriod }),
+};
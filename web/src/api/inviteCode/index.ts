@@ -0,0 +1,54 @@
+import { request } from '@/utils/request';
+import type { PageParams, PageResponse } from '@/types/api';
+
+// 注册邀请码
+export interface InviteCode {
+  id: number;
+  code: string;
+  orgId: number;
+  roleIds: number[];
+  maxUses: number;
+  usedCount: number;
+  expireAt: number;
+  status: number;
+  usable: boolean;
+  remark?: string;
+  createdTime?: string;
+}
+
+// 生成邀请码请求参数
+export interface CreateInviteCodeParams {
+  orgId: number;
+  roleIds: number[];
+  maxUses: number;
+  expireAt: number;
+  count: number;
+  remark?: string;
+}
+
+// 更新邀请码请求参数
+export interface UpdateInviteCodeParams {
+  roleIds: number[];
+  maxUses: number;
+  expireAt: number;
+  status: number;
+  remark?: string;
+}
+
+export const inviteCodeApi = {
+  // 获取邀请码列表（分页）
+  page: (params: PageParams & { code?: string; orgId?: number; status?: number }) =>
+    request.post<PageResponse<InviteCode>>('/api/v1/invite-code/page', params),
+
+  // 批量生成邀请码
+  create: (data: CreateInviteCodeParams) =>
+    request.post<InviteCode[]>('/api/v1/invite-code', data),
+
+  // 更新邀请码
+  update: (id: number, data: UpdateInviteCodeParams) =>
+    request.put(`/api/v1/invite-code/${id}`, data),
+
+  // 删除邀请码
+  delete: (id: number) =>
+    request.delete(`/api/v1/invite-code/${id}`),
+};
@@ -12,7 +12,7 @@ export interface LoginLog {
   msg?: string;
   loginTime: string;
   clientId?: string;
-  tenantId?: string;
+  tenantId?: number;
 }
 
 export interface OperLog {
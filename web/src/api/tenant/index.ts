@@ -0,0 +1,82 @@
+import { request } from '@/utils/request';
+import type { PageParams, PageResponse } from '@/types/api';
+
+// 租户
+export interface Tenant {
+  id: number;
+  tenantCode: string;
+  tenantName: string;
+  plan?: string;
+  expireAt: number;
+  status: number;
+  active: boolean;
+  contactName?: string;
+  contactPhone?: string;
+  adminUserId: number;
+  remark?: string;
+  createdTime?: string;
+}
+
+// 开通租户请求参数
+export interface CreateTenantParams {
+  tenantCode: string;
+  tenantName: string;
+  plan?: string;
+  expireAt: number;
+  contactName?: string;
+  contactPhone?: string;
+  remark?: string;
+  adminUserName: string;
+  adminPassword: string;
+  adminNickName?: string;
+}
+
+// 更新租户请求参数
+export interface UpdateTenantParams {
+  tenantName: string;
+  plan?: string;
+  expireAt: number;
+  contactName?: string;
+  contactPhone?: string;
+  remark?: string;
+}
+
+// 开通租户结果
+export interface TenantOnboardResult {
+  tenant: Tenant;
+  rootOrgId: number;
+  adminUserId: number;
+  adminUserName: string;
+  roleCount: number;
+  policyCount: number;
+}
+
+export const tenantApi = {
+  // 获取租户列表（分页）
+  page: (params: PageParams & { tenantCode?: string; tenantName?: string; status?: number }) =>
+    request.post<PageResponse<Tenant>>('/api/v1/tenant/page', params),
+
+  // 获取租户详情
+  get: (id: number) =>
+    request.get<Tenant>(`/api/v1/tenant/${id}`),
+
+  // 开通租户
+  create: (data: CreateTenantParams) =>
+    request.post<TenantOnboardResult>('/api/v1/tenant', data),
+
+  // 更新租户
+  update: (id: number, data: UpdateTenantParams) =>
+    request.put(`/api/v1/tenant/${id}`, data),
+
+  // 停用租户
+  suspend: (id: number) =>
+    request.post(`/api/v1/tenant/${id}/suspend`),
+
+  // 恢复租户
+  resume: (id: number) =>
+    request.post(`/api/v1/tenant/${id}/resume`),
+
+  // 删除租户（清除全部数据）
+  delete: (id: number) =>
+    request.delete(`/api/v1/tenant/${id}`),
+};
@@ -28,6 +28,10 @@ export const userApi = {
   resetPassword: (id: number, newPassword: string) =>
     request.put(`/api/v1/user/${id}/password`, { newPassword }),
 
+  // 审批自助注册用户（拒绝将删除该注册）
+  approve: (id: number, approved: boolean, reason?: string) =>
+    request.post(`/api/v1/user/${id}/approve`, { approved, reason }),
+
   // 修改密码（用户自己）
   changePassword: (oldPassword: string, newPassword: string) =>
     request.post('/api/v1/user/password/change', { oldPassword, newPassword }),
@@ -8,7 +8,7 @@ import 'nprogress/nprogress.css';
 NProgress.configure({ showSpinner: false });
 
 // 白名单路由（不需要登录即可访问）
-const whiteList = ['/login', '/404'];
+const whiteList = ['/login', '/register', '/login-risk/revoke', '/404'];
 
 export function setupRouterGuard(router: Router) {
   // 前置守卫
@@ -15,6 +15,24 @@ export const basicRoutes: RouteRecordRaw[] = [
       requiresAuth: false,
     },
   },
+  {
+    path: '/register',
+    name: 'Register',
+    component: () => import('@/views/auth/register/index.vue'),
+    meta: {
+      title: '注册',
+      requiresAuth: false,
+    },
+  },
+  {
+    path: '/login-risk/revoke',
+    name: 'LoginRiskRevoke',
+    component: () => import('@/views/auth/login-risk/revoke.vue'),
+    meta: {
+      title: '异常登录处理',
+      requiresAuth: false,
+    },
+  },
   {
     path: '/',
     name: 'Root',
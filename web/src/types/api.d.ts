@@ -46,6 +46,7 @@ export interface LoginParams {
   captchaType?: string;
   captchaId?: string;
   captchaCode?: string;
+  deviceId?: string;
 }
 
 // 登录响应
@@ -26,7 +26,8 @@ export interface Role {
   roleName: string;
   roleKey: string;
   roleSort: number;
-  dataScope: number;
+  dataScope: number; // 数据范围：1全部 2自定义 3本组织 4本组织及以下 5仅本人
+  orgIds?: number[]; // 自定义数据范围的组织ID
   status: number;
   remark?: string;
   createTime?: string;
@@ -45,6 +46,7 @@ export interface Organization {
   phone?: string;
   email?: string;
   status: number;
+  regApproval?: boolean; // 自助注册是否需要审批
   children?: Organization[];
 }
 
@@ -58,6 +58,15 @@ service.interceptors.response.use(
       return data;
     }
     
+    // 会话空闲超时，RefreshToken 已同时失效，直接跳转登录页
+    if (code === 419) {
+      const authStore = useAuthStore();
+      authStore.clearAuthState();
+      router.push('/login');
+      message.error(msg || '长时间未操作，请重新登录');
+      return Promise.reject(new Error('Session idle timeout'));
+    }
+
     // Token 过期，尝试刷新（但排除登录和刷新接口）
     if (code === 401 && !response.config.url?.includes('/login') && !response.config.url?.includes('/refresh')) {
       const authStore = useAuthStore();